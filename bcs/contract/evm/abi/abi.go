@@ -3,6 +3,7 @@ package abi
 import (
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/hyperledger/burrow/execution/evm/abi"
 )
@@ -65,6 +66,40 @@ func (a *ABI) encodeMethod(method *abi.FunctionSpec, args map[string]interface{}
 	return out, err
 }
 
+// DecodeInput decodes abi encoded call input into method name and arguments
+func (a *ABI) DecodeInput(input []byte) (string, []interface{}, error) {
+	if len(input) < abi.FunctionIDSize {
+		return "", nil, errors.New("input too short")
+	}
+	var id abi.FunctionID
+	copy(id[:], input)
+	for _, method := range a.spec.Functions {
+		if method.FunctionID != id {
+			continue
+		}
+		vals := abi.GetPackingTypes(method.Inputs)
+		err := abi.Unpack(method.Inputs, input[abi.FunctionIDSize:], vals...)
+		if err != nil {
+			return "", nil, err
+		}
+		args := make([]interface{}, len(vals))
+		for i, v := range vals {
+			args[i] = reflect.Indirect(reflect.ValueOf(v)).Interface()
+		}
+		return method.Name, args, nil
+	}
+	return "", nil, fmt.Errorf("method id %x not found", id)
+}
+
+// EncodeOutput encodes the return values of method
+func (a *ABI) EncodeOutput(methodName string, rets ...interface{}) ([]byte, error) {
+	method, ok := a.spec.Functions[methodName]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found", methodName)
+	}
+	return abi.Pack(method.Outputs, rets...)
+}
+
 //func decodeHex(str string) []byte {
 //	var buf []byte
 //	n, err := fmt.Sscanf(str, "0x%x", &buf)
@@ -0,0 +1,201 @@
+package evm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/burrow/execution/evm/abi"
+
+	"github.com/xuperchain/xupercore/lib/cache"
+)
+
+const (
+	// 缓存解析后的abi的最大个数
+	abiCacheCapacity = 1024
+	// 磁盘缓存的abi文件后缀
+	abiCacheFileSuffix = ".abi"
+	// 写入中的临时文件
+	abiCacheTmpPrefix = ".tmp-"
+)
+
+type abiCacheFile struct {
+	size     int64
+	lastUsed time.Time
+}
+
+// abiSpecCache 缓存解析后的合约abi，按照abi内容的sha256寻址，
+// 合约升级后使用新的abi，旧的abi由LRU淘汰。
+// dir不为空时abi同时保存在磁盘上，重启后预先解析最近使用的abi，
+// 磁盘缓存总大小超过maxSize时按最近最少使用淘汰，最近使用时间记录在文件修改时间中
+type abiSpecCache struct {
+	lru *cache.LRUCache
+
+	dir     string
+	maxSize int64
+
+	mutex sync.Mutex
+	files map[string]*abiCacheFile
+	size  int64
+}
+
+// newAbiSpecCache 创建abi缓存，dir为空时只缓存在内存中，maxSize为0表示磁盘缓存不限制大小
+func newAbiSpecCache(capacity int, dir string, maxSize int64) (*abiSpecCache, error) {
+	c := &abiSpecCache{
+		lru:     cache.NewLRUCache(capacity),
+		dir:     dir,
+		maxSize: maxSize,
+		files:   make(map[string]*abiCacheFile),
+	}
+	if dir == "" {
+		return c, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(infos))
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, abiCacheFileSuffix) {
+			if strings.HasPrefix(name, abiCacheTmpPrefix) {
+				os.Remove(filepath.Join(dir, name))
+			}
+			continue
+		}
+		id := strings.TrimSuffix(name, abiCacheFileSuffix)
+		c.files[id] = &abiCacheFile{
+			size:     info.Size(),
+			lastUsed: info.ModTime(),
+		}
+		c.size += info.Size()
+		ids = append(ids, id)
+	}
+	// 按最近使用时间从新到旧预先解析，加入LRU的顺序从旧到新
+	sort.Slice(ids, func(i, j int) bool {
+		return c.files[ids[i]].lastUsed.After(c.files[ids[j]].lastUsed)
+	})
+	if len(ids) > capacity {
+		ids = ids[:capacity]
+	}
+	for i := len(ids) - 1; i >= 0; i-- {
+		c.load(ids[i])
+	}
+	c.mutex.Lock()
+	c.evictLocked()
+	c.mutex.Unlock()
+	return c, nil
+}
+
+func (c *abiSpecCache) filePath(id string) string {
+	return filepath.Join(c.dir, id+abiCacheFileSuffix)
+}
+
+// load 从磁盘读取abi并解析，内容和文件名不一致或者解析失败时删除文件
+func (c *abiSpecCache) load(id string) {
+	abiByte, err := ioutil.ReadFile(c.filePath(id))
+	if err == nil {
+		key := sha256.Sum256(abiByte)
+		if hex.EncodeToString(key[:]) != id {
+			err = os.ErrInvalid
+		}
+	}
+	var spec *abi.Spec
+	if err == nil {
+		spec, err = abi.ReadSpec(abiByte)
+	}
+	if err != nil {
+		c.mutex.Lock()
+		c.removeLocked(id)
+		c.mutex.Unlock()
+		return
+	}
+	c.lru.Add(sha256.Sum256(abiByte), spec)
+}
+
+// Get 返回abi解析后的结果，没有缓存时解析并加入缓存
+func (c *abiSpecCache) Get(abiByte []byte) (*abi.Spec, error) {
+	key := sha256.Sum256(abiByte)
+	if spec, ok := c.lru.Get(key); ok {
+		return spec.(*abi.Spec), nil
+	}
+	spec, err := abi.ReadSpec(abiByte)
+	if err != nil {
+		return nil, err
+	}
+	c.lru.Add(key, spec)
+	c.persist(hex.EncodeToString(key[:]), abiByte)
+	return spec, nil
+}
+
+// persist 把abi写入磁盘缓存，写入失败不影响合约执行
+func (c *abiSpecCache) persist(id string, abiByte []byte) {
+	if c.dir == "" {
+		return
+	}
+	now := time.Now()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if file, ok := c.files[id]; ok {
+		file.lastUsed = now
+		os.Chtimes(c.filePath(id), now, now)
+		return
+	}
+	// 先写入临时文件，完成后再改名，避免出现不完整的缓存
+	tmpfile, err := ioutil.TempFile(c.dir, abiCacheTmpPrefix)
+	if err != nil {
+		return
+	}
+	_, err = tmpfile.Write(abiByte)
+	tmpfile.Close()
+	if err == nil {
+		err = os.Rename(tmpfile.Name(), c.filePath(id))
+	}
+	if err != nil {
+		os.Remove(tmpfile.Name())
+		return
+	}
+	c.files[id] = &abiCacheFile{
+		size:     int64(len(abiByte)),
+		lastUsed: now,
+	}
+	c.size += int64(len(abiByte))
+	c.evictLocked()
+}
+
+func (c *abiSpecCache) removeLocked(id string) {
+	if file, ok := c.files[id]; ok {
+		c.size -= file.size
+		delete(c.files, id)
+	}
+	os.Remove(c.filePath(id))
+}
+
+// evictLocked 淘汰最近最少使用的abi文件直到总大小不超过maxSize，内存中已解析的abi不受影响
+func (c *abiSpecCache) evictLocked() {
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return
+	}
+	ids := make([]string, 0, len(c.files))
+	for id := range c.files {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return c.files[ids[i]].lastUsed.Before(c.files[ids[j]].lastUsed)
+	})
+	for _, id := range ids {
+		if c.size <= c.maxSize {
+			return
+		}
+		c.removeLocked(id)
+	}
+}
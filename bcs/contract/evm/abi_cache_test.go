@@ -0,0 +1,61 @@
+package evm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestAbiSpecCache(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "evm-abi-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	abi1 := []byte(`[{"inputs":[],"name":"retrieve","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`)
+	abi2 := []byte(`[{"inputs":[{"internalType":"uint256","name":"num","type":"uint256"}],"name":"store","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+
+	c, err := newAbiSpecCache(abiCacheCapacity, tmpdir, int64(len(abi1)+len(abi2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(abi1); err != nil {
+		t.Fatal(err)
+	}
+	if c.size != int64(len(abi1)) {
+		t.Fatalf("expect abi persisted, got size %d", c.size)
+	}
+
+	// 重启后从磁盘预先解析
+	c, err = newAbiSpecCache(abiCacheCapacity, tmpdir, int64(len(abi1)+len(abi2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.lru.Get(sha256.Sum256(abi1)); !ok {
+		t.Fatal("expect abi loaded after restart")
+	}
+
+	// 被篡改的文件不会被加载
+	key := sha256.Sum256(abi1)
+	if err := ioutil.WriteFile(c.filePath(hex.EncodeToString(key[:])), abi2, 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err = newAbiSpecCache(abiCacheCapacity, tmpdir, int64(len(abi1)+len(abi2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.lru.Get(key); ok || len(c.files) != 0 {
+		t.Fatal("expect corrupted abi removed")
+	}
+
+	// 超过最大大小后淘汰最近最少使用的文件
+	c.maxSize = int64(len(abi2))
+	c.Get(abi1)
+	c.Get(abi2)
+	if len(c.files) != 1 || c.size != int64(len(abi2)) {
+		t.Fatalf("expect abi1 evicted, got %d files size %d", len(c.files), c.size)
+	}
+}
@@ -19,9 +19,10 @@ const (
 	contractNamePrefixs    = "1111"
 	contractAccountPrefixs = "1112"
 
-	xchainAddrType      = "xchain"
-	contractNameType    = "contract-name"
-	contractAccountType = "contract-account"
+	// EVM地址映射的XuperChain地址类型
+	XchainAddrType      = "xchain"
+	ContractNameType    = "contract-name"
+	ContractAccountType = "contract-account"
 )
 
 // transfer xchain address to evm address
@@ -134,13 +135,13 @@ func DetermineEVMAddress(evmAddr crypto.Address) (string, string, error) {
 	var err error
 	if evmAddrStrWithPrefix[0:4] == contractAccountPrefixs {
 		addr, err = EVMAddressToContractAccount(evmAddr)
-		addrType = contractAccountType
+		addrType = ContractAccountType
 	} else if evmAddrStrWithPrefix[0:4] == contractNamePrefixs {
 		addr, err = EVMAddressToContractName(evmAddr)
-		addrType = contractNameType
+		addrType = ContractNameType
 	} else {
 		addr, err = EVMAddressToXchain(evmAddr)
-		addrType = xchainAddrType
+		addrType = XchainAddrType
 	}
 	if err != nil {
 		return "", "", err
@@ -156,13 +157,13 @@ func DetermineXchainAddress(xAddr string) (string, string, error) {
 	var err error
 	if DetermineContractAccount(xAddr) {
 		addr, err = ContractAccountToEVMAddress(xAddr)
-		addrType = contractAccountType
+		addrType = ContractAccountType
 	} else if DetermineContractName(xAddr) == nil {
 		addr, err = ContractNameToEVMAddress(xAddr)
-		addrType = contractNameType
+		addrType = ContractNameType
 	} else {
 		addr, err = XchainToEVMAddress(xAddr)
-		addrType = xchainAddrType
+		addrType = XchainAddrType
 	}
 	if err != nil {
 		return "", "", err
@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
-	"reflect"
+	"path/filepath"
 
 	"github.com/hyperledger/burrow/crypto"
 	"github.com/hyperledger/burrow/execution/engine"
@@ -18,6 +18,7 @@ import (
 	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
 	xchainpb "github.com/xuperchain/xupercore/protos"
 )
 
@@ -29,38 +30,95 @@ const (
 
 type evmCreator struct {
 	vm *evm.EVM
+	// 启用区块信息之前的虚拟机，只包含以太坊默认的预编译合约
+	legacyVM *evm.EVM
+	syscall  *bridge.SyscallService
+	abiCache *abiSpecCache
+	// 合约事件使用结构化编码的启用高度
+	eventJSON *contract.ForkConfig
+	// 读取账户余额和映射合约账户调用者的启用高度
+	account *contract.ForkConfig
+	// 读取区块信息和调用超级链预编译合约的启用高度
+	blockContext *contract.ForkConfig
 }
 
 func newEvmCreator(config *bridge.InstanceCreatorConfig) (bridge.InstanceCreator, error) {
-	opt := evm.Options{}
+	natives, err := newPrecompiles()
+	if err != nil {
+		return nil, err
+	}
+	opt := evm.Options{
+		Natives: natives,
+	}
 	vm := evm.New(opt)
-	return &evmCreator{
-		vm: vm,
-	}, nil
+	creator := &evmCreator{
+		vm:       vm,
+		legacyVM: evm.New(evm.Options{}),
+	}
+	var cacheDir string
+	var cacheSize int64
+	if config != nil {
+		creator.syscall = config.SyscallService
+		cacheDir = filepath.Join(config.Basedir, "var", "cache")
+		if vmconfig, ok := config.VMConfig.(*contract.EVMConfig); ok {
+			cacheSize = vmconfig.CacheSizeMB << 20
+			creator.eventJSON = vmconfig.EventJSON
+			creator.account = vmconfig.Account
+			creator.blockContext = vmconfig.BlockContext
+		}
+	}
+	creator.abiCache, err = newAbiSpecCache(abiCacheCapacity, cacheDir, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return creator, nil
 }
 
 // CreateInstance instances an evm virtual machine instance which can run a single contract call
 func (e *evmCreator) CreateInstance(ctx *bridge.Context, cp bridge.ContractCodeProvider) (bridge.Instance, error) {
 	state := newStateManager(ctx)
-	blockState := newBlockStateManager(ctx)
+	state.readBalance = e.account.Active(ctx.BlockHeight)
+	blockState := newBlockStateManager(ctx, e.syscall)
+	vm := e.legacyVM
+	if e.blockContext.Active(ctx.BlockHeight) {
+		blockState.active = true
+		vm = e.vm
+	}
 	return &evmInstance{
-		vm:         e.vm,
+		vm:         vm,
 		ctx:        ctx,
 		state:      state,
 		blockState: blockState,
 		cp:         cp,
+		abiCache:   e.abiCache,
+		eventJSON:  e.eventJSON,
+		account:    e.account,
 	}, nil
 }
 
+// RemoveCache abi缓存按内容寻址，合约升级不需要删除
 func (e *evmCreator) RemoveCache(name string) {
 }
 
+// WarmUpCache 预先解析合约的abi
+func (e *evmCreator) WarmUpCache(name string, cp bridge.ContractCodeProvider) error {
+	abiByte, err := cp.GetContractAbi(name)
+	if err != nil {
+		return err
+	}
+	_, err = e.abiCache.Get(abiByte)
+	return err
+}
+
 type evmInstance struct {
 	vm         *evm.EVM
 	ctx        *bridge.Context
 	state      *stateManager
 	blockState *blockStateManager
 	cp         bridge.ContractCodeProvider
+	abiCache   *abiSpecCache
+	eventJSON  *contract.ForkConfig
+	account    *contract.ForkConfig
 	code       []byte
 	abi        []byte
 	gasUsed    uint64
@@ -85,11 +143,12 @@ func (e *evmInstance) Exec() error {
 		return e.deployContract()
 	}
 
-	var caller crypto.Address
-	if DetermineContractAccount(e.state.ctx.Initiator) {
-		caller, err = ContractAccountToEVMAddress(e.state.ctx.Initiator)
+	var origin, caller crypto.Address
+	if e.account.Active(e.ctx.BlockHeight) {
+		origin, caller, err = e.callerAddress()
 	} else {
-		caller, err = XchainToEVMAddress(e.state.ctx.Initiator)
+		// 启用前msg.sender为发起者，不设置tx.origin
+		caller, err = e.initiatorAddress()
 	}
 	if err != nil {
 		return err
@@ -125,6 +184,7 @@ func (e *evmInstance) Exec() error {
 	}
 	params := engine.CallParams{
 		CallType: exec.CallTypeCode,
+		Origin:   origin,
 		Caller:   caller,
 		Callee:   callee,
 		Input:    input,
@@ -153,6 +213,59 @@ func (e *evmInstance) Exec() error {
 	return nil
 }
 
+// initiatorAddress 返回交易发起者对应的EVM地址，用于启用合约账户映射之前的区块
+func (e *evmInstance) initiatorAddress() (crypto.Address, error) {
+	if DetermineContractAccount(e.ctx.Initiator) {
+		return ContractAccountToEVMAddress(e.ctx.Initiator)
+	}
+	return XchainToEVMAddress(e.ctx.Initiator)
+}
+
+// callerAddress 返回交易发起者(tx.origin)和直接调用者(msg.sender)对应的EVM地址。
+// 发起者代表合约账户调用时(发起者为合约账户，或者AuthRequire满足某个合约账户的ACL)，origin为合约账户；
+// 被其他合约调用时，msg.sender为调用方合约，否则与origin相同
+func (e *evmInstance) callerAddress() (crypto.Address, crypto.Address, error) {
+	var origin crypto.Address
+	var err error
+	initiator := e.ctx.Initiator
+	if DetermineContractAccount(initiator) {
+		origin, err = ContractAccountToEVMAddress(initiator)
+	} else if account := authRequireAccount(e.ctx.AuthRequire); account != "" &&
+		e.ctx.Core.VerifyAccountPermission(account, e.ctx.AuthRequire) == nil {
+		origin, err = ContractAccountToEVMAddress(account)
+	} else {
+		origin, err = XchainToEVMAddress(initiator)
+	}
+	if err != nil {
+		return crypto.ZeroAddress, crypto.ZeroAddress, err
+	}
+	if e.ctx.Caller == "" {
+		return origin, origin, nil
+	}
+	caller, err := ContractNameToEVMAddress(e.ctx.Caller)
+	if err != nil {
+		return crypto.ZeroAddress, crypto.ZeroAddress, err
+	}
+	return origin, caller, nil
+}
+
+// authRequireAccount AuthRequire全部是以同一个合约账户开头的路径(account/address)时返回该账户
+func authRequireAccount(authRequire []string) string {
+	var account string
+	for _, auth := range authRequire {
+		ids := utils.SplitAccountURI(auth)
+		if len(ids) < 2 {
+			return ""
+		}
+		name := ids[0]
+		if !DetermineContractAccount(name) || (account != "" && account != name) {
+			return ""
+		}
+		account = name
+	}
+	return account
+}
+
 func (e *evmInstance) ResourceUsed() contract.Limits {
 	return contract.Limits{
 		Cpu: int64(e.gasUsed),
@@ -179,7 +292,11 @@ func (e *evmInstance) Log(log *exec.LogEvent) error {
 	if err != nil {
 		return err
 	}
-	event, err := unpackEventFromAbi(contractAbiByte, contractName, log)
+	spec, err := e.abiCache.Get(contractAbiByte)
+	if err != nil {
+		return err
+	}
+	event, err := unpackEventFromSpec(spec, contractName, log, e.eventJSON.Active(e.ctx.BlockHeight))
 	if err != nil {
 		return err
 	}
@@ -188,13 +305,20 @@ func (e *evmInstance) Log(log *exec.LogEvent) error {
 	return nil
 }
 
-func unpackEventFromAbi(abiByte []byte, contractName string, log *exec.LogEvent) (*xchainpb.ContractEvent, error) {
-	var eventID abi.EventID
-	copy(eventID[:], log.GetTopic(0).Bytes())
+func unpackEventFromAbi(abiByte []byte, contractName string, log *exec.LogEvent,
+	structured bool) (*xchainpb.ContractEvent, error) {
 	spec, err := abi.ReadSpec(abiByte)
 	if err != nil {
 		return nil, err
 	}
+	return unpackEventFromSpec(spec, contractName, log, structured)
+}
+
+// unpackEventFromSpec 按照abi解码事件，structured为false时使用启用结构化编码之前的事件编码
+func unpackEventFromSpec(spec *abi.Spec, contractName string, log *exec.LogEvent,
+	structured bool) (*xchainpb.ContractEvent, error) {
+	var eventID abi.EventID
+	copy(eventID[:], log.GetTopic(0).Bytes())
 	eventSpec, ok := spec.EventsByID[eventID]
 	if !ok {
 		return nil, fmt.Errorf("The Event By ID Not Found ")
@@ -203,33 +327,33 @@ func unpackEventFromAbi(abiByte []byte, contractName string, log *exec.LogEvent)
 	if err := abi.UnpackEvent(eventSpec, log.Topics, log.Data, vals...); err != nil {
 		return nil, err
 	}
-	event := &xchainpb.ContractEvent{
-		Contract: contractName,
-	}
-	var uint8type = reflect.TypeOf((*[]uint8)(nil))
-	event.Name = eventSpec.Name
-	for i := 0; i < len(vals); i++ {
-		t := reflect.TypeOf(vals[i])
-		if t == uint8type {
-			s := fmt.Sprintf("%x", vals[i])
-			vals[i] = s[1:]
-		}
+	var data []byte
+	var err error
+	if structured {
+		data, err = json.Marshal(newEvmEventBody(eventSpec, log, vals))
+	} else {
+		data, err = legacyEventBody(vals)
 	}
-	data, err := json.Marshal(vals)
 	if err != nil {
 		return nil, err
 	}
-	event.Body = data
+	event := &xchainpb.ContractEvent{
+		Contract: contractName,
+		Name:     eventSpec.Name,
+		Body:     data,
+	}
 	return event, nil
 }
 
 func (e *evmInstance) deployContract() error {
-	var caller crypto.Address
+	var origin, caller crypto.Address
 	var err error
-	if DetermineContractAccount(e.state.ctx.Initiator) {
-		caller, err = ContractAccountToEVMAddress(e.state.ctx.Initiator)
+	if e.account.Active(e.ctx.BlockHeight) {
+		origin, caller, err = e.callerAddress()
 	} else {
-		caller, err = XchainToEVMAddress(e.state.ctx.Initiator)
+		// 启用前tx.origin和msg.sender都为发起者
+		caller, err = e.initiatorAddress()
+		origin = caller
 	}
 	if err != nil {
 		return err
@@ -256,7 +380,7 @@ func (e *evmInstance) deployContract() error {
 
 	params := engine.CallParams{
 		CallType: exec.CallTypeCode,
-		Origin:   caller,
+		Origin:   origin,
 		Caller:   caller,
 		Callee:   callee,
 		Input:    input,
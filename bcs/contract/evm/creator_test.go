@@ -4,10 +4,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/hyperledger/burrow/crypto"
 	"github.com/hyperledger/burrow/execution/evm/abi"
 	"github.com/hyperledger/burrow/execution/exec"
+	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 )
 
@@ -127,9 +131,167 @@ func TestUnpackEventFromAbi(t *testing.T) {
 	log.Topics = topics
 	log.Data = data
 
-	event, err := unpackEventFromAbi([]byte(abiJson), contractName, log)
+	event, err := unpackEventFromAbi([]byte(abiJson), contractName, log, false)
 	if err != nil {
 		t.Error(err)
 	}
 	fmt.Printf("%+v\n", event)
 }
+
+func TestUnpackEventBody(t *testing.T) {
+	abiJson := `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"string","name":"key","type":"string"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"},{"indexed":false,"internalType":"bytes","name":"memo","type":"bytes"},{"indexed":false,"internalType":"bool","name":"ok","type":"bool"}],"name":"Increase","type":"event"}]`
+	spec, err := abi.ReadSpec([]byte(abiJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var eventSpec *abi.EventSpec
+	for _, s := range spec.EventsByID {
+		eventSpec = s
+	}
+	type args struct {
+		From  crypto.Address
+		Key   string
+		Value int64
+		Memo  []byte
+		Ok    bool
+	}
+	from := crypto.Address{0xab, 0xcd}
+	topics, data, err := abi.PackEvent(eventSpec, &args{
+		From:  from,
+		Key:   "test",
+		Value: 12,
+		Memo:  []byte{0x01, 0x02},
+		Ok:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := &exec.LogEvent{Topics: topics, Data: data}
+	event, err := unpackEventFromAbi([]byte(abiJson), "counter", log, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Contract != "counter" || event.Name != "Increase" {
+		t.Fatalf("unexpected event:%v", event)
+	}
+
+	body := new(evmEventBody)
+	if err := json.Unmarshal(event.Body, body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Topics) != 3 || body.Topics[0] != hex.EncodeToString(eventSpec.ID.Bytes()) {
+		t.Errorf("unexpected topics:%v", body.Topics)
+	}
+	if body.Data != hex.EncodeToString(data) {
+		t.Errorf("unexpected data:%s", body.Data)
+	}
+	expect := []evmEventParam{
+		{Name: "from", Type: "address", Indexed: true, Value: hex.EncodeToString(from.Bytes())},
+		{Name: "key", Type: "bytes32", Indexed: true, Hashed: true, Value: body.Topics[2]},
+		{Name: "value", Type: "uint256", Value: "12"},
+		{Name: "memo", Type: "bytes", Value: "0102"},
+		{Name: "ok", Type: "bool", Value: true},
+	}
+	if len(body.Params) != len(expect) {
+		t.Fatalf("unexpected params:%s", event.Body)
+	}
+	for i, param := range body.Params {
+		if *param != expect[i] {
+			t.Errorf("param %d expect %v, got %v", i, expect[i], *param)
+		}
+	}
+
+	// 启用高度之前保持原有编码
+	event, err = unpackEventFromAbi([]byte(abiJson), "counter", log, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy := `["ABCD000000000000000000000000000000000000","7465737400000000000000000000000000000000000000000000000000000000",12,"0102",true]`
+	if string(event.Body) != legacy {
+		t.Errorf("unexpected legacy body:%s", event.Body)
+	}
+}
+
+func TestCallerAddress(t *testing.T) {
+	xchainAddr := "jSPJQSAR3NWoKcSFMxYGfcY8KVskvNMtm"
+	contractAccount := "XC1111111111111113@xuper"
+	xchainEVMAddr, _ := XchainToEVMAddress(xchainAddr)
+	accountEVMAddr, _ := ContractAccountToEVMAddress(contractAccount)
+	callerEVMAddr, _ := ContractNameToEVMAddress("caller")
+
+	testCases := []struct {
+		name        string
+		initiator   string
+		authRequire []string
+		caller      string
+		origin      crypto.Address
+		sender      crypto.Address
+	}{
+		{"address", xchainAddr, []string{xchainAddr}, "", xchainEVMAddr, xchainEVMAddr},
+		{"account initiator", contractAccount, []string{contractAccount + "/" + xchainAddr}, "", accountEVMAddr, accountEVMAddr},
+		{"account authorized", xchainAddr, []string{contractAccount + "/" + xchainAddr, contractAccount + "/addr2"}, "", accountEVMAddr, accountEVMAddr},
+		{"account unauthorized", xchainAddr, []string{"XC2222222222222222@xuper/" + xchainAddr}, "", xchainEVMAddr, xchainEVMAddr},
+		{"mixed auth require", xchainAddr, []string{contractAccount + "/" + xchainAddr, xchainAddr}, "", xchainEVMAddr, xchainEVMAddr},
+		{"contract caller", contractAccount, nil, "caller", accountEVMAddr, callerEVMAddr},
+	}
+	for _, tc := range testCases {
+		e := &evmInstance{
+			ctx: &bridge.Context{
+				Initiator:   tc.initiator,
+				AuthRequire: tc.authRequire,
+				Caller:      tc.caller,
+				Core:        new(fakeChainCore),
+			},
+		}
+		origin, sender, err := e.callerAddress()
+		if err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		}
+		if origin != tc.origin || sender != tc.sender {
+			t.Errorf("%s: expect origin %s sender %s, got %s %s", tc.name, tc.origin, tc.sender, origin, sender)
+		}
+	}
+}
+
+func TestAccountFork(t *testing.T) {
+	creator := &evmCreator{
+		account: &contract.ForkConfig{Height: 10},
+	}
+	for height, expect := range map[int64]bool{9: false, 10: true, 11: true} {
+		instance, err := creator.CreateInstance(&bridge.Context{BlockHeight: height}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if instance.(*evmInstance).state.readBalance != expect {
+			t.Errorf("height %d: expect read balance %v", height, expect)
+		}
+	}
+}
+
+func TestBlockContextFork(t *testing.T) {
+	basedir, err := ioutil.TempDir("", "evm-block-context")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(basedir)
+	creator, err := newEvmCreator(&bridge.InstanceCreatorConfig{
+		Basedir: basedir,
+		VMConfig: &contract.EVMConfig{
+			BlockContext: &contract.ForkConfig{Height: 10},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evmCreator := creator.(*evmCreator)
+	for height, expect := range map[int64]bool{9: false, 10: true, 11: true} {
+		instance, err := creator.CreateInstance(&bridge.Context{BlockHeight: height}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ins := instance.(*evmInstance)
+		if ins.blockState.active != expect || (ins.vm == evmCreator.vm) != expect {
+			t.Errorf("height %d: expect block context %v", height, expect)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package evm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/hyperledger/burrow/crypto"
+	"github.com/hyperledger/burrow/execution/evm/abi"
+	"github.com/hyperledger/burrow/execution/exec"
+)
+
+// evmEventBody 是EVM合约事件的结构化编码，作为ContractEvent的Body以JSON格式保存。
+// 除了按照ABI解码的参数之外，还保留原始的topics和data，订阅者不需要ABI也可以按照indexed参数匹配事件
+type evmEventBody struct {
+	Name   string           `json:"name"`
+	Params []*evmEventParam `json:"params"`
+	// 原始topics，第一个topic为事件签名的哈希，均为小写十六进制
+	Topics []string `json:"topics"`
+	// 原始data，小写十六进制
+	Data string `json:"data"`
+}
+
+// evmEventParam 是解码后的事件参数，Value的编码方式：
+// 整数为十进制字符串，address和bytes为小写十六进制字符串，bool和string保持原样，数组为元素的列表。
+// indexed的动态类型参数(string、bytes和数组)在topic中只保存了哈希，Hashed为true，Type为bytes32，Value为哈希值
+type evmEventParam struct {
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Indexed bool        `json:"indexed"`
+	Hashed  bool        `json:"hashed,omitempty"`
+	Value   interface{} `json:"value"`
+}
+
+// legacyEventBody 是启用结构化编码之前的事件编码，为参数值的JSON数组，bytes参数为十六进制字符串。
+// 启用高度之前的区块中的事件使用这种编码，保证重放时事件内容不变
+func legacyEventBody(vals []interface{}) ([]byte, error) {
+	var uint8type = reflect.TypeOf((*[]uint8)(nil))
+	for i := 0; i < len(vals); i++ {
+		t := reflect.TypeOf(vals[i])
+		if t == uint8type {
+			s := fmt.Sprintf("%x", vals[i])
+			vals[i] = s[1:]
+		}
+	}
+	return json.Marshal(vals)
+}
+
+func newEvmEventBody(eventSpec *abi.EventSpec, log *exec.LogEvent, vals []interface{}) *evmEventBody {
+	body := &evmEventBody{
+		Name:   eventSpec.Name,
+		Params: make([]*evmEventParam, 0, len(eventSpec.Inputs)),
+		Topics: make([]string, 0, len(log.Topics)),
+		Data:   hex.EncodeToString(log.Data),
+	}
+	for i, input := range eventSpec.Inputs {
+		typ := input.EVM.GetSignature()
+		if input.IsArray {
+			if input.ArrayLength > 0 {
+				typ = fmt.Sprintf("%s[%d]", typ, input.ArrayLength)
+			} else {
+				typ += "[]"
+			}
+		}
+		body.Params = append(body.Params, &evmEventParam{
+			Name:    input.Name,
+			Type:    typ,
+			Indexed: input.Indexed,
+			Hashed:  input.Hashed,
+			Value:   formatEventValue(reflect.ValueOf(vals[i])),
+		})
+	}
+	for _, topic := range log.Topics {
+		body.Topics = append(body.Topics, hex.EncodeToString(topic.Bytes()))
+	}
+	return body
+}
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	addressType  = reflect.TypeOf(crypto.Address{})
+)
+
+// formatEventValue 把abi.GetPackingTypes解码出的值转换为稳定的JSON编码
+func formatEventValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case bigIntType:
+		n := v.Interface().(big.Int)
+		return n.String()
+	case bigFloatType:
+		f := v.Interface().(big.Float)
+		return f.String()
+	case addressType:
+		addr := v.Interface().(crypto.Address)
+		return hex.EncodeToString(addr.Bytes())
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("%d", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%d", v.Uint())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			buf := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(buf), v)
+			return hex.EncodeToString(buf)
+		}
+		list := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, formatEventValue(v.Index(i)))
+		}
+		return list
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,269 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/burrow/crypto"
+	"github.com/hyperledger/burrow/execution/errors"
+	"github.com/hyperledger/burrow/execution/exec"
+	"github.com/hyperledger/burrow/execution/native"
+	"github.com/hyperledger/burrow/permission"
+
+	xabi "github.com/xuperchain/xupercore/bcs/contract/evm/abi"
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
+	"github.com/xuperchain/xupercore/kernel/contract/proposal/utils"
+)
+
+// 预编译合约的保留地址，以太坊的预编译合约使用0x01~0x09，超级链从0x0400开始
+var (
+	aclPrecompileAddress          = crypto.Address{18: 0x04, 19: 0x00}
+	governTokenPrecompileAddress  = crypto.Address{18: 0x04, 19: 0x01}
+	contractCallPrecompileAddress = crypto.Address{18: 0x04, 19: 0x02}
+)
+
+const (
+	// 每次调用预编译合约消耗的gas，被调用合约的资源消耗另外计算
+	precompileGas = 1000
+
+	aclPrecompileABI = `[
+	{"type":"function","name":"getAccountAddresses","stateMutability":"view",
+	 "inputs":[{"name":"account","type":"string"}],"outputs":[{"name":"addresses","type":"string"}]},
+	{"type":"function","name":"hasAddress","stateMutability":"view",
+	 "inputs":[{"name":"account","type":"string"},{"name":"addr","type":"string"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+	governTokenPrecompileABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view",
+	 "inputs":[{"name":"account","type":"string"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view",
+	 "inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+	contractCallPrecompileABI = `[
+	{"type":"function","name":"call","stateMutability":"nonpayable",
+	 "inputs":[{"name":"module","type":"string"},{"name":"contractName","type":"string"},
+	           {"name":"method","type":"string"},{"name":"args","type":"string"}],
+	 "outputs":[{"name":"","type":"bytes"}]}
+]`
+)
+
+// precompileMethod 执行预编译合约的方法，参数和返回值由abi自动编解码
+type precompileMethod func(env *precompileEnv, args []interface{}) ([]interface{}, error)
+
+type precompiledContract struct {
+	abi     *xabi.ABI
+	methods map[string]precompileMethod
+}
+
+// precompileEnv 是预编译合约执行时当前合约调用的上下文
+type precompileEnv struct {
+	ctx     *bridge.Context
+	syscall *bridge.SyscallService
+	static  bool
+}
+
+var (
+	aclPrecompile = mustPrecompiledContract(aclPrecompileABI, map[string]precompileMethod{
+		"getAccountAddresses": getAccountAddresses,
+		"hasAddress":          hasAddress,
+	})
+	governTokenPrecompile = mustPrecompiledContract(governTokenPrecompileABI, map[string]precompileMethod{
+		"balanceOf":   governTokenBalanceOf,
+		"totalSupply": governTokenTotalSupply,
+	})
+	contractCallPrecompile = mustPrecompiledContract(contractCallPrecompileABI, map[string]precompileMethod{
+		"call": contractCall,
+	})
+)
+
+func mustPrecompiledContract(abiJSON string, methods map[string]precompileMethod) *precompiledContract {
+	enc, err := xabi.New([]byte(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return &precompiledContract{
+		abi:     enc,
+		methods: methods,
+	}
+}
+
+// newPrecompiles returns burrow default natives together with xuperchain precompiled contracts
+func newPrecompiles() (*native.Natives, error) {
+	xnatives, err := native.New().
+		Function("Query addresses of xuperchain account", aclPrecompileAddress,
+			permission.None, xuperAcl)
+	if err != nil {
+		return nil, err
+	}
+	xnatives, err = xnatives.Function("Query govern token balance", governTokenPrecompileAddress,
+		permission.None, xuperGovernToken)
+	if err != nil {
+		return nil, err
+	}
+	xnatives, err = xnatives.Function("Call wasm, native or kernel contract", contractCallPrecompileAddress,
+		permission.None, xuperContractCall)
+	if err != nil {
+		return nil, err
+	}
+	return native.Merge(native.MustDefaultNatives(), xnatives)
+}
+
+// burrow使用函数名作为预编译合约的名字，因此每个预编译合约需要一个独立的入口函数
+func xuperAcl(ctx native.Context) ([]byte, error) {
+	return aclPrecompile.call(ctx)
+}
+
+func xuperGovernToken(ctx native.Context) ([]byte, error) {
+	return governTokenPrecompile.call(ctx)
+}
+
+func xuperContractCall(ctx native.Context) ([]byte, error) {
+	return contractCallPrecompile.call(ctx)
+}
+
+func (p *precompiledContract) call(ctx native.Context) ([]byte, error) {
+	if *ctx.Gas < precompileGas {
+		return nil, errors.Codes.InsufficientGas
+	}
+	*ctx.Gas -= precompileGas
+
+	blockState, ok := ctx.State.Blockchain.(*blockStateManager)
+	if !ok {
+		return nil, errors.Errorf(errors.Codes.NativeFunction, "contract context not found")
+	}
+	env := &precompileEnv{
+		ctx:     blockState.ctx,
+		syscall: blockState.syscall,
+		static:  ctx.CallType == exec.CallTypeStatic,
+	}
+
+	methodName, args, err := p.abi.DecodeInput(ctx.Input)
+	if err != nil {
+		return nil, errors.Errorf(errors.Codes.NativeFunction, "decode input error:%s", err)
+	}
+	method, ok := p.methods[methodName]
+	if !ok {
+		return nil, errors.Errorf(errors.Codes.NativeFunction, "method %s not found", methodName)
+	}
+	rets, err := method(env, args)
+	if err != nil {
+		return nil, errors.Errorf(errors.Codes.ExecutionReverted, "%s", err)
+	}
+	return p.abi.EncodeOutput(methodName, rets...)
+}
+
+// callContract 调用其他合约，和KContext.Call一样经由SyscallService执行，被调用合约的资源消耗计入当前合约
+func (env *precompileEnv) callContract(module, contractName, method string, args map[string][]byte) (*pb.Response, error) {
+	if env.syscall == nil {
+		return nil, fmt.Errorf("contract call not supported")
+	}
+	var argPairs []*pb.ArgPair
+	for k, v := range args {
+		argPairs = append(argPairs, &pb.ArgPair{
+			Key:   k,
+			Value: v,
+		})
+	}
+	request := &pb.ContractCallRequest{
+		Header: &pb.SyscallHeader{
+			Ctxid: env.ctx.ID,
+		},
+		Module:   module,
+		Contract: contractName,
+		Method:   method,
+		Args:     argPairs,
+	}
+	resp, err := env.syscall.ContractCall(context.TODO(), request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetResponse().GetStatus() >= contract.StatusErrorThreshold {
+		return nil, fmt.Errorf("call %s.%s failed:%s", contractName, method, resp.GetResponse().GetMessage())
+	}
+	return resp.GetResponse(), nil
+}
+
+func getAccountAddresses(env *precompileEnv, args []interface{}) ([]interface{}, error) {
+	addresses, err := env.ctx.Core.GetAccountAddresses(args[0].(string))
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(addresses)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{string(out)}, nil
+}
+
+func hasAddress(env *precompileEnv, args []interface{}) ([]interface{}, error) {
+	addresses, err := env.ctx.Core.GetAccountAddresses(args[0].(string))
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addresses {
+		if addr == args[1].(string) {
+			return []interface{}{true}, nil
+		}
+	}
+	return []interface{}{false}, nil
+}
+
+func governTokenBalanceOf(env *precompileEnv, args []interface{}) ([]interface{}, error) {
+	resp, err := env.callContract(string(bridge.TypeKernel), utils.GovernTokenKernelContract, "Query",
+		map[string][]byte{"account": []byte(args[0].(string))})
+	if err != nil {
+		return nil, err
+	}
+	balance := new(utils.GovernTokenBalance)
+	err = json.Unmarshal(resp.GetBody(), balance)
+	if err != nil {
+		return nil, err
+	}
+	total := balance.TotalBalance
+	if total == nil {
+		total = big.NewInt(0)
+	}
+	return []interface{}{total}, nil
+}
+
+func governTokenTotalSupply(env *precompileEnv, args []interface{}) ([]interface{}, error) {
+	resp, err := env.callContract(string(bridge.TypeKernel), utils.GovernTokenKernelContract, "TotalSupply", nil)
+	if err != nil {
+		return nil, err
+	}
+	totalSupply, ok := new(big.Int).SetString(string(resp.GetBody()), 10)
+	if !ok {
+		return nil, fmt.Errorf("bad total supply %s", resp.GetBody())
+	}
+	return []interface{}{totalSupply}, nil
+}
+
+// contractCall 的args为json编码的参数，如{"key":"value"}
+func contractCall(env *precompileEnv, args []interface{}) ([]interface{}, error) {
+	// STATICCALL中不允许调用可能修改状态的合约
+	if env.static {
+		return nil, fmt.Errorf("contract call not permitted in static call")
+	}
+	callArgs := make(map[string]string)
+	if argsJSON := args[3].(string); argsJSON != "" {
+		if err := json.Unmarshal([]byte(argsJSON), &callArgs); err != nil {
+			return nil, fmt.Errorf("bad contract call args:%s", err)
+		}
+	}
+	kargs := make(map[string][]byte, len(callArgs))
+	for k, v := range callArgs {
+		kargs[k] = []byte(v)
+	}
+	resp, err := env.callContract(args[0].(string), args[1].(string), args[2].(string), kargs)
+	if err != nil {
+		return nil, err
+	}
+	body := resp.GetBody()
+	if body == nil {
+		body = []byte{}
+	}
+	return []interface{}{body}, nil
+}
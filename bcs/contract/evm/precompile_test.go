@@ -0,0 +1,194 @@
+package evm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/burrow/execution/engine"
+	"github.com/hyperledger/burrow/execution/evm/abi"
+	"github.com/hyperledger/burrow/execution/exec"
+	"github.com/hyperledger/burrow/execution/native"
+
+	xabi "github.com/xuperchain/xupercore/bcs/contract/evm/abi"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge"
+	"github.com/xuperchain/xupercore/kernel/ledger"
+)
+
+type fakeChainCore struct {
+	contract.ChainCore
+}
+
+func (f *fakeChainCore) GetAccountAddresses(accountName string) ([]string, error) {
+	return []string{"addr1", "addr2"}, nil
+}
+
+func (f *fakeChainCore) VerifyAccountPermission(accountName string, authRequire []string) error {
+	if accountName != "XC1111111111111113@xuper" {
+		return errors.New("verify account permission failed")
+	}
+	return nil
+}
+
+// QueryBlock 测试链的区块id为区块高度
+func (f *fakeChainCore) QueryBlock(blockid []byte) (ledger.BlockHandle, error) {
+	height := int64(blockid[0])
+	return state.NewBlockAgent(&xldgpb.InternalBlock{
+		Blockid:   blockid,
+		PreHash:   []byte{byte(height - 1)},
+		Height:    height,
+		Timestamp: height * int64(time.Second),
+	}), nil
+}
+
+// blockReadState 记录合约读取区块信息的高度
+type blockReadState struct {
+	contract.StateSandbox
+	height int64
+}
+
+func (s *blockReadState) RecordBlockRead(height int64) {
+	s.height = height
+}
+
+func newTestBlockState() *blockStateManager {
+	bs := newBlockStateManager(&bridge.Context{
+		ContractName: "contractName",
+		State:        &blockReadState{height: -1},
+		Core:         new(fakeChainCore),
+		BlockHeight:  10,
+		BlockTime:    9 * int64(time.Second),
+		BlockPreHash: []byte{9},
+	}, nil)
+	bs.active = true
+	return bs
+}
+
+func TestBlockStateManager(t *testing.T) {
+	bs := newTestBlockState()
+	state := bs.ctx.State.(*blockReadState)
+	if bs.LastBlockHeight() != 10 {
+		t.Errorf("expect last block height 10, got %d", bs.LastBlockHeight())
+	}
+	if !bs.LastBlockTime().Equal(time.Unix(9, 0)) {
+		t.Errorf("expect last block time %v, got %v", time.Unix(9, 0), bs.LastBlockTime())
+	}
+	for _, height := range []uint64{9, 5, 0} {
+		hash, err := bs.BlockHash(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hash) != 1 || uint64(hash[0]) != height {
+			t.Errorf("bad block hash at height %d: %x", height, hash)
+		}
+	}
+	// 当前及之后的区块没有区块id
+	for _, height := range []uint64{10, 11} {
+		hash, err := bs.BlockHash(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hash) != 0 {
+			t.Errorf("expect empty block hash at height %d, got %x", height, hash)
+		}
+	}
+
+	if state.height != 10 {
+		t.Errorf("expect block read at height 10, got %d", state.height)
+	}
+
+	// 启用之前区块信息为空，也不记录读取
+	state.height = -1
+	bs.active = false
+	if bs.LastBlockHeight() != 0 || !bs.LastBlockTime().IsZero() {
+		t.Errorf("expect empty block info before fork, got %d %v", bs.LastBlockHeight(), bs.LastBlockTime())
+	}
+	if hash, err := bs.BlockHash(9); err != nil || len(hash) != 0 {
+		t.Errorf("expect empty block hash before fork, got %x, err:%v", hash, err)
+	}
+	if state.height != -1 {
+		t.Errorf("expect no block read before fork, got %d", state.height)
+	}
+}
+
+func callPrecompile(t *testing.T, f func(native.Context) ([]byte, error), callType exec.CallType,
+	abiJSON, method string, args map[string]interface{}, gas *uint64) ([]byte, error) {
+	enc, err := xabi.New([]byte(abiJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := enc.Encode(method, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f(native.Context{
+		State: engine.State{
+			Blockchain: newTestBlockState(),
+		},
+		CallParams: engine.CallParams{
+			CallType: callType,
+			Input:    input,
+			Gas:      gas,
+		},
+	})
+}
+
+func TestAclPrecompile(t *testing.T) {
+	spec, err := abi.ReadSpec([]byte(aclPrecompileABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gas := uint64(precompileGas * 2)
+	out, err := callPrecompile(t, xuperAcl, exec.CallTypeStatic, aclPrecompileABI, "getAccountAddresses",
+		map[string]interface{}{"account": "XC1111111111111111@xuper"}, &gas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addresses string
+	if err := spec.Unpack(out, "getAccountAddresses", &addresses); err != nil {
+		t.Fatal(err)
+	}
+	if addresses != `["addr1","addr2"]` {
+		t.Errorf("bad addresses %s", addresses)
+	}
+	if gas != precompileGas {
+		t.Errorf("expect gas left %d, got %d", precompileGas, gas)
+	}
+
+	out, err = callPrecompile(t, xuperAcl, exec.CallTypeStatic, aclPrecompileABI, "hasAddress",
+		map[string]interface{}{"account": "XC1111111111111111@xuper", "addr": "addr2"}, &gas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var has bool
+	if err := spec.Unpack(out, "hasAddress", &has); err != nil {
+		t.Fatal(err)
+	}
+	if !has {
+		t.Error("expect addr2 in account")
+	}
+
+	_, err = callPrecompile(t, xuperAcl, exec.CallTypeStatic, aclPrecompileABI, "hasAddress",
+		map[string]interface{}{"account": "XC1111111111111111@xuper", "addr": "addr2"}, &gas)
+	if err == nil {
+		t.Error("expect insufficient gas error")
+	}
+}
+
+func TestContractCallPrecompileInStaticCall(t *testing.T) {
+	gas := uint64(precompileGas)
+	_, err := callPrecompile(t, xuperContractCall, exec.CallTypeStatic, contractCallPrecompileABI, "call",
+		map[string]interface{}{
+			"module":       "wasm",
+			"contractName": "counter",
+			"method":       "increase",
+			"args":         `{"key":"test"}`,
+		}, &gas)
+	if err == nil {
+		t.Error("expect contract call in static call failed")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hyperledger/burrow/acm"
 	"github.com/hyperledger/burrow/binary"
 	"github.com/hyperledger/burrow/crypto"
+	"github.com/hyperledger/burrow/execution/evm"
 	"github.com/hyperledger/burrow/permission"
 
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
@@ -14,6 +15,8 @@ import (
 
 type stateManager struct {
 	ctx *bridge.Context
+	// 是否读取账户余额，启用前账户余额为空
+	readBalance bool
 }
 
 func newStateManager(ctx *bridge.Context) *stateManager {
@@ -30,7 +33,7 @@ func (s *stateManager) GetAccount(address crypto.Address) (*acm.Account, error)
 	}
 
 	var evmCode []byte
-	if addrType == contractNameType {
+	if addrType == ContractNameType {
 		v, err := s.ctx.State.Get("contract", evmCodeKey(addr))
 		if err != nil {
 			return nil, nil
@@ -38,11 +41,14 @@ func (s *stateManager) GetAccount(address crypto.Address) (*acm.Account, error)
 		evmCode = v
 	}
 
-	// balance, err := s.ctx.Core.GetBalance(addr)
-	// if err != nil {
-	// return nil, nil
-	// }
+	// 余额来自映射的地址、合约账户或者合约名下的utxo
 	var balance *big.Int
+	if s.readBalance {
+		balance, err = s.ctx.State.Balance(addr)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &acm.Account{
 		Address:     address,
 		Balance:     balance,
@@ -96,7 +102,7 @@ func (s *stateManager) Transfer(from, to crypto.Address, amount *big.Int) error
 
 	// return directly when from is xchain address or contract account
 	// only transfer from a contract name works
-	if addrType == contractAccountType || addrType == xchainAddrType {
+	if addrType == ContractAccountType || addrType == XchainAddrType {
 		return nil
 	}
 
@@ -108,46 +114,61 @@ func (s *stateManager) Transfer(from, to crypto.Address, amount *big.Int) error
 	return s.ctx.State.Transfer(fromAddr, toAddr, amount)
 }
 
+// blockStateManager 提供EVM的区块信息，同时为预编译合约提供当前合约调用的上下文
 type blockStateManager struct {
-	ctx *bridge.Context
+	ctx     *bridge.Context
+	syscall *bridge.SyscallService
+	// 区块信息在启用高度之后才能读取，之前的区块读取结果为空
+	active bool
 }
 
-func newBlockStateManager(ctx *bridge.Context) *blockStateManager {
+func newBlockStateManager(ctx *bridge.Context, syscall *bridge.SyscallService) *blockStateManager {
 	return &blockStateManager{
-		ctx: ctx,
+		ctx:     ctx,
+		syscall: syscall,
 	}
 }
 
-// LastBlockHeight
+// LastBlockHeight 返回交易执行所在区块的高度，在整个交易的执行过程中保持不变
 func (s *blockStateManager) LastBlockHeight() uint64 {
-	// TODO
-	return 0
-	//block, err := s.ctx.Core.QueryLastBlock()
-	//if err != nil {
-	//	return 0
-	//}
-	//return uint64(block.GetHeight())
+	if !s.active {
+		return 0
+	}
+	s.ctx.State.RecordBlockRead(s.ctx.BlockHeight)
+	return uint64(s.ctx.BlockHeight)
 }
 
-// LastBlockTime
+// LastBlockTime 返回交易执行所在区块的时间
 func (s *blockStateManager) LastBlockTime() time.Time {
-	// TODO
-	return time.Time{}
-	//block, err := s.ctx.Core.QueryLastBlock()
-	//if err != nil {
-	//	return time.Time{}
-	//}
-	//timestamp := block.GetTimestamp()
-	//return time.Unix(timestamp/1e9, timestamp%1e9)
+	if !s.active {
+		return time.Time{}
+	}
+	s.ctx.State.RecordBlockRead(s.ctx.BlockHeight)
+	return time.Unix(0, s.ctx.BlockTime)
 }
 
-// LastBlockHeight
+// BlockHash 返回交易执行所在区块的祖先区块中指定高度的区块id，
+// 从父区块沿着PreHash回溯，分叉上的执行结果与主干无关
 func (s *blockStateManager) BlockHash(height uint64) ([]byte, error) {
-	return nil, nil
-	// TODO
-	//block, err := s.ctx.Core.QueryBlockByHeight(int64(height))
-	//if err != nil {
-	//	return nil, err
-	//}
-	//return block.GetBlockid(), nil
+	if !s.active {
+		return nil, nil
+	}
+	s.ctx.State.RecordBlockRead(s.ctx.BlockHeight)
+	if s.ctx.BlockHeight <= 0 || height >= uint64(s.ctx.BlockHeight) {
+		return nil, nil
+	}
+	blockid := s.ctx.BlockPreHash
+	for h := s.ctx.BlockHeight - 1; ; h-- {
+		if uint64(h) == height {
+			return blockid, nil
+		}
+		if uint64(s.ctx.BlockHeight)-uint64(h) >= evm.MaximumAllowedBlockLookBack {
+			return nil, nil
+		}
+		block, err := s.ctx.Core.QueryBlock(blockid)
+		if err != nil {
+			return nil, err
+		}
+		blockid = block.GetPreHash()
+	}
 }
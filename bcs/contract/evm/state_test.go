@@ -1,10 +1,12 @@
 package evm
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/hyperledger/burrow/crypto"
 
+	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 )
 
@@ -19,3 +21,62 @@ func TestNewStateManager(t *testing.T) {
 
 	st.RemoveAccount(crypto.Address{})
 }
+
+type balanceState struct {
+	contract.StateSandbox
+	balances map[string]*big.Int
+}
+
+func (s *balanceState) Balance(addr string) (*big.Int, error) {
+	balance, ok := s.balances[addr]
+	if !ok {
+		return new(big.Int), nil
+	}
+	return balance, nil
+}
+
+func TestGetAccountBalance(t *testing.T) {
+	xchainAddr := "jSPJQSAR3NWoKcSFMxYGfcY8KVskvNMtm"
+	contractAccount := "XC1111111111111113@xuper"
+	st := newStateManager(&bridge.Context{
+		State: &balanceState{
+			balances: map[string]*big.Int{
+				xchainAddr:      big.NewInt(10),
+				contractAccount: big.NewInt(20),
+			},
+		},
+	})
+	st.readBalance = true
+	testCases := []struct {
+		addr    string
+		balance int64
+	}{
+		{xchainAddr, 10},
+		{contractAccount, 20},
+	}
+	for _, tc := range testCases {
+		evmAddrHex, _, err := DetermineXchainAddress(tc.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		evmAddr, _ := crypto.AddressFromHexString(evmAddrHex)
+		account, err := st.GetAccount(evmAddr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.Balance.Int64() != tc.balance {
+			t.Errorf("expect balance of %s %d, got %s", tc.addr, tc.balance, account.Balance)
+		}
+	}
+
+	// 启用高度之前不读取余额
+	st.readBalance = false
+	evmAddr, _ := XchainToEVMAddress(xchainAddr)
+	account, err := st.GetAccount(evmAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Balance != nil {
+		t.Errorf("expect nil balance before fork, got %s", account.Balance)
+	}
+}
@@ -33,6 +33,9 @@ type contractProcess struct {
 	monitorWaiter sync.WaitGroup
 	logger        log15.Logger
 
+	// 心跳检查和调用超时都会重启进程
+	restartMutex sync.Mutex
+
 	mutex     sync.Mutex
 	rpcPort   int
 	rpcConn   *grpc.ClientConn
@@ -63,6 +66,19 @@ func (c *contractProcess) makeNativeProcess() (Process, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.cfg.Docker.Enable && c.cfg.Sandbox.Enable {
+		return nil, fmt.Errorf("native docker and sandbox can not be enabled at the same time")
+	}
+	if c.cfg.Sandbox.Enable {
+		return &SandboxProcess{
+			basedir:   c.basedir,
+			startcmd:  startcmd,
+			envs:      envs,
+			chainAddr: c.chainAddr,
+			cfg:       &c.cfg.Sandbox,
+			Logger:    c.logger,
+		}, nil
+	}
 	if !c.cfg.Docker.Enable {
 		return &HostProcess{
 			basedir:  c.basedir,
@@ -146,7 +162,8 @@ func (c *contractProcess) resetRpcClient() error {
 	if err != nil {
 		return err
 	}
-	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure())
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure(),
+		grpc.WithContextDialer(c.dialCode))
 	if err != nil {
 		return err
 	}
@@ -156,6 +173,22 @@ func (c *contractProcess) resetRpcClient() error {
 	return nil
 }
 
+// codeDialer is implemented by Process which can not be reached from host network
+type codeDialer interface {
+	DialContext(ctx context.Context, addr string) (net.Conn, error)
+}
+
+// dialCode 连接合约进程的rpc端口
+func (c *contractProcess) dialCode(ctx context.Context, addr string) (net.Conn, error) {
+	c.mutex.Lock()
+	process := c.process
+	c.mutex.Unlock()
+	if dialer, ok := process.(codeDialer); ok {
+		return dialer.DialContext(ctx, addr)
+	}
+	return new(net.Dialer).DialContext(ctx, "tcp", addr)
+}
+
 func (c *contractProcess) RpcClient() pbrpc.NativeCodeClient {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -163,6 +196,8 @@ func (c *contractProcess) RpcClient() pbrpc.NativeCodeClient {
 }
 
 func (c *contractProcess) restartProcess() error {
+	c.restartMutex.Lock()
+	defer c.restartMutex.Unlock()
 	c.process.Stop(time.Second)
 	return c.start(false)
 }
@@ -172,10 +207,13 @@ func (c *contractProcess) start(startMonitor bool) error {
 	if err != nil {
 		return err
 	}
-	c.process, err = c.makeNativeProcess()
+	process, err := c.makeNativeProcess()
 	if err != nil {
 		return err
 	}
+	c.mutex.Lock()
+	c.process = process
+	c.mutex.Unlock()
 
 	err = c.process.Start()
 	if err != nil {
@@ -2,8 +2,12 @@ package native
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
@@ -12,15 +16,27 @@ import (
 	"google.golang.org/grpc"
 )
 
+var (
+	// ErrResourceExceeds is returned when native contract invocation exceeds resource limits
+	ErrResourceExceeds = errors.New("resource exceeds")
+)
+
 type nativeCreator struct {
 	config   *bridge.InstanceCreatorConfig
+	vmconfig *contract.NativeConfig
 	listener net.Listener
 	pm       *processManager
+
+	// 正在执行的合约实例，用于系统调用的资源计量
+	mutex     sync.Mutex
+	instances map[int64]*nativeVmInstance
 }
 
 func newNativeCreator(cfg *bridge.InstanceCreatorConfig) (bridge.InstanceCreator, error) {
 	creator := &nativeCreator{
-		config: cfg,
+		config:    cfg,
+		vmconfig:  cfg.VMConfig.(*contract.NativeConfig),
+		instances: make(map[int64]*nativeVmInstance),
 	}
 	err := os.MkdirAll(cfg.Basedir, 0755)
 	if err != nil {
@@ -32,7 +48,7 @@ func newNativeCreator(cfg *bridge.InstanceCreatorConfig) (bridge.InstanceCreator
 		return nil, err
 	}
 
-	pm, err := newProcessManager(cfg.VMConfig.(*contract.NativeConfig), cfg.Basedir, listenAddr)
+	pm, err := newProcessManager(creator.vmconfig, cfg.Basedir, listenAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +63,7 @@ func (n *nativeCreator) startRpcServer(service *bridge.SyscallService) (string,
 		return "", err
 	}
 	n.listener = listener
-	rpcServer := grpc.NewServer()
+	rpcServer := grpc.NewServer(grpc.UnaryInterceptor(n.meterSyscall))
 	pbrpc.RegisterSyscallServer(rpcServer, service)
 	go rpcServer.Serve(listener)
 
@@ -60,7 +76,24 @@ func (n *nativeCreator) CreateInstance(ctx *bridge.Context, cp bridge.ContractCo
 	if err != nil {
 		return nil, err
 	}
-	return newNativeVmInstance(ctx, process), nil
+	instance := newNativeVmInstance(ctx, n.vmconfig, process, n.removeInstance)
+	n.mutex.Lock()
+	n.instances[ctx.ID] = instance
+	n.mutex.Unlock()
+	return instance, nil
+}
+
+func (n *nativeCreator) lookupInstance(ctxid int64) (*nativeVmInstance, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	instance, ok := n.instances[ctxid]
+	return instance, ok
+}
+
+func (n *nativeCreator) removeInstance(ctxid int64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.instances, ctxid)
 }
 
 func (n *nativeCreator) RemoveCache(name string) {
@@ -68,36 +101,121 @@ func (n *nativeCreator) RemoveCache(name string) {
 }
 
 type nativeVmInstance struct {
-	ctx     *bridge.Context
-	process *contractProcess
+	ctx      *bridge.Context
+	vmconfig *contract.NativeConfig
+	process  *contractProcess
+	meter    resourceMeter
+	release  func(ctxid int64)
+
+	mutex    sync.Mutex
+	cancel   context.CancelFunc
+	abortErr error
 }
 
-func newNativeVmInstance(ctx *bridge.Context, process *contractProcess) *nativeVmInstance {
+func newNativeVmInstance(ctx *bridge.Context, vmconfig *contract.NativeConfig,
+	process *contractProcess, release func(int64)) *nativeVmInstance {
 	return &nativeVmInstance{
-		ctx:     ctx,
-		process: process,
+		ctx:      ctx,
+		vmconfig: vmconfig,
+		process:  process,
+		release:  release,
 	}
 }
 
 func (i *nativeVmInstance) Exec() error {
+	if err := i.abortError(); err != nil {
+		return err
+	}
+	cctx, cancel, timeout := i.callContext()
+	defer cancel()
+	i.mutex.Lock()
+	i.cancel = cancel
+	i.mutex.Unlock()
+
 	request := &pb.NativeCallRequest{
 		Ctxid: i.ctx.ID,
 	}
-	_, err := i.process.RpcClient().Call(context.TODO(), request)
+	_, err := i.process.RpcClient().Call(cctx, request)
+	if abortErr := i.abortError(); abortErr != nil {
+		return abortErr
+	}
+	if cctx.Err() == context.DeadlineExceeded {
+		// 合约进程可能仍在空转，重启进程释放占用的cpu
+		i.Abort(fmt.Sprintf("%s, execution timeout:%s", ErrResourceExceeds, timeout))
+		if restartErr := i.process.restartProcess(); restartErr != nil {
+			i.process.logger.Error("restart process after timeout error", "error", restartErr)
+		}
+		return i.abortError()
+	}
 	return err
 }
 
+// callContext 返回合约调用的context。执行时间与节点负载相关，
+// 只在预执行和查询时按剩余资源设置超时，打包和验证交易时不限制执行时间
+func (i *nativeVmInstance) callContext() (context.Context, context.CancelFunc, time.Duration) {
+	if !i.ctx.PreExec {
+		cctx, cancel := context.WithCancel(context.TODO())
+		return cctx, cancel, 0
+	}
+	// 启用高度之前cpu资源不计量，只使用配置的最长执行时间
+	remain := contract.MaxLimits.Cpu
+	if i.gasMetering() {
+		remain = i.ctx.ResourceLimits.Cpu - i.meter.limits().Cpu
+	}
+	timeout := execTimeout(i.vmconfig, remain)
+	cctx, cancel := context.WithTimeout(context.TODO(), timeout)
+	return cctx, cancel, timeout
+}
+
+// checkLimits 资源超限时中止合约调用并返回错误
+// 子合约调用的资源上限已经扣除了当前的消耗，这里只检查合约进程自身的消耗
+func (i *nativeVmInstance) checkLimits() error {
+	if !i.gasMetering() {
+		return i.abortError()
+	}
+	used := i.ResourceUsed()
+	if used.Exceed(i.ctx.ResourceLimits) {
+		i.Abort(fmt.Sprintf("%s, used:%+v limits:%+v", ErrResourceExceeds, used, i.ctx.ResourceLimits))
+	}
+	return i.abortError()
+}
+
+func (i *nativeVmInstance) abortError() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.abortErr
+}
+
+// gasMetering 返回执行区块的高度上是否按系统调用计量资源消耗
+func (i *nativeVmInstance) gasMetering() bool {
+	return i.vmconfig.GasMetering.Active(i.ctx.BlockHeight)
+}
+
 func (i *nativeVmInstance) ResourceUsed() contract.Limits {
-	return contract.Limits{
-		XFee: 1,
+	// 启用高度之前的区块每次调用固定消耗1个xfee，保证重放历史交易时结果一致
+	if !i.gasMetering() {
+		return contract.Limits{
+			XFee: 1,
+		}
 	}
+	return i.meter.limits()
 }
 
 func (i *nativeVmInstance) Release() {
-
+	i.release(i.ctx.ID)
 }
 
+// Abort 中止正在执行的合约调用，之后的系统调用和Exec都会返回错误
 func (i *nativeVmInstance) Abort(msg string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.abortErr != nil {
+		return
+	}
+	i.abortErr = errors.New(msg)
+	if i.cancel != nil {
+		i.cancel()
+	}
 }
 
 func init() {
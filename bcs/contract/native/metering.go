@@ -0,0 +1,114 @@
+package native
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
+	"google.golang.org/grpc"
+)
+
+// 原生合约的资源计量规则，用于把合约进程的资源消耗折算为contract.Limits
+// 合约进程的运行时间在不同节点上不一致，只计量系统调用次数和传输的字节数，保证重放交易时资源消耗一致
+const (
+	// 每次系统调用折算的cpu资源
+	syscallCpuCost = 100
+	// PutObject和GetObject每传输一个字节折算的cpu资源
+	objectByteCpuCost = 1
+)
+
+// 合约进程的运行时间不计入资源消耗，只用于中止长时间不发起系统调用的合约
+const (
+	// 每单位剩余cpu资源允许合约运行的时间
+	execTimePerCpu = time.Microsecond
+	// 单次合约调用的默认最长执行时间
+	defaultExecTimeout = 10 * time.Second
+)
+
+// execTimeout 返回合约调用的最长执行时间，由剩余的cpu资源remain折算，不超过配置的最长执行时间
+func execTimeout(cfg *contract.NativeConfig, remain int64) time.Duration {
+	maxTimeout := defaultExecTimeout
+	if cfg != nil && cfg.ExecTimeout > 0 {
+		maxTimeout = time.Duration(cfg.ExecTimeout) * time.Second
+	}
+	if remain <= 0 {
+		return 0
+	}
+	if remain > int64(maxTimeout/execTimePerCpu) {
+		return maxTimeout
+	}
+	return time.Duration(remain) * execTimePerCpu
+}
+
+// syscallRequest 是所有带合约上下文的系统调用请求
+type syscallRequest interface {
+	GetHeader() *pb.SyscallHeader
+}
+
+// resourceMeter 记录一次原生合约调用的资源消耗
+type resourceMeter struct {
+	mutex sync.Mutex
+
+	syscalls int64
+	// PutObject和GetObject传输的字节数
+	objectBytes int64
+}
+
+func (r *resourceMeter) addSyscall(objectBytes int64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.syscalls++
+	r.objectBytes += objectBytes
+}
+
+// limits 把资源消耗折算为contract.Limits
+func (r *resourceMeter) limits() contract.Limits {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return contract.Limits{
+		Cpu: r.syscalls*syscallCpuCost + r.objectBytes*objectByteCpuCost,
+	}
+}
+
+// objectBytes 返回PutObject和GetObject系统调用传输的字节数
+func objectBytes(req, resp interface{}) int64 {
+	var size int
+	switch r := req.(type) {
+	case *pb.PutRequest:
+		size = len(r.GetKey()) + len(r.GetValue())
+	case *pb.GetRequest:
+		size = len(r.GetKey())
+		if out, ok := resp.(*pb.GetResponse); ok {
+			size += len(out.GetValue())
+		}
+	}
+	return int64(size)
+}
+
+// meterSyscall 统计原生合约的系统调用，资源超限时中止合约调用
+func (n *nativeCreator) meterSyscall(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	sreq, ok := req.(syscallRequest)
+	if !ok {
+		return handler(ctx, req)
+	}
+	instance, ok := n.lookupInstance(sreq.GetHeader().GetCtxid())
+	if !ok {
+		return handler(ctx, req)
+	}
+	if err := instance.abortError(); err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	instance.meter.addSyscall(objectBytes(req, resp))
+	if err != nil {
+		return nil, err
+	}
+	if err := instance.checkLimits(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
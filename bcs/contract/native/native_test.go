@@ -3,9 +3,12 @@ package native
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	log15 "github.com/xuperchain/log15"
 	"github.com/xuperchain/xupercore/kernel/contract"
@@ -104,6 +107,119 @@ func TestNativeInvoke(t *testing.T) {
 	t.Logf("body:%s", resp.Body)
 }
 
+func invokeWithLimits(th *mock.TestHelper, method string, args map[string][]byte,
+	limits contract.Limits, preExec bool) (contract.Limits, error) {
+	m := th.Manager()
+	state, err := m.NewStateSandbox(&contract.SandboxConfig{
+		XMReader: th.State(),
+	})
+	if err != nil {
+		return contract.Limits{}, err
+	}
+	ctx, err := m.NewContext(&contract.ContextConfig{
+		Module:         "native",
+		ContractName:   "counter",
+		State:          state,
+		ResourceLimits: limits,
+		Initiator:      mock.ContractAccount,
+		PreExec:        preExec,
+	})
+	if err != nil {
+		return contract.Limits{}, err
+	}
+	defer ctx.Release()
+	_, err = ctx.Invoke(method, args)
+	return ctx.ResourceUsed(), err
+}
+
+func TestNativeResourceLimits(t *testing.T) {
+	th := mock.NewTestHelperWithManagerConfig(contractConfig, contract.ManagerConfig{
+		NativeGas: &contract.ForkConfig{Height: 0},
+	})
+	defer th.Close()
+
+	bin, err := compile(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = th.Deploy("native", "go", "counter", bin, map[string][]byte{
+		"creator": []byte("icexin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := map[string][]byte{
+		"key": []byte("k1"),
+	}
+	used, err := invokeWithLimits(th, "increase", args, contract.MaxLimits, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// increase至少包含GetObject和PutObject两次系统调用
+	if used.Cpu < 2*syscallCpuCost || used.Memory != 0 {
+		t.Errorf("unexpected resource used %+v", used)
+	}
+	// 相同的调用资源消耗一致，和合约进程的运行时间无关
+	again, err := invokeWithLimits(th, "increase", args, contract.MaxLimits, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != used {
+		t.Errorf("expect deterministic resource used, first:%+v again:%+v", used, again)
+	}
+
+	limits := contract.MaxLimits
+	limits.Cpu = syscallCpuCost
+	_, err = invokeWithLimits(th, "increase", args, limits, false)
+	if err == nil {
+		t.Error("expect resource exceeds error")
+	}
+
+	// 预执行时不发起系统调用的合约按剩余cpu资源折算的时间中止
+	limits.Cpu = int64(200 * time.Millisecond / execTimePerCpu)
+	_, err = invokeWithLimits(th, "loop", nil, limits, true)
+	if err == nil || !strings.Contains(err.Error(), ErrResourceExceeds.Error()) {
+		t.Fatalf("expect execution timeout got %v", err)
+	}
+	// 超时后合约进程被重启，可以继续调用
+	if _, err = invokeWithLimits(th, "increase", args, contract.MaxLimits, false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNativeResourceLimitsBeforeFork(t *testing.T) {
+	th := mock.NewTestHelperWithManagerConfig(contractConfig, contract.ManagerConfig{
+		NativeGas: &contract.ForkConfig{Height: 100},
+	})
+	defer th.Close()
+
+	bin, err := compile(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = th.Deploy("native", "go", "counter", bin, map[string][]byte{
+		"creator": []byte("icexin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 启用高度之前每次调用固定消耗1个xfee，也不按cpu资源中止
+	limits := contract.MaxLimits
+	limits.Cpu = 0
+	used, err := invokeWithLimits(th, "increase", map[string][]byte{
+		"key": []byte("k1"),
+	}, limits, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used.Cpu != 0 || used.XFee != 1 {
+		t.Errorf("unexpected resource used %+v", used)
+	}
+}
+
 func TestNativeUpgrade(t *testing.T) {
 	th := mock.NewTestHelper(contractConfig)
 	defer th.Close()
@@ -158,3 +274,63 @@ func TestNativeDocker(t *testing.T) {
 
 	t.Logf("%#v", resp)
 }
+
+func TestNativeSandbox(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("native sandbox requires root")
+	}
+	// 沙盒总是通过cgroup限制合约的进程数
+	controllers, err := ioutil.ReadFile("/sys/fs/cgroup/cgroup.controllers")
+	if err != nil || !strings.Contains(string(controllers), "pids") {
+		t.Skip("native sandbox requires cgroup v2 with pids controller")
+	}
+	cfg := *contractConfig
+	cfg.Native.Sandbox = contract.NativeSandboxConfig{
+		Enable:     true,
+		CgroupRoot: "/sys/fs/cgroup/xchain-native-test",
+	}
+
+	th := mock.NewTestHelper(&cfg)
+	defer th.Close()
+
+	bin, err := compile(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = th.Deploy("native", "go", "counter", bin, map[string][]byte{
+		"creator": []byte("icexin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := th.Invoke("native", "counter", "increase", map[string][]byte{
+		"key": []byte("k1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != "1" {
+		t.Errorf("expect 1 got %s", resp.Body)
+	}
+
+	// 合约以非特权用户运行，看不到合约目录以外的文件
+	resp, err = th.Invoke("native", "counter", "uid", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) == "0" {
+		t.Error("expect contract not running as root")
+	}
+	secret := filepath.Join(th.Basedir(), "secret")
+	if err := ioutil.WriteFile(secret, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = th.Invoke("native", "counter", "readFile", map[string][]byte{
+		"path": []byte(secret),
+	})
+	if err == nil && resp.Status < 400 {
+		t.Errorf("expect host file invisible in sandbox, got %s", resp.Body)
+	}
+}
@@ -191,6 +191,10 @@ func processExists(pid int) bool {
 
 // Stop implements process interface
 func (h *HostProcess) Stop(timeout time.Duration) error {
+	// 进程启动失败时没有cmd
+	if h.cmd == nil {
+		return nil
+	}
 	h.cmd.Process.Signal(syscall.SIGTERM)
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
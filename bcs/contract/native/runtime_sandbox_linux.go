@@ -0,0 +1,404 @@
+//go:build linux
+// +build linux
+
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	units "github.com/docker/go-units"
+	log "github.com/xuperchain/log15"
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// 设置该环境变量时当前程序作为沙盒初始化进程运行
+	sandboxInitEnv    = "XCHAIN_NATIVE_SANDBOX_INIT"
+	defaultCgroupRoot = "/sys/fs/cgroup/xchain-native"
+	cgroupCpuPeriod   = 100000
+	// 合约进程cgroup默认的最大进程和线程数，防止合约无限创建进程
+	defaultSandboxPids = 128
+	// 合约进程默认使用nobody运行
+	defaultSandboxUid = 65534
+	defaultSandboxGid = 65534
+	// 合约目录中合约进程可写的数据目录，同时作为合约进程的临时目录
+	sandboxDataDir = "data"
+)
+
+// SandboxProcess is the process running in new mount, pid and network namespaces
+// as an unprivileged user whose root filesystem only contains the read-only contract dir
+// and a writable data dir,
+// limited by cgroup v2 and seccomp, without docker daemon
+type SandboxProcess struct {
+	basedir   string
+	startcmd  *exec.Cmd
+	envs      []string
+	chainAddr string
+	cfg       *contract.NativeSandboxConfig
+
+	cmd      *exec.Cmd
+	cgroup   string
+	rootdir  string
+	netns    *os.File
+	listener net.Listener
+	proxyWg  sync.WaitGroup
+	log.Logger
+}
+
+// Start implements process interface
+func (s *SandboxProcess) Start() error {
+	if os.Geteuid() != 0 {
+		return errors.New("native sandbox requires root privilege")
+	}
+	uid, gid := s.cfg.Uid, s.cfg.Gid
+	if uid == 0 {
+		uid = defaultSandboxUid
+	}
+	if gid == 0 {
+		gid = defaultSandboxGid
+	}
+	datadir, err := s.makeDatadir(uid, gid)
+	if err != nil {
+		return err
+	}
+	// 合约根文件系统的挂载点，只在合约的mount namespace中挂载
+	rootdir := filepath.Join(filepath.Dir(s.basedir), "."+filepath.Base(s.basedir)+".root")
+	if err := os.MkdirAll(rootdir, 0700); err != nil {
+		return err
+	}
+	s.rootdir = rootdir
+
+	cgroup, err := s.makeCgroup()
+	if err != nil {
+		s.removeRootdir()
+		return err
+	}
+	s.cgroup = cgroup
+
+	initCfg := &sandboxInitConfig{
+		Basedir: s.basedir,
+		Datadir: datadir,
+		Rootdir: rootdir,
+		Cgroup:  cgroup,
+		Path:    s.startcmd.Path,
+		Args:    s.startcmd.Args,
+		// 根文件系统和合约目录只读，临时文件写到数据目录
+		Envs: append([]string{
+			"XCHAIN_PING_TIMEOUT=" + strconv.Itoa(pingTimeoutSecond),
+			"TMPDIR=" + datadir,
+		}, s.envs...),
+		Uid: uid,
+		Gid: gid,
+	}
+	initBuf, err := json.Marshal(initCfg)
+	if err != nil {
+		s.removeCgroup()
+		s.removeRootdir()
+		return err
+	}
+
+	// 重新执行当前程序，由sandboxInit在新的namespace中完成初始化后再执行合约
+	cmd := exec.Command("/proc/self/exe")
+	cmd.Dir = s.basedir
+	cmd.Env = []string{sandboxInitEnv + "=" + string(initBuf)}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:     true,
+		Pdeathsig:  syscall.SIGKILL,
+		Cloneflags: syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWNET,
+	}
+	if err := cmd.Start(); err != nil {
+		s.removeCgroup()
+		s.removeRootdir()
+		return err
+	}
+	s.Info("start sandbox process success", "pid", cmd.Process.Pid, "cgroup", cgroup)
+	s.cmd = cmd
+
+	err = s.setupNetwork()
+	if err != nil {
+		s.Stop(time.Second)
+		return err
+	}
+	return nil
+}
+
+// Stop implements process interface
+func (s *SandboxProcess) Stop(timeout time.Duration) error {
+	if s.cmd == nil {
+		return nil
+	}
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.cmd.Process.Signal(syscall.SIGTERM)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !processExists(s.cmd.Process.Pid) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	// 合约进程是pid namespace中的1号进程，可能会忽略SIGTERM
+	if !time.Now().Before(deadline) {
+		s.cmd.Process.Kill()
+	}
+	err := s.cmd.Wait()
+	s.proxyWg.Wait()
+	if s.netns != nil {
+		s.netns.Close()
+	}
+	s.removeCgroup()
+	s.removeRootdir()
+	s.Info("stop sandbox process success", "pid", s.cmd.Process.Pid)
+	return err
+}
+
+// DialContext 在合约进程的network namespace中连接合约的rpc端口
+func (s *SandboxProcess) DialContext(ctx context.Context, addr string) (net.Conn, error) {
+	var conn net.Conn
+	err := s.inNetns(func() error {
+		var err error
+		conn, err = new(net.Dialer).DialContext(ctx, "tcp", addr)
+		return err
+	})
+	return conn, err
+}
+
+// setupNetwork 启用合约network namespace中的lo网卡，
+// 并在其中监听链的地址，把合约的系统调用转发给链
+func (s *SandboxProcess) setupNetwork() error {
+	netns, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", s.cmd.Process.Pid))
+	if err != nil {
+		return err
+	}
+	s.netns = netns
+
+	chainAddr, err := parseChainAddr(s.chainAddr)
+	if err != nil {
+		return err
+	}
+	err = s.inNetns(func() error {
+		if err := setLoopbackUp(); err != nil {
+			return err
+		}
+		s.listener, err = net.Listen("tcp", chainAddr)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.proxyWg.Add(1)
+	go s.proxy(chainAddr)
+	return nil
+}
+
+func (s *SandboxProcess) proxy(chainAddr string) {
+	defer s.proxyWg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			upstream, err := net.Dial("tcp", chainAddr)
+			if err != nil {
+				s.Error("dial chain from sandbox error", "error", err)
+				return
+			}
+			defer upstream.Close()
+			done := make(chan struct{}, 2)
+			go func() {
+				io.Copy(upstream, conn)
+				done <- struct{}{}
+			}()
+			go func() {
+				io.Copy(conn, upstream)
+				done <- struct{}{}
+			}()
+			<-done
+		}()
+	}
+}
+
+// inNetns 在合约进程的network namespace中执行fn，fn中创建的socket属于该namespace
+func (s *SandboxProcess) inNetns(fn func() error) error {
+	runtime.LockOSThread()
+	origin, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+	if err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	defer origin.Close()
+
+	err = unix.Setns(int(s.netns.Fd()), unix.CLONE_NEWNET)
+	if err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	fnErr := fn()
+	// 无法恢复的线程不再交还给调度器，随goroutine退出而销毁
+	if err := unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET); err != nil {
+		return fmt.Errorf("restore network namespace error:%s", err)
+	}
+	runtime.UnlockOSThread()
+	return fnErr
+}
+
+// makeCgroup 创建合约进程的cgroup，总是限制进程数，cpu和内存按配置限制
+func (s *SandboxProcess) makeCgroup() (string, error) {
+	root := s.cfg.CgroupRoot
+	if root == "" {
+		root = defaultCgroupRoot
+	}
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("+cpu +memory +pids"), 0644)
+	if err != nil {
+		return "", fmt.Errorf("enable cgroup v2 controllers error:%s", err)
+	}
+	cgroup, err := ioutil.TempDir(root, filepath.Base(s.basedir)+"-")
+	if err != nil {
+		return "", err
+	}
+
+	pids := s.cfg.Pids
+	if pids <= 0 {
+		pids = defaultSandboxPids
+	}
+	err = ioutil.WriteFile(filepath.Join(cgroup, "pids.max"), []byte(strconv.FormatInt(pids, 10)), 0644)
+	if err != nil {
+		os.Remove(cgroup)
+		return "", fmt.Errorf("set cgroup pids limit error:%s", err)
+	}
+	if s.cfg.Cpus > 0 {
+		quota := int64(cgroupCpuPeriod * s.cfg.Cpus)
+		err = ioutil.WriteFile(filepath.Join(cgroup, "cpu.max"), []byte(fmt.Sprintf("%d %d", quota, cgroupCpuPeriod)), 0644)
+		if err != nil {
+			os.Remove(cgroup)
+			return "", err
+		}
+	}
+	if s.cfg.Memory != "" {
+		memLimit, err := units.RAMInBytes(s.cfg.Memory)
+		if err != nil {
+			os.Remove(cgroup)
+			return "", err
+		}
+		err = ioutil.WriteFile(filepath.Join(cgroup, "memory.max"), []byte(strconv.FormatInt(memLimit, 10)), 0644)
+		if err != nil {
+			os.Remove(cgroup)
+			return "", err
+		}
+	}
+	return cgroup, nil
+}
+
+func (s *SandboxProcess) removeCgroup() {
+	if s.cgroup == "" {
+		return
+	}
+	err := os.Remove(s.cgroup)
+	if err != nil {
+		s.Warn("remove sandbox cgroup error", "cgroup", s.cgroup, "error", err)
+	}
+}
+
+func (s *SandboxProcess) removeRootdir() {
+	if s.rootdir == "" {
+		return
+	}
+	err := os.Remove(s.rootdir)
+	if err != nil {
+		s.Warn("remove sandbox root dir error", "rootdir", s.rootdir, "error", err)
+	}
+}
+
+// makeDatadir 创建合约进程唯一可写的数据目录并交给合约进程的用户，
+// 合约目录和合约程序属于root且对合约进程只读，上次运行留下的数据在启动时清除
+func (s *SandboxProcess) makeDatadir(uid, gid int) (string, error) {
+	datadir := filepath.Join(s.basedir, sandboxDataDir)
+	if err := os.RemoveAll(datadir); err != nil {
+		return "", err
+	}
+	// 旧版本把整个合约目录交给了合约进程的用户，这里收回
+	if err := chownTree(s.basedir, 0, 0); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(s.basedir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.Mkdir(datadir, 0700); err != nil {
+		return "", err
+	}
+	if err := os.Chown(datadir, uid, gid); err != nil {
+		return "", err
+	}
+	return datadir, nil
+}
+
+// chownTree 修改目录及其中文件的属主，不跟随符号链接
+func chownTree(dir string, uid, gid int) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, gid)
+	})
+}
+
+// parseChainAddr 把tcp://host:port形式的地址转换为host:port
+func parseChainAddr(addr string) (string, error) {
+	const prefix = "tcp://"
+	if len(addr) <= len(prefix) || addr[:len(prefix)] != prefix {
+		return "", fmt.Errorf("unsupported chain address %s", addr)
+	}
+	return addr[len(prefix):], nil
+}
+
+// setLoopbackUp 启用当前network namespace中的lo网卡
+func setLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var ifr struct {
+		name  [unix.IFNAMSIZ]byte
+		flags uint16
+		_     [22]byte
+	}
+	copy(ifr.name[:], "lo")
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCGIFFLAGS, uintptr(unsafe.Pointer(&ifr)))
+	if errno != 0 {
+		return errno
+	}
+	ifr.flags |= unix.IFF_UP | unix.IFF_RUNNING
+	_, _, errno = unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&ifr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+//go:build !linux
+// +build !linux
+
+package native
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os/exec"
+	"time"
+
+	log "github.com/xuperchain/log15"
+	"github.com/xuperchain/xupercore/kernel/contract"
+)
+
+var errSandboxUnsupported = errors.New("native sandbox is only supported on linux")
+
+// SandboxProcess is only supported on linux
+type SandboxProcess struct {
+	basedir   string
+	startcmd  *exec.Cmd
+	envs      []string
+	chainAddr string
+	cfg       *contract.NativeSandboxConfig
+
+	log.Logger
+}
+
+// Start implements process interface
+func (s *SandboxProcess) Start() error {
+	return errSandboxUnsupported
+}
+
+// Stop implements process interface
+func (s *SandboxProcess) Stop(timeout time.Duration) error {
+	return nil
+}
+
+// DialContext is only supported on linux
+func (s *SandboxProcess) DialContext(ctx context.Context, addr string) (net.Conn, error) {
+	return nil, errSandboxUnsupported
+}
@@ -0,0 +1,400 @@
+//go:build linux
+// +build linux
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	seccompRetAllow = 0x7fff0000
+	seccompRetErrno = 0x00050000
+	seccompRetKill  = 0x80000000
+
+	seccompSetModeFilter   = 1
+	seccompFilterFlagTsync = 1
+
+	// offsetof(struct seccomp_data, nr/arch/args)，args[0]取小端的低32位
+	seccompDataNrOffset   = 0
+	seccompDataArchOffset = 4
+	seccompDataArgsOffset = 16
+
+	// 合约进程不允许创建新的namespace
+	cloneNewFlags = unix.CLONE_NEWNS | unix.CLONE_NEWUTS | unix.CLONE_NEWIPC | unix.CLONE_NEWUSER |
+		unix.CLONE_NEWPID | unix.CLONE_NEWNET | unix.CLONE_NEWCGROUP
+
+	// 合约程序打开在固定的文件描述符上，seccomp只允许通过它执行execveat。
+	// 合约进程的RLIMIT_NOFILE不超过该值且无法调高，因此执行后无法再得到这个描述符
+	sandboxExecFd = 4096
+)
+
+// sandboxInitConfig 由SandboxProcess传递给沙盒初始化进程
+type sandboxInitConfig struct {
+	Basedir string
+	// 合约目录中唯一可写的数据目录
+	Datadir string
+	// 合约根文件系统的挂载点
+	Rootdir string
+	Cgroup  string
+	Path    string
+	Args    []string
+	Envs    []string
+	// 合约进程运行使用的非特权用户
+	Uid int
+	Gid int
+}
+
+func init() {
+	initBuf := os.Getenv(sandboxInitEnv)
+	if initBuf == "" {
+		return
+	}
+	err := sandboxInit(initBuf)
+	// sandboxInit成功时不会返回
+	fmt.Fprintf(os.Stderr, "native sandbox init error:%s\n", err)
+	os.Exit(1)
+}
+
+// sandboxInit 加入cgroup，切换到只包含合约目录的根文件系统，
+// 放弃所有特权并设置seccomp后执行合约
+func sandboxInit(initBuf string) error {
+	cfg := new(sandboxInitConfig)
+	err := json.Unmarshal([]byte(initBuf), cfg)
+	if err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+	if err := joinCgroup(cfg.Cgroup); err != nil {
+		return err
+	}
+	if err := reserveExecFd(cfg.Path); err != nil {
+		return err
+	}
+	if err := setupRoot(cfg.Rootdir, cfg.Basedir, cfg.Datadir); err != nil {
+		return err
+	}
+	if err := os.Chdir(cfg.Basedir); err != nil {
+		return err
+	}
+	if err := dropPrivileges(cfg.Uid, cfg.Gid); err != nil {
+		return err
+	}
+	if err := loadSeccomp(); err != nil {
+		return err
+	}
+	return execContract(cfg.Args, cfg.Envs)
+}
+
+// reserveExecFd 把合约程序打开到sandboxExecFd上，并把RLIMIT_NOFILE降到sandboxExecFd
+func reserveExecFd(path string) error {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("open contract binary error:%s", err)
+	}
+	defer unix.Close(fd)
+	limit := &unix.Rlimit{Cur: sandboxExecFd + 1, Max: sandboxExecFd + 1}
+	if err := unix.Setrlimit(unix.RLIMIT_NOFILE, limit); err != nil {
+		return fmt.Errorf("set nofile limit error:%s", err)
+	}
+	if err := unix.Dup3(fd, sandboxExecFd, unix.O_CLOEXEC); err != nil {
+		return fmt.Errorf("reserve exec fd error:%s", err)
+	}
+	limit = &unix.Rlimit{Cur: sandboxExecFd, Max: sandboxExecFd}
+	if err := unix.Setrlimit(unix.RLIMIT_NOFILE, limit); err != nil {
+		return fmt.Errorf("set nofile limit error:%s", err)
+	}
+	return nil
+}
+
+// execContract 通过sandboxExecFd执行合约程序，成功时不会返回
+func execContract(args, envs []string) error {
+	path, err := unix.BytePtrFromString("")
+	if err != nil {
+		return err
+	}
+	argv, err := syscall.SlicePtrFromStrings(args)
+	if err != nil {
+		return err
+	}
+	envv, err := syscall.SlicePtrFromStrings(envs)
+	if err != nil {
+		return err
+	}
+	_, _, errno := unix.Syscall6(unix.SYS_EXECVEAT, sandboxExecFd, uintptr(unsafe.Pointer(path)),
+		uintptr(unsafe.Pointer(&argv[0])), uintptr(unsafe.Pointer(&envv[0])), unix.AT_EMPTY_PATH, 0)
+	return fmt.Errorf("exec contract error:%s", errno)
+}
+
+func joinCgroup(cgroup string) error {
+	if cgroup == "" {
+		return nil
+	}
+	// 写入0表示把当前进程加入cgroup
+	err := ioutil.WriteFile(filepath.Join(cgroup, "cgroup.procs"), []byte("0"), 0644)
+	if err != nil {
+		return fmt.Errorf("join cgroup error:%s", err)
+	}
+	return nil
+}
+
+// sandboxLibDirs 合约程序运行需要的系统库目录，只读挂载到合约的根文件系统中
+var sandboxLibDirs = []string{"/lib", "/lib32", "/lib64", "/libx32", "/usr/lib", "/usr/lib32", "/usr/lib64"}
+
+// sandboxDevices 挂载到合约根文件系统中的设备文件
+var sandboxDevices = []string{"/dev/null", "/dev/zero", "/dev/random", "/dev/urandom"}
+
+// setupRoot 在rootdir上构建只包含只读的合约目录、可写的数据目录、只读系统库和少量设备文件的根文件系统，
+// 通过pivot_root切换根目录并卸载原有的根文件系统，合约进程无法访问宿主机的其他文件
+func setupRoot(rootdir, basedir, datadir string) error {
+	err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, "")
+	if err != nil {
+		return fmt.Errorf("make mounts private error:%s", err)
+	}
+	err = unix.Mount("tmpfs", rootdir, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "size=1m,mode=0755")
+	if err != nil {
+		return fmt.Errorf("mount sandbox root error:%s", err)
+	}
+	// 合约目录和合约程序只读，只有其中的数据目录可写
+	if err := bindMount(basedir, filepath.Join(rootdir, basedir), true); err != nil {
+		return err
+	}
+	if err := bindMount(datadir, filepath.Join(rootdir, datadir), false); err != nil {
+		return err
+	}
+	for _, dir := range sandboxLibDirs {
+		if err := bindLibDir(dir, filepath.Join(rootdir, dir)); err != nil {
+			return err
+		}
+	}
+	for _, dev := range sandboxDevices {
+		if err := bindMount(dev, filepath.Join(rootdir, dev), true); err != nil {
+			return err
+		}
+	}
+
+	oldroot := filepath.Join(rootdir, ".oldroot")
+	if err := os.Mkdir(oldroot, 0700); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(rootdir, oldroot); err != nil {
+		return fmt.Errorf("pivot root error:%s", err)
+	}
+	if err := os.Chdir("/"); err != nil {
+		return err
+	}
+	if err := unix.Unmount("/.oldroot", unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("unmount old root error:%s", err)
+	}
+	if err := os.Remove("/.oldroot"); err != nil {
+		return err
+	}
+
+	if err := os.Mkdir("/proc", 0555); err != nil {
+		return err
+	}
+	err = unix.Mount("proc", "/proc", "proc", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "")
+	if err != nil {
+		return fmt.Errorf("mount proc error:%s", err)
+	}
+	err = unix.Mount("", "/", "", unix.MS_REMOUNT|unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV, "")
+	if err != nil {
+		return fmt.Errorf("remount sandbox root readonly error:%s", err)
+	}
+	return nil
+}
+
+// bindLibDir 只读挂载系统库目录，目录为符号链接时在新的根文件系统中创建相同的链接
+func bindLibDir(source, target string) error {
+	info, err := os.Lstat(source)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err := os.Readlink(source)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, target)
+	}
+	return bindMount(source, target, true)
+}
+
+// bindMount 把source挂载到target，挂载点不允许setuid，目录挂载点中的设备文件不可用
+func bindMount(source, target string, readonly bool) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		err = os.MkdirAll(target, 0755)
+	} else {
+		err = createFile(target)
+	}
+	if err != nil {
+		return err
+	}
+	err = unix.Mount(source, target, "", unix.MS_BIND|unix.MS_REC, "")
+	if err != nil {
+		return fmt.Errorf("bind %s error:%s", source, err)
+	}
+	flags := uintptr(unix.MS_REMOUNT | unix.MS_BIND | unix.MS_NOSUID)
+	if info.IsDir() {
+		flags |= unix.MS_NODEV
+	}
+	if readonly {
+		flags |= unix.MS_RDONLY
+	}
+	err = unix.Mount("", target, "", flags, "")
+	if err != nil {
+		return fmt.Errorf("remount %s error:%s", target, err)
+	}
+	return nil
+}
+
+func createFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// dropPrivileges 清空所有capability后切换到非特权用户运行合约
+func dropPrivileges(uid, gid int) error {
+	// bounding set清空后，执行合约时无法再获得任何capability
+	for c := 0; c <= 63; c++ {
+		err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(c), 0, 0, 0)
+		if err == unix.EINVAL {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("drop bounding capability %d error:%s", c, err)
+		}
+	}
+	err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("clear ambient capabilities error:%s", err)
+	}
+	if err := unix.Setgroups(nil); err != nil {
+		return fmt.Errorf("clear supplementary groups error:%s", err)
+	}
+	if err := unix.Setresgid(gid, gid, gid); err != nil {
+		return fmt.Errorf("set gid error:%s", err)
+	}
+	if err := unix.Setresuid(uid, uid, uid); err != nil {
+		return fmt.Errorf("set uid error:%s", err)
+	}
+	// 切换uid会清空permitted和effective，inheritable需要显式清空
+	hdr := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	if err := unix.Capset(&hdr, &data[0]); err != nil {
+		return fmt.Errorf("clear capabilities error:%s", err)
+	}
+	return nil
+}
+
+// loadSeccomp 只允许白名单中的系统调用，其他系统调用返回EPERM。
+// execve不在白名单中，只允许通过sandboxExecFd执行合约程序；
+// socket只能创建合约rpc需要的TCP连接，合约所在的network namespace中只有lo网卡
+func loadSeccomp() error {
+	if seccompAuditArch == 0 {
+		return errors.New("seccomp unsupported on " + runtime.GOARCH)
+	}
+
+	filter := []unix.SockFilter{
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArchOffset),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, seccompAuditArch, 1, 0),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetKill),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataNrOffset),
+		// clone只允许创建线程和子进程
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_CLONE, 0, 4),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArgsOffset),
+		bpfJump(unix.BPF_JMP|unix.BPF_JSET|unix.BPF_K, cloneNewFlags, 0, 1),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM)),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow),
+		// clone3的参数无法检查，返回ENOSYS让libc回退到clone
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_CLONE3, 0, 1),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.ENOSYS)),
+		// execveat只能以AT_EMPTY_PATH执行sandboxExecFd
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_EXECVEAT, 0, 6),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompArgOffset(0)),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, sandboxExecFd, 0, 3),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompArgOffset(4)),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.AT_EMPTY_PATH, 0, 1),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM)),
+		// socket只允许AF_INET的SOCK_STREAM
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_SOCKET, 0, 7),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompArgOffset(0)),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.AF_INET, 0, 4),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompArgOffset(1)),
+		bpfStmt(unix.BPF_ALU|unix.BPF_AND|unix.BPF_K, 0xf),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SOCK_STREAM, 0, 1),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM)),
+		// prlimit64只能查询，不能调高RLIMIT_NOFILE
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, unix.SYS_PRLIMIT64, 0, 6),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompArgOffset(2)),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, 0, 0, 3),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompArgOffset(2)+4),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, 0, 0, 1),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM)),
+	}
+	for _, nr := range seccompAllowlist {
+		filter = append(filter,
+			bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, uint32(nr), 0, 1),
+			bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetAllow),
+		)
+	}
+	filter = append(filter, bpfStmt(unix.BPF_RET|unix.BPF_K, seccompRetErrno|uint32(unix.EPERM)))
+
+	prog := unix.SockFprog{
+		Len:    uint16(len(filter)),
+		Filter: &filter[0],
+	}
+	err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("set no new privs error:%s", err)
+	}
+	_, _, errno := unix.Syscall(unix.SYS_SECCOMP, seccompSetModeFilter,
+		seccompFilterFlagTsync, uintptr(unsafe.Pointer(&prog)))
+	if errno != 0 {
+		return fmt.Errorf("load seccomp filter error:%s", errno)
+	}
+	return nil
+}
+
+// seccompArgOffset 返回第i个参数低32位在seccomp_data中的偏移
+func seccompArgOffset(i uint32) uint32 {
+	return seccompDataArgsOffset + 8*i
+}
+
+func bpfStmt(code uint16, k uint32) unix.SockFilter {
+	return unix.SockFilter{Code: code, K: k}
+}
+
+func bpfJump(code uint16, k uint32, jt, jf uint8) unix.SockFilter {
+	return unix.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+}
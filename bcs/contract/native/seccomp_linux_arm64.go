@@ -0,0 +1,33 @@
+//go:build linux && arm64
+// +build linux,arm64
+
+package native
+
+import "golang.org/x/sys/unix"
+
+const seccompAuditArch = 0xc00000b7 // AUDIT_ARCH_AARCH64
+
+// seccompAllowlist 合约进程允许使用的系统调用，覆盖go运行时和合约rpc的需要，
+// socket、execveat和prlimit64在loadSeccomp中检查参数后放行
+var seccompAllowlist = []uintptr{
+	unix.SYS_READ, unix.SYS_WRITE, unix.SYS_OPENAT, unix.SYS_CLOSE,
+	unix.SYS_FSTAT, unix.SYS_FSTATAT, unix.SYS_STATX,
+	unix.SYS_PPOLL, unix.SYS_LSEEK, unix.SYS_MMAP, unix.SYS_MPROTECT,
+	unix.SYS_MUNMAP, unix.SYS_MREMAP, unix.SYS_MADVISE, unix.SYS_BRK,
+	unix.SYS_RT_SIGACTION, unix.SYS_RT_SIGPROCMASK, unix.SYS_RT_SIGRETURN, unix.SYS_SIGALTSTACK,
+	unix.SYS_IOCTL, unix.SYS_PREAD64, unix.SYS_PWRITE64, unix.SYS_READV, unix.SYS_WRITEV,
+	unix.SYS_FACCESSAT, unix.SYS_PIPE2, unix.SYS_PSELECT6,
+	unix.SYS_SCHED_YIELD, unix.SYS_SCHED_GETAFFINITY, unix.SYS_DUP, unix.SYS_DUP3,
+	unix.SYS_NANOSLEEP, unix.SYS_CLOCK_NANOSLEEP, unix.SYS_CLOCK_GETTIME, unix.SYS_CLOCK_GETRES,
+	unix.SYS_GETTIMEOFDAY, unix.SYS_GETPID, unix.SYS_GETPPID, unix.SYS_GETTID,
+	unix.SYS_GETUID, unix.SYS_GETGID, unix.SYS_GETEUID, unix.SYS_GETEGID,
+	unix.SYS_CONNECT, unix.SYS_ACCEPT4, unix.SYS_BIND, unix.SYS_LISTEN,
+	unix.SYS_GETSOCKNAME, unix.SYS_GETPEERNAME, unix.SYS_SETSOCKOPT, unix.SYS_GETSOCKOPT,
+	unix.SYS_EXIT, unix.SYS_EXIT_GROUP, unix.SYS_WAIT4, unix.SYS_TGKILL,
+	unix.SYS_UNAME, unix.SYS_FCNTL, unix.SYS_FLOCK, unix.SYS_FSYNC, unix.SYS_FDATASYNC,
+	unix.SYS_GETCWD, unix.SYS_READLINKAT, unix.SYS_GETDENTS64,
+	unix.SYS_GETRLIMIT, unix.SYS_GETRUSAGE, unix.SYS_SYSINFO,
+	unix.SYS_FUTEX, unix.SYS_SET_TID_ADDRESS,
+	unix.SYS_SET_ROBUST_LIST, unix.SYS_GET_ROBUST_LIST, unix.SYS_RSEQ, unix.SYS_RESTART_SYSCALL,
+	unix.SYS_EPOLL_CREATE1, unix.SYS_EPOLL_CTL, unix.SYS_EPOLL_PWAIT, unix.SYS_EVENTFD2, unix.SYS_GETRANDOM,
+}
@@ -0,0 +1,9 @@
+//go:build linux && !amd64 && !arm64
+// +build linux,!amd64,!arm64
+
+package native
+
+// 其他架构暂不支持沙盒运行原生合约
+const seccompAuditArch = 0
+
+var seccompAllowlist []uintptr
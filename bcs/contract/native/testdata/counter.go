@@ -1,6 +1,8 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
 	"strconv"
 
 	"github.com/xuperchain/contract-sdk-go/code"
@@ -58,6 +60,26 @@ func (c *counter) Get(ctx code.Context) code.Response {
 	return code.OK(value)
 }
 
+// ReadFile 读取合约进程可以访问的文件，用于测试沙盒隔离
+func (c *counter) ReadFile(ctx code.Context) code.Response {
+	value, err := ioutil.ReadFile(string(ctx.Args()["path"]))
+	if err != nil {
+		return code.Error(err)
+	}
+	return code.OK(value)
+}
+
+// Uid 返回合约进程的uid，用于测试沙盒隔离
+func (c *counter) Uid(ctx code.Context) code.Response {
+	return code.OK([]byte(strconv.Itoa(os.Getuid())))
+}
+
+// Loop 不发起系统调用的死循环，用于测试执行超时
+func (c *counter) Loop(ctx code.Context) code.Response {
+	for {
+	}
+}
+
 func main() {
 	driver.Serve(new(counter))
 }
@@ -53,7 +53,11 @@ func newXVMCreator(creatorConfig *bridge.InstanceCreatorConfig) (bridge.Instance
 			return nil, fmt.Errorf("bad xvm optlevel:%d", optlevel)
 		}
 	}
-	creator.cm, err = newCodeManager(creator.config.Basedir,
+	var cacheSize int64
+	if creator.vmconfig != nil {
+		cacheSize = creator.vmconfig.XVM.CacheSizeMB << 20
+	}
+	creator.cm, err = newCodeManager(creator.config.Basedir, cacheSize,
 		creator.CompileCode, creator.MakeExecCode)
 	if err != nil {
 		return nil, err
@@ -134,6 +138,12 @@ func (x *xvmCreator) RemoveCache(contractName string) {
 	x.cm.RemoveCode(contractName)
 }
 
+// WarmUpCache 编译并加载合约代码，后续调用不再需要编译
+func (x *xvmCreator) WarmUpCache(contractName string, cp bridge.ContractCodeProvider) error {
+	_, err := x.getContractCodeCache(contractName, cp)
+	return err
+}
+
 func init() {
 	bridge.Register(bridge.TypeWasm, "xvm", newXVMCreator)
 }
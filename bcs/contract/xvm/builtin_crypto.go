@@ -0,0 +1,100 @@
+package xvm
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/xuperchain/crypto/gm/gmsm/sm2"
+	gmsign "github.com/xuperchain/crypto/gm/sign"
+	"golang.org/x/crypto/sha3"
+)
+
+// verifyFunc verifies sig of hash using the raw public key
+type verifyFunc func(pubkey, sig, hash []byte) bool
+
+func signatureVerifier(name string) verifyFunc {
+	switch name {
+	case "secp256k1":
+		return verifySecp256k1
+	case "sm2":
+		return verifySM2
+	default:
+		return nil
+	}
+}
+
+// verifySecp256k1 supports compressed or uncompressed public key,
+// and DER encoded or 64 bytes r||s signature
+func verifySecp256k1(pubkey, sig, hash []byte) bool {
+	pub, err := btcec.ParsePubKey(pubkey, btcec.S256())
+	if err != nil {
+		return false
+	}
+	var signature *btcec.Signature
+	if len(sig) == 64 {
+		signature = &btcec.Signature{
+			R: new(big.Int).SetBytes(sig[:32]),
+			S: new(big.Int).SetBytes(sig[32:]),
+		}
+	} else {
+		signature, err = btcec.ParseDERSignature(sig, btcec.S256())
+		if err != nil {
+			return false
+		}
+	}
+	return signature.Verify(hash, pub)
+}
+
+// verifySM2 supports uncompressed public key, and DER encoded or 64 bytes r||s signature
+func verifySM2(pubkey, sig, hash []byte) bool {
+	curve := sm2.P256Sm2()
+	x, y := elliptic.Unmarshal(curve, pubkey)
+	if x == nil {
+		return false
+	}
+	var r, s *big.Int
+	if len(sig) == 64 {
+		r = new(big.Int).SetBytes(sig[:32])
+		s = new(big.Int).SetBytes(sig[32:])
+	} else {
+		var err error
+		r, s, err = gmsign.UnmarshalECDSASignature(sig)
+		if err != nil {
+			return false
+		}
+	}
+	pub := &sm2.PublicKey{
+		Curve: curve,
+		X:     x,
+		Y:     y,
+	}
+	return sm2.Verify(pub, hash, r, s)
+}
+
+// ecrecover recovers the ethereum style address from the 65 bytes r||s||v signature,
+// v is 0, 1 or 27, 28
+func ecrecover(hash, sig []byte) ([]byte, error) {
+	if len(hash) != 32 || len(sig) != 65 {
+		return nil, errors.New("bad hash or signature length")
+	}
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, errors.New("bad signature recovery id")
+	}
+	// btcec的压缩签名格式为<27+v><r><s>
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), compact, hash)
+	if err != nil {
+		return nil, err
+	}
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(pub.SerializeUncompressed()[1:])
+	return hasher.Sum(nil)[12:], nil
+}
@@ -0,0 +1,61 @@
+package xvm
+
+import (
+	"github.com/xuperchain/xvm/exec"
+)
+
+const (
+	hostGasUsedKey  = "hostGasUsed"
+	hostGasLimitKey = "hostGasLimit"
+)
+
+// 内置函数的gas消耗，只和输入长度相关，保证各节点的计算结果一致
+// sha256、hex和ecverify为已部署合约使用的函数，为了兼容不收取gas
+const (
+	hashGasBase    = 60
+	hashGasPerWord = 12
+
+	codecGasBase           = 10
+	codecGasPerWord        = 3
+	base58GasPerWordSquare = 3
+
+	verifyGas    = 3000
+	ecrecoverGas = 3000
+)
+
+// wordsOf returns the number of 32 bytes words of n bytes
+func wordsOf(n int) int64 {
+	return int64((n + 31) / 32)
+}
+
+func hashGas(name string, n int) int64 {
+	if name == "sha256" {
+		return 0
+	}
+	return hashGasBase + wordsOf(n)*hashGasPerWord
+}
+
+// initHostGas sets the gas limit of builtin functions, which shares the gas limit with the contract
+func initHostGas(ctx exec.Context, limit int64) {
+	ctx.SetUserData(hostGasLimitKey, limit)
+	ctx.SetUserData(hostGasUsedKey, int64(0))
+}
+
+// hostGasUsed returns the gas used by builtin functions
+func hostGasUsed(ctx exec.Context) int64 {
+	used, _ := ctx.GetUserData(hostGasUsedKey).(int64)
+	return used
+}
+
+// chargeGas charges the gas of builtin functions, throws TrapGasExhaustion when exceeds the gas limit
+func chargeGas(ctx exec.Context, gas int64) {
+	if gas == 0 {
+		return
+	}
+	used := hostGasUsed(ctx) + gas
+	limit, ok := ctx.GetUserData(hostGasLimitKey).(int64)
+	if ok && ctx.GasUsed()+used > limit {
+		exec.Throw(exec.TrapGasExhaustion)
+	}
+	ctx.SetUserData(hostGasUsedKey, used)
+}
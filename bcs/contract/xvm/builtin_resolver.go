@@ -2,15 +2,22 @@ package xvm
 
 import (
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"github.com/xuperchain/crypto/core/account"
-	"github.com/xuperchain/crypto/core/sign"
 	"hash"
 	"unsafe"
 
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/xuperchain/crypto/core/account"
+	"github.com/xuperchain/crypto/core/sign"
+	"github.com/xuperchain/crypto/gm/gmsm/sm3"
 	"github.com/xuperchain/xvm/exec"
 	"github.com/xuperchain/xvm/runtime/emscripten"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
 )
 
 func touint32(n int32) uint32 {
@@ -21,6 +28,17 @@ func hashFunc(name string) hash.Hash {
 	switch name {
 	case "sha256":
 		return sha256.New()
+	case "keccak256":
+		return sha3.NewLegacyKeccak256()
+	case "sha3-256":
+		return sha3.New256()
+	case "ripemd160":
+		return ripemd160.New()
+	case "blake2b-256":
+		h, _ := blake2b.New256(nil)
+		return h
+	case "sm3":
+		return sm3.New()
 	default:
 		return nil
 	}
@@ -40,6 +58,7 @@ func xvmHash(ctx exec.Context,
 	if hasher == nil {
 		exec.ThrowMessage(fmt.Sprintf("hash %s not found", name))
 	}
+	chargeGas(ctx, hashGas(name, len(input)))
 	hasher.Write(input)
 	out := hasher.Sum(nil)
 	copy(output, out[:])
@@ -49,12 +68,18 @@ func xvmHash(ctx exec.Context,
 type codec interface {
 	Encode(in []byte) []byte
 	Decode(in []byte) ([]byte, error)
+	// Gas returns the gas cost of encoding or decoding n bytes
+	Gas(n int) int64
 }
 
 func getCodec(name string) codec {
 	switch name {
 	case "hex":
 		return hexCodec{}
+	case "base58":
+		return base58Codec{}
+	case "base64":
+		return base64Codec{}
 	default:
 		return nil
 	}
@@ -73,6 +98,47 @@ func (h hexCodec) Decode(in []byte) ([]byte, error) {
 	return out, err
 }
 
+// hex is free for compatibility with deployed contracts
+func (h hexCodec) Gas(n int) int64 {
+	return 0
+}
+
+type base58Codec struct{}
+
+func (b base58Codec) Encode(in []byte) []byte {
+	return []byte(base58.Encode(in))
+}
+func (b base58Codec) Decode(in []byte) ([]byte, error) {
+	out := base58.Decode(string(in))
+	if len(out) == 0 && len(in) != 0 {
+		return nil, errors.New("invalid base58 string")
+	}
+	return out, nil
+}
+
+// base58 is a big number conversion, the cost grows quadratically with the input size
+func (b base58Codec) Gas(n int) int64 {
+	words := wordsOf(n)
+	return codecGasBase + words*words*base58GasPerWordSquare
+}
+
+type base64Codec struct{}
+
+func (b base64Codec) Encode(in []byte) []byte {
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(in)))
+	base64.StdEncoding.Encode(out, in)
+	return out
+}
+func (b base64Codec) Decode(in []byte) ([]byte, error) {
+	out := make([]byte, base64.StdEncoding.DecodedLen(len(in)))
+	n, err := base64.StdEncoding.Decode(out, in)
+	return out[:n], err
+}
+
+func (b base64Codec) Gas(n int) int64 {
+	return codecGasBase + wordsOf(n)*codecGasPerWord
+}
+
 func xvmEncode(ctx exec.Context,
 	nameptr uint32,
 	inputptr uint32, inputlen uint32,
@@ -86,6 +152,7 @@ func xvmEncode(ctx exec.Context,
 	if c == nil {
 		exec.ThrowMessage(fmt.Sprintf("codec %s not found", name))
 	}
+	chargeGas(ctx, c.Gas(len(input)))
 	out := c.Encode(input)
 
 	codec.SetUint32(outputpptr, bytesdup(ctx, out))
@@ -106,6 +173,7 @@ func xvmDecode(ctx exec.Context,
 	if c == nil {
 		exec.ThrowMessage(fmt.Sprintf("codec %s not found", name))
 	}
+	chargeGas(ctx, c.Gas(len(input)))
 	out, err := c.Decode(input)
 	if err != nil {
 		return 1
@@ -136,6 +204,49 @@ func xvmECVerify(ctx exec.Context,
 	return touint32(-1)
 }
 
+// xvmVerify verifies the signature of hash using the raw public key of the named curve
+func xvmVerify(ctx exec.Context,
+	nameptr uint32,
+	pubptr, publen,
+	sigptr, siglen, hashptr, hashlen uint32) uint32 {
+	codec := exec.NewCodec(ctx)
+
+	name := codec.CString(nameptr)
+	pubkey := codec.Bytes(pubptr, publen)
+	sig := codec.Bytes(sigptr, siglen)
+	hash := codec.Bytes(hashptr, hashlen)
+	verifier := signatureVerifier(name)
+	if verifier == nil {
+		exec.ThrowMessage(fmt.Sprintf("signature algorithm %s not found", name))
+	}
+	chargeGas(ctx, verifyGas)
+
+	if !verifier(pubkey, sig, hash) {
+		return touint32(-1)
+	}
+	return 0
+}
+
+// xvmECRecover recovers the ethereum style address from the secp256k1 signature of hash
+func xvmECRecover(ctx exec.Context,
+	hashptr, hashlen,
+	sigptr, siglen,
+	outputptr, outputlen uint32) uint32 {
+	codec := exec.NewCodec(ctx)
+
+	hash := codec.Bytes(hashptr, hashlen)
+	sig := codec.Bytes(sigptr, siglen)
+	output := codec.Bytes(outputptr, outputlen)
+	chargeGas(ctx, ecrecoverGas)
+
+	addr, err := ecrecover(hash, sig)
+	if err != nil || len(output) != len(addr) {
+		return touint32(-1)
+	}
+	copy(output, addr)
+	return 0
+}
+
 // func xvmMakeTx(ctx exec.Context, txptr, txlen, outpptr, outlenPtr uint32) uint32 {
 // 	codec := exec.NewCodec(ctx)
 // 	txbuf := codec.Bytes(txptr, txlen)
@@ -199,10 +310,12 @@ func strdup(ctx exec.Context, s string) uint32 {
 }
 
 var builtinResolver = exec.MapResolver(map[string]interface{}{
-	"env._xvm_hash":     xvmHash,
-	"env._xvm_encode":   xvmEncode,
-	"env._xvm_decode":   xvmDecode,
-	"env._xvm_ecverify": xvmECVerify,
+	"env._xvm_hash":      xvmHash,
+	"env._xvm_encode":    xvmEncode,
+	"env._xvm_decode":    xvmDecode,
+	"env._xvm_ecverify":  xvmECVerify,
+	"env._xvm_verify":    xvmVerify,
+	"env._xvm_ecrecover": xvmECRecover,
 	// "env._xvm_make_tx":          xvmMakeTx,
 	"env._xvm_addr_from_pubkey": xvmAddressFromPubkey,
 })
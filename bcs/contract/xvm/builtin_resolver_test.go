@@ -0,0 +1,194 @@
+package xvm
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/xuperchain/crypto/gm/gmsm/sm2"
+	"github.com/xuperchain/xvm/exec"
+	"golang.org/x/crypto/sha3"
+)
+
+type fakeContext struct {
+	exec.Context
+	gasUsed  int64
+	userData map[string]interface{}
+}
+
+func newFakeContext() *fakeContext {
+	return &fakeContext{
+		userData: make(map[string]interface{}),
+	}
+}
+
+func (f *fakeContext) GasUsed() int64 {
+	return f.gasUsed
+}
+
+func (f *fakeContext) SetUserData(key string, value interface{}) {
+	f.userData[key] = value
+}
+
+func (f *fakeContext) GetUserData(key string) interface{} {
+	return f.userData[key]
+}
+
+func TestHashFunc(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		output string
+	}{
+		{"keccak256", "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"sha3-256", "", "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+		{"ripemd160", "", "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+		{"blake2b-256", "", "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+		{"sm3", "abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+	}
+	for _, c := range cases {
+		hasher := hashFunc(c.name)
+		if hasher == nil {
+			t.Fatalf("hash %s not found", c.name)
+		}
+		hasher.Write([]byte(c.input))
+		out := hex.EncodeToString(hasher.Sum(nil))
+		if out != c.output {
+			t.Errorf("hash %s expect %s, got %s", c.name, c.output, out)
+		}
+	}
+	if hashFunc("md5") != nil {
+		t.Error("md5 should not be supported")
+	}
+}
+
+func TestCodec(t *testing.T) {
+	input := []byte("\x00\x01hello xuperchain")
+	for _, name := range []string{"hex", "base58", "base64"} {
+		c := getCodec(name)
+		if c == nil {
+			t.Fatalf("codec %s not found", name)
+		}
+		out, err := c.Decode(c.Encode(input))
+		if err != nil {
+			t.Fatalf("codec %s decode error:%s", name, err)
+		}
+		if string(out) != string(input) {
+			t.Errorf("codec %s expect %q, got %q", name, input, out)
+		}
+	}
+	if _, err := getCodec("base58").Decode([]byte("0OIl")); err == nil {
+		t.Error("expect base58 decode error")
+	}
+	if _, err := getCodec("base64").Decode([]byte("!!")); err == nil {
+		t.Error("expect base64 decode error")
+	}
+}
+
+func TestVerifySecp256k1(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("hello"))
+	sig, err := priv.Sign(hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify := signatureVerifier("secp256k1")
+	pubkey := priv.PubKey().SerializeCompressed()
+	if !verify(pubkey, sig.Serialize(), hash[:]) {
+		t.Error("verify DER signature failed")
+	}
+	raw := append(padBytes(sig.R.Bytes()), padBytes(sig.S.Bytes())...)
+	if !verify(priv.PubKey().SerializeUncompressed(), raw, hash[:]) {
+		t.Error("verify r||s signature failed")
+	}
+	hash[0]++
+	if verify(pubkey, sig.Serialize(), hash[:]) {
+		t.Error("verify should fail with wrong hash")
+	}
+}
+
+func TestVerifySM2(t *testing.T) {
+	priv, err := sm2.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("hello"))
+	r, s, err := sm2.Sign(priv, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify := signatureVerifier("sm2")
+	pubkey := elliptic.Marshal(priv.Curve, priv.X, priv.Y)
+	raw := append(padBytes(r.Bytes()), padBytes(s.Bytes())...)
+	if !verify(pubkey, raw, hash[:]) {
+		t.Error("verify sm2 signature failed")
+	}
+	if verify(pubkey[1:], raw, hash[:]) {
+		t.Error("verify should fail with bad public key")
+	}
+}
+
+func TestECRecover(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("hello"))
+	compact, err := btcec.SignCompact(btcec.S256(), priv, hash[:], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 转换为r||s||v
+	sig := append(compact[1:], compact[0])
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(priv.PubKey().SerializeUncompressed()[1:])
+	expect := hasher.Sum(nil)[12:]
+
+	addr, err := ecrecover(hash[:], sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(addr) != hex.EncodeToString(expect) {
+		t.Errorf("expect address %x, got %x", expect, addr)
+	}
+
+	sig[64] = 30
+	if _, err := ecrecover(hash[:], sig); err == nil {
+		t.Error("expect error with bad recovery id")
+	}
+}
+
+func TestChargeGas(t *testing.T) {
+	ctx := newFakeContext()
+	initHostGas(ctx, 100)
+	ctx.gasUsed = 50
+
+	chargeGas(ctx, 30)
+	if hostGasUsed(ctx) != 30 {
+		t.Fatalf("expect host gas 30, got %d", hostGasUsed(ctx))
+	}
+
+	var err error
+	func() {
+		defer exec.CaptureTrap(&err)
+		chargeGas(ctx, 30)
+	}()
+	if err == nil {
+		t.Fatal("expect gas exhaustion trap")
+	}
+	if hostGasUsed(ctx) != 30 {
+		t.Errorf("host gas should not change after trap, got %d", hostGasUsed(ctx))
+	}
+}
+
+func padBytes(b []byte) []byte {
+	out := make([]byte, 32)
+	copy(out[32-len(b):], b)
+	return out
+}
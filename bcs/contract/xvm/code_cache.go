@@ -0,0 +1,358 @@
+package xvm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xuperchain/crypto/core/hash"
+	"github.com/xuperchain/xupercore/protos"
+	"github.com/xuperchain/xvm/compile"
+)
+
+const (
+	cacheCodeFile = "code.so"
+	cacheMetaFile = "code.meta"
+	// 编译中的临时目录
+	cacheTmpPrefix = ".tmp-"
+)
+
+// errCodeDigestMismatch 合约代码与代码摘要不一致，产物按摘要寻址，不一致的代码不能编译和缓存
+var errCodeDigestMismatch = errors.New("contract code mismatch with digest")
+
+// codeCacheMeta 记录编译产物的校验信息
+type codeCacheMeta struct {
+	// 合约代码摘要
+	Digest []byte
+	// 编译器版本
+	VmCompiler string
+	// 编译产物的sha256，加载前校验，防止使用损坏的文件
+	CodeHash string
+	Size     int64
+}
+
+type codeCacheEntry struct {
+	size     int64
+	lastUsed time.Time
+	// 正在被复制到运行目录的次数，不为0时不能淘汰
+	refs int
+	// 校验失败但仍被引用的产物，最后一个引用释放时删除
+	invalid bool
+}
+
+// codeCacheCall 是正在进行的编译，相同代码的编译只进行一次
+type codeCacheCall struct {
+	wg  sync.WaitGroup
+	err error
+	// 等待编译完成的调用数，产物写入缓存时为它们保留引用
+	waiters int
+}
+
+// diskCodeCache 是按照合约代码摘要和编译器版本寻址的编译产物缓存，
+// 相同代码的合约共享一份编译产物，合约升级后旧版本的产物保留到被淘汰为止。
+// 缓存总大小超过maxSize时按最近最少使用淘汰，最近使用时间记录在文件修改时间中，重启后依然有效。
+// Lookup和Make返回的产物在调用release之前不会被淘汰，release时重新检查缓存大小。
+type diskCodeCache struct {
+	dir     string
+	maxSize int64
+
+	mutex   sync.Mutex
+	entries map[string]*codeCacheEntry
+	calls   map[string]*codeCacheCall
+	size    int64
+}
+
+func newDiskCodeCache(dir string, maxSize int64) (*diskCodeCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	c := &diskCodeCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*codeCacheEntry),
+		calls:   make(map[string]*codeCacheCall),
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		id := info.Name()
+		if strings.HasPrefix(id, cacheTmpPrefix) {
+			os.RemoveAll(c.entryDir(id))
+			continue
+		}
+		meta, err := c.readMeta(id)
+		if err != nil {
+			// 不完整的缓存，可能是写入时进程退出
+			os.RemoveAll(c.entryDir(id))
+			continue
+		}
+		metaInfo, err := os.Stat(filepath.Join(c.entryDir(id), cacheMetaFile))
+		if err != nil {
+			continue
+		}
+		c.entries[id] = &codeCacheEntry{
+			size:     meta.Size,
+			lastUsed: metaInfo.ModTime(),
+		}
+		c.size += meta.Size
+	}
+	c.mutex.Lock()
+	c.evictLocked()
+	c.mutex.Unlock()
+	return c, nil
+}
+
+func (c *diskCodeCache) entryDir(id string) string {
+	return filepath.Join(c.dir, id)
+}
+
+func (c *diskCodeCache) readMeta(id string) (*codeCacheMeta, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(c.entryDir(id), cacheMetaFile))
+	if err != nil {
+		return nil, err
+	}
+	meta := new(codeCacheMeta)
+	if err := json.Unmarshal(buf, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func fileSha256(fpath string) (string, int64, error) {
+	buf, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return "", 0, err
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:]), int64(len(buf)), nil
+}
+
+// Lookup 查找合约代码对应的编译产物，校验失败的产物会被删除，
+// 命中时返回的产物在调用release之前不会被淘汰
+func (c *diskCodeCache) Lookup(desc *protos.WasmCodeDesc) (string, func(), bool) {
+	id := makeCacheId(desc)
+	c.mutex.Lock()
+	entry, ok := c.entries[id]
+	if ok && entry.invalid {
+		ok = false
+	}
+	if ok {
+		entry.refs++
+	}
+	c.mutex.Unlock()
+	if !ok {
+		return "", nil, false
+	}
+	meta, err := c.readMeta(id)
+	libpath := filepath.Join(c.entryDir(id), cacheCodeFile)
+	if err == nil {
+		var hash string
+		hash, _, err = fileSha256(libpath)
+		if err == nil && (hash != meta.CodeHash || meta.VmCompiler != compile.Version) {
+			err = os.ErrInvalid
+		}
+	}
+	if err != nil {
+		c.invalidate(id)
+		return "", nil, false
+	}
+	c.touch(id)
+	return libpath, func() { c.release(id) }, true
+}
+
+// Make 编译合约代码并写入缓存，返回编译产物的路径，返回的产物在调用release之前不会被淘汰。
+// 相同代码的合约同时调用时只编译一次，已经写入缓存的产物直接返回。
+// 代码必须与desc中的摘要一致，否则会以其他合约的摘要缓存错误的产物
+func (c *diskCodeCache) Make(desc *protos.WasmCodeDesc, codebuf []byte,
+	compileCode compileFunc) (string, func(), error) {
+	if !bytes.Equal(hash.DoubleSha256(codebuf), desc.GetDigest()) {
+		return "", nil, errCodeDigestMismatch
+	}
+	id := makeCacheId(desc)
+	libpath := filepath.Join(c.entryDir(id), cacheCodeFile)
+	c.mutex.Lock()
+	if entry, ok := c.entries[id]; ok {
+		if entry.invalid {
+			// 损坏的产物还在被引用，不能覆盖，编译到临时目录单独使用
+			c.mutex.Unlock()
+			tmpdir, _, err := c.compile(id, desc, codebuf, compileCode)
+			if err != nil {
+				return "", nil, err
+			}
+			return filepath.Join(tmpdir, cacheCodeFile), func() { os.RemoveAll(tmpdir) }, nil
+		}
+		entry.refs++
+		c.mutex.Unlock()
+		return libpath, func() { c.release(id) }, nil
+	}
+	if call, ok := c.calls[id]; ok {
+		call.waiters++
+		c.mutex.Unlock()
+		call.wg.Wait()
+		if call.err != nil {
+			return "", nil, call.err
+		}
+		return libpath, func() { c.release(id) }, nil
+	}
+	call := new(codeCacheCall)
+	call.wg.Add(1)
+	c.calls[id] = call
+	c.mutex.Unlock()
+
+	tmpdir, size, err := c.compile(id, desc, codebuf, compileCode)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	defer call.wg.Done()
+	delete(c.calls, id)
+	if err == nil {
+		// 没有缓存记录的目录不会被引用，可能是校验失败后残留的
+		os.RemoveAll(c.entryDir(id))
+		err = os.Rename(tmpdir, c.entryDir(id))
+		if err != nil {
+			os.RemoveAll(tmpdir)
+		}
+	}
+	if err != nil {
+		call.err = err
+		return "", nil, err
+	}
+	c.entries[id] = &codeCacheEntry{
+		size:     size,
+		lastUsed: time.Now(),
+		refs:     1 + call.waiters,
+	}
+	c.size += size
+	c.evictLocked()
+	return libpath, func() { c.release(id) }, nil
+}
+
+// compile 编译合约代码到临时目录，完成后再改名，避免出现不完整的缓存
+func (c *diskCodeCache) compile(id string, desc *protos.WasmCodeDesc, codebuf []byte,
+	compileCode compileFunc) (string, int64, error) {
+	tmpdir, err := ioutil.TempDir(c.dir, cacheTmpPrefix+id)
+	if err != nil {
+		return "", 0, err
+	}
+	size, err := c.writeCode(tmpdir, desc, codebuf, compileCode)
+	if err != nil {
+		os.RemoveAll(tmpdir)
+		return "", 0, err
+	}
+	return tmpdir, size, nil
+}
+
+func (c *diskCodeCache) writeCode(dir string, desc *protos.WasmCodeDesc, codebuf []byte,
+	compileCode compileFunc) (int64, error) {
+	err := compileCode(codebuf, filepath.Join(dir, cacheCodeFile))
+	if err != nil {
+		return 0, err
+	}
+	hash, size, err := fileSha256(filepath.Join(dir, cacheCodeFile))
+	if err != nil {
+		return 0, err
+	}
+	meta := &codeCacheMeta{
+		Digest:     desc.GetDigest(),
+		VmCompiler: compile.Version,
+		CodeHash:   hash,
+		Size:       size,
+	}
+	metabuf, _ := json.Marshal(meta)
+	err = ioutil.WriteFile(filepath.Join(dir, cacheMetaFile), metabuf, 0600)
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (c *diskCodeCache) touch(id string) {
+	now := time.Now()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		return
+	}
+	entry.lastUsed = now
+	os.Chtimes(filepath.Join(c.entryDir(id), cacheMetaFile), now, now)
+}
+
+// release 释放Lookup或Make对产物的引用，并淘汰超出大小的产物
+func (c *diskCodeCache) release(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.releaseLocked(id)
+	c.evictLocked()
+}
+
+// invalidate 释放对校验失败的产物的引用并删除产物
+func (c *diskCodeCache) invalidate(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.releaseLocked(id)
+	c.removeLocked(id)
+}
+
+func (c *diskCodeCache) releaseLocked(id string) {
+	entry, ok := c.entries[id]
+	if !ok || entry.refs == 0 {
+		return
+	}
+	entry.refs--
+	if entry.invalid && entry.refs == 0 {
+		c.removeLocked(id)
+	}
+}
+
+// removeLocked 删除产物，仍被引用的产物标记为无效，最后一个引用释放时删除
+func (c *diskCodeCache) removeLocked(id string) {
+	entry, ok := c.entries[id]
+	if !ok {
+		return
+	}
+	if entry.refs > 0 {
+		entry.invalid = true
+		return
+	}
+	c.size -= entry.size
+	delete(c.entries, id)
+	os.RemoveAll(c.entryDir(id))
+}
+
+// evictLocked 淘汰最近最少使用的产物直到总大小不超过maxSize，正在被复制的产物在释放时再淘汰。
+// 已经加载的合约使用的是运行目录中的副本，淘汰不影响正在执行的合约
+func (c *diskCodeCache) evictLocked() {
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return
+	}
+	ids := make([]string, 0, len(c.entries))
+	for id, entry := range c.entries {
+		if entry.refs == 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return c.entries[ids[i]].lastUsed.Before(c.entries[ids[j]].lastUsed)
+	})
+	for _, id := range ids {
+		if c.size <= c.maxSize {
+			return
+		}
+		c.removeLocked(id)
+	}
+}
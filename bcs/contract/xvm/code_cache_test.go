@@ -0,0 +1,192 @@
+package xvm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/xuperchain/crypto/core/hash"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func newTestCodeDesc(code []byte) *protos.WasmCodeDesc {
+	return &protos.WasmCodeDesc{Digest: hash.DoubleSha256(code)}
+}
+
+func TestDiskCodeCache(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "xvm-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	compiled := 0
+	compileFunc := func(code []byte, output string) error {
+		compiled++
+		return ioutil.WriteFile(output, code, 0700)
+	}
+	code1, code2 := []byte("0123456789"), []byte("abcdefghij")
+	desc1 := newTestCodeDesc(code1)
+	desc2 := newTestCodeDesc(code2)
+
+	cache, err := newDiskCodeCache(tmpdir, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := cache.Lookup(desc1); ok {
+		t.Fatal("expect cache miss")
+	}
+	// 代码与摘要不一致时不编译
+	if _, _, err := cache.Make(desc1, code2, compileFunc); err != errCodeDigestMismatch {
+		t.Fatalf("expect digest mismatch, got %v", err)
+	}
+	libpath, release, err := cache.Make(desc1, code1, compileFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if path, release, ok := cache.Lookup(desc1); !ok || path != libpath {
+		t.Fatalf("expect cache hit, got %s %v", path, ok)
+	} else {
+		release()
+	}
+
+	// 重启之后仍然命中
+	cache, err = newDiskCodeCache(tmpdir, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, release, ok := cache.Lookup(desc1); !ok {
+		t.Fatal("expect cache hit after restart")
+	} else {
+		release()
+	}
+
+	// 被篡改的产物校验失败
+	if err := ioutil.WriteFile(libpath, []byte("9876543210"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := cache.Lookup(desc1); ok {
+		t.Fatal("expect corrupted code removed")
+	}
+
+	// 超过最大大小后立即淘汰最近最少使用的产物，正在使用的产物在释放后淘汰
+	_, release1, _ := cache.Make(desc1, code1, compileFunc)
+	_, release2, _ := cache.Make(desc2, code2, compileFunc)
+	if cache.size != 20 {
+		t.Fatalf("expect referenced code kept, got size %d", cache.size)
+	}
+	release1()
+	release2()
+	if _, _, ok := cache.Lookup(desc1); ok {
+		t.Fatal("expect desc1 evicted")
+	}
+	if _, release, ok := cache.Lookup(desc2); !ok {
+		t.Fatal("expect desc2 cached")
+	} else {
+		release()
+	}
+	if cache.size != 10 {
+		t.Fatalf("expect cache size 10, got %d", cache.size)
+	}
+	if _, err := os.Stat(filepath.Join(tmpdir, makeCacheId(desc1))); !os.IsNotExist(err) {
+		t.Fatal("expect evicted code removed from disk")
+	}
+	if compiled != 3 {
+		t.Fatalf("expect compiled 3 times, got %d", compiled)
+	}
+}
+
+func TestDiskCodeCacheShared(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "xvm-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	var compiled int32
+	start := make(chan struct{})
+	compileFunc := func(code []byte, output string) error {
+		atomic.AddInt32(&compiled, 1)
+		<-start
+		return ioutil.WriteFile(output, code, 0700)
+	}
+	cache, err := newDiskCodeCache(tmpdir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 代码相同的多个合约同时编译，只编译一次，每个调用都持有引用
+	const N = 4
+	code := []byte("0123456789")
+	desc := newTestCodeDesc(code)
+	releases := make([]func(), N)
+	var wg sync.WaitGroup
+	for i := 0; i < N; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, release, err := cache.Make(desc, code, compileFunc)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			releases[i] = release
+		}(i)
+	}
+	for {
+		cache.mutex.Lock()
+		call := cache.calls[makeCacheId(desc)]
+		waiting := call != nil && call.waiters == N-1
+		cache.mutex.Unlock()
+		if waiting {
+			break
+		}
+		runtime.Gosched()
+	}
+	close(start)
+	wg.Wait()
+	if compiled != 1 {
+		t.Fatalf("expect compiled once, got %d", compiled)
+	}
+	if refs := cache.entries[makeCacheId(desc)].refs; refs != N {
+		t.Fatalf("expect %d refs, got %d", N, refs)
+	}
+
+	// 校验失败的产物在被引用时保留，最后一个引用释放后删除
+	libpath := filepath.Join(tmpdir, makeCacheId(desc), cacheCodeFile)
+	if err := ioutil.WriteFile(libpath, []byte("9876543210"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := cache.Lookup(desc); ok {
+		t.Fatal("expect corrupted code miss")
+	}
+	if _, err := os.Stat(libpath); err != nil {
+		t.Fatal("expect referenced code kept")
+	}
+	// 无效的产物不能被覆盖，重新编译的产物单独使用
+	path, release, err := cache.Make(desc, code, compileFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path == libpath {
+		t.Fatal("expect code compiled outside the cache")
+	}
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("expect temporary code removed")
+	}
+	for _, release := range releases {
+		release()
+	}
+	if _, err := os.Stat(libpath); !os.IsNotExist(err) {
+		t.Fatal("expect invalid code removed after released")
+	}
+	if cache.size != 0 || len(cache.entries) != 0 {
+		t.Fatalf("unexpected cache state size:%d entries:%d", cache.size, len(cache.entries))
+	}
+}
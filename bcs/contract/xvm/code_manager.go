@@ -4,9 +4,7 @@ import (
 	"bytes"
 	"crypto/sha1"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -34,6 +32,7 @@ type codeManager struct {
 	basedir      string
 	rundir       string
 	cachedir     string
+	diskCache    *diskCodeCache
 	compileCode  compileFunc
 	makeExecCode makeExecCodeFunc
 
@@ -43,7 +42,8 @@ type codeManager struct {
 	codes map[string]*contractCode
 }
 
-func newCodeManager(basedir string, compile compileFunc, makeExec makeExecCodeFunc) (*codeManager, error) {
+// newCodeManager 创建合约代码管理器，maxCacheSize为编译产物磁盘缓存的最大字节数，0表示不限制
+func newCodeManager(basedir string, maxCacheSize int64, compile compileFunc, makeExec makeExecCodeFunc) (*codeManager, error) {
 	runDirFull := filepath.Join(basedir, "var", "run")
 	// clean all contract.so file in the run dir
 	os.RemoveAll(runDirFull)
@@ -51,7 +51,8 @@ func newCodeManager(basedir string, compile compileFunc, makeExec makeExecCodeFu
 	if err := os.MkdirAll(runDirFull, 0755); err != nil {
 		return nil, err
 	}
-	if err := os.MkdirAll(cacheDirFull, 0755); err != nil {
+	diskCache, err := newDiskCodeCache(cacheDirFull, maxCacheSize)
+	if err != nil {
 		return nil, err
 	}
 
@@ -59,6 +60,7 @@ func newCodeManager(basedir string, compile compileFunc, makeExec makeExecCodeFu
 		basedir:      basedir,
 		rundir:       runDirFull,
 		cachedir:     cacheDirFull,
+		diskCache:    diskCache,
 		compileCode:  compile,
 		makeExecCode: makeExec,
 		codes:        make(map[string]*contractCode),
@@ -110,61 +112,6 @@ func (c *codeManager) makeMemCache(name, libpath string, desc *protos.WasmCodeDe
 	return code, nil
 }
 
-func fileExists(fpath string) bool {
-	stat, err := os.Stat(fpath)
-	if err == nil && !stat.IsDir() {
-		return true
-	}
-	return false
-}
-
-func (c *codeManager) lookupDiskCache(name string, desc *protos.WasmCodeDesc) (string, bool) {
-	descpath := filepath.Join(c.basedir, name, "code.desc")
-	libpath := filepath.Join(c.basedir, name, "code.so")
-	if !fileExists(descpath) || !fileExists(libpath) {
-		return "", false
-	}
-	var localDesc protos.WasmCodeDesc
-	descbuf, err := ioutil.ReadFile(descpath)
-	if err != nil {
-		return "", false
-	}
-	err = json.Unmarshal(descbuf, &localDesc)
-	if err != nil {
-		return "", false
-	}
-	if !codeDescEqual(&localDesc, desc) ||
-		localDesc.GetVmCompiler() != compile.Version {
-		return "", false
-	}
-	return libpath, true
-}
-
-func (c *codeManager) makeDiskCache(name string, desc *protos.WasmCodeDesc, codebuf []byte) (string, error) {
-	basedir := filepath.Join(c.basedir, name)
-	descpath := filepath.Join(basedir, "code.desc")
-	libpath := filepath.Join(basedir, "code.so")
-
-	err := os.MkdirAll(basedir, 0700)
-	if err != nil {
-		return "", err
-	}
-
-	err = c.compileCode(codebuf, libpath)
-	if err != nil {
-		return "", err
-	}
-	localDesc := *desc
-	localDesc.VmCompiler = compile.Version
-	descbuf, _ := json.Marshal(&localDesc)
-	err = ioutil.WriteFile(descpath, descbuf, 0600)
-	if err != nil {
-		os.RemoveAll(basedir)
-		return "", err
-	}
-	return libpath, nil
-}
-
 func (c *codeManager) GetExecCode(name string, cp bridge.ContractCodeProvider) (*contractCode, error) {
 	desc, err := cp.GetContractCodeDesc(name)
 	if err != nil {
@@ -189,20 +136,22 @@ func (c *codeManager) GetExecCode(name string, cp bridge.ContractCodeProvider) (
 		if ok {
 			return execCode, nil
 		}
-		libpath, ok := c.lookupDiskCache(name, desc)
+		libpath, release, ok := c.diskCache.Lookup(desc)
 		if !ok {
 			// log.Debug("contract code need make disk cache", "contract", name)
 			codebuf, err := cp.GetContractCode(name)
 			if err != nil {
 				return nil, err
 			}
-			libpath, err = c.makeDiskCache(name, desc, codebuf)
+			libpath, release, err = c.diskCache.Make(desc, codebuf, c.compileCode)
 			if err != nil {
 				return nil, err
 			}
 		} else {
 			// log.Debug("contract code hit disk cache", "contract", name)
 		}
+		// 产物复制到运行目录后才可以被淘汰
+		defer release()
 		return c.makeMemCache(name, libpath, desc)
 	})
 	if err != nil {
@@ -211,14 +160,14 @@ func (c *codeManager) GetExecCode(name string, cp bridge.ContractCodeProvider) (
 	return icode.(*contractCode), nil
 }
 
+// RemoveCode 删除合约的内存缓存，磁盘缓存按照代码寻址，可能被其他合约共享，由LRU淘汰
 func (c *codeManager) RemoveCode(name string) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	delete(c.codes, name)
-	os.RemoveAll(filepath.Join(c.basedir, name))
 }
 
-// not used now
+// makeCacheId 返回编译产物在磁盘缓存中的地址，由合约代码摘要和编译器版本决定
 func makeCacheId(desc *protos.WasmCodeDesc) string {
 	h := sha1.New()
 	h.Write(desc.GetDigest())
@@ -52,11 +52,9 @@ func TestGetCacheExecCode(t *testing.T) {
 
 	cp := &memCodeProvider{
 		code: []byte("binary code"),
-		desc: &protos.WasmCodeDesc{
-			Digest: []byte("digest1"),
-		},
+		desc: newTestCodeDesc([]byte("binary code")),
 	}
-	cm, err := newCodeManager(tmpdir, compileFunc, makeExecCodeFunc)
+	cm, err := newCodeManager(tmpdir, 0, compileFunc, makeExecCodeFunc)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -74,14 +72,15 @@ func TestGetCacheExecCode(t *testing.T) {
 	}
 
 	// digest改变之后需要重新填充cache
-	cp.desc.Digest = []byte("digest2")
+	cp.code = []byte("binary code2")
+	cp.desc = newTestCodeDesc(cp.code)
 	code1, _ := cm.GetExecCode("c1", cp)
 	if code1 == code {
 		t.Fatalf("expect none equal code:%p, %p", code1, code)
 	}
 
 	// 期待从磁盘中获取
-	cm1, err := newCodeManager(tmpdir, compileFunc, makeExecCodeFunc)
+	cm1, err := newCodeManager(tmpdir, 0, compileFunc, makeExecCodeFunc)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -113,19 +112,25 @@ func TestMakeCacheBlocking(t *testing.T) {
 
 	cp := &memCodeProvider{
 		code: []byte("binary code"),
-		desc: &protos.WasmCodeDesc{
-			Digest: []byte("digest1"),
-		},
+		desc: newTestCodeDesc([]byte("binary code")),
 	}
-	cm, err := newCodeManager(tmpdir, compileFunc, makeExecCodeFunc)
+	cm, err := newCodeManager(tmpdir, 0, compileFunc, makeExecCodeFunc)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	// 磁盘缓存按代码寻址，不同的代码才需要重新编译
+	newBlockingCP := func(digest string) *memCodeProvider {
+		return &memCodeProvider{
+			code: []byte(digest),
+			desc: newTestCodeDesc([]byte(digest)),
+		}
+	}
+
 	// fill cache
 	cm.GetExecCode("c1", cp)
 	// making a blocking contract for c2
-	go cm.GetExecCode("blocking1", cp)
+	go cm.GetExecCode("blocking1", newBlockingCP("blocking1"))
 	c1 := make(chan int)
 	go func() {
 		// c1 should return immediately
@@ -138,11 +143,12 @@ func TestMakeCacheBlocking(t *testing.T) {
 	case <-c1:
 	}
 
-	go cm.GetExecCode("blocking2", cp)
+	blocking2 := newBlockingCP("blocking2")
+	go cm.GetExecCode("blocking2", blocking2)
 	c2 := make(chan int)
 	go func() {
 		// c1 should return immediately
-		cm.GetExecCode("blocking2", cp)
+		cm.GetExecCode("blocking2", blocking2)
 		close(c2)
 	}()
 	select {
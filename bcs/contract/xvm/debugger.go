@@ -0,0 +1,351 @@
+package xvm
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge/memrpc"
+	"github.com/xuperchain/xvm/exec"
+)
+
+const (
+	// 调试会话保存在exec.Context的userdata中，系统调用通过它通知调试器
+	debugSessionKey = "debugSession"
+
+	// 合约执行在DAP中表示为唯一的线程
+	debugThreadID = 1
+)
+
+var (
+	errNotPaused = errors.New("contract not paused")
+)
+
+// xvmDebugger 是ixvm解释器的单步调试器，调试客户端通过本地socket使用DAP协议连接。
+// 支持在合约导出函数和系统调用(GetObject、PutObject、ContractCall等)上设置断点，
+// 单步执行到下一次系统调用，以及在暂停时读取合约的线性内存。
+// 只有开启了调试的预执行请求中，调试白名单内的合约会被暂停，没有客户端连接时合约正常执行。
+type xvmDebugger struct {
+	listener  net.Listener
+	rpcserver *memrpc.Server
+
+	mutex       sync.Mutex
+	conn        *debugConn
+	breakpoints map[string]bool
+	stepping    bool
+	paused      *pausedSession
+
+	// 同一时间只有一个合约处于暂停状态
+	pauseLock sync.Mutex
+}
+
+type pausedSession struct {
+	session     *debugSession
+	description string
+	resume      chan struct{}
+}
+
+// debugSession 对应一个合约实例的调试状态
+type debugSession struct {
+	debugger  *xvmDebugger
+	execCtx   exec.Context
+	bridgeCtx *bridge.Context
+}
+
+// name 返回当前执行的合约方法，方法名在Invoke时才确定
+func (s *debugSession) name() string {
+	return s.bridgeCtx.ContractName + "." + s.bridgeCtx.Method
+}
+
+func newXVMDebugger(listen string, syscall *bridge.SyscallService) (*xvmDebugger, error) {
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, fmt.Errorf("xvm debugger listen error:%s", err)
+	}
+	d := &xvmDebugger{
+		listener:    listener,
+		rpcserver:   memrpc.NewServer(syscall),
+		breakpoints: make(map[string]bool),
+	}
+	go d.serve()
+	return d, nil
+}
+
+// attach 为合约实例开启调试
+func (d *xvmDebugger) attach(instance *xvmInstance) {
+	session := &debugSession{
+		debugger:  d,
+		execCtx:   instance.execCtx,
+		bridgeCtx: instance.bridgeCtx,
+	}
+	instance.execCtx.SetUserData(debugSessionKey, session)
+	instance.debug = session
+}
+
+func (d *xvmDebugger) serve() {
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		d.mutex.Lock()
+		if d.conn != nil {
+			// 只允许一个调试客户端
+			d.mutex.Unlock()
+			conn.Close()
+			continue
+		}
+		dconn := &debugConn{conn: conn}
+		d.conn = dconn
+		d.mutex.Unlock()
+		go d.handleConn(dconn)
+	}
+}
+
+func (d *xvmDebugger) handleConn(conn *debugConn) {
+	defer d.detach(conn)
+	r := bufio.NewReader(conn.conn)
+	for {
+		buf, err := readDAPMessage(r)
+		if err != nil {
+			return
+		}
+		req := new(dapRequest)
+		if err := json.Unmarshal(buf, req); err != nil {
+			return
+		}
+		body, err := d.handleRequest(req)
+		if err := conn.sendResponse(req, body, err); err != nil {
+			return
+		}
+		switch req.Command {
+		case "initialize":
+			conn.sendEvent("initialized", nil)
+		case "disconnect":
+			return
+		}
+	}
+}
+
+// detach 客户端断开后清除所有断点，恢复暂停的合约
+func (d *xvmDebugger) detach(conn *debugConn) {
+	conn.conn.Close()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.conn != conn {
+		return
+	}
+	d.conn = nil
+	d.breakpoints = make(map[string]bool)
+	d.stepping = false
+	d.resumeLocked()
+}
+
+func (d *xvmDebugger) handleRequest(req *dapRequest) (interface{}, error) {
+	switch req.Command {
+	case "initialize":
+		return map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsFunctionBreakpoints":      true,
+			"supportsReadMemoryRequest":        true,
+		}, nil
+	case "configurationDone", "disconnect":
+		return nil, nil
+	case "setFunctionBreakpoints":
+		var args functionBreakpointsArgs
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		breakpoints := make(map[string]bool)
+		verified := make([]map[string]interface{}, 0, len(args.Breakpoints))
+		for _, bp := range args.Breakpoints {
+			breakpoints[bp.Name] = true
+			verified = append(verified, map[string]interface{}{"verified": true})
+		}
+		d.mutex.Lock()
+		d.breakpoints = breakpoints
+		d.mutex.Unlock()
+		return map[string]interface{}{"breakpoints": verified}, nil
+	case "threads":
+		return map[string]interface{}{
+			"threads": []map[string]interface{}{{"id": debugThreadID, "name": "contract"}},
+		}, nil
+	case "continue":
+		if !d.resume(false) {
+			return nil, errNotPaused
+		}
+		return map[string]interface{}{"allThreadsContinued": true}, nil
+	case "next", "stepIn":
+		if !d.resume(true) {
+			return nil, errNotPaused
+		}
+		return nil, nil
+	case "pause":
+		// 在下一次系统调用或函数入口处暂停
+		d.mutex.Lock()
+		d.stepping = true
+		d.mutex.Unlock()
+		return nil, nil
+	case "stackTrace":
+		paused := d.currentPaused()
+		if paused == nil {
+			return nil, errNotPaused
+		}
+		frame := map[string]interface{}{
+			"id":     1,
+			"name":   paused.session.name() + ": " + paused.description,
+			"line":   0,
+			"column": 0,
+		}
+		return map[string]interface{}{
+			"stackFrames": []interface{}{frame},
+			"totalFrames": 1,
+		}, nil
+	case "readMemory":
+		var args readMemoryArgs
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		return d.readMemory(&args)
+	default:
+		return nil, fmt.Errorf("unsupported command %s", req.Command)
+	}
+}
+
+func (d *xvmDebugger) currentPaused() *pausedSession {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.paused
+}
+
+func (d *xvmDebugger) readMemory(args *readMemoryArgs) (interface{}, error) {
+	paused := d.currentPaused()
+	if paused == nil {
+		return nil, errNotPaused
+	}
+	base, err := strconv.ParseInt(args.MemoryReference, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad memory reference %s", args.MemoryReference)
+	}
+	mem := paused.session.execCtx.Memory()
+	start := base + args.Offset
+	if start < 0 || args.Count < 0 || start > int64(len(mem)) {
+		return nil, fmt.Errorf("memory address %d out of range", start)
+	}
+	end := start + args.Count
+	if end > int64(len(mem)) {
+		end = int64(len(mem))
+	}
+	return map[string]interface{}{
+		"address":         fmt.Sprintf("0x%x", start),
+		"data":            base64.StdEncoding.EncodeToString(mem[start:end]),
+		"unreadableBytes": args.Count - (end - start),
+	}, nil
+}
+
+// resume 恢复暂停的合约，stepping为true时在下一次系统调用或函数入口处再次暂停
+func (d *xvmDebugger) resume(stepping bool) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.paused == nil {
+		return false
+	}
+	d.stepping = stepping
+	d.resumeLocked()
+	return true
+}
+
+func (d *xvmDebugger) resumeLocked() {
+	if d.paused == nil {
+		return
+	}
+	close(d.paused.resume)
+	d.paused = nil
+}
+
+// stopReason 判断是否需要暂停，返回暂停原因，空表示不需要暂停
+func (d *xvmDebugger) stopReason(names ...string) string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.conn == nil {
+		return ""
+	}
+	if d.stepping {
+		return "step"
+	}
+	for _, name := range names {
+		if d.breakpoints[name] {
+			return "function breakpoint"
+		}
+	}
+	return ""
+}
+
+// stop 暂停合约的执行，直到客户端继续执行或者断开连接
+func (d *xvmDebugger) stop(session *debugSession, reason, description string) {
+	d.pauseLock.Lock()
+	defer d.pauseLock.Unlock()
+
+	d.mutex.Lock()
+	conn := d.conn
+	if conn == nil {
+		d.mutex.Unlock()
+		return
+	}
+	paused := &pausedSession{
+		session:     session,
+		description: description,
+		resume:      make(chan struct{}),
+	}
+	d.paused = paused
+	d.stepping = false
+	d.mutex.Unlock()
+
+	err := conn.sendEvent("stopped", map[string]interface{}{
+		"reason":            reason,
+		"description":       description,
+		"text":              session.name(),
+		"threadId":          debugThreadID,
+		"allThreadsStopped": true,
+	})
+	if err != nil {
+		d.resume(false)
+	}
+	<-paused.resume
+}
+
+// enterFunction 合约的导出函数被调用，断点名可以是"合约名.方法名"、方法名或者导出函数名
+func (s *debugSession) enterFunction(function string) {
+	reason := s.debugger.stopReason(s.name(), s.bridgeCtx.Method, function)
+	if reason == "" {
+		return
+	}
+	s.debugger.stop(s, reason, "enter "+function)
+}
+
+// hostCall 合约发起系统调用，断点名为系统调用的方法名，如PutObject
+func (s *debugSession) hostCall(method string, requestBuf []byte) {
+	reason := s.debugger.stopReason(method)
+	if reason == "" {
+		return
+	}
+	description := method
+	if request, err := s.debugger.rpcserver.DecodeRequest(method, requestBuf); err == nil {
+		description += " " + proto.CompactTextString(request)
+	}
+	s.debugger.stop(s, reason, description)
+}
+
+// debugHostCall 在系统调用执行前通知调试器
+func debugHostCall(ctx exec.Context, method string, requestBuf []byte) {
+	if session, ok := ctx.GetUserData(debugSessionKey).(*debugSession); ok {
+		session.hostCall(method, requestBuf)
+	}
+}
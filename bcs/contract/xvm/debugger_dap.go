@@ -0,0 +1,121 @@
+package xvm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 调试器使用Debug Adapter Protocol(DAP)的消息格式，每条消息由Content-Length头和json组成，
+// 只实现了调试合约需要的部分请求
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type dapResponse struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+type functionBreakpointsArgs struct {
+	Breakpoints []struct {
+		Name string `json:"name"`
+	} `json:"breakpoints"`
+}
+
+type readMemoryArgs struct {
+	MemoryReference string `json:"memoryReference"`
+	Offset          int64  `json:"offset"`
+	Count           int64  `json:"count"`
+}
+
+// readDAPMessage 读取一条DAP消息的内容
+func readDAPMessage(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length <= 0 {
+		return nil, fmt.Errorf("bad Content-Length:%s", header.Get("Content-Length"))
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func writeDAPMessage(w io.Writer, msg interface{}) error {
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(buf), buf)
+	return err
+}
+
+// debugConn 调试客户端的连接，合约执行的goroutine和连接处理的goroutine都会写入消息
+type debugConn struct {
+	conn  net.Conn
+	mutex sync.Mutex
+	seq   int
+}
+
+func (c *debugConn) send(msg interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.seq++
+	switch m := msg.(type) {
+	case *dapResponse:
+		m.Seq = c.seq
+	case *dapEvent:
+		m.Seq = c.seq
+	}
+	return writeDAPMessage(c.conn, msg)
+}
+
+func (c *debugConn) sendEvent(event string, body interface{}) error {
+	return c.send(&dapEvent{
+		Type:  "event",
+		Event: event,
+		Body:  body,
+	})
+}
+
+func (c *debugConn) sendResponse(req *dapRequest, body interface{}, err error) error {
+	resp := &dapResponse{
+		Type:       "response",
+		RequestSeq: req.Seq,
+		Success:    err == nil,
+		Command:    req.Command,
+		Body:       body,
+	}
+	if err != nil {
+		resp.Message = err.Error()
+		resp.Body = nil
+	}
+	return c.send(resp)
+}
@@ -0,0 +1,138 @@
+package xvm
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
+)
+
+type debugFakeContext struct {
+	*fakeContext
+	memory []byte
+}
+
+func (d *debugFakeContext) Memory() []byte {
+	return d.memory
+}
+
+type debugClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+	seq  int
+}
+
+func (c *debugClient) request(command string, args interface{}) {
+	c.seq++
+	msg := map[string]interface{}{
+		"seq":       c.seq,
+		"type":      "request",
+		"command":   command,
+		"arguments": args,
+	}
+	if err := writeDAPMessage(c.conn, msg); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// wait 读取消息直到出现指定的响应或事件
+func (c *debugClient) wait(tp, name string) map[string]interface{} {
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		buf, err := readDAPMessage(c.r)
+		if err != nil {
+			c.t.Fatalf("wait %s %s error:%s", tp, name, err)
+		}
+		var msg map[string]interface{}
+		if err := json.Unmarshal(buf, &msg); err != nil {
+			c.t.Fatal(err)
+		}
+		if msg["type"] == tp && (msg["command"] == name || msg["event"] == name) {
+			return msg
+		}
+	}
+}
+
+func TestDebuggerBreakpointAndStep(t *testing.T) {
+	d, err := newXVMDebugger("127.0.0.1:0", bridge.NewSyscallService(nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.listener.Close()
+
+	conn, err := net.Dial("tcp", d.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := &debugClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+	client.request("initialize", nil)
+	client.wait("event", "initialized")
+	client.request("setFunctionBreakpoints", map[string]interface{}{
+		"breakpoints": []map[string]string{{"name": "counter.increase"}},
+	})
+	client.wait("response", "setFunctionBreakpoints")
+
+	execCtx := &debugFakeContext{
+		fakeContext: newFakeContext(),
+		memory:      []byte("hello world"),
+	}
+	session := &debugSession{
+		debugger:  d,
+		execCtx:   execCtx,
+		bridgeCtx: &bridge.Context{ContractName: "counter", Method: "increase"},
+	}
+	execCtx.SetUserData(debugSessionKey, session)
+	request, _ := proto.Marshal(&pb.PutRequest{Key: []byte("count"), Value: []byte("1")})
+
+	done := make(chan struct{})
+	go func() {
+		session.enterFunction("_increase")
+		debugHostCall(execCtx, "PutObject", request)
+		close(done)
+	}()
+
+	stopped := client.wait("event", "stopped")
+	if stopped["body"].(map[string]interface{})["reason"] != "function breakpoint" {
+		t.Fatalf("unexpected stop:%v", stopped)
+	}
+
+	client.request("readMemory", map[string]interface{}{"memoryReference": "0x6", "count": 5})
+	resp := client.wait("response", "readMemory")
+	data, _ := base64.StdEncoding.DecodeString(resp["body"].(map[string]interface{})["data"].(string))
+	if string(data) != "world" {
+		t.Fatalf("want world, got %s", data)
+	}
+
+	// 单步执行到下一次系统调用
+	client.request("next", nil)
+	stopped = client.wait("event", "stopped")
+	body := stopped["body"].(map[string]interface{})
+	if body["reason"] != "step" || !strings.Contains(body["description"].(string), "PutObject") ||
+		!strings.Contains(body["description"].(string), "count") {
+		t.Fatalf("unexpected step:%v", stopped)
+	}
+
+	client.request("continue", nil)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("contract not resumed")
+	}
+
+	// 没有命中断点的合约不会暂停
+	other := &debugSession{
+		debugger:  d,
+		execCtx:   execCtx,
+		bridgeCtx: &bridge.Context{ContractName: "counter", Method: "get"},
+	}
+	other.enterFunction("_get")
+}
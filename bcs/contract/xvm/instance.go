@@ -33,6 +33,7 @@ func createInstance(ctx *bridge.Context, code *contractCode, syscall *bridge.Sys
 		}
 	}
 	execCtx.SetUserData(contextIDKey, ctx.ID)
+	initHostGas(execCtx, ctx.ResourceLimits.Cpu)
 	instance := &xvmInstance{
 		bridgeCtx: ctx,
 		execCtx:   execCtx,
@@ -47,6 +48,8 @@ type xvmInstance struct {
 	execCtx   exec.Context
 	desc      protos.WasmCodeDesc
 	syscall   *bridge.SyscallService
+	// 调试模式下不为空
+	debug *debugSession
 }
 
 func (x *xvmInstance) Exec() error {
@@ -63,6 +66,9 @@ func (x *xvmInstance) Exec() error {
 	if err != nil {
 		return err
 	}
+	if x.debug != nil {
+		x.debug.enterFunction(function)
+	}
 	_, err = x.execCtx.Exec(function, args)
 	if err != nil {
 		// log.Error("exec contract error", "error", err, "contract", x.bridgeCtx.ContractName)
@@ -72,7 +78,7 @@ func (x *xvmInstance) Exec() error {
 
 func (x *xvmInstance) ResourceUsed() contract.Limits {
 	limits := contract.Limits{
-		Cpu: x.execCtx.GasUsed(),
+		Cpu: x.execCtx.GasUsed() + hostGasUsed(x.execCtx),
 	}
 	mem := x.execCtx.Memory()
 	if mem != nil {
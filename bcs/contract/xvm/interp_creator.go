@@ -3,6 +3,7 @@ package xvm
 import (
 	"io/ioutil"
 
+	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 	"github.com/xuperchain/xvm/exec"
 	"github.com/xuperchain/xvm/runtime/emscripten"
@@ -12,18 +13,37 @@ import (
 type xvmInterpCreator struct {
 	cm     *codeManager
 	config bridge.InstanceCreatorConfig
+	// 开启调试模式时不为空
+	debugger *xvmDebugger
+	// 可以被调试器暂停的合约
+	debugContracts map[string]bool
 }
 
 func newXVMInterpCreator(creatorConfig *bridge.InstanceCreatorConfig) (bridge.InstanceCreator, error) {
 	creator := &xvmInterpCreator{
 		config: *creatorConfig,
 	}
+	vmconfig, _ := creatorConfig.VMConfig.(*contract.WasmConfig)
+	var cacheSize int64
+	if vmconfig != nil {
+		cacheSize = vmconfig.XVM.CacheSizeMB << 20
+	}
 	var err error
-	creator.cm, err = newCodeManager(creator.config.Basedir,
+	creator.cm, err = newCodeManager(creator.config.Basedir, cacheSize,
 		creator.compileCode, creator.makeExecCode)
 	if err != nil {
 		return nil, err
 	}
+	if vmconfig != nil && vmconfig.XVM.Debug.Enable {
+		creator.debugger, err = newXVMDebugger(vmconfig.XVM.Debug.Listen, creator.config.SyscallService)
+		if err != nil {
+			return nil, err
+		}
+		creator.debugContracts = make(map[string]bool)
+		for _, name := range vmconfig.XVM.Debug.Contracts {
+			creator.debugContracts[name] = true
+		}
+	}
 	return creator, nil
 }
 
@@ -50,13 +70,27 @@ func (x *xvmInterpCreator) CreateInstance(ctx *bridge.Context, cp bridge.Contrac
 	if err != nil {
 		return nil, err
 	}
-	return createInstance(ctx, code, x.config.SyscallService)
+	instance, err := createInstance(ctx, code, x.config.SyscallService)
+	if err != nil {
+		return nil, err
+	}
+	// 只有开启了调试的预执行请求中，白名单内的合约可以被调试器暂停
+	if x.debugger != nil && ctx.Debug && x.debugContracts[ctx.ContractName] {
+		x.debugger.attach(instance.(*xvmInstance))
+	}
+	return instance, nil
 }
 
 func (x *xvmInterpCreator) RemoveCache(contractName string) {
 	x.cm.RemoveCode(contractName)
 }
 
+// WarmUpCache 解析并加载合约代码，后续调用不再需要解析
+func (x *xvmInterpCreator) WarmUpCache(contractName string, cp bridge.ContractCodeProvider) error {
+	_, err := x.cm.GetExecCode(contractName, cp)
+	return err
+}
+
 func init() {
 	bridge.Register(bridge.TypeWasm, "ixvm", newXVMInterpCreator)
 }
@@ -67,6 +67,7 @@ func (s *syscallResolver) goCallMethod(ctx exec.Context, sp uint32) uint32 {
 	ctxid := ctx.GetUserData(contextIDKey).(int64)
 	method := codec.GoString(sp + 8)
 	requestBuf := codec.GoBytes(sp + 24)
+	debugHostCall(ctx, method, requestBuf)
 	responseBuf, err := s.rpcserver.CallMethod(context.TODO(), ctxid, method, requestBuf)
 	var responseDesc responseDesc
 	if err != nil {
@@ -106,6 +107,7 @@ func (s *syscallResolver) cCallMethod(ctx exec.Context, methodAddr, methodLen, r
 	ctxid := ctx.GetUserData(contextIDKey).(int64)
 	method := codec.String(methodAddr, methodLen)
 	requestBuf := codec.Bytes(requestAddr, requestLen)
+	debugHostCall(ctx, method, requestBuf)
 	responseBuf, err := s.rpcserver.CallMethod(context.TODO(), ctxid, method, requestBuf)
 	var responseDesc responseDesc
 	if err != nil {
@@ -152,6 +154,7 @@ func (s *syscallResolver) cCallMethodv2(
 	requestBuf := codec.Bytes(requestAddr, requestLen)
 	responseBuf := codec.Bytes(responseAddr, responseLen)
 
+	debugHostCall(ctx, method, requestBuf)
 	response, err := s.rpcserver.CallMethod(context.TODO(), ctxid, method, requestBuf)
 
 	// fast path
@@ -17,6 +17,23 @@ type XLedgerConf struct {
 	BlockCacheSize int        `yaml:"blockCacheSize,omitempty"`
 	TxCacheSize    int        `yaml:"txCacheSize,omitempty"`
 	MempoolTxLimit int        `yaml:"mempoolTxLimit,omitempty"`
+	// 存储引擎的调优参数，由具体的引擎解析
+	KVOptions map[string]interface{} `yaml:"kvOptions,omitempty"`
+	// 是否开启合约事件索引，开启后只索引之后执行的区块
+	EventIndex bool `yaml:"eventIndex,omitempty"`
+	// 账本裁剪配置，默认为归档模式，保存全部区块
+	Prune PruneConfig `yaml:"prune,omitempty"`
+}
+
+// PruneConfig 账本裁剪配置，开启后只保留最近的区块和当前状态需要的交易
+type PruneConfig struct {
+	Enable bool `yaml:"enable,omitempty"`
+	// 保留最近的区块数，不可逆窗口内的区块始终保留
+	KeepBlocks int64 `yaml:"keepBlocks,omitempty"`
+	// 每产生多少个区块触发一次裁剪
+	Interval int64 `yaml:"interval,omitempty"`
+	// 裁剪后是否对交易表触发在线compaction，回收已删除交易占用的空间
+	Compact bool `yaml:"compact,omitempty"`
 }
 
 type UtxoConfig struct {
@@ -43,6 +60,11 @@ func GetDefLedgerConf() *XLedgerConf {
 			CacheSize:      100000,
 			TmpLockSeconds: 60,
 		},
+		Prune: PruneConfig{
+			Enable:     false,
+			KeepBlocks: 100000,
+			Interval:   1000,
+		},
 	}
 }
 
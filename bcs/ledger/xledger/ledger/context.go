@@ -1,7 +1,11 @@
 package ledger
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	lconf "github.com/xuperchain/xupercore/bcs/ledger/xledger/config"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/def"
@@ -45,6 +49,34 @@ func NewLedgerCtx(envCfg *xconf.EnvConf, bcName string) (*LedgerCtx, error) {
 	ctx.EnvCfg = envCfg
 	ctx.LedgerCfg = lcfg
 	ctx.BCName = bcName
+	if err := ApplyGenesisKVEngine(envCfg, bcName, lcfg); err != nil {
+		return nil, fmt.Errorf("create ledger context failed because load genesis kvengine error.err:%v", err)
+	}
 
 	return ctx, nil
 }
+
+// ApplyGenesisKVEngine 创世块配置中指定了kvengine时覆盖账本配置的存储引擎，
+// 创世块配置文件在创建链时写入链的数据目录，不存在时使用账本配置
+func ApplyGenesisKVEngine(envCfg *xconf.EnvConf, bcName string, lcfg *lconf.XLedgerConf) error {
+	rootfile := filepath.Join(envCfg.GenDataAbsPath(envCfg.ChainDir), bcName, fmt.Sprintf("%s.json", bcName))
+	data, err := ioutil.ReadFile(rootfile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return applyKVEngine(data, lcfg)
+}
+
+func applyKVEngine(genesisCfg []byte, lcfg *lconf.XLedgerConf) error {
+	rootCfg := &RootConfig{}
+	if err := json.Unmarshal(genesisCfg, rootCfg); err != nil {
+		return err
+	}
+	if engine := rootCfg.GetKVEngine(); engine != "" {
+		lcfg.KVEngineType = engine
+	}
+	return nil
+}
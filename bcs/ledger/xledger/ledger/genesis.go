@@ -55,6 +55,47 @@ type RootConfig struct {
 	IrreversibleSlideWindow string `json:"irreversibleslidewindow"`
 	// GroupChainContract
 	GroupChainContract InvokeRequest `json:"group_chain_contract"`
+	// StorageRent 合约存储的配额和租金，租金计算方式同gas_price.disk_rate
+	StorageRent StorageRent `json:"storage_rent"`
+	// ContractVersion 合约版本记录和升级迁移的启用高度
+	ContractVersion ForkHeight `json:"contract_version"`
+	// EvmEventJSON evm合约事件使用结构化JSON编码的启用高度，之前的区块使用原有编码
+	EvmEventJSON ForkHeight `json:"evm_event_json"`
+	// NativeGas native合约按系统调用计量资源消耗的启用高度，之前的区块每次调用固定消耗1个xfee
+	NativeGas ForkHeight `json:"native_gas"`
+	// ContractFreeze 调用合约的交易必须在读集中记录合约冻结状态的启用高度
+	ContractFreeze ForkHeight `json:"contract_freeze"`
+	// EvmAccount evm合约读取账户余额、按ACL映射tx.origin和msg.sender的启用高度，之前的区块余额为空，msg.sender为发起者
+	EvmAccount ForkHeight `json:"evm_account"`
+	// EvmBlockContext evm合约读取区块高度、时间和哈希以及调用超级链预编译合约的启用高度，之前的区块读取结果为空
+	EvmBlockContext ForkHeight `json:"evm_block_context"`
+	// StateRoot 区块带有状态树根的启用高度，之前的区块不带状态树根，区块ID保持不变
+	StateRoot ForkHeight `json:"state_root"`
+}
+
+// ForkHeight define the activation height of a new feature
+type ForkHeight struct {
+	Enable bool `json:"enable"`
+	// 功能从该高度的区块开始生效
+	Height int64 `json:"height"`
+}
+
+// Active 返回功能在区块高度height是否生效
+func (f ForkHeight) Active(height int64) bool {
+	return f.Enable && height >= f.Height
+}
+
+// StorageRent define contract storage quota and rent
+type StorageRent struct {
+	Enable bool `json:"enable"`
+	// 每个单位租金对应的存储字节数，0表示不收取租金
+	RentRate int64 `json:"rent_rate"`
+	// 每个合约免租金的存储字节数，按key的哈希平均分配到合约的16个存储分片
+	FreeBytes int64 `json:"free_bytes"`
+	// 每个合约最多占用的存储字节数，0表示不限制，同样平均分配到各个存储分片
+	MaxBytes int64 `json:"max_bytes"`
+	// 合约单个key的value最大字节数，0表示不限制
+	MaxValueSize int64 `json:"max_value_size"`
 }
 
 // GasPrice define gas rate for utxo
@@ -95,6 +136,14 @@ func InvokeRequestFromJSON2Pb(jsonRequest []InvokeRequest) ([]*protos.InvokeRequ
 	return requestsWithPb, nil
 }
 
+// GetKVEngine 返回创世块指定的kv存储引擎，未指定时返回空，使用账本配置中的引擎
+func (rc *RootConfig) GetKVEngine() string {
+	if rc.Kvengine == "default" {
+		return ""
+	}
+	return rc.Kvengine
+}
+
 func (rc *RootConfig) GetCryptoType() string {
 	if rc.Crypto != "" {
 		return rc.Crypto
@@ -153,6 +202,19 @@ func (rc *RootConfig) GetReservedWhitelistAccount() string {
 	return rc.ReservedWhitelist.Account
 }
 
+// GetExecForkHeights 返回会改变合约执行结果的功能的启用高度，
+// 跨过这些高度前预执行的交易需要重新校验
+func (rc *RootConfig) GetExecForkHeights() []int64 {
+	var heights []int64
+	for _, fork := range []ForkHeight{rc.ContractVersion, rc.EvmEventJSON, rc.NativeGas, rc.EvmAccount,
+		rc.EvmBlockContext} {
+		if fork.Enable {
+			heights = append(heights, fork.Height)
+		}
+	}
+	return heights
+}
+
 // GetPredistribution return predistribution
 func (rc *RootConfig) GetPredistribution() []Predistribution {
 	return PredistributionTranslator(rc.Predistribution)
@@ -204,6 +266,8 @@ func NewGenesisBlock(genesisCfg []byte) (*GenesisBlock, error) {
 		config.GasPrice.DiskRate = 0
 		config.GasPrice.MemRate = 0
 		config.GasPrice.XfeeRate = 0
+		// nofee场景下只限制合约存储，不收取租金
+		config.StorageRent.RentRate = 0
 	}
 
 	gb := &GenesisBlock{
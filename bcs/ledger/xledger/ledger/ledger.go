@@ -37,6 +37,8 @@ var (
 	ErrRootBlockAlreadyExist = errors.New("this ledger already has genesis block")
 	// ErrTxNotConfirmed return tx not confirmed error
 	ErrTxNotConfirmed = errors.New("transaction not confirmed")
+	// ErrBlockPruned is returned when transactions of block have been pruned
+	ErrBlockPruned = errors.New("block has been pruned")
 	// NumCPU returns the number of CPU cores for the current system
 	NumCPU = runtime.NumCPU()
 )
@@ -88,6 +90,14 @@ type Ledger struct {
 	txCache        *cache.LRUCache // tx cache
 	cryptoClient   cryptoBase.CryptoClient
 	confirmBatch   kvdb.Batch //新增区块
+	prunedHeight   int64      //已裁剪的区块高度，原子读写
+	stateCommitter StateCommitter
+}
+
+// StateCommitter 计算区块的状态树根，由状态机注册
+type StateCommitter interface {
+	// StateRoot 返回在父区块的状态上执行block后的状态树根，状态机当前不在父区块时返回nil
+	StateRoot(block *pb.InternalBlock) ([]byte, error)
 }
 
 // ConfirmStatus block status
@@ -113,6 +123,14 @@ func newLedger(lctx *LedgerCtx, createIfMissing bool, genesisCfg []byte) (*Ledge
 	ledger := &Ledger{}
 	ledger.mutex = &sync.RWMutex{}
 
+	// 创建账本时以创世块配置中的kvengine为准
+	if genesisCfg != nil {
+		if err := applyKVEngine(genesisCfg, lctx.LedgerCfg); err != nil {
+			lctx.XLog.Warn("fail to parse genesis kvengine", "err", err)
+			return nil, err
+		}
+	}
+
 	// new kvdb instance
 	storePath := lctx.EnvCfg.GenDataAbsPath(lctx.EnvCfg.ChainDir)
 	storePath = filepath.Join(storePath, lctx.BCName)
@@ -124,6 +142,7 @@ func newLedger(lctx *LedgerCtx, createIfMissing bool, genesisCfg []byte) (*Ledge
 		FileHandlersCacheSize: FileHandlersCacheSize,
 		OtherPaths:            lctx.LedgerCfg.OtherPaths,
 		StorageType:           lctx.LedgerCfg.StorageType,
+		Options:               lctx.LedgerCfg.KVOptions,
 	}
 	baseDB, err := kvdb.CreateKVInstance(kvParam)
 	if err != nil {
@@ -179,6 +198,10 @@ func newLedger(lctx *LedgerCtx, createIfMissing bool, genesisCfg []byte) (*Ledge
 			return nil, pbErr
 		}
 	}
+	if err := ledger.loadPrunedHeight(); err != nil {
+		lctx.XLog.Warn("failed to load pruned height", "err", err)
+		return nil, err
+	}
 	lctx.XLog.Info("ledger meta", "genesis_block", utils.F(ledger.meta.RootBlockid), "tip_block",
 		utils.F(ledger.meta.TipBlockid), "trunk_height", ledger.meta.TrunkHeight)
 
@@ -253,6 +276,11 @@ func (l *Ledger) loadGenesisBlock(isEmptyLedger bool, genesisCfg []byte) error {
 // FormatRootBlock format genesis block
 func (l *Ledger) FormatRootBlock(txList []*pb.Transaction) (*pb.InternalBlock, error) {
 	l.xlog.Info("begin format genesis block")
+	return MakeRootBlock(txList)
+}
+
+// MakeRootBlock 生成创世块，相同的创世交易生成的创世块相同
+func MakeRootBlock(txList []*pb.Transaction) (*pb.InternalBlock, error) {
 	block := &pb.InternalBlock{Version: RootBlockVersion}
 	block.Transactions = txList
 	block.TxCount = int32(len(txList))
@@ -337,6 +365,12 @@ func (l *Ledger) formatBlock(txList []*pb.Transaction,
 		block.MerkleRoot = block.MerkleTree[len(block.MerkleTree)-1]
 	}
 	var err error
+	if needSign && l.stateCommitter != nil && l.stateRootActive(block.Height) {
+		block.StateRoot, err = l.stateCommitter.StateRoot(block)
+		if err != nil {
+			return nil, err
+		}
+	}
 	block.Blockid, err = MakeBlockID(block)
 	if err != nil {
 		return nil, err
@@ -840,6 +874,9 @@ func (l *Ledger) queryBlock(blockid []byte, needBody bool) (*pb.InternalBlock, e
 		for _, txid := range block.MerkleTree[:block.TxCount] {
 			pbTxBuf, kvErr := l.confirmedTable.Get(txid)
 			if kvErr != nil {
+				if def.NormalizedKVError(kvErr) == def.ErrKVNotFound && l.isPruned(block) {
+					return block, ErrBlockPruned
+				}
 				l.xlog.Warn("tx not found", "kvErr", kvErr, "txid", utils.F(txid))
 				return block, kvErr
 			}
@@ -1193,6 +1230,11 @@ func (l *Ledger) Truncate(utxovmLastID []byte) error {
 		l.xlog.Warn("failed to find utxovm last block", "err", err, "blockid", utils.F(utxovmLastID))
 		return err
 	}
+	// 已裁剪的区块无法回放，不能裁剪到已裁剪高度以下
+	if block.Height < l.GetPrunedHeight() {
+		l.xlog.Warn("failed to truncate to pruned block", "height", block.Height, "prunedHeight", l.GetPrunedHeight())
+		return ErrBlockPruned
+	}
 	// 查询分支信息
 	branchTips, err := l.GetBranchInfo(block.Blockid, block.Height)
 	if err != nil {
@@ -1271,7 +1313,52 @@ func (l *Ledger) VerifyBlock(block *pb.InternalBlock, logid string) (bool, error
 		l.xlog.Warn("VerifyBlock VerifyECDSA error", "logid", logid, "error", err)
 		return false, nil
 	}
-	return true, nil
+	return l.verifyStateRoot(block, logid), nil
+}
+
+// SetStateCommitter 注册状态树根的计算方，注册后启用高度之后打包的区块带有状态树根，校验区块时一并校验
+func (l *Ledger) SetStateCommitter(committer StateCommitter) {
+	l.stateCommitter = committer
+}
+
+// StateRootActive 返回高度为height的区块是否必须带有状态树根
+func (l *Ledger) StateRootActive(height int64) bool {
+	return l.stateRootActive(height)
+}
+
+func (l *Ledger) stateRootActive(height int64) bool {
+	return l.GenesisBlock != nil && l.GenesisBlock.GetConfig().StateRoot.Active(height)
+}
+
+// verifyStateRoot 启用高度之后的区块必须带有状态树根，之前的区块不能带有状态树根；
+// 状态机处于父区块时重新计算并比较，否则留到执行区块时校验
+func (l *Ledger) verifyStateRoot(block *pb.InternalBlock, logid string) bool {
+	if !l.stateRootActive(block.Height) {
+		if len(block.StateRoot) > 0 {
+			l.xlog.Warn("VerifyBlock unexpected state root before fork height", "logid", logid,
+				"blockid", utils.F(block.Blockid), "height", block.Height)
+			return false
+		}
+		return true
+	}
+	if len(block.StateRoot) == 0 {
+		l.xlog.Warn("VerifyBlock state root missing", "logid", logid, "blockid", utils.F(block.Blockid))
+		return false
+	}
+	if l.stateCommitter == nil {
+		return true
+	}
+	stateRoot, err := l.stateCommitter.StateRoot(block)
+	if err != nil {
+		l.xlog.Warn("VerifyBlock calculate state root error", "logid", logid, "error", err)
+		return false
+	}
+	if stateRoot != nil && !bytes.Equal(stateRoot, block.StateRoot) {
+		l.xlog.Warn("VerifyBlock state root mismatch", "logid", logid, "expect", utils.F(stateRoot),
+			"get", utils.F(block.StateRoot))
+		return false
+	}
+	return true
 }
 
 // QueryBlockByTxid query block by txid after it has confirmed
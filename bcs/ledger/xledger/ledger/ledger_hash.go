@@ -216,6 +216,42 @@ func VerifyMerkle(block *pb.InternalBlock) error {
 	}
 }
 
+// MakeMerklePath 生成第index个交易到merkle根的路径，路径为从叶子到根每一层的兄弟节点，
+// 缺少右兄弟时与自身配对
+func MakeMerklePath(tree [][]byte, index int) ([][]byte, error) {
+	leafSize := (len(tree) + 1) / 2
+	if index < 0 || index >= leafSize || tree[index] == nil {
+		return nil, fmt.Errorf("bad merkle tree index %d", index)
+	}
+	path := make([][]byte, 0)
+	for offset, size := 0, leafSize; size > 1; offset, size = offset+size, size/2 {
+		sibling := tree[offset+(index^1)]
+		if sibling == nil {
+			sibling = tree[offset+index]
+		}
+		path = append(path, sibling)
+		index /= 2
+	}
+	return path, nil
+}
+
+// VerifyMerklePath 校验txid是区块中第index个交易
+func VerifyMerklePath(txid []byte, index int, path [][]byte, merkleRoot []byte) bool {
+	if index < 0 || index>>uint(len(path)) != 0 {
+		return false
+	}
+	node := txid
+	for _, sibling := range path {
+		if index%2 == 0 {
+			node = merkleDoubleSha256(node, sibling, nil)
+		} else {
+			node = merkleDoubleSha256(sibling, node, nil)
+		}
+		index /= 2
+	}
+	return bytes.Equal(node, merkleRoot)
+}
+
 // MakeBlockID generate BlockID
 func MakeBlockID(block *pb.InternalBlock) ([]byte, error) {
 	buf := new(bytes.Buffer)
@@ -277,5 +313,12 @@ func MakeBlockID(block *pb.InternalBlock) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("encodeJustify failed, err=%v", err)
 	}
+	// 状态树根启用高度之前的区块没有状态树根，区块ID与原有算法一致
+	if len(block.StateRoot) > 0 {
+		err = binary.Write(buf, binary.LittleEndian, block.StateRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return hash.DoubleSha256(buf.Bytes()), nil
 }
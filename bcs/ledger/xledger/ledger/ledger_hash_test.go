@@ -35,3 +35,33 @@ func BenchmarkNormalMerkle(b *testing.B) {
 		MakeMerkleTree(txs)
 	}
 }
+
+func TestMerklePath(t *testing.T) {
+	for _, txCount := range []int{1, 2, 3, 5, 8} {
+		var txs []*pb.Transaction
+		for i := 0; i < txCount; i++ {
+			buf := make([]byte, 32)
+			rand.Read(buf)
+			txs = append(txs, &pb.Transaction{
+				Txid: buf,
+			})
+		}
+		tree := MakeMerkleTree(txs)
+		root := tree[len(tree)-1]
+		for i, tx := range txs {
+			path, err := MakeMerklePath(tree, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !VerifyMerklePath(tx.Txid, i, path, root) {
+				t.Fatalf("verify merkle path failed, tx_count:%d, index:%d", txCount, i)
+			}
+			if i+1 < txCount && VerifyMerklePath(tx.Txid, i+1, path, root) {
+				t.Fatalf("verify merkle path with wrong index should fail, tx_count:%d, index:%d", txCount, i)
+			}
+		}
+		if _, err := MakeMerklePath(tree, txCount); err == nil {
+			t.Fatalf("expect error for index out of range, tx_count:%d", txCount)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	//"io/ioutil"
 	"math/big"
@@ -19,7 +20,9 @@ import (
 	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
 	"github.com/xuperchain/xupercore/kernel/mock"
 	"github.com/xuperchain/xupercore/lib/logs"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
 	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	pebbledb "github.com/xuperchain/xupercore/lib/storage/kvdb/pebble"
 	"github.com/xuperchain/xupercore/protos"
 )
 
@@ -494,3 +497,108 @@ func TestTruncate(t *testing.T) {
 
 	ledger.Close()
 }
+
+func TestGenesisKVEngine(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(workspace)
+	econf, err := mock.NewEnvConfForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs.InitLog(econf.GenConfFilePath(econf.LogConf), econf.GenDirAbsPath(econf.LogDir))
+	econf.ChainDir = workspace
+	chainDir := filepath.Join(econf.GenDataAbsPath(econf.ChainDir), "xuper")
+	defer os.RemoveAll(econf.GenDataAbsPath(econf.ChainDir))
+
+	genesisConf := []byte(`{
+    "version": "1",
+    "kvengine": "pebble",
+    "predistribution": [],
+    "maxblocksize": "16",
+    "award": "1000000",
+    "decimals": "8",
+    "genesis_consensus": {"name": "single", "config": {"miner": "TeyyPLpp9L7QAcxHangtcHTu7HUZ6iydY", "period": 3000}}
+}`)
+	lctx, err := NewLedgerCtx(econf, "xuper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger, err := CreateLedger(lctx, genesisConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ledger.baseDB.(*pebbledb.PebbleDatabase); !ok {
+		t.Fatalf("expect pebble engine, got %T", ledger.baseDB)
+	}
+	if err := ledger.CompactPruned(); err != nil {
+		t.Fatal(err)
+	}
+	ledger.Close()
+
+	// 打开已有账本时从链目录下的创世块配置读取
+	if err := ioutil.WriteFile(filepath.Join(chainDir, "xuper.json"), genesisConf, 0666); err != nil {
+		t.Fatal(err)
+	}
+	lctx, err = NewLedgerCtx(econf, "xuper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lctx.LedgerCfg.KVEngineType != kvdb.KVEngineTypePebble {
+		t.Fatalf("expect genesis kvengine, got %s", lctx.LedgerCfg.KVEngineType)
+	}
+}
+
+func TestGenesisExecForkHeights(t *testing.T) {
+	rc := &RootConfig{}
+	if heights := rc.GetExecForkHeights(); len(heights) != 0 {
+		t.Fatalf("expect no fork heights, got %v", heights)
+	}
+	rc.ContractVersion = ForkHeight{Enable: true, Height: 10}
+	rc.EvmEventJSON = ForkHeight{Enable: false, Height: 20}
+	rc.NativeGas = ForkHeight{Enable: true, Height: 30}
+	rc.EvmAccount = ForkHeight{Enable: true, Height: 40}
+	rc.EvmBlockContext = ForkHeight{Enable: true, Height: 50}
+	heights := rc.GetExecForkHeights()
+	if len(heights) != 4 || heights[0] != 10 || heights[1] != 30 || heights[2] != 40 || heights[3] != 50 {
+		t.Fatalf("unexpected fork heights %v", heights)
+	}
+}
+
+type fakeStateCommitter struct {
+	root []byte
+}
+
+func (f *fakeStateCommitter) StateRoot(block *pb.InternalBlock) ([]byte, error) {
+	return f.root, nil
+}
+
+func TestVerifyStateRootFork(t *testing.T) {
+	ledger, err := openLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ledger.Close()
+	ledger.GenesisBlock.GetConfig().StateRoot = ForkHeight{Enable: true, Height: 10}
+	ledger.SetStateCommitter(&fakeStateCommitter{root: []byte("root")})
+
+	testCases := []struct {
+		height int64
+		root   []byte
+		valid  bool
+	}{
+		{9, nil, true},
+		{9, []byte("root"), false},
+		{10, nil, false},
+		{10, []byte("root"), true},
+		{10, []byte("other"), false},
+	}
+	for _, tc := range testCases {
+		block := &pb.InternalBlock{Height: tc.height, StateRoot: tc.root}
+		if valid := ledger.verifyStateRoot(block, "test"); valid != tc.valid {
+			t.Errorf("height %d root %s: expect valid %v", tc.height, tc.root, tc.valid)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/def"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	"github.com/xuperchain/xupercore/lib/utils"
+)
+
+// 账本裁剪：删除主干上已裁剪高度以下区块的交易，区块头全部保留，用于校验区块链和查询区块高度。
+// 当前状态仍然引用的交易(即某个key在裁剪高度时的版本)会被保留，并记录在保留表中，
+// 之后每次裁剪重新判断，不再被引用时删除。创世块的交易始终保留。
+
+const (
+	// PrunedHeightKey 已裁剪的区块高度，该高度及以下的区块(创世块除外)的交易已经被删除
+	PrunedHeightKey = "PrunedHeight"
+	// 每次写盘裁剪的区块数
+	pruneBatchBlocks = 100
+)
+
+// TxRetainer 判断裁剪区块时交易是否需要保留
+type TxRetainer func(tx *pb.Transaction) (bool, error)
+
+func (l *Ledger) loadPrunedHeight() error {
+	buf, err := l.metaTable.Get([]byte(PrunedHeightKey))
+	if err != nil {
+		if def.NormalizedKVError(err) == def.ErrKVNotFound {
+			return nil
+		}
+		return err
+	}
+	height, err := strconv.ParseInt(string(buf), 10, 64)
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(&l.prunedHeight, height)
+	return nil
+}
+
+// GetPrunedHeight 返回已裁剪的区块高度，没有裁剪过时返回0
+func (l *Ledger) GetPrunedHeight() int64 {
+	return atomic.LoadInt64(&l.prunedHeight)
+}
+
+func (l *Ledger) isPruned(block *pb.InternalBlock) bool {
+	return block.Height > 0 && block.Height <= l.GetPrunedHeight()
+}
+
+// PruneBlocks 裁剪高度在(已裁剪高度, targetHeight]之间的主干区块的交易，retain返回true的交易会被保留
+func (l *Ledger) PruneBlocks(targetHeight int64, retain TxRetainer) error {
+	if targetHeight > l.GetMeta().GetTrunkHeight() {
+		return fmt.Errorf("prune height %d is higher than trunk height %d", targetHeight, l.GetMeta().GetTrunkHeight())
+	}
+	err := l.pruneRetainedTxs(retain)
+	if err != nil {
+		return err
+	}
+
+	for begin := l.GetPrunedHeight() + 1; begin <= targetHeight; begin += pruneBatchBlocks {
+		end := begin + pruneBatchBlocks - 1
+		if end > targetHeight {
+			end = targetHeight
+		}
+		batch := l.baseDB.NewBatch()
+		var prunedBlocks, prunedTxs []string
+		for height := begin; height <= end; height++ {
+			block, err := l.QueryBlockHeaderByHeight(height)
+			if err != nil {
+				return fmt.Errorf("query block failed when prune.height:%d,err:%v", height, err)
+			}
+			prunedBlocks = append(prunedBlocks, string(block.Blockid))
+			for _, txid := range block.MerkleTree[:block.TxCount] {
+				tx, err := l.QueryTransaction(txid)
+				if err == ErrTxNotFound {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				// 交易可能被后来的区块重复打包，只裁剪交易所在的区块
+				if string(tx.Blockid) != string(block.Blockid) {
+					continue
+				}
+				keep, err := retain(tx)
+				if err != nil {
+					return err
+				}
+				if keep {
+					batch.Put(append([]byte(pb.PruneRetainedTxPrefix), txid...), []byte{})
+					continue
+				}
+				batch.Delete(append([]byte(pb.ConfirmedTablePrefix), txid...))
+				prunedTxs = append(prunedTxs, string(txid))
+			}
+		}
+		batch.Put(append([]byte(pb.MetaTablePrefix), PrunedHeightKey...), []byte(strconv.FormatInt(end, 10)))
+
+		l.mutex.Lock()
+		err = batch.Write()
+		if err == nil {
+			atomic.StoreInt64(&l.prunedHeight, end)
+			for _, blockid := range prunedBlocks {
+				l.blockCache.Del(blockid)
+			}
+			for _, txid := range prunedTxs {
+				l.txCache.Del(txid)
+			}
+		}
+		l.mutex.Unlock()
+		if err != nil {
+			l.xlog.Warn("batch write failed when prune", "err", err)
+			return err
+		}
+		l.xlog.Info("prune blocks", "from", begin, "to", end)
+	}
+	return nil
+}
+
+// CompactPruned 对交易表触发在线compaction，回收裁剪删除的交易占用的空间，存储引擎不支持时直接返回
+func (l *Ledger) CompactPruned() error {
+	compactor, ok := l.baseDB.(kvdb.Compactor)
+	if !ok {
+		return nil
+	}
+	prefix := []byte(pb.ConfirmedTablePrefix)
+	return compactor.Compact(prefix, kvdb.PrefixLimit(prefix))
+}
+
+// pruneRetainedTxs 重新判断之前保留的交易，不再需要保留的交易删除
+func (l *Ledger) pruneRetainedTxs(retain TxRetainer) error {
+	it := l.baseDB.NewIteratorWithPrefix([]byte(pb.PruneRetainedTxPrefix))
+	defer it.Release()
+
+	batch := l.baseDB.NewBatch()
+	for it.Next() {
+		txid := it.Key()[len(pb.PruneRetainedTxPrefix):]
+		tx, err := l.QueryTransaction(txid)
+		if err != nil && err != ErrTxNotFound {
+			return err
+		}
+		if err == nil {
+			keep, err := retain(tx)
+			if err != nil {
+				return err
+			}
+			if keep {
+				continue
+			}
+			batch.Delete(append([]byte(pb.ConfirmedTablePrefix), txid...))
+			l.txCache.Del(string(txid))
+			l.xlog.Debug("prune retained tx", "txid", utils.F(txid))
+		}
+		batch.Delete(append([]byte{}, it.Key()...))
+	}
+	if it.Error() != nil {
+		return it.Error()
+	}
+	return batch.Write()
+}
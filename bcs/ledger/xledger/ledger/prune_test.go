@@ -0,0 +1,97 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo/txhash"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func TestPruneBlocks(t *testing.T) {
+	ledger, err := openLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ledger.Close()
+	ecdsaPk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootTx := &pb.Transaction{Coinbase: true, Desc: []byte(`{"maxblocksize" : "128"}`)}
+	rootTx.Txid, _ = txhash.MakeTransactionID(rootTx)
+	root, err := ledger.FormatRootBlock([]*pb.Transaction{rootTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ledger.ConfirmBlock(root, true).Succ {
+		t.Fatal("confirm root block fail")
+	}
+	blocks := []*pb.InternalBlock{root}
+	txs := []*pb.Transaction{rootTx}
+	for i := 1; i <= 3; i++ {
+		tx := &pb.Transaction{Desc: []byte(fmt.Sprintf("tx%d", i))}
+		tx.TxOutputs = append(tx.TxOutputs, &protos.TxOutput{Amount: []byte("1"), ToAddr: []byte(BobAddress)})
+		tx.Txid, _ = txhash.MakeTransactionID(tx)
+		block, err := ledger.FormatBlock([]*pb.Transaction{tx}, []byte(AliceAddress), ecdsaPk,
+			int64(223456789+i), 0, 0, blocks[i-1].Blockid, big.NewInt(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ledger.ConfirmBlock(block, false).Succ {
+			t.Fatalf("confirm block %d fail", i)
+		}
+		blocks = append(blocks, block)
+		txs = append(txs, tx)
+	}
+
+	// 保留第1个区块的交易
+	retain := func(tx *pb.Transaction) (bool, error) {
+		return bytes.Equal(tx.Txid, txs[1].Txid), nil
+	}
+	if err := ledger.PruneBlocks(4, retain); err == nil {
+		t.Fatal("expect error when prune above trunk height")
+	}
+	if err := ledger.PruneBlocks(2, retain); err != nil {
+		t.Fatal(err)
+	}
+	if ledger.GetPrunedHeight() != 2 {
+		t.Fatalf("unexpected pruned height %d", ledger.GetPrunedHeight())
+	}
+	if _, err := ledger.QueryTransaction(txs[1].Txid); err != nil {
+		t.Fatal("retained tx should exist", err)
+	}
+	if _, err := ledger.QueryTransaction(txs[2].Txid); err != ErrTxNotFound {
+		t.Fatal("pruned tx should not exist", err)
+	}
+	if _, err := ledger.QueryBlock(blocks[2].Blockid); err != ErrBlockPruned {
+		t.Fatal("expect block pruned", err)
+	}
+	if _, err := ledger.QueryBlockHeaderByHeight(2); err != nil {
+		t.Fatal("header of pruned block should exist", err)
+	}
+	for _, i := range []int{0, 3} {
+		if _, err := ledger.QueryBlock(blocks[i].Blockid); err != nil {
+			t.Fatalf("block %d should not be pruned, err:%v", i, err)
+		}
+	}
+	if err := ledger.Truncate(blocks[1].Blockid); err != ErrBlockPruned {
+		t.Fatal("expect truncate fail below pruned height", err)
+	}
+
+	// 不再需要保留的交易在下次裁剪时删除
+	err = ledger.PruneBlocks(2, func(tx *pb.Transaction) (bool, error) { return false, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ledger.QueryTransaction(txs[1].Txid); err != ErrTxNotFound {
+		t.Fatal("retained tx should be pruned", err)
+	}
+}
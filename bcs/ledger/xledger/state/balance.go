@@ -0,0 +1,118 @@
+package state
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// utxoReader 预执行时合约读取的余额为最新确认区块的余额，不包含未确认交易
+type utxoReader struct {
+	*utxo.UtxoVM
+	state *State
+}
+
+func (r *utxoReader) GetBalance(addr string) (*big.Int, error) {
+	return r.state.confirmedBalance(addr)
+}
+
+// confirmedBalance 返回地址在最新确认区块的余额。
+// utxo表中已经包含了未确认交易的输入输出，需要排除未确认交易产生的utxo并加回被未确认交易花费的utxo
+func (t *State) confirmedBalance(addr string) (*big.Int, error) {
+	unconfirmed := make(map[string]bool)
+	var spent []*protos.TxInput
+	t.tx.Mempool.Range(func(tx *pb.Transaction) bool {
+		unconfirmed[string(tx.GetTxid())] = true
+		for _, input := range tx.GetTxInputs() {
+			if string(input.GetFromAddr()) == addr {
+				spent = append(spent, input)
+			}
+		}
+		return true
+	})
+
+	balance := big.NewInt(0)
+	for _, input := range spent {
+		if !unconfirmed[string(input.GetRefTxid())] {
+			balance.Add(balance, new(big.Int).SetBytes(input.GetAmount()))
+		}
+	}
+
+	// 底层key: table_prefix addr "_" txid "_" offset
+	addrPrefix := fmt.Sprintf("%s%s_", pb.UTXOTablePrefix, addr)
+	it := t.ldb.NewIteratorWithPrefix([]byte(addrPrefix))
+	defer it.Release()
+	for it.Next() {
+		// 地址本身包含"_"时前缀可能匹配到其他地址的utxo
+		parts := strings.Split(string(it.Key()[len(addrPrefix):]), "_")
+		if len(parts) != 2 {
+			continue
+		}
+		refTxid, err := hex.DecodeString(parts[0])
+		if err != nil || unconfirmed[string(refTxid)] {
+			continue
+		}
+		uItem := &utxo.UtxoItem{}
+		if err := uItem.Loads(it.Value()); err != nil {
+			return nil, err
+		}
+		balance.Add(balance, uItem.Amount)
+	}
+	if it.Error() != nil {
+		return nil, it.Error()
+	}
+	return balance, nil
+}
+
+// verifyBalanceReads 校验交易记录的余额与最新确认区块的余额一致
+func (t *State) verifyBalanceReads(balances []*protos.TxOutput) error {
+	for _, record := range balances {
+		balance, err := t.confirmedBalance(string(record.GetToAddr()))
+		if err != nil {
+			return err
+		}
+		if balance.Cmp(new(big.Int).SetBytes(record.GetAmount())) != 0 {
+			return fmt.Errorf("balance of %s changed", record.GetToAddr())
+		}
+	}
+	return nil
+}
+
+// readsBalanceChangedBy 返回未确认交易中是否有交易读取了区块中转账涉及地址的余额
+func (t *State) readsBalanceChangedBy(block *pb.InternalBlock) bool {
+	changed := make(map[string]bool)
+	for _, tx := range block.Transactions {
+		for _, input := range tx.GetTxInputs() {
+			changed[string(input.GetFromAddr())] = true
+		}
+		for _, output := range tx.GetTxOutputs() {
+			addr := output.GetToAddr()
+			if bytes.Equal(addr, []byte(FeePlaceholder)) {
+				addr = block.Proposer
+			}
+			changed[string(addr)] = true
+		}
+	}
+	found := false
+	t.tx.Mempool.Range(func(tx *pb.Transaction) bool {
+		balances, err := sandbox.ParseBalanceReads(tx)
+		if err != nil {
+			return true
+		}
+		for _, record := range balances {
+			if changed[string(record.GetToAddr())] {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
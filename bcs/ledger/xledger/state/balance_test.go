@@ -0,0 +1,131 @@
+package state
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	ledger_pkg "github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo/txhash"
+	txn "github.com/xuperchain/xupercore/bcs/ledger/xledger/tx"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/mock"
+	crypto_client "github.com/xuperchain/xupercore/lib/crypto/client"
+	"github.com/xuperchain/xupercore/lib/logs"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func TestConfirmedBalance(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "confirmed-balance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+	econf, err := mock.NewEnvConfForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs.InitLog(econf.GenConfFilePath(econf.LogConf), econf.GenDirAbsPath(econf.LogDir))
+	lctx, err := ledger_pkg.NewLedgerCtx(econf, "xuper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lctx.EnvCfg.ChainDir = workspace
+	ledger, err := ledger_pkg.CreateLedger(lctx, GenesisConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootTx, err := txn.GenerateRootTx([]byte(`{
+		"version": "1",
+		"consensus": {"miner": "0x00000000000"},
+		"predistribution": [
+			{"address": "` + BobAddress + `", "quota": "100"},
+			{"address": "` + AliceAddress + `", "quota": "200"}
+		],
+		"maxblocksize": "128",
+		"period": "5000",
+		"award": "1000"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := ledger.FormatRootBlock([]*pb.Transaction{rootTx})
+	if !ledger.ConfirmBlock(block, true).Succ {
+		t.Fatal("confirm block fail")
+	}
+	crypt, err := crypto_client.CreateCryptoClient(crypto_client.CryptoTypeDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sctx, err := context.NewStateCtx(econf, "xuper", ledger, crypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sctx.EnvCfg.ChainDir = workspace
+	st, err := NewState(sctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	if err := st.Play(block.Blockid); err != nil {
+		t.Fatal(err)
+	}
+
+	// bob转给alice 30，交易只进入未确认交易池
+	tx := &pb.Transaction{
+		Nonce:       "nonce",
+		Timestamp:   time.Now().UnixNano(),
+		Version:     1,
+		Initiator:   BobAddress,
+		AuthRequire: []string{BobAddress},
+	}
+	inputs, _, total, err := st.SelectUtxos(BobAddress, big.NewInt(30), true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.TxInputs = inputs
+	tx.TxOutputs = []*protos.TxOutput{
+		{ToAddr: []byte(AliceAddress), Amount: big.NewInt(30).Bytes()},
+		{ToAddr: []byte(BobAddress), Amount: new(big.Int).Sub(total, big.NewInt(30)).Bytes()},
+	}
+	sign, err := txhash.ProcessSignTx(crypt, tx, []byte(BobPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.InitiatorSigns = []*protos.SignatureInfo{{PublicKey: BobPubkey, Sign: sign}}
+	tx.AuthRequireSigns = tx.InitiatorSigns
+	tx.Txid, _ = txhash.MakeTransactionID(tx)
+	if err := st.DoTx(tx); err != nil {
+		t.Fatal(err)
+	}
+
+	balance, _ := st.GetBalance(BobAddress)
+	if balance.Int64() != 70 {
+		t.Fatalf("expect bob balance with unconfirmed tx 70, got %s", balance)
+	}
+	// 合约读取的余额不包含未确认交易
+	reader := st.CreateUtxoReader()
+	for addr, expect := range map[string]int64{BobAddress: 100, AliceAddress: 200} {
+		balance, err := reader.GetBalance(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance.Int64() != expect {
+			t.Fatalf("expect confirmed balance of %s %d, got %s", addr, expect, balance)
+		}
+	}
+
+	if err := st.verifyBalanceReads([]*protos.TxOutput{
+		{ToAddr: []byte(BobAddress), Amount: big.NewInt(100).Bytes()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.verifyBalanceReads([]*protos.TxOutput{
+		{ToAddr: []byte(BobAddress), Amount: big.NewInt(70).Bytes()},
+	}); err == nil {
+		t.Fatal("expect balance changed error")
+	}
+}
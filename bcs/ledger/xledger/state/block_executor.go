@@ -0,0 +1,143 @@
+package state
+
+import (
+	"runtime"
+	"sync"
+
+	txn "github.com/xuperchain/xupercore/bcs/ledger/xledger/tx"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+)
+
+// txExecutor 执行区块中的一个交易，将结果写入batch
+type txExecutor func(tx *pb.Transaction, batch kvdb.Batch) error
+
+// syncBatch 为kvdb.Batch加锁，使多个DAG的交易可以并发写入同一个batch
+type syncBatch struct {
+	mutex sync.Mutex
+	batch kvdb.Batch
+}
+
+func (b *syncBatch) ValueSize() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.batch.ValueSize()
+}
+
+func (b *syncBatch) Write() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.batch.Write()
+}
+
+func (b *syncBatch) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.batch.Reset()
+}
+
+func (b *syncBatch) Put(key []byte, value []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.batch.Put(key, value)
+}
+
+func (b *syncBatch) Delete(key []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.batch.Delete(key)
+}
+
+func (b *syncBatch) PutIfAbsent(key []byte, value []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.batch.PutIfAbsent(key, value)
+}
+
+func (b *syncBatch) Exist(key []byte) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.batch.Exist(key)
+}
+
+// executeBlockTxs 按照交易的读写集将区块划分为互不冲突的DAG，不同DAG并发执行，DAG内部按区块顺序串行执行。
+// 不同DAG写入的key互不相交，因此最终写入batch的状态与串行执行一致。
+func (t *State) executeBlockTxs(block *pb.InternalBlock, batch kvdb.Batch, exec txExecutor) error {
+	dags := txn.SplitToConflictFreeDags(block)
+	if len(dags) <= 1 {
+		return executeDags(block, dags, batch, exec)
+	}
+	// 并发执行期间由加锁的batch写入，batch cache中已有的撤销结果等需要保留
+	sbatch := &syncBatch{batch: batch}
+	t.utxo.SwitchBatchCache(batch, sbatch)
+	t.xmodel.SwitchBatchCache(batch, sbatch)
+	defer func() {
+		t.utxo.SwitchBatchCache(sbatch, batch)
+		t.xmodel.SwitchBatchCache(sbatch, batch)
+	}()
+	err := executeDags(block, dags, sbatch, exec)
+	if err != nil {
+		// 失败交易之后的交易可能已经在其他DAG中执行，清理它们写入的缓存
+		t.ClearCache()
+	}
+	return err
+}
+
+// executeDags 并发执行多个DAG，返回区块中第一个执行失败的交易的错误，保证与串行执行返回的错误一致
+func executeDags(block *pb.InternalBlock, dags [][]*pb.Transaction, batch kvdb.Batch, exec txExecutor) error {
+	if len(dags) <= 1 {
+		for _, txs := range dags {
+			for _, tx := range txs {
+				if err := exec(tx, batch); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	txIndex := make(map[string]int, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		txIndex[string(tx.Txid)] = i
+	}
+	var (
+		mutex    sync.Mutex
+		errIndex = len(block.Transactions)
+		firstErr error
+	)
+	wg := sync.WaitGroup{}
+	limit := make(chan struct{}, runtime.NumCPU())
+	for _, txs := range dags {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(txs []*pb.Transaction) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			for _, tx := range txs {
+				idx := txIndex[string(tx.Txid)]
+				// 区块中更早的交易已经执行失败，当前交易不需要再执行
+				mutex.Lock()
+				skip := idx > errIndex
+				mutex.Unlock()
+				if skip {
+					return
+				}
+				err := exec(tx, batch)
+				if err == nil {
+					continue
+				}
+				mutex.Lock()
+				if idx < errIndex {
+					errIndex = idx
+					firstErr = err
+				}
+				mutex.Unlock()
+				return
+			}
+		}(txs)
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,300 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ledger_pkg "github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
+	txn "github.com/xuperchain/xupercore/bcs/ledger/xledger/tx"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/mock"
+	crypto_client "github.com/xuperchain/xupercore/lib/crypto/client"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// mapBatch 记录写入的最终状态，本身不是并发安全的
+type mapBatch struct {
+	kvdb.Batch
+	kv map[string]string
+}
+
+func (b *mapBatch) Put(key []byte, value []byte) error {
+	b.kv[string(key)] = string(value)
+	return nil
+}
+
+func (b *mapBatch) Delete(key []byte) error {
+	delete(b.kv, string(key))
+	return nil
+}
+
+func newTestBlock(n int) *pb.InternalBlock {
+	r := rand.New(rand.NewSource(1))
+	block := &pb.InternalBlock{}
+	for i := 0; i < n; i++ {
+		key := []byte(fmt.Sprintf("key%d", r.Intn(n/4)))
+		block.Transactions = append(block.Transactions, &pb.Transaction{
+			Txid:         []byte(fmt.Sprintf("tx%d", i)),
+			TxInputsExt:  []*protos.TxInputExt{{Bucket: "test", Key: key}},
+			TxOutputsExt: []*protos.TxOutputExt{{Bucket: "test", Key: key, Value: []byte(fmt.Sprintf("tx%d", i))}},
+		})
+	}
+	return block
+}
+
+// 模拟交易的执行，写入交易的写集
+func writeOutputs(tx *pb.Transaction, batch kvdb.Batch) error {
+	for _, output := range tx.TxOutputsExt {
+		batch.Put([]byte(output.Bucket+"/"+string(output.Key)), output.Value)
+	}
+	return batch.Put(append([]byte("tx/"), tx.Txid...), []byte("done"))
+}
+
+func TestExecuteDagsSameAsSerial(t *testing.T) {
+	block := newTestBlock(200)
+
+	serial := &mapBatch{kv: map[string]string{}}
+	err := executeDags(block, [][]*pb.Transaction{block.Transactions}, serial, writeOutputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parallel := &mapBatch{kv: map[string]string{}}
+	dags := txn.SplitToConflictFreeDags(block)
+	if len(dags) <= 1 {
+		t.Fatalf("expect more than one dag, got %d", len(dags))
+	}
+	err = executeDags(block, dags, &syncBatch{batch: parallel}, writeOutputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(serial.kv, parallel.kv) {
+		t.Error("parallel execution result differs from serial execution")
+	}
+}
+
+func TestExecuteDagsReturnFirstError(t *testing.T) {
+	block := newTestBlock(100)
+	dags := txn.SplitToConflictFreeDags(block)
+	for i := 0; i < 10; i++ {
+		err := executeDags(block, dags, &syncBatch{batch: &mapBatch{kv: map[string]string{}}},
+			func(tx *pb.Transaction, batch kvdb.Batch) error {
+				switch string(tx.Txid) {
+				case "tx30", "tx31", "tx90":
+					return errors.New(string(tx.Txid))
+				}
+				return nil
+			})
+		if err == nil || err.Error() != "tx30" {
+			t.Fatalf("expect error of tx30, got %v", err)
+		}
+	}
+}
+
+// newPlayTestStates 创建n个执行了同一个创世区块的状态机，创世区块给addr0...addrN-1各分配100
+func newPlayTestStates(t *testing.T, n, accounts int) ([]*State, *pb.Transaction, []byte, func()) {
+	workspace, err := ioutil.TempDir("", "block-executor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	econf, err := mock.NewEnvConfForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lctx, err := ledger_pkg.NewLedgerCtx(econf, "xuper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lctx.EnvCfg.ChainDir = filepath.Join(workspace, "ledger")
+	ledger, err := ledger_pkg.CreateLedger(lctx, GenesisConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	predistribution := ""
+	for i := 0; i < accounts; i++ {
+		if i > 0 {
+			predistribution += ","
+		}
+		predistribution += fmt.Sprintf(`{"address": "addr%d", "quota": "100"}`, i)
+	}
+	rootTx, err := txn.GenerateRootTx([]byte(`{"version": "1", "consensus": {"miner": "0x00000000000"}, "predistribution": [` +
+		predistribution + `], "maxblocksize": "128", "period": "5000", "award": "1000"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := ledger.FormatRootBlock([]*pb.Transaction{rootTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := ledger.ConfirmBlock(genesis, true); !status.Succ {
+		t.Fatal("confirm genesis block fail")
+	}
+	crypt, err := crypto_client.CreateCryptoClient(crypto_client.CryptoTypeDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var states []*State
+	for i := 0; i < n; i++ {
+		econf, err := mock.NewEnvConfForTest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sctx, err := context.NewStateCtx(econf, "xuper", ledger, crypt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sctx.EnvCfg.ChainDir = filepath.Join(workspace, fmt.Sprintf("state%d", i))
+		st, err := NewState(sctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := st.Play(genesis.Blockid); err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, st)
+	}
+	return states, rootTx, genesis.Blockid, func() {
+		for _, st := range states {
+			st.Close()
+		}
+		ledger.Close()
+		os.RemoveAll(workspace)
+	}
+}
+
+// newIndependentTxsBlock 生成包含多个互不冲突DAG的区块：
+// 相邻的两个交易读写同一个key，另有一个交易花费区块内交易的输出，以及一个coinbase交易
+func newIndependentTxsBlock(t *testing.T, rootTx *pb.Transaction, preHash []byte, accounts int) *pb.InternalBlock {
+	blockid := []byte("block1")
+	var txs []*pb.Transaction
+	for i := 0; i < accounts; i++ {
+		input := &protos.TxInputExt{Bucket: "test", Key: []byte(fmt.Sprintf("k%d", i/2))}
+		if i%2 == 1 {
+			input.RefTxid = txs[i-1].Txid
+		}
+		txs = append(txs, &pb.Transaction{
+			Txid:    []byte(fmt.Sprintf("tx%d", i)),
+			Blockid: blockid,
+			TxInputs: []*protos.TxInput{
+				{RefTxid: rootTx.Txid, RefOffset: int32(i), FromAddr: []byte(fmt.Sprintf("addr%d", i)), Amount: big.NewInt(100).Bytes()},
+			},
+			TxOutputs: []*protos.TxOutput{
+				{ToAddr: []byte(fmt.Sprintf("to%d", i)), Amount: big.NewInt(90).Bytes()},
+				{ToAddr: []byte(FeePlaceholder), Amount: big.NewInt(10).Bytes()},
+			},
+			TxInputsExt:  []*protos.TxInputExt{input},
+			TxOutputsExt: []*protos.TxOutputExt{{Bucket: "test", Key: input.Key, Value: []byte(fmt.Sprintf("tx%d", i))}},
+		})
+	}
+	txs = append(txs, &pb.Transaction{
+		Txid:    []byte("spend-tx0"),
+		Blockid: blockid,
+		TxInputs: []*protos.TxInput{
+			{RefTxid: txs[0].Txid, RefOffset: 0, FromAddr: []byte("to0"), Amount: big.NewInt(90).Bytes()},
+		},
+		TxOutputs: []*protos.TxOutput{
+			{ToAddr: []byte("addr0"), Amount: big.NewInt(90).Bytes()},
+		},
+	})
+	awardTx, err := txn.GenerateAwardTx("miner", "1000", []byte("award"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	awardTx.Blockid = blockid
+	txs = append(txs, awardTx)
+	return &pb.InternalBlock{
+		Blockid:      blockid,
+		PreHash:      preHash,
+		Height:       1,
+		Proposer:     []byte("miner"),
+		Transactions: txs,
+	}
+}
+
+func dumpState(t *testing.T, st *State) map[string]string {
+	kv := map[string]string{}
+	iter := st.ldb.NewIteratorWithPrefix(nil)
+	defer iter.Release()
+	for iter.Next() {
+		kv[string(iter.Key())] = string(iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return kv
+}
+
+func TestExecuteBlockTxsSameStateRootAsSerial(t *testing.T) {
+	const accounts = 8
+	states, rootTx, genesisid, clean := newPlayTestStates(t, 2, accounts)
+	defer clean()
+	block := newIndependentTxsBlock(t, rootTx, genesisid, accounts)
+	if dags := txn.SplitToConflictFreeDags(block); len(dags) != accounts/2+1 {
+		t.Fatalf("expect %d dags, got %d", accounts/2+1, len(dags))
+	}
+
+	play := func(st *State, parallel bool) {
+		batch := st.ldb.NewBatch()
+		exec := func(tx *pb.Transaction, batch kvdb.Batch) error {
+			if err := st.doTxInternal(tx, batch, nil); err != nil {
+				return err
+			}
+			return st.payFee(tx, batch, block)
+		}
+		var err error
+		if parallel {
+			err = st.executeBlockTxs(block, batch, exec)
+		} else {
+			err = executeDags(block, [][]*pb.Transaction{block.Transactions}, batch, exec)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		session, err := st.prepareStateTree(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		session.Commit(batch)
+		if err := batch.Write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	serial, parallel := states[0], states[1]
+	play(serial, false)
+	play(parallel, true)
+
+	serialRoot, err := serial.GetStateRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallelRoot, err := parallel.GetStateRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(serialRoot, parallelRoot) {
+		t.Fatalf("state root mismatch, serial:%x, parallel:%x", serialRoot, parallelRoot)
+	}
+	if !reflect.DeepEqual(dumpState(t, serial), dumpState(t, parallel)) {
+		t.Fatal("parallel execution state differs from serial execution")
+	}
+	for _, st := range states {
+		balance, err := st.GetBalance("addr0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance.Int64() != 90 {
+			t.Errorf("expect balance of addr0 90, got %s", balance)
+		}
+	}
+}
@@ -44,6 +44,9 @@ type StateCtx struct {
 	ProposalMgr propose.ProposeManager
 	// 注意：注入后才可以使用
 	TimerTaskMgr timerTask.TimerManager
+	// 跨链查询，验证交易时重新执行交易记录的跨链查询
+	// 注意：注入后才可以使用
+	CrossQueryReader contract.CrossQueryBlockReader
 }
 
 func NewStateCtx(envCfg *xconf.EnvConf, bcName string,
@@ -58,6 +61,9 @@ func NewStateCtx(envCfg *xconf.EnvConf, bcName string,
 	if err != nil {
 		return nil, fmt.Errorf("create state context failed because load config error.err:%v", err)
 	}
+	if err := ledger.ApplyGenesisKVEngine(envCfg, bcName, lcfg); err != nil {
+		return nil, fmt.Errorf("create state context failed because load genesis kvengine error.err:%v", err)
+	}
 	log, err := logs.NewLogger("", def.StateSubModName)
 	if err != nil {
 		return nil, fmt.Errorf("create state context failed because new logger error. err:%v", err)
@@ -91,6 +97,10 @@ func (t *StateCtx) SetProposalMG(proposalMgr propose.ProposeManager) {
 	t.ProposalMgr = proposalMgr
 }
 
+func (t *StateCtx) SetCrossQueryReader(reader contract.CrossQueryBlockReader) {
+	t.CrossQueryReader = reader
+}
+
 func (t *StateCtx) SetTimerTaskMG(timerTaskMgr timerTask.TimerManager) {
 	t.TimerTaskMgr = timerTaskMgr
 }
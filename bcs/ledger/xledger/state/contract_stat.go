@@ -0,0 +1,134 @@
+package state
+
+import (
+	"encoding/binary"
+	"sort"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	aclu "github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// 合约调用次数只在本地统计，不参与共识，用于节点启动时预热调用最多的合约的代码缓存。
+// 区块执行时与状态写入同一个batch，回滚区块时扣减。
+// 合约销毁时写入墓碑，同一区块中清理其调用统计，回滚区块时不恢复。
+
+const (
+	// MaxHotContracts QueryContractStatData返回的调用最多的合约数
+	MaxHotContracts = 20
+
+	kernelModuleName = "xkernel"
+)
+
+func contractCallStatKey(contractName string) []byte {
+	return []byte(pb.ContractCallStatPrefix + contractName)
+}
+
+// countBlockContractCalls 统计区块中每个合约被交易直接调用的次数，内核合约不统计
+func countBlockContractCalls(block *pb.InternalBlock) map[string]int64 {
+	calls := make(map[string]int64)
+	for _, tx := range block.GetTransactions() {
+		for _, req := range tx.GetContractRequests() {
+			if req.GetModuleName() == kernelModuleName || req.GetContractName() == "" {
+				continue
+			}
+			calls[req.GetContractName()]++
+		}
+	}
+	return calls
+}
+
+// destroyedContracts 返回区块中写入墓碑的合约
+func destroyedContracts(block *pb.InternalBlock) map[string]bool {
+	destroyed := make(map[string]bool)
+	for _, tx := range block.GetTransactions() {
+		for _, output := range tx.GetTxOutputsExt() {
+			if output.GetBucket() == aclu.GetContractTombstoneBucket() && len(output.GetValue()) > 0 {
+				destroyed[string(output.GetKey())] = true
+			}
+		}
+	}
+	return destroyed
+}
+
+// updateContractCallStat 将区块中合约的调用次数累加到统计中，undo为true时扣减。
+// 区块中被销毁的合约删除统计
+func (t *State) updateContractCallStat(block *pb.InternalBlock, batch kvdb.Batch, undo bool) error {
+	destroyed := make(map[string]bool)
+	if !undo {
+		destroyed = destroyedContracts(block)
+	}
+	for name := range destroyed {
+		if err := batch.Delete(contractCallStatKey(name)); err != nil {
+			return err
+		}
+	}
+	for name, count := range countBlockContractCalls(block) {
+		if destroyed[name] {
+			continue
+		}
+		key := contractCallStatKey(name)
+		total, err := t.getContractCallCount(key)
+		if err != nil {
+			return err
+		}
+		if undo {
+			total -= count
+		} else {
+			total += count
+		}
+		if total <= 0 {
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+			continue
+		}
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(total))
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *State) getContractCallCount(key []byte) (int64, error) {
+	value, err := t.ldb.Get(key)
+	if err != nil {
+		if kvdb.ErrNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(value) != 8 {
+		return 0, nil
+	}
+	return int64(binary.BigEndian.Uint64(value)), nil
+}
+
+// queryHotContracts 返回调用次数最多的limit个合约，按调用次数降序排列
+func (t *State) queryHotContracts(limit int) ([]*protos.ContractCallStat, error) {
+	iter := t.ldb.NewIteratorWithPrefix([]byte(pb.ContractCallStatPrefix))
+	defer iter.Release()
+	var stats []*protos.ContractCallStat
+	for iter.Next() {
+		if len(iter.Value()) != 8 {
+			continue
+		}
+		stats = append(stats, &protos.ContractCallStat{
+			ContractName: string(iter.Key()[len(pb.ContractCallStatPrefix):]),
+			CallCount:    int64(binary.BigEndian.Uint64(iter.Value())),
+		})
+	}
+	if iter.Error() != nil {
+		return nil, iter.Error()
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].CallCount > stats[j].CallCount
+	})
+	if len(stats) > limit {
+		stats = stats[:limit]
+	}
+	return stats, nil
+}
@@ -0,0 +1,91 @@
+package state
+
+import (
+	"testing"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	aclu "github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func newCallBlock(height int64, contracts ...string) *pb.InternalBlock {
+	tx := &pb.Transaction{Txid: []byte("tx")}
+	for _, name := range contracts {
+		tx.ContractRequests = append(tx.ContractRequests, &protos.InvokeRequest{
+			ModuleName:   "wasm",
+			ContractName: name,
+		})
+	}
+	tx.ContractRequests = append(tx.ContractRequests, &protos.InvokeRequest{
+		ModuleName:   "xkernel",
+		ContractName: "$contract",
+	})
+	return &pb.InternalBlock{
+		Height:       height,
+		Transactions: []*pb.Transaction{tx},
+	}
+}
+
+func TestContractCallStat(t *testing.T) {
+	st, clean := newEventIndexState(t, false)
+	defer clean()
+
+	blocks := []*pb.InternalBlock{
+		newCallBlock(1, "counter", "erc20", "counter"),
+		newCallBlock(2, "counter", "game"),
+	}
+	for _, block := range blocks {
+		batch := st.ldb.NewBatch()
+		if err := st.updateContractCallStat(block, batch, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := batch.Write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := st.queryHotContracts(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 || stats[0].ContractName != "counter" || stats[0].CallCount != 3 ||
+		stats[1].ContractName != "erc20" || stats[1].CallCount != 1 {
+		t.Fatalf("unexpected hot contracts:%v", stats)
+	}
+
+	// 回滚区块后扣减调用次数，次数为0的合约被删除
+	batch := st.ldb.NewBatch()
+	if err := st.updateContractCallStat(blocks[1], batch, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = st.queryHotContracts(MaxHotContracts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 2 || stats[0].CallCount != 2 {
+		t.Fatalf("unexpected hot contracts after undo:%v", stats)
+	}
+
+	// 销毁合约的区块清理其调用统计
+	block := newCallBlock(2, "erc20")
+	block.Transactions[0].TxOutputsExt = []*protos.TxOutputExt{
+		{Bucket: aclu.GetContractTombstoneBucket(), Key: []byte("counter"), Value: []byte("XC1111111111111111@xuper")},
+	}
+	batch = st.ldb.NewBatch()
+	if err := st.updateContractCallStat(block, batch, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = st.queryHotContracts(MaxHotContracts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].ContractName != "erc20" || stats[0].CallCount != 2 {
+		t.Fatalf("unexpected hot contracts after destroy:%v", stats)
+	}
+}
@@ -0,0 +1,127 @@
+package state
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// 合约事件索引按照合约名、事件名、区块高度、交易在区块中的序号和事件在交易中的序号排序，
+// 区块执行时与状态写入同一个batch，回滚区块时按照相同的key删除。
+// 索引只覆盖开启之后执行的区块，开启前的事件仍需要遍历区块查询。
+
+var (
+	ErrEventIndexDisabled = errors.New("contract event index disabled")
+	ErrInvalidEventQuery  = errors.New("invalid contract event query")
+)
+
+// eventIndexPrefix 返回合约事件在指定高度的索引前缀，高度定长编码保证按高度有序。
+// 事件名可以包含任意字符，加上长度前缀避免一个事件的索引落在另一个事件的前缀范围内
+func eventIndexPrefix(contractName, eventName string, height int64) []byte {
+	return []byte(fmt.Sprintf("%s%s/%d:%s/%020d/", pb.ContractEventIndexPrefix, contractName, len(eventName), eventName, height))
+}
+
+func eventIndexKey(event *protos.ContractEvent, height int64, txIndex, eventIndex int) []byte {
+	prefix := eventIndexPrefix(event.GetContract(), event.GetName(), height)
+	return append(prefix, fmt.Sprintf("%06d/%06d", txIndex, eventIndex)...)
+}
+
+func (t *State) eventIndexEnabled() bool {
+	return t.sctx.LedgerCfg != nil && t.sctx.LedgerCfg.EventIndex
+}
+
+// indexBlockEvents 将区块中的合约事件写入索引
+func (t *State) indexBlockEvents(block *pb.InternalBlock, batch kvdb.Batch) error {
+	if !t.eventIndexEnabled() {
+		return nil
+	}
+	return walkBlockEvents(block, func(key []byte, info *protos.ContractEventInfo) error {
+		info.Bcname = t.sctx.BCName
+		value, err := proto.Marshal(info)
+		if err != nil {
+			return err
+		}
+		return batch.Put(key, value)
+	})
+}
+
+// undoBlockEvents 回滚区块时删除区块中合约事件的索引
+func (t *State) undoBlockEvents(block *pb.InternalBlock, batch kvdb.Batch) error {
+	if !t.eventIndexEnabled() {
+		return nil
+	}
+	return walkBlockEvents(block, func(key []byte, info *protos.ContractEventInfo) error {
+		return batch.Delete(key)
+	})
+}
+
+func walkBlockEvents(block *pb.InternalBlock, fn func(key []byte, info *protos.ContractEventInfo) error) error {
+	for txIndex, tx := range block.GetTransactions() {
+		events, err := sandbox.ParseContractEvents(tx)
+		if err != nil {
+			return fmt.Errorf("parse contract events failed.txid:%x,err:%v", tx.GetTxid(), err)
+		}
+		for eventIndex, event := range events {
+			info := &protos.ContractEventInfo{
+				Blockid:     hex.EncodeToString(block.GetBlockid()),
+				BlockHeight: block.GetHeight(),
+				Txid:        hex.EncodeToString(tx.GetTxid()),
+				Event:       event,
+			}
+			if err := fn(eventIndexKey(event, block.GetHeight(), txIndex, eventIndex), info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// QueryContractEvents 查询合约在区块高度[startHeight, endHeight]之间的指定事件，按照事件在账本中的顺序返回最多limit个。
+// cursor为上一次查询返回的游标，为空时从startHeight开始查询；返回的游标为空表示没有更多的事件。
+func (t *State) QueryContractEvents(contractName, eventName string, startHeight, endHeight int64,
+	cursor []byte, limit int) ([]*protos.ContractEventInfo, []byte, error) {
+	if !t.eventIndexEnabled() {
+		return nil, nil, ErrEventIndexDisabled
+	}
+	if contractName == "" || eventName == "" || startHeight < 0 || endHeight < startHeight || limit <= 0 {
+		return nil, nil, ErrInvalidEventQuery
+	}
+
+	start := eventIndexPrefix(contractName, eventName, startHeight)
+	end := eventIndexPrefix(contractName, eventName, endHeight+1)
+	if len(cursor) > 0 {
+		key := append([]byte(pb.ContractEventIndexPrefix), cursor...)
+		if bytes.Compare(key, start) < 0 || bytes.Compare(key, end) >= 0 {
+			return nil, nil, ErrInvalidEventQuery
+		}
+		start = key
+	}
+
+	iter := t.ldb.NewIteratorWithRange(start, end)
+	defer iter.Release()
+	var events []*protos.ContractEventInfo
+	var next []byte
+	for iter.Next() {
+		if len(events) >= limit {
+			next = append([]byte{}, iter.Key()[len(pb.ContractEventIndexPrefix):]...)
+			break
+		}
+		info := new(protos.ContractEventInfo)
+		if err := proto.Unmarshal(iter.Value(), info); err != nil {
+			return nil, nil, err
+		}
+		events = append(events, info)
+	}
+	if iter.Error() != nil {
+		return nil, nil, iter.Error()
+	}
+	return events, next, nil
+}
@@ -0,0 +1,199 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	lconf "github.com/xuperchain/xupercore/bcs/ledger/xledger/config"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func newEventIndexState(t *testing.T, enable bool) (*State, func()) {
+	workspace, err := ioutil.TempDir("", "event-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldb, err := kvdb.CreateKVInstance(&kvdb.KVParameter{
+		DBPath:                filepath.Join(workspace, "state"),
+		KVEngineType:          "leveldb",
+		MemCacheSize:          16,
+		FileHandlersCacheSize: 16,
+		StorageType:           "single",
+	})
+	if err != nil {
+		os.RemoveAll(workspace)
+		t.Fatal(err)
+	}
+	lcfg := lconf.GetDefLedgerConf()
+	lcfg.EventIndex = enable
+	st := &State{
+		sctx: &context.StateCtx{
+			LedgerCfg: lcfg,
+			BCName:    "xuper",
+		},
+		ldb: ldb,
+	}
+	return st, func() {
+		ldb.Close()
+		os.RemoveAll(workspace)
+	}
+}
+
+func newEventTx(t *testing.T, txid string, events ...*protos.ContractEvent) *pb.Transaction {
+	buf, err := xmodel.MarshalMessages(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.Transaction{
+		Txid: []byte(txid),
+		TxOutputsExt: []*protos.TxOutputExt{
+			{
+				Bucket: "$transient",
+				Key:    []byte("contractEvent"),
+				Value:  buf,
+			},
+		},
+	}
+}
+
+func newEventBlock(t *testing.T, height int64) *pb.InternalBlock {
+	transfer := &protos.ContractEvent{Contract: "counter", Name: "Transfer", Body: []byte{byte(height)}}
+	approve := &protos.ContractEvent{Contract: "counter", Name: "Approve"}
+	other := &protos.ContractEvent{Contract: "erc20", Name: "Transfer"}
+	return &pb.InternalBlock{
+		Blockid: []byte{byte(height)},
+		Height:  height,
+		Transactions: []*pb.Transaction{
+			{Txid: []byte("coinbase")},
+			newEventTx(t, "tx1", transfer, approve, transfer),
+			newEventTx(t, "tx2", other, transfer),
+		},
+	}
+}
+
+func indexBlocks(t *testing.T, st *State, blocks []*pb.InternalBlock, undo bool) {
+	batch := st.ldb.NewBatch()
+	for _, block := range blocks {
+		var err error
+		if undo {
+			err = st.undoBlockEvents(block, batch)
+		} else {
+			err = st.indexBlockEvents(block, batch)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryContractEvents(t *testing.T) {
+	st, clean := newEventIndexState(t, true)
+	defer clean()
+
+	var blocks []*pb.InternalBlock
+	for height := int64(1); height <= 5; height++ {
+		blocks = append(blocks, newEventBlock(t, height))
+	}
+	indexBlocks(t, st, blocks, false)
+
+	// 高度[2, 4]之间每个区块有3个counter的Transfer事件，分页查询
+	var all []*protos.ContractEventInfo
+	var cursor []byte
+	for {
+		events, next, err := st.QueryContractEvents("counter", "Transfer", 2, 4, cursor, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) > 2 {
+			t.Fatalf("page size exceed limit:%d", len(events))
+		}
+		all = append(all, events...)
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	if len(all) != 9 {
+		t.Fatalf("expect 9 events, got %d", len(all))
+	}
+	for i, info := range all {
+		height := int64(2 + i/3)
+		if info.GetBlockHeight() != height || info.GetBcname() != "xuper" {
+			t.Fatalf("unexpected event %d:%v", i, info)
+		}
+		if info.GetEvent().GetContract() != "counter" || info.GetEvent().GetName() != "Transfer" {
+			t.Fatalf("unexpected event %d:%v", i, info)
+		}
+	}
+	if all[0].GetTxid() != "747831" || all[2].GetTxid() != "747832" {
+		t.Fatalf("events not in ledger order:%v", all)
+	}
+
+	// 回滚最后两个区块后，索引中不再有对应的事件
+	indexBlocks(t, st, blocks[3:], true)
+	events, next, err := st.QueryContractEvents("counter", "Transfer", 1, 5, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 9 || next != nil {
+		t.Fatalf("expect 9 events after undo, got %d", len(events))
+	}
+
+	if _, _, err := st.QueryContractEvents("counter", "Transfer", 1, 2, []byte("bad cursor"), 1); err != ErrInvalidEventQuery {
+		t.Fatalf("expect invalid query, got %v", err)
+	}
+}
+
+func TestQueryContractEventsDisabled(t *testing.T) {
+	st, clean := newEventIndexState(t, false)
+	defer clean()
+
+	indexBlocks(t, st, []*pb.InternalBlock{newEventBlock(t, 1)}, false)
+	if _, _, err := st.QueryContractEvents("counter", "Transfer", 0, 1, nil, 10); err != ErrEventIndexDisabled {
+		t.Fatalf("expect index disabled, got %v", err)
+	}
+	iter := st.ldb.NewIteratorWithPrefix([]byte(pb.ContractEventIndexPrefix))
+	defer iter.Release()
+	if iter.Next() {
+		t.Fatal("index should not be written when disabled")
+	}
+}
+
+func TestQueryContractEventsNameWithSlash(t *testing.T) {
+	st, clean := newEventIndexState(t, true)
+	defer clean()
+
+	// 事件名中伪造高度和序号，不能出现在Transfer事件的查询结果中
+	spoof := &protos.ContractEvent{Contract: "counter", Name: "Transfer/00000000000000000001/000000/000000"}
+	block := &pb.InternalBlock{
+		Blockid:      []byte{1},
+		Height:       1,
+		Transactions: []*pb.Transaction{newEventTx(t, "tx1", spoof)},
+	}
+	indexBlocks(t, st, []*pb.InternalBlock{block}, false)
+
+	events, _, err := st.QueryContractEvents("counter", "Transfer", 0, 10, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expect no Transfer events, got %v", events)
+	}
+	events, _, err = st.QueryContractEvents("counter", spoof.Name, 0, 10, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expect 1 event, got %d", len(events))
+	}
+}
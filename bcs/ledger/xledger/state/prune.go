@@ -0,0 +1,83 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	kledger "github.com/xuperchain/xupercore/kernel/ledger"
+)
+
+// tryPruneLedger 开启账本裁剪时，每隔Interval个区块异步裁剪一次，同一时间只有一个裁剪任务
+func (t *State) tryPruneLedger(height int64) {
+	cfg := t.sctx.LedgerCfg.Prune
+	if !cfg.Enable || cfg.Interval <= 0 || height%cfg.Interval != 0 {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&t.pruning, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&t.pruning, 0)
+		err := t.PruneLedger(height)
+		if err != nil {
+			t.log.Warn("prune ledger failed", "height", height, "err", err)
+			return
+		}
+		if cfg.Compact {
+			err = t.sctx.Ledger.CompactPruned()
+			if err != nil {
+				t.log.Warn("compact ledger failed", "height", height, "err", err)
+			}
+		}
+	}()
+}
+
+// PruneLedger 裁剪账本，保留最近KeepBlocks个区块，不可逆窗口内的区块不会被裁剪。
+// 裁剪高度以下的交易只保留当前状态仍然需要的，即裁剪高度时每个key的版本对应的交易，
+// 因此裁剪高度及以上的快照仍然可以读取。
+func (t *State) PruneLedger(tipHeight int64) error {
+	cfg := t.sctx.LedgerCfg.Prune
+	if cfg.KeepBlocks <= 0 {
+		return fmt.Errorf("invalid prune config, keepBlocks:%d", cfg.KeepBlocks)
+	}
+	target := tipHeight - cfg.KeepBlocks
+	if t.meta.GetIrreversibleSlideWindow() > 0 && target > t.meta.GetIrreversibleBlockHeight() {
+		target = t.meta.GetIrreversibleBlockHeight()
+	}
+	if target <= t.sctx.Ledger.GetPrunedHeight() {
+		return nil
+	}
+
+	block, err := t.sctx.Ledger.QueryBlockHeaderByHeight(target)
+	if err != nil {
+		return err
+	}
+	snapshot, err := t.xmodel.CreateSnapshot(block.Blockid)
+	if err != nil {
+		return err
+	}
+	t.log.Info("start prune ledger", "height", target, "blockid", fmt.Sprintf("%x", block.Blockid))
+	return t.sctx.Ledger.PruneBlocks(target, func(tx *pb.Transaction) (bool, error) {
+		return isTxReferenced(snapshot, tx)
+	})
+}
+
+// isTxReferenced 交易写入的key在快照中的版本是否仍然是这个交易
+func isTxReferenced(snapshot kledger.XMReader, tx *pb.Transaction) (bool, error) {
+	for _, txOut := range tx.GetTxOutputsExt() {
+		if txOut.GetBucket() == xmodel.TransientBucket {
+			continue
+		}
+		data, err := snapshot.Get(txOut.GetBucket(), txOut.GetKey())
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(data.GetRefTxid(), tx.GetTxid()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,318 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+)
+
+// 状态快照：导出某个区块高度的完整状态，新节点导入后从该高度开始同步区块，不需要从创世块回放。
+// 快照由一组记录组成，每条记录为 类型(1字节) + key长度(uvarint) + key + value长度(uvarint) + value，
+// 第一条记录为快照头，最后一条记录为之前所有记录的sha256摘要。快照中包含：
+// 1. 从快照区块回溯到创世块的所有区块头，导入时逐个校验区块ID和哈希链
+// 2. 快照区块和创世块的交易，以及当前状态引用的交易
+// 3. 状态机中除未确认交易和状态树以外的全部数据，状态树由导入方重建并与区块头中的状态树根比较，
+//    快照区块必须带有状态树根
+
+const (
+	// SnapshotVersion 快照格式版本
+	SnapshotVersion = 1
+
+	// SnapshotRecordHeader 快照头，value为json编码的SnapshotHeader
+	SnapshotRecordHeader = byte('H')
+	// SnapshotRecordLedger 账本数据，key和value与账本存储一致
+	SnapshotRecordLedger = byte('L')
+	// SnapshotRecordState 状态机数据，key和value与状态机存储一致
+	SnapshotRecordState = byte('S')
+	// SnapshotRecordEnd 快照结束，value为之前所有记录的sha256摘要
+	SnapshotRecordEnd = byte('E')
+
+	snapshotMagic         = "xuper-snapshot"
+	maxSnapshotRecordSize = 512 << 20
+)
+
+var (
+	// ErrSnapshotCorrupted is returned when snapshot format is invalid or digest mismatch
+	ErrSnapshotCorrupted = errors.New("snapshot corrupted")
+)
+
+// SnapshotHeader 快照头
+type SnapshotHeader struct {
+	Version     int    `json:"version"`
+	BCName      string `json:"bcname"`
+	Height      int64  `json:"height"`
+	Blockid     string `json:"blockid"`
+	RootBlockid string `json:"root_blockid"`
+}
+
+type snapshotWriter struct {
+	w    *bufio.Writer
+	hash hash.Hash
+}
+
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	return &snapshotWriter{
+		w:    bufio.NewWriter(w),
+		hash: sha256.New(),
+	}
+}
+
+func encodeSnapshotRecord(kind byte, key, value []byte) []byte {
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(key)+len(value))
+	buf = append(buf, kind)
+	buf = appendUvarint(buf, uint64(len(key)))
+	buf = append(buf, key...)
+	buf = appendUvarint(buf, uint64(len(value)))
+	buf = append(buf, value...)
+	return buf
+}
+
+func appendUvarint(buf []byte, x uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], x)
+	return append(buf, tmp[:n]...)
+}
+
+func (s *snapshotWriter) writeRecord(kind byte, key, value []byte) error {
+	record := encodeSnapshotRecord(kind, key, value)
+	s.hash.Write(record)
+	_, err := s.w.Write(record)
+	return err
+}
+
+func (s *snapshotWriter) finish() error {
+	_, err := s.w.Write(encodeSnapshotRecord(SnapshotRecordEnd, nil, s.hash.Sum(nil)))
+	if err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// SnapshotReader 按顺序读取快照记录，读完后校验摘要
+type SnapshotReader struct {
+	r      *bufio.Reader
+	hash   hash.Hash
+	Header *SnapshotHeader
+}
+
+// NewSnapshotReader 读取并解析快照头
+func NewSnapshotReader(r io.Reader) (*SnapshotReader, error) {
+	reader := &SnapshotReader{
+		r:    bufio.NewReader(r),
+		hash: sha256.New(),
+	}
+	kind, key, value, err := reader.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	if kind != SnapshotRecordHeader || string(key) != snapshotMagic {
+		return nil, fmt.Errorf("%s, bad snapshot header", ErrSnapshotCorrupted)
+	}
+	header := &SnapshotHeader{}
+	if err := json.Unmarshal(value, header); err != nil {
+		return nil, fmt.Errorf("%s, %v", ErrSnapshotCorrupted, err)
+	}
+	if header.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", header.Version)
+	}
+	reader.Header = header
+	return reader, nil
+}
+
+// Next 返回下一条账本或状态机记录，读到结束记录时校验摘要，摘要一致返回io.EOF
+func (s *SnapshotReader) Next() (byte, []byte, []byte, error) {
+	kind, key, value, err := s.readRecord()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	switch kind {
+	case SnapshotRecordLedger, SnapshotRecordState:
+		return kind, key, value, nil
+	case SnapshotRecordEnd:
+		if !bytes.Equal(value, s.hash.Sum(nil)) {
+			return 0, nil, nil, fmt.Errorf("%s, digest mismatch", ErrSnapshotCorrupted)
+		}
+		return 0, nil, nil, io.EOF
+	}
+	return 0, nil, nil, fmt.Errorf("%s, unknown record type %d", ErrSnapshotCorrupted, kind)
+}
+
+func (s *SnapshotReader) readRecord() (byte, []byte, []byte, error) {
+	kind, err := s.r.ReadByte()
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("%s, %v", ErrSnapshotCorrupted, err)
+	}
+	key, err := s.readBytes()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	value, err := s.readBytes()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	// 结束记录是摘要本身，不计入摘要
+	if kind != SnapshotRecordEnd {
+		s.hash.Write(encodeSnapshotRecord(kind, key, value))
+	}
+	return kind, key, value, nil
+}
+
+func (s *SnapshotReader) readBytes() ([]byte, error) {
+	size, err := binary.ReadUvarint(s.r)
+	if err != nil {
+		return nil, fmt.Errorf("%s, %v", ErrSnapshotCorrupted, err)
+	}
+	if size > maxSnapshotRecordSize {
+		return nil, fmt.Errorf("%s, record too large", ErrSnapshotCorrupted)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(s.r, buf); err != nil {
+		return nil, fmt.Errorf("%s, %v", ErrSnapshotCorrupted, err)
+	}
+	return buf, nil
+}
+
+// snapshotStatePrefixes 快照中包含的状态机数据前缀
+var snapshotStatePrefixes = []string{
+	pb.UTXOTablePrefix,
+	pb.ExtUtxoTablePrefix,
+	pb.ExtUtxoDelTablePrefix,
+	pb.MetaTablePrefix,
+	pb.ContractEventIndexPrefix,
+	pb.ContractCallStatPrefix,
+}
+
+// IsSnapshotStateKey 返回key是否为快照中包含的状态机数据，未确认交易、状态树和未知前缀的数据不包含在快照中
+func IsSnapshotStateKey(key []byte) bool {
+	if bytes.Equal(key, []byte(pb.MetaTablePrefix+statetree.RootKey)) {
+		return false
+	}
+	for _, prefix := range snapshotStatePrefixes {
+		if bytes.HasPrefix(key, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportSnapshot 导出当前区块的状态快照。导出期间暂停区块执行，未确认交易先回滚，导出完成后重新执行
+func (t *State) ExportSnapshot(w io.Writer) (*SnapshotHeader, error) {
+	t.utxo.Mutex.Lock()
+	defer t.utxo.Mutex.Unlock()
+
+	_, undoList, err := t.RollBackUnconfirmedTx()
+	if err != nil {
+		return nil, fmt.Errorf("rollback unconfirmed tx failed, err:%v", err)
+	}
+	defer func() {
+		go t.recoverUnconfirmedTx(undoList)
+	}()
+
+	ledger := t.sctx.Ledger
+	tipBlock, err := ledger.QueryBlockHeader(t.latestBlockid)
+	if err != nil {
+		return nil, err
+	}
+	// 导入方需要用状态树根校验导入的状态
+	if len(tipBlock.StateRoot) == 0 {
+		return nil, fmt.Errorf("block %x has no state root, can not export snapshot", tipBlock.Blockid)
+	}
+	header := &SnapshotHeader{
+		Version:     SnapshotVersion,
+		BCName:      t.sctx.BCName,
+		Height:      tipBlock.Height,
+		Blockid:     hex.EncodeToString(tipBlock.Blockid),
+		RootBlockid: hex.EncodeToString(ledger.GetMeta().GetRootBlockid()),
+	}
+	headerBuf, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	sw := newSnapshotWriter(w)
+	if err := sw.writeRecord(SnapshotRecordHeader, []byte(snapshotMagic), headerBuf); err != nil {
+		return nil, err
+	}
+
+	// 区块头，从快照区块回溯到创世块
+	ldb := ledger.GetBaseDB()
+	txids := make([][]byte, 0)
+	for blockid := tipBlock.Blockid; len(blockid) > 0; {
+		key := append([]byte(pb.BlocksTablePrefix), blockid...)
+		value, err := ldb.Get(key)
+		if err != nil {
+			return nil, fmt.Errorf("get block failed, blockid:%x, err:%v", blockid, err)
+		}
+		block := &pb.InternalBlock{}
+		if err := proto.Unmarshal(value, block); err != nil {
+			return nil, err
+		}
+		if err := sw.writeRecord(SnapshotRecordLedger, key, value); err != nil {
+			return nil, err
+		}
+		if block.Height == tipBlock.Height || len(block.PreHash) == 0 {
+			txids = append(txids, block.MerkleTree[:block.TxCount]...)
+		}
+		blockid = block.PreHash
+	}
+
+	// 交易，当前状态中每个key的版本对应的交易
+	for _, prefix := range []string{pb.ExtUtxoTablePrefix, pb.ExtUtxoDelTablePrefix} {
+		it := t.ldb.NewIteratorWithPrefix([]byte(prefix))
+		for it.Next() {
+			txids = append(txids, xmodel.GetTxidFromVersion(string(it.Value())))
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	exported := make(map[string]bool, len(txids))
+	for _, txid := range txids {
+		if len(txid) == 0 || exported[string(txid)] {
+			continue
+		}
+		exported[string(txid)] = true
+		key := append([]byte(pb.ConfirmedTablePrefix), txid...)
+		value, err := ldb.Get(key)
+		if err != nil {
+			return nil, fmt.Errorf("get tx failed, txid:%x, err:%v", txid, err)
+		}
+		if err := sw.writeRecord(SnapshotRecordLedger, key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	// 状态机数据，未确认交易和状态树不导出
+	it := t.ldb.NewIteratorWithPrefix(nil)
+	defer it.Release()
+	for it.Next() {
+		if !IsSnapshotStateKey(it.Key()) {
+			continue
+		}
+		if err := sw.writeRecord(SnapshotRecordState, it.Key(), it.Value()); err != nil {
+			return nil, err
+		}
+	}
+	if it.Error() != nil {
+		return nil, it.Error()
+	}
+	if err := sw.finish(); err != nil {
+		return nil, err
+	}
+	t.log.Info("export snapshot", "height", header.Height, "blockid", header.Blockid)
+	return header, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/meta"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/tx"
@@ -28,6 +29,7 @@ import (
 	governToken "github.com/xuperchain/xupercore/kernel/contract/proposal/govern_token"
 	"github.com/xuperchain/xupercore/kernel/contract/proposal/propose"
 	timerTask "github.com/xuperchain/xupercore/kernel/contract/proposal/timer"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
 	kledger "github.com/xuperchain/xupercore/kernel/ledger"
 	aclBase "github.com/xuperchain/xupercore/kernel/permission/acl/base"
 	"github.com/xuperchain/xupercore/lib/cache"
@@ -82,15 +84,18 @@ type State struct {
 	// 状态机运行环境上下文
 	sctx          *context.StateCtx
 	log           logs.Logger
-	utxo          *utxo.UtxoVM   //utxo表
-	xmodel        *xmodel.XModel //xmodel数据表和历史表
-	meta          *meta.Meta     //meta表
-	tx            *tx.Tx         //未确认交易表
+	utxo          *utxo.UtxoVM    //utxo表
+	xmodel        *xmodel.XModel  //xmodel数据表和历史表
+	meta          *meta.Meta      //meta表
+	tx            *tx.Tx          //未确认交易表
+	stateTree     *statetree.Tree //状态树
 	ldb           kvdb.Database
 	latestBlockid []byte
 
 	// 最新区块高度通知装置
 	heightNotifier *BlockHeightNotifier
+	// 是否正在裁剪账本，原子读写
+	pruning int32
 }
 
 func NewState(sctx *context.StateCtx) (*State, error) {
@@ -114,6 +119,7 @@ func NewState(sctx *context.StateCtx) (*State, error) {
 		FileHandlersCacheSize: ledger.FileHandlersCacheSize,
 		OtherPaths:            sctx.LedgerCfg.OtherPaths,
 		StorageType:           sctx.LedgerCfg.StorageType,
+		Options:               sctx.LedgerCfg.KVOptions,
 	}
 	obj.ldb, err = kvdb.CreateKVInstance(kvParam)
 	if err != nil {
@@ -155,6 +161,13 @@ func NewState(sctx *context.StateCtx) (*State, error) {
 		return nil, loadErr
 	}
 
+	obj.stateTree = statetree.NewTree(obj.ldb)
+	err = obj.loadStateTree()
+	if err != nil {
+		return nil, fmt.Errorf("create state failed because load state tree error:%s", err)
+	}
+	sctx.Ledger.SetStateCommitter(obj)
+
 	obj.heightNotifier = NewBlockHeightNotifier()
 
 	// go obj.collectDelayedTxs(defaultUndoDelayedTxsInterval)
@@ -170,6 +183,10 @@ func (t *State) SetContractMG(contractMgr contract.Manager) {
 	t.sctx.SetContractMG(contractMgr)
 }
 
+func (t *State) SetCrossQueryReader(reader contract.CrossQueryBlockReader) {
+	t.sctx.SetCrossQueryReader(reader)
+}
+
 func (t *State) SetGovernTokenMG(governTokenMgr governToken.GovManager) {
 	t.sctx.SetGovernTokenMG(governTokenMgr)
 }
@@ -205,7 +222,15 @@ func (t *State) SelectUtxosBySize(fromAddr string, needLock, excludeUnconfirmed
 }
 
 func (t *State) QueryContractStatData() (*protos.ContractStatData, error) {
-	return t.utxo.QueryContractStatData()
+	data, err := t.utxo.QueryContractStatData()
+	if err != nil {
+		return data, err
+	}
+	data.HotContracts, err = t.queryHotContracts(MaxHotContracts)
+	if err != nil {
+		return &protos.ContractStatData{}, err
+	}
+	return data, nil
 }
 
 func (t *State) GetAccountContracts(account string) ([]string, error) {
@@ -236,6 +261,7 @@ func (t *State) GetContractStatus(contractName string) (*protos.ContractStatus,
 	res.Timestamp = tx.GetReceivedTimestamp()
 	// query if contract is bannded
 	res.IsBanned, err = t.queryContractBannedStatus(contractName)
+	res.IsFrozen = t.isContractFrozen(contractName)
 	return res, nil
 }
 
@@ -366,15 +392,15 @@ func (t *State) CreateXMReader() kledger.XMReader {
 	return t.xmodel
 }
 func (t *State) CreateUtxoReader() contract.UtxoReader {
-	return t.utxo
+	return &utxoReader{UtxoVM: t.utxo, state: t}
 }
 
-// 根据指定blockid创建快照（Select方法不可用）
+// 根据指定blockid创建快照
 func (t *State) CreateSnapshot(blkId []byte) (kledger.XMReader, error) {
 	return t.xmodel.CreateSnapshot(blkId)
 }
 
-// 获取最新确认高度快照（Select方法不可用）
+// 获取最新确认高度快照
 func (t *State) GetTipSnapshot() (kledger.XMReader, error) {
 	return t.CreateSnapshot(t.latestBlockid)
 }
@@ -424,10 +450,16 @@ func (t *State) PlayForMiner(blockid []byte) error {
 			t.clearBalanceCache()
 		}
 	}()
-	for _, tx := range block.Transactions {
+	treeSession, err := t.prepareStateTree(block)
+	if err != nil {
+		t.log.Warn("prepare state tree failed when PlayForMiner", "err", err)
+		return err
+	}
+	// 互不冲突的交易并发执行
+	err = t.executeBlockTxs(block, batch, func(tx *pb.Transaction, batch kvdb.Batch) error {
 		txid := string(tx.Txid)
 		if tx.Coinbase || tx.Autogen {
-			err = t.doTxInternal(tx, batch, nil)
+			err := t.doTxInternal(tx, batch, nil)
 			if err != nil {
 				t.log.Warn("dotx failed when PlayForMiner", "txid", utils.F(tx.Txid), "err", err)
 				return err
@@ -435,13 +467,25 @@ func (t *State) PlayForMiner(blockid []byte) error {
 		} else {
 			batch.Delete(append([]byte(pb.UnconfirmedTablePrefix), []byte(txid)...))
 		}
-		err = t.payFee(tx, batch, block)
-		if err != nil {
-			t.log.Warn("payFee failed", "feeErr", err)
-			return err
+		feeErr := t.payFee(tx, batch, block)
+		if feeErr != nil {
+			t.log.Warn("payFee failed", "feeErr", feeErr)
+			return feeErr
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 	timer.Mark("do_tx")
+	err = t.indexBlockEvents(block, batch)
+	if err != nil {
+		return err
+	}
+	err = t.updateContractCallStat(block, batch, false)
+	if err != nil {
+		return err
+	}
 	// 更新不可逆区块高度
 	curIrreversibleBlockHeight := t.meta.GetIrreversibleBlockHeight()
 	curIrreversibleSlideWindow := t.meta.GetIrreversibleSlideWindow()
@@ -449,6 +493,7 @@ func (t *State) PlayForMiner(blockid []byte) error {
 	if updateErr != nil {
 		return updateErr
 	}
+	treeSession.Commit(batch)
 	//更新latestBlockid
 	err = t.updateLatestBlockid(block.Blockid, batch, "failed to save block")
 	timer.Mark("persist_tx")
@@ -462,6 +507,7 @@ func (t *State) PlayForMiner(blockid []byte) error {
 	newMeta := proto.Clone(t.meta.MetaTmp).(*pb.UtxoMeta)
 	t.meta.Meta = newMeta
 	t.meta.MutexMeta.Unlock()
+	t.reverifyUnconfirmedTx(block)
 	t.log.Info("play for miner", "height", block.Height, "blockId", utils.F(block.Blockid), "costs", timer.Print())
 	return nil
 }
@@ -501,8 +547,15 @@ func (t *State) PlayAndRepost(blockid []byte, needRepost bool, isRootTx bool) er
 	}
 	t.log.Debug("play and repost verify block tx succ")
 
-	for idx := 0; idx < len(block.Transactions); idx++ {
-		tx := block.Transactions[idx]
+	treeSession, err := t.prepareStateTree(block)
+	timer.Mark("prepare_state_tree")
+	if err != nil {
+		t.log.Warn("prepare state tree failed", "err", err)
+		return err
+	}
+
+	// 互不冲突的交易并发执行
+	err = t.executeBlockTxs(block, batch, func(tx *pb.Transaction, batch kvdb.Batch) error {
 		txid := string(tx.Txid)
 		if unconfirmToConfirm[txid] == false { // 本地没预执行过的Tx, 从block中收到的，需要Play执行
 			cacheFiller := &utxo.CacheFiller{}
@@ -518,8 +571,20 @@ func (t *State) PlayAndRepost(blockid []byte, needRepost bool, isRootTx bool) er
 			t.log.Warn("payFee failed", "feeErr", feeErr)
 			return feeErr
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 	timer.Mark("do_tx")
+	err = t.indexBlockEvents(block, batch)
+	if err != nil {
+		return err
+	}
+	err = t.updateContractCallStat(block, batch, false)
+	if err != nil {
+		return err
+	}
 	// 更新不可逆区块高度
 	curIrreversibleBlockHeight := t.meta.GetIrreversibleBlockHeight()
 	curIrreversibleSlideWindow := t.meta.GetIrreversibleSlideWindow()
@@ -527,6 +592,7 @@ func (t *State) PlayAndRepost(blockid []byte, needRepost bool, isRootTx bool) er
 	if updateErr != nil {
 		return updateErr
 	}
+	treeSession.Commit(batch)
 	//更新latestBlockid
 	persistErr := t.updateLatestBlockid(block.Blockid, batch, "failed to save block")
 	timer.Mark("persist_tx")
@@ -549,11 +615,30 @@ func (t *State) PlayAndRepost(blockid []byte, needRepost bool, isRootTx bool) er
 	t.meta.Meta = newMeta
 	t.meta.MutexMeta.Unlock()
 
+	t.reverifyUnconfirmedTx(block)
+
 	t.log.Info("play and repost", "height", block.Height, "blockId", utils.F(block.Blockid), "unconfirmed", len(unconfirmToConfirm), "costs", timer.Print())
 	return nil
 }
 
+// GetTimerTx 生成状态机下一个待执行区块的定时交易
 func (t *State) GetTimerTx(blockHeight int64) (*pb.Transaction, error) {
+	if !t.sctx.IsInit() {
+		return nil, nil
+	}
+	blk, err := t.pendingExecBlock()
+	if err != nil {
+		return nil, err
+	}
+	if blk.height != blockHeight {
+		return nil, fmt.Errorf("timer tx height %d mismatch with pending block height %d", blockHeight, blk.height)
+	}
+	return t.getTimerTx(blk)
+}
+
+// getTimerTx 生成指定区块的定时交易
+func (t *State) getTimerTx(blk *execBlock) (*pb.Transaction, error) {
+	blockHeight := blk.height
 	stateConfig := &contract.SandboxConfig{
 		XMReader:   t.CreateXMReader(),
 		UTXOReader: t.CreateUtxoReader(),
@@ -569,9 +654,12 @@ func (t *State) GetTimerTx(blockHeight int64) (*pb.Transaction, error) {
 	}
 
 	contextConfig := &contract.ContextConfig{
-		State:       sandBox,
-		Initiator:   "",
-		AuthRequire: nil,
+		State:        sandBox,
+		Initiator:    "",
+		AuthRequire:  nil,
+		BlockHeight:  blk.height,
+		BlockTime:    blk.time,
+		BlockPreHash: blk.preHash,
 	}
 
 	args := make(map[string][]byte)
@@ -663,6 +751,49 @@ func (t *State) RollBackUnconfirmedTx() (map[string]bool, []*pb.Transaction, err
 	return undoDone, undoList, nil
 }
 
+// reverifyUnconfirmedTx 执行区块后，下一个待执行区块的高度为合约执行相关功能的启用高度，
+// 未确认交易读取的余额被区块中的交易改变，或者有未确认交易读取了区块信息时，
+// 回滚全部未确认交易并按新的状态重新校验，避免预执行时的结果与打包区块的执行结果不一致。
+// 调用方需要持有utxo锁
+func (t *State) reverifyUnconfirmedTx(block *pb.InternalBlock) {
+	pendingHeight := block.Height + 1
+	if !t.isExecForkHeight(pendingHeight) && !t.readsBalanceChangedBy(block) && !t.hasBlockReads() {
+		return
+	}
+	_, undoList, err := t.RollBackUnconfirmedTx()
+	if err != nil {
+		t.log.Warn("rollback unconfirm tx for reverify fail", "height", pendingHeight, "err", err)
+		return
+	}
+	t.log.Info("reverify unconfirm tx", "height", pendingHeight, "tx_count", len(undoList))
+	go t.recoverUnconfirmedTx(undoList)
+}
+
+// hasBlockReads 返回未确认交易中是否有交易读取了区块信息，这些交易只能打包在预执行时的区块高度，
+// 执行新的区块后需要重新校验并淘汰
+func (t *State) hasBlockReads() bool {
+	found := false
+	t.tx.Mempool.Range(func(tx *pb.Transaction) bool {
+		height, err := sandbox.ParseBlockRead(tx)
+		if err != nil || height >= 0 {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// isExecForkHeight 返回height是否为创世配置中合约执行相关功能的启用高度
+func (t *State) isExecForkHeight(height int64) bool {
+	for _, forkHeight := range t.sctx.Ledger.GetGenesisBlock().GetConfig().GetExecForkHeights() {
+		if forkHeight == height {
+			return true
+		}
+	}
+	return false
+}
+
 // 同步账本和状态机
 func (t *State) Walk(blockid []byte, ledgerPrune bool) error {
 	t.log.Info("state walk", "ledger_block_id", hex.EncodeToString(blockid),
@@ -765,9 +896,19 @@ func (t *State) doTxSync(tx *pb.Transaction) error {
 		t.log.Warn("    fail to marshal tx", "pbErr", pbErr)
 		return pbErr
 	}
+	// 读取了余额的交易需要独占锁，避免校验余额时其他交易写了一半
+	balances, err := sandbox.ParseBalanceReads(tx)
+	if err != nil {
+		return err
+	}
 	recvTime := time.Now()
-	t.utxo.Mutex.RLock()
-	defer t.utxo.Mutex.RUnlock() //lock guard
+	if len(balances) > 0 {
+		t.utxo.Mutex.Lock()
+		defer t.utxo.Mutex.Unlock()
+	} else {
+		t.utxo.Mutex.RLock()
+		defer t.utxo.Mutex.RUnlock() //lock guard
+	}
 	spLockKeys := t.utxo.SpLock.ExtractLockKeys(tx)
 	succLockKeys, lockOK := t.utxo.SpLock.TryLock(spLockKeys)
 	defer t.utxo.SpLock.Unlock(succLockKeys)
@@ -791,6 +932,23 @@ func (t *State) doTxSync(tx *pb.Transaction) error {
 		t.log.Warn("The tx mempool if full", "txid", utils.F(tx.Txid))
 		return ErrMempoolIsFull
 	}
+	// 交易可能在状态机跨过功能启用高度前校验，持锁后按当前高度重新校验合约读写集
+	blk, err := t.pendingExecBlock()
+	if err != nil {
+		return err
+	}
+	if t.isExecForkHeight(blk.height) {
+		ok, err := t.verifyTxRWSets(tx, blk)
+		if err != nil || !ok {
+			t.log.Info("verify tx rwset at fork height failed, when DoTx", "txid", utils.F(tx.Txid), "err", err)
+			return ErrRWSetInvalid
+		}
+	}
+	// 预执行后可能有新的区块改变了交易读取的余额
+	if err := t.verifyBalanceReads(balances); err != nil {
+		t.log.Info("verify tx balance reads failed, when DoTx", "txid", utils.F(tx.Txid), "err", err)
+		return ErrRWSetInvalid
+	}
 	batch := t.ldb.NewBatch()
 	cacheFiller := &utxo.CacheFiller{}
 	beginTime := time.Now()
@@ -801,7 +959,7 @@ func (t *State) doTxSync(tx *pb.Transaction) error {
 		return doErr
 	}
 
-	err := t.tx.Mempool.PutTx(tx)
+	err = t.tx.Mempool.PutTx(tx)
 	if err != nil && err != txpkg.ErrTxExist {
 		// 如果交易已经存在 mempool 中，不需要返回 error。
 		// 即使上面已经判断了当前 mempool 中不存在此交易，但是 desc 类存证交易（没有交易输入输出），可能在多个协程调用 doTxSync 方法时，产生冲突。
@@ -914,6 +1072,75 @@ func (t *State) QueryBlock(blockid []byte) (kledger.BlockHandle, error) {
 	return NewBlockAgent(block), nil
 
 }
+
+// execBlock 合约执行所在的区块，区块时间使用父区块的时间，出块前即可确定，
+// 使预执行、交易池和区块中的合约执行结果一致
+type execBlock struct {
+	height  int64
+	time    int64
+	preHash []byte
+}
+
+// pendingExecBlock 返回状态机下一个待执行的区块
+func (t *State) pendingExecBlock() (*execBlock, error) {
+	parent, err := t.sctx.Ledger.QueryBlockHeader(t.latestBlockid)
+	if err != nil {
+		return nil, err
+	}
+	return &execBlock{
+		height:  parent.GetHeight() + 1,
+		time:    parent.GetTimestamp(),
+		preHash: parent.GetBlockid(),
+	}, nil
+}
+
+// execBlockOf 返回执行指定区块中的交易时合约所在的区块
+func (t *State) execBlockOf(block *pb.InternalBlock) (*execBlock, error) {
+	// 创世区块没有父区块
+	if len(block.GetPreHash()) == 0 {
+		return &execBlock{height: block.GetHeight()}, nil
+	}
+	parent, err := t.sctx.Ledger.QueryBlockHeader(block.GetPreHash())
+	if err != nil {
+		return nil, err
+	}
+	return &execBlock{
+		height:  block.GetHeight(),
+		time:    parent.GetTimestamp(),
+		preHash: block.GetPreHash(),
+	}, nil
+}
+
+// PendingBlock 返回状态机下一个待执行区块的高度，以及父区块的时间和id，用于预执行和交易池中的交易校验
+func (t *State) PendingBlock() (int64, int64, []byte, error) {
+	blk, err := t.pendingExecBlock()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return blk.height, blk.time, blk.preHash, nil
+}
+
+// ExecBlock 返回执行指定区块中的交易时合约所在区块的高度，以及父区块的时间和id，用于在该区块的状态上查询合约
+func (t *State) ExecBlock(blockid []byte) (int64, int64, []byte, error) {
+	block, err := t.sctx.Ledger.QueryBlockHeader(blockid)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	blk, err := t.execBlockOf(block)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return blk.height, blk.time, blk.preHash, nil
+}
+
+// QueryBlockByHeight 查询主干上指定高度的区块，只包含区块头
+func (t *State) QueryBlockByHeight(height int64) (kledger.BlockHandle, error) {
+	block, err := t.sctx.Ledger.QueryBlockHeaderByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return NewBlockAgent(block), nil
+}
 func (t *State) QueryTransaction(txid []byte) (*pb2.Transaction, error) {
 	ltx, err := t.sctx.Ledger.QueryTransaction(txid)
 	if err != nil {
@@ -1087,6 +1314,19 @@ func (t *State) procUndoBlkForWalk(undoBlocks []*pb.InternalBlock,
 			}
 		}
 
+		err = t.undoBlockEvents(undoBlk, batch)
+		if err != nil {
+			return fmt.Errorf("undo contract events fail.blockid:%s,err:%v", showBlkId, err)
+		}
+		err = t.updateContractCallStat(undoBlk, batch, true)
+		if err != nil {
+			return fmt.Errorf("undo contract call stat fail.blockid:%s,err:%v", showBlkId, err)
+		}
+		err = t.undoStateTree(undoBlk, batch)
+		if err != nil {
+			return fmt.Errorf("undo state tree fail.blockid:%s,err:%v", showBlkId, err)
+		}
+
 		// 账本裁剪时，无视区块不可逆原则
 		if ledgerPrune {
 			curIrreversibleBlockHeight := t.meta.GetIrreversibleBlockHeight()
@@ -1132,6 +1372,7 @@ func (t *State) updateLatestBlockid(newBlockid []byte, batch kvdb.Batch, reason
 	}
 	t.latestBlockid = newBlockid
 	t.heightNotifier.UpdateHeight(blk.GetHeight())
+	t.tryPruneLedger(blk.GetHeight())
 	return nil
 }
 
@@ -1152,7 +1393,7 @@ func (t *State) undoPayFee(tx *pb.Transaction, batch kvdb.Batch, block *pb.Inter
 	return nil
 }
 
-//批量执行区块
+// 批量执行区块
 func (t *State) procTodoBlkForWalk(todoBlocks []*pb.InternalBlock) (err error) {
 	var todoBlk *pb.InternalBlock
 	var showBlkId string
@@ -1165,6 +1406,15 @@ func (t *State) procTodoBlkForWalk(todoBlocks []*pb.InternalBlock) (err error) {
 		showBlkId = hex.EncodeToString(todoBlk.Blockid)
 
 		t.log.Info("start do block for walk", "blockid", showBlkId)
+		var treeSession *statetree.Session
+		treeSession, err = t.prepareStateTree(todoBlk)
+		if err != nil {
+			return fmt.Errorf("prepare state tree fail.blockid:%s,err:%v", showBlkId, err)
+		}
+		execBlk, err := t.execBlockOf(todoBlk)
+		if err != nil {
+			return fmt.Errorf("query parent block fail.blockid:%s,err:%v", showBlkId, err)
+		}
 		// 将batch赋值到合约机的上下文
 		batch := t.ldb.NewBatch()
 
@@ -1177,14 +1427,14 @@ func (t *State) procTodoBlkForWalk(todoBlocks []*pb.InternalBlock) (err error) {
 			// 校验定时交易合法性
 			if t.verifyAutogenTxValid(tx) && !tx.Coinbase {
 				// 校验auto tx
-				if ok, err := t.ImmediateVerifyAutoTx(todoBlk.Height, tx, false); !ok {
+				if ok, err := t.immediateVerifyAutoTx(execBlk, tx, false); !ok {
 					return fmt.Errorf("immediate verify auto tx error.txid:%s,err:%v", showTxId, err)
 				}
 			}
 
 			// 校验普通交易合法性
 			if !tx.Autogen && !tx.Coinbase {
-				if ok, err := t.ImmediateVerifyTx(tx, false); !ok {
+				if ok, err := t.immediateVerifyTx(tx, false, execBlk); !ok {
 					return fmt.Errorf("immediate verify tx error.txid:%s,err:%v", showTxId, err)
 				}
 			}
@@ -1205,6 +1455,16 @@ func (t *State) procTodoBlkForWalk(todoBlocks []*pb.InternalBlock) (err error) {
 			idx++
 		}
 
+		err = t.indexBlockEvents(todoBlk, batch)
+		if err != nil {
+			return fmt.Errorf("index contract events fail.blockid:%s,err:%v", showBlkId, err)
+		}
+		err = t.updateContractCallStat(todoBlk, batch, false)
+		if err != nil {
+			return fmt.Errorf("update contract call stat fail.blockid:%s,err:%v", showBlkId, err)
+		}
+		treeSession.Commit(batch)
+
 		t.log.Debug("Begin to Finalize", "blockid", showBlkId)
 
 		// 更新不可逆区块高度
@@ -1352,8 +1612,8 @@ func (t *State) collectDelayedTxs(interval time.Duration) {
 	}
 }
 
-//执行一个block的时候, 处理本地未确认交易
-//返回：被确认的txid集合、err
+// 执行一个block的时候, 处理本地未确认交易
+// 返回：被确认的txid集合、err
 // 目的：把 mempool（准确来说是未确认交易池）中与区块中交易有冲突的交易（双花等），状态机回滚这些交易同时从 mempool 删除。
 func (t *State) processUnconfirmTxs(block *pb.InternalBlock, batch kvdb.Batch, needRepost bool) ([]*pb.Transaction, map[string]bool, error) {
 	if !bytes.Equal(block.PreHash, t.latestBlockid) {
@@ -1426,6 +1686,15 @@ func (t *State) Close() {
 	t.ldb.Close()
 }
 
+// isContractFrozen 查询合约是否被所有者冻结
+func (t *State) isContractFrozen(contractName string) bool {
+	verData, err := t.xmodel.Get("contract", contract.ContractStatusKey(contractName))
+	if err != nil {
+		return false
+	}
+	return string(verData.GetPureData().GetValue()) == contract.ContractStatusFrozen
+}
+
 func (t *State) queryContractBannedStatus(contractName string) (bool, error) {
 	request := &protos.InvokeRequest{
 		ModuleName:   "wasm",
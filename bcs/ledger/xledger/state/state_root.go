@@ -0,0 +1,384 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	kledger "github.com/xuperchain/xupercore/kernel/ledger"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	"github.com/xuperchain/xupercore/lib/timer"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// 状态树在区块粒度上维护已确认的状态，未确认交易不进入状态树，保证各个节点在同一个区块上的树根一致。
+// 区块对状态的修改完全由交易的读写集和UTXO输入输出决定，因此执行区块之前就可以计算出区块的状态树根。
+
+var (
+	// ErrStateRootMismatch is returned when state root of block is different from local state
+	ErrStateRootMismatch = errors.New("state root mismatch")
+)
+
+// StateRoot 实现ledger.StateCommitter，计算在当前状态上执行block后的状态树根，
+// 当前状态不是block的父区块时返回nil
+func (t *State) StateRoot(block *pb.InternalBlock) ([]byte, error) {
+	t.utxo.Mutex.Lock()
+	defer t.utxo.Mutex.Unlock()
+	if !bytes.Equal(block.PreHash, t.latestBlockid) {
+		return nil, nil
+	}
+	session, err := t.newStateTreeSession()
+	if err != nil {
+		return nil, err
+	}
+	if err := applyBlockToTree(session, block); err != nil {
+		return nil, err
+	}
+	return session.Root(), nil
+}
+
+// GetStateRoot 返回当前区块的状态树根
+func (t *State) GetStateRoot() ([]byte, error) {
+	return t.stateTree.Root()
+}
+
+// StateProof 当前区块上XModel数据的状态证明
+type StateProof struct {
+	// 状态所在的区块
+	Blockid []byte
+	// key对应的数据，key不存在时为nil
+	Data  *kledger.VersionedData
+	Proof *statetree.Proof
+}
+
+// GetStateProof 生成当前区块上bucket/key的状态证明，key不存在时生成不存在证明
+func (t *State) GetStateProof(bucket string, key []byte) (*StateProof, error) {
+	t.utxo.Mutex.Lock()
+	defer t.utxo.Mutex.Unlock()
+	root, err := t.stateTree.Root()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("state tree not initialized")
+	}
+	treeKey := statetree.XModelKey(bucket, key)
+	proof, err := t.stateTree.Prove(root, treeKey)
+	if err != nil {
+		return nil, err
+	}
+	// 状态树只包含已确认的数据，从当前区块的快照中读取
+	reader, err := t.xmodel.CreateSnapshot(t.latestBlockid)
+	if err != nil {
+		return nil, err
+	}
+	verData, err := reader.Get(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	out := &StateProof{
+		Blockid: t.latestBlockid,
+		Proof:   proof,
+	}
+	// 值为空的key同样存在于状态树中，以树中是否有叶子为准
+	valueHash, err := t.stateTree.Get(root, treeKey)
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	if valueHash != nil {
+		out.Data = verData
+		value = append([]byte{}, verData.PureData.Value...)
+	}
+	if err := statetree.VerifyProof(root, treeKey, value, proof); err != nil {
+		return nil, fmt.Errorf("%s, blockid:%x, bucket:%s, key:%q", err, t.latestBlockid, bucket, key)
+	}
+	return out, nil
+}
+
+func (t *State) newStateTreeSession() (*statetree.Session, error) {
+	root, err := t.stateTree.Root()
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("state tree not initialized")
+	}
+	return t.stateTree.NewSession(root), nil
+}
+
+// prepareStateTree 计算执行block后的状态树，启用高度之后的区块必须带有一致的状态树根
+func (t *State) prepareStateTree(block *pb.InternalBlock) (*statetree.Session, error) {
+	session, err := t.newStateTreeSession()
+	if err != nil {
+		return nil, err
+	}
+	if err := applyBlockToTree(session, block); err != nil {
+		return nil, err
+	}
+	if len(block.StateRoot) == 0 && t.sctx.Ledger.StateRootActive(block.Height) {
+		return nil, fmt.Errorf("%s, blockid:%x, block state root missing", ErrStateRootMismatch, block.Blockid)
+	}
+	if len(block.StateRoot) > 0 && !bytes.Equal(block.StateRoot, session.Root()) {
+		return nil, fmt.Errorf("%s, blockid:%x, block state root:%x, local state root:%x",
+			ErrStateRootMismatch, block.Blockid, block.StateRoot, session.Root())
+	}
+	return session, nil
+}
+
+// undoStateTree 将回滚block后的状态树写入batch
+func (t *State) undoStateTree(block *pb.InternalBlock, batch kvdb.Batch) error {
+	session, err := t.newStateTreeSession()
+	if err != nil {
+		return err
+	}
+	for i := len(block.Transactions) - 1; i >= 0; i-- {
+		tx := block.Transactions[i]
+		if err := undoFeeInTree(session, tx, block); err != nil {
+			return err
+		}
+		if err := t.undoTxInTree(session, tx); err != nil {
+			return err
+		}
+	}
+	session.Commit(batch)
+	return nil
+}
+
+// applyBlockToTree 按doTxInternal和payFee的逻辑将区块的状态变更写入状态树
+func applyBlockToTree(session *statetree.Session, block *pb.InternalBlock) error {
+	for _, tx := range block.Transactions {
+		if err := applyTxToTree(session, tx); err != nil {
+			return err
+		}
+		if err := applyFeeToTree(session, tx, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyTxToTree(session *statetree.Session, tx *pb.Transaction) error {
+	for _, txOut := range tx.TxOutputsExt {
+		if txOut.Bucket == xmodel.TransientBucket {
+			continue
+		}
+		key := statetree.XModelKey(txOut.Bucket, txOut.Key)
+		var err error
+		if bytes.Equal(txOut.Value, []byte(xmodel.DelFlag)) {
+			err = session.Delete(key)
+		} else {
+			err = session.Put(key, txOut.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, txInput := range tx.TxInputs {
+		if err := session.Delete(statetree.UtxoKey(txInput.FromAddr, txInput.RefTxid, txInput.RefOffset)); err != nil {
+			return err
+		}
+	}
+	for offset, txOutput := range tx.TxOutputs {
+		if bytes.Equal(txOutput.ToAddr, []byte(FeePlaceholder)) {
+			continue
+		}
+		amount := big.NewInt(0).SetBytes(txOutput.Amount)
+		if amount.Sign() == 0 {
+			continue
+		}
+		err := putUtxoToTree(session, statetree.UtxoKey(txOutput.ToAddr, tx.Txid, int32(offset)),
+			amount, txOutput.FrozenHeight)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyFeeToTree(session *statetree.Session, tx *pb.Transaction, block *pb.InternalBlock) error {
+	for offset, txOutput := range tx.TxOutputs {
+		if !bytes.Equal(txOutput.ToAddr, []byte(FeePlaceholder)) {
+			continue
+		}
+		err := putUtxoToTree(session, statetree.UtxoKey(block.Proposer, tx.Txid, int32(offset)),
+			big.NewInt(0).SetBytes(txOutput.Amount), 0)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func undoFeeInTree(session *statetree.Session, tx *pb.Transaction, block *pb.InternalBlock) error {
+	for offset, txOutput := range tx.TxOutputs {
+		if !bytes.Equal(txOutput.ToAddr, []byte(FeePlaceholder)) {
+			continue
+		}
+		if err := session.Delete(statetree.UtxoKey(block.Proposer, tx.Txid, int32(offset))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// undoTxInTree 按undoTxInternal的逻辑回滚状态树，XModel的数据恢复为读集中的版本
+func (t *State) undoTxInTree(session *statetree.Session, tx *pb.Transaction) error {
+	inputs := make(map[string]*protos.TxInputExt, len(tx.TxInputsExt))
+	for _, txIn := range tx.TxInputsExt {
+		inputs[string(xmodel.MakeRawKey(txIn.Bucket, txIn.Key))] = txIn
+	}
+	for _, txOut := range tx.TxOutputsExt {
+		if txOut.Bucket == xmodel.TransientBucket {
+			continue
+		}
+		key := statetree.XModelKey(txOut.Bucket, txOut.Key)
+		txIn := inputs[string(xmodel.MakeRawKey(txOut.Bucket, txOut.Key))]
+		if txIn == nil || txIn.RefTxid == nil {
+			if err := session.Delete(key); err != nil {
+				return err
+			}
+			continue
+		}
+		verData, err := t.xmodel.GetFromLedger(txIn)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(verData.PureData.Value, []byte(xmodel.DelFlag)) {
+			err = session.Delete(key)
+		} else {
+			err = session.Put(key, verData.PureData.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, txInput := range tx.TxInputs {
+		err := putUtxoToTree(session, statetree.UtxoKey(txInput.FromAddr, txInput.RefTxid, txInput.RefOffset),
+			big.NewInt(0).SetBytes(txInput.Amount), txInput.FrozenHeight)
+		if err != nil {
+			return err
+		}
+	}
+	for offset, txOutput := range tx.TxOutputs {
+		if bytes.Equal(txOutput.ToAddr, []byte(FeePlaceholder)) {
+			continue
+		}
+		if big.NewInt(0).SetBytes(txOutput.Amount).Sign() == 0 {
+			continue
+		}
+		if err := session.Delete(statetree.UtxoKey(txOutput.ToAddr, tx.Txid, int32(offset))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putUtxoToTree(session *statetree.Session, key []byte, amount *big.Int, frozenHeight int64) error {
+	uItem := &utxo.UtxoItem{
+		Amount:       amount,
+		FrozenHeight: frozenHeight,
+	}
+	value, err := uItem.Dumps()
+	if err != nil {
+		return err
+	}
+	return session.Put(key, value)
+}
+
+// loadStateTree 加载状态树，升级前创建的账本没有状态树，根据当前状态重建
+func (t *State) loadStateTree() error {
+	root, err := t.stateTree.Root()
+	if err != nil || root != nil {
+		return err
+	}
+	batch := t.ldb.NewBatch()
+	if len(t.latestBlockid) == 0 {
+		batch.Put(append([]byte(pb.MetaTablePrefix), statetree.RootKey...), statetree.EmptyRoot())
+		return batch.Write()
+	}
+	if err := t.rebuildStateTree(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// rebuildStateTree 根据当前已确认的状态重建状态树，磁盘上的状态包含未确认交易，需要在状态树上回滚。
+// 当前区块带有状态树根时校验重建结果
+func (t *State) rebuildStateTree(batch kvdb.Batch) error {
+	xTimer := timer.NewXTimer()
+	t.log.Info("start rebuild state tree", "blockid", fmt.Sprintf("%x", t.latestBlockid))
+	session := t.stateTree.NewSession(statetree.EmptyRoot())
+
+	it := t.ldb.NewIteratorWithPrefix([]byte(pb.UTXOTablePrefix))
+	for it.Next() {
+		if err := session.Put(it.Key(), it.Value()); err != nil {
+			it.Release()
+			return err
+		}
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+	xTimer.Mark("utxo")
+
+	it = t.ldb.NewIteratorWithPrefix([]byte(pb.ExtUtxoTablePrefix))
+	for it.Next() {
+		rawKey := it.Key()[len(pb.ExtUtxoTablePrefix):]
+		pos := bytes.Index(rawKey, []byte(xmodel.BucketSeperator))
+		txid, offset, err := xmodel.ParseVersion(string(it.Value()))
+		if pos < 0 || err != nil {
+			it.Release()
+			return fmt.Errorf("bad xmodel data, key:%q, version:%s", it.Key(), it.Value())
+		}
+		verData, err := t.xmodel.GetFromLedger(&protos.TxInputExt{
+			Bucket:    string(rawKey[:pos]),
+			Key:       rawKey[pos+1:],
+			RefTxid:   txid,
+			RefOffset: int32(offset),
+		})
+		if err == nil {
+			err = session.Put(it.Key(), verData.PureData.Value)
+		}
+		if err != nil {
+			it.Release()
+			return err
+		}
+	}
+	err = it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+	xTimer.Mark("xmodel")
+
+	unconfirmTxs, _, err := t.tx.SortUnconfirmedTx(0)
+	if err != nil {
+		return err
+	}
+	for i := len(unconfirmTxs) - 1; i >= 0; i-- {
+		if err := t.undoTxInTree(session, unconfirmTxs[i]); err != nil {
+			return err
+		}
+	}
+	xTimer.Mark("unconfirmed")
+
+	block, err := t.sctx.Ledger.QueryBlockHeader(t.latestBlockid)
+	if err != nil {
+		return err
+	}
+	if len(block.StateRoot) > 0 && !bytes.Equal(block.StateRoot, session.Root()) {
+		return fmt.Errorf("%s, blockid:%x, block state root:%x, local state root:%x",
+			ErrStateRootMismatch, block.Blockid, block.StateRoot, session.Root())
+	}
+	session.Commit(batch)
+	t.log.Info("finish rebuild state tree", "root", fmt.Sprintf("%x", session.Root()), "costs", xTimer.Print())
+	return nil
+}
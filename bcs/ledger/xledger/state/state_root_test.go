@@ -0,0 +1,169 @@
+package state
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func newStateRootState(t *testing.T) (*State, func()) {
+	workspace, err := ioutil.TempDir("", "state-root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldb, err := kvdb.CreateKVInstance(&kvdb.KVParameter{
+		DBPath:                filepath.Join(workspace, "state"),
+		KVEngineType:          "leveldb",
+		MemCacheSize:          16,
+		FileHandlersCacheSize: 16,
+		StorageType:           "single",
+	})
+	if err != nil {
+		os.RemoveAll(workspace)
+		t.Fatal(err)
+	}
+	genesis, err := ledger.NewGenesisBlock([]byte(`{"state_root": {"enable": true, "height": 100}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := &State{
+		sctx: &context.StateCtx{
+			Ledger: &ledger.Ledger{GenesisBlock: genesis},
+		},
+		ldb:       ldb,
+		stateTree: statetree.NewTree(ldb),
+	}
+	if err := st.loadStateTree(); err != nil {
+		t.Fatal(err)
+	}
+	return st, func() {
+		ldb.Close()
+		os.RemoveAll(workspace)
+	}
+}
+
+func commitStateTree(t *testing.T, st *State, session *statetree.Session) {
+	batch := st.ldb.NewBatch()
+	session.Commit(batch)
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStateRoot(t *testing.T) {
+	st, clean := newStateRootState(t)
+	defer clean()
+
+	miner := []byte("miner")
+	tx1 := &pb.Transaction{
+		Txid: []byte("tx1"),
+		TxOutputs: []*protos.TxOutput{
+			{ToAddr: []byte(BobAddress), Amount: big.NewInt(100).Bytes(), FrozenHeight: 10},
+			{ToAddr: []byte(AliceAddress), Amount: big.NewInt(0).Bytes()},
+			{ToAddr: []byte(FeePlaceholder), Amount: big.NewInt(5).Bytes()},
+		},
+		TxOutputsExt: []*protos.TxOutputExt{
+			{Bucket: "bucket", Key: []byte("k1"), Value: []byte("v1")},
+			{Bucket: xmodel.TransientBucket, Key: []byte("k1"), Value: []byte("transient")},
+		},
+	}
+	block1 := &pb.InternalBlock{Blockid: []byte("block1"), Proposer: miner, Transactions: []*pb.Transaction{tx1}}
+	session, err := st.prepareStateTree(block1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root1 := session.Root()
+	commitStateTree(t, st, session)
+
+	// 树根只取决于执行区块后的状态
+	expect := st.stateTree.NewSession(statetree.EmptyRoot())
+	expect.Put(statetree.XModelKey("bucket", []byte("k1")), []byte("v1"))
+	putUtxoToTree(expect, statetree.UtxoKey([]byte(BobAddress), tx1.Txid, 0), big.NewInt(100), 10)
+	putUtxoToTree(expect, statetree.UtxoKey(miner, tx1.Txid, 2), big.NewInt(5), 0)
+	if !bytes.Equal(root1, expect.Root()) {
+		t.Fatalf("unexpected state root %x, expect %x", root1, expect.Root())
+	}
+	root, err := st.GetStateRoot()
+	if err != nil || !bytes.Equal(root, root1) {
+		t.Fatalf("unexpected committed state root %x, err:%v", root, err)
+	}
+
+	tx2 := &pb.Transaction{
+		Txid: []byte("tx2"),
+		TxInputs: []*protos.TxInput{
+			{FromAddr: []byte(BobAddress), RefTxid: tx1.Txid, RefOffset: 0, Amount: big.NewInt(100).Bytes(), FrozenHeight: 10},
+		},
+		TxOutputs: []*protos.TxOutput{
+			{ToAddr: []byte(AliceAddress), Amount: big.NewInt(100).Bytes()},
+		},
+		TxOutputsExt: []*protos.TxOutputExt{
+			{Bucket: "bucket", Key: []byte("k2"), Value: []byte("v2")},
+		},
+	}
+	block2 := &pb.InternalBlock{Blockid: []byte("block2"), Proposer: miner, Transactions: []*pb.Transaction{tx2},
+		StateRoot: root1}
+	_, err = st.prepareStateTree(block2)
+	if err == nil || !strings.Contains(err.Error(), ErrStateRootMismatch.Error()) {
+		t.Fatalf("expect state root mismatch, err:%v", err)
+	}
+	// 启用高度之后的区块必须带有状态树根
+	block2.StateRoot = nil
+	block2.Height = 100
+	_, err = st.prepareStateTree(block2)
+	if err == nil || !strings.Contains(err.Error(), ErrStateRootMismatch.Error()) {
+		t.Fatalf("expect state root missing, err:%v", err)
+	}
+	block2.Height = 99
+	session, err = st.prepareStateTree(block2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitStateTree(t, st, session)
+	valueHash, err := st.stateTree.Get(session.Root(), statetree.UtxoKey([]byte(BobAddress), tx1.Txid, 0))
+	if err != nil || valueHash != nil {
+		t.Fatalf("spent utxo still in state tree, err:%v", err)
+	}
+
+	// 回滚区块后树根恢复
+	batch := st.ldb.NewBatch()
+	if err := st.undoStateTree(block2, batch); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	root, err = st.GetStateRoot()
+	if err != nil || !bytes.Equal(root, root1) {
+		t.Fatalf("state root not restored after undo, root:%x, err:%v", root, err)
+	}
+
+	// 删除XModel数据
+	tx3 := &pb.Transaction{
+		Txid: []byte("tx3"),
+		TxOutputsExt: []*protos.TxOutputExt{
+			{Bucket: "bucket", Key: []byte("k1"), Value: []byte(xmodel.DelFlag)},
+		},
+	}
+	block3 := &pb.InternalBlock{Blockid: []byte("block3"), Proposer: miner, Transactions: []*pb.Transaction{tx3}}
+	session, err = st.prepareStateTree(block3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Delete(statetree.XModelKey("bucket", []byte("k1")))
+	if !bytes.Equal(session.Root(), expect.Root()) {
+		t.Fatalf("unexpected state root after delete %x, expect %x", session.Root(), expect.Root())
+	}
+}
@@ -0,0 +1,21 @@
+package statetree
+
+import (
+	"fmt"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+)
+
+// 状态树中的key与状态机存储中的key一致：XModel为 ZU + bucket/key，value为数据本身；
+// UTXO为 U + addr_txid_offset，value为json编码的UtxoItem
+
+// XModelKey XModel数据在状态树中的key
+func XModelKey(bucket string, key []byte) []byte {
+	k := append([]byte(pb.ExtUtxoTablePrefix+bucket), '/')
+	return append(k, key...)
+}
+
+// UtxoKey UTXO在状态树中的key
+func UtxoKey(addr, txid []byte, offset int32) []byte {
+	return []byte(fmt.Sprintf("%s%s_%x_%d", pb.UTXOTablePrefix, addr, txid, offset))
+}
@@ -0,0 +1,103 @@
+package statetree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const (
+	// HashSize 节点哈希长度
+	HashSize = sha256.Size
+
+	leafNodeType     = byte(0)
+	internalNodeType = byte(1)
+	nodeSize         = 1 + 2*HashSize
+)
+
+var (
+	// ErrNodeCorrupted is returned when a tree node can not be decoded
+	ErrNodeCorrupted = errors.New("state tree node corrupted")
+
+	emptyHash = make([]byte, HashSize)
+)
+
+// EmptyRoot 空树的根，全0
+func EmptyRoot() []byte {
+	return make([]byte, HashSize)
+}
+
+// IsEmpty 是否为空子树
+func IsEmpty(hash []byte) bool {
+	return bytes.Equal(hash, emptyHash)
+}
+
+// KeyHash 状态key在树中的路径
+func KeyHash(key []byte) []byte {
+	h := sha256.Sum256(key)
+	return h[:]
+}
+
+// ValueHash 叶子节点保存的value摘要
+func ValueHash(value []byte) []byte {
+	h := sha256.Sum256(value)
+	return h[:]
+}
+
+// LeafHash 叶子节点哈希 sha256(0x00 | keyHash | valueHash)
+func LeafHash(keyHash, valueHash []byte) []byte {
+	h := sha256.Sum256(encodeNode(leafNodeType, keyHash, valueHash))
+	return h[:]
+}
+
+// InternalHash 中间节点哈希 sha256(0x01 | left | right)
+func InternalHash(left, right []byte) []byte {
+	h := sha256.Sum256(encodeNode(internalNodeType, left, right))
+	return h[:]
+}
+
+// bitAt 返回keyHash从高位开始第depth位
+func bitAt(keyHash []byte, depth int) int {
+	return int(keyHash[depth/8]>>(7-uint(depth%8))) & 1
+}
+
+// node 树节点。叶子节点保存keyHash和valueHash，中间节点保存左右子树的哈希，
+// 只包含一个叶子的子树直接用该叶子表示，因此树的形状只取决于当前的key集合
+type node struct {
+	leaf bool
+	// 叶子节点为keyHash，中间节点为左子树
+	left []byte
+	// 叶子节点为valueHash，中间节点为右子树
+	right []byte
+}
+
+func encodeNode(nodeType byte, left, right []byte) []byte {
+	buf := make([]byte, 0, nodeSize)
+	buf = append(buf, nodeType)
+	buf = append(buf, left...)
+	return append(buf, right...)
+}
+
+func (n *node) encode() []byte {
+	if n.leaf {
+		return encodeNode(leafNodeType, n.left, n.right)
+	}
+	return encodeNode(internalNodeType, n.left, n.right)
+}
+
+func (n *node) hash() []byte {
+	h := sha256.Sum256(n.encode())
+	return h[:]
+}
+
+func decodeNode(buf []byte) (*node, error) {
+	if len(buf) != nodeSize || (buf[0] != leafNodeType && buf[0] != internalNodeType) {
+		return nil, fmt.Errorf("%s, size:%d", ErrNodeCorrupted, len(buf))
+	}
+	return &node{
+		leaf:  buf[0] == leafNodeType,
+		left:  buf[1 : 1+HashSize],
+		right: buf[1+HashSize:],
+	}, nil
+}
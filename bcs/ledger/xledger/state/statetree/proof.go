@@ -0,0 +1,99 @@
+package statetree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidProof is returned when a state proof does not match the state root
+	ErrInvalidProof = errors.New("invalid state proof")
+)
+
+// Proof 状态证明。Siblings为从根到路径终点的兄弟节点，路径终止于其他key的叶子时，
+// LeafKeyHash和LeafValueHash为该叶子的内容，用于证明key不存在
+type Proof struct {
+	Siblings      [][]byte
+	LeafKeyHash   []byte
+	LeafValueHash []byte
+}
+
+// Prove 生成key在root对应的状态中的证明，key不存在时生成不存在证明
+func (t *Tree) Prove(root, key []byte) (*Proof, error) {
+	keyHash := KeyHash(key)
+	proof := &Proof{}
+	hash := root
+	for depth := 0; !IsEmpty(hash); depth++ {
+		if depth >= maxDepth {
+			return nil, fmt.Errorf("%s, tree too deep", ErrNodeCorrupted)
+		}
+		n, err := t.getNode(hash)
+		if err != nil {
+			return nil, err
+		}
+		if n.leaf {
+			if !bytes.Equal(n.left, keyHash) {
+				proof.LeafKeyHash = n.left
+				proof.LeafValueHash = n.right
+			}
+			break
+		}
+		if bitAt(keyHash, depth) == 0 {
+			proof.Siblings = append(proof.Siblings, n.right)
+			hash = n.left
+		} else {
+			proof.Siblings = append(proof.Siblings, n.left)
+			hash = n.right
+		}
+	}
+	return proof, nil
+}
+
+// VerifyProof 校验root对应的状态中key的值为value，value为nil表示校验key不存在
+func VerifyProof(root, key, value []byte, proof *Proof) error {
+	if proof == nil || len(proof.Siblings) > maxDepth {
+		return fmt.Errorf("%s, bad proof", ErrInvalidProof)
+	}
+	keyHash := KeyHash(key)
+	depth := len(proof.Siblings)
+
+	var hash []byte
+	switch {
+	case value != nil:
+		if proof.LeafKeyHash != nil {
+			return fmt.Errorf("%s, unexpected leaf in existence proof", ErrInvalidProof)
+		}
+		hash = LeafHash(keyHash, ValueHash(value))
+	case proof.LeafKeyHash == nil:
+		hash = emptyHash
+	default:
+		// 其他key的叶子必须与key有相同的路径前缀
+		if len(proof.LeafKeyHash) != HashSize || len(proof.LeafValueHash) != HashSize ||
+			bytes.Equal(proof.LeafKeyHash, keyHash) {
+			return fmt.Errorf("%s, bad leaf in non-existence proof", ErrInvalidProof)
+		}
+		for i := 0; i < depth; i++ {
+			if bitAt(proof.LeafKeyHash, i) != bitAt(keyHash, i) {
+				return fmt.Errorf("%s, leaf not on the path of key", ErrInvalidProof)
+			}
+		}
+		hash = LeafHash(proof.LeafKeyHash, proof.LeafValueHash)
+	}
+
+	for i := depth - 1; i >= 0; i-- {
+		sibling := proof.Siblings[i]
+		if len(sibling) != HashSize {
+			return fmt.Errorf("%s, bad sibling size %d", ErrInvalidProof, len(sibling))
+		}
+		if bitAt(keyHash, i) == 0 {
+			hash = InternalHash(hash, sibling)
+		} else {
+			hash = InternalHash(sibling, hash)
+		}
+	}
+	if !bytes.Equal(hash, root) {
+		return fmt.Errorf("%s, root mismatch", ErrInvalidProof)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// 状态树：覆盖XModel和UTXO的稀疏默克尔树，树根写入区块头，用于轻节点和跨链的状态证明
+package statetree
+
+import (
+	"bytes"
+	"fmt"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+)
+
+const (
+	// RootKey 状态树根在状态机meta表中的key
+	RootKey = "StateRoot"
+
+	maxDepth = HashSize * 8
+)
+
+// Tree 状态树，节点以哈希为key保存在状态机数据库中，只保留最新状态的节点
+type Tree struct {
+	nodeTable kvdb.Database
+	metaTable kvdb.Database
+}
+
+// NewTree 创建状态树
+func NewTree(db kvdb.Database) *Tree {
+	return &Tree{
+		nodeTable: kvdb.NewTable(db, pb.StateTreeNodePrefix),
+		metaTable: kvdb.NewTable(db, pb.MetaTablePrefix),
+	}
+}
+
+// Root 返回已持久化的状态树根，状态树尚未建立时返回nil
+func (t *Tree) Root() ([]byte, error) {
+	root, err := t.metaTable.Get([]byte(RootKey))
+	if err != nil {
+		if kvdb.ErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return root, nil
+}
+
+func (t *Tree) getNode(hash []byte) (*node, error) {
+	buf, err := t.nodeTable.Get(hash)
+	if err != nil {
+		return nil, fmt.Errorf("get state tree node failed, hash:%x, err:%v", hash, err)
+	}
+	return decodeNode(buf)
+}
+
+// Get 查询root对应的状态中key的valueHash，key不存在时返回nil
+func (t *Tree) Get(root, key []byte) ([]byte, error) {
+	keyHash := KeyHash(key)
+	hash := root
+	for depth := 0; depth < maxDepth && !IsEmpty(hash); depth++ {
+		n, err := t.getNode(hash)
+		if err != nil {
+			return nil, err
+		}
+		if n.leaf {
+			if bytes.Equal(n.left, keyHash) {
+				return n.right, nil
+			}
+			return nil, nil
+		}
+		if bitAt(keyHash, depth) == 0 {
+			hash = n.left
+		} else {
+			hash = n.right
+		}
+	}
+	return nil, nil
+}
+
+// NewSession 在root的基础上开始一组更新，Commit之前所有变更只保存在内存中
+func (t *Tree) NewSession(root []byte) *Session {
+	return &Session{
+		tree:    t,
+		root:    root,
+		nodes:   make(map[string][]byte),
+		deleted: make(map[string]bool),
+	}
+}
+
+// Session 状态树的一组更新，不是并发安全的
+type Session struct {
+	tree *Tree
+	root []byte
+	// 新增的节点
+	nodes map[string][]byte
+	// 需要删除的节点
+	deleted map[string]bool
+}
+
+// Root 当前的树根
+func (s *Session) Root() []byte {
+	return s.root
+}
+
+// Put 设置key对应的value
+func (s *Session) Put(key, value []byte) error {
+	return s.update(KeyHash(key), ValueHash(value))
+}
+
+// Delete 删除key
+func (s *Session) Delete(key []byte) error {
+	return s.update(KeyHash(key), nil)
+}
+
+// Commit 将节点变更和新的树根写入batch
+func (s *Session) Commit(batch kvdb.Batch) {
+	for hash := range s.deleted {
+		batch.Delete(append([]byte(pb.StateTreeNodePrefix), hash...))
+	}
+	for hash, buf := range s.nodes {
+		batch.Put(append([]byte(pb.StateTreeNodePrefix), hash...), buf)
+	}
+	batch.Put(append([]byte(pb.MetaTablePrefix), RootKey...), s.root)
+}
+
+func (s *Session) update(keyHash, valueHash []byte) error {
+	root, err := s.updateNode(s.root, 0, keyHash, valueHash)
+	if err != nil {
+		return err
+	}
+	s.root = root
+	return nil
+}
+
+func (s *Session) getNode(hash []byte) (*node, error) {
+	if buf, ok := s.nodes[string(hash)]; ok {
+		return decodeNode(buf)
+	}
+	return s.tree.getNode(hash)
+}
+
+func (s *Session) putNode(n *node) []byte {
+	hash := n.hash()
+	s.nodes[string(hash)] = n.encode()
+	delete(s.deleted, string(hash))
+	return hash
+}
+
+func (s *Session) deleteNode(hash []byte) {
+	delete(s.nodes, string(hash))
+	s.deleted[string(hash)] = true
+}
+
+// updateNode 更新以hash为根、深度为depth的子树，valueHash为nil表示删除，返回新的子树根
+func (s *Session) updateNode(hash []byte, depth int, keyHash, valueHash []byte) ([]byte, error) {
+	if IsEmpty(hash) {
+		if valueHash == nil {
+			return hash, nil
+		}
+		return s.putNode(&node{leaf: true, left: keyHash, right: valueHash}), nil
+	}
+	n, err := s.getNode(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.leaf {
+		if bytes.Equal(n.left, keyHash) {
+			if valueHash != nil && bytes.Equal(n.right, valueHash) {
+				return hash, nil
+			}
+			s.deleteNode(hash)
+			if valueHash == nil {
+				return EmptyRoot(), nil
+			}
+			return s.putNode(&node{leaf: true, left: keyHash, right: valueHash}), nil
+		}
+		if valueHash == nil {
+			return hash, nil
+		}
+		// 两个叶子从第一个不同的位开始分叉
+		leaf := s.putNode(&node{leaf: true, left: keyHash, right: valueHash})
+		return s.splitLeaves(depth, hash, n.left, leaf, keyHash)
+	}
+
+	if depth >= maxDepth {
+		return nil, fmt.Errorf("%s, tree too deep", ErrNodeCorrupted)
+	}
+	left, right := n.left, n.right
+	if bitAt(keyHash, depth) == 0 {
+		left, err = s.updateNode(left, depth+1, keyHash, valueHash)
+	} else {
+		right, err = s.updateNode(right, depth+1, keyHash, valueHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(left, n.left) && bytes.Equal(right, n.right) {
+		return hash, nil
+	}
+	s.deleteNode(hash)
+
+	// 只剩一个叶子的子树收缩为该叶子
+	if IsEmpty(left) || IsEmpty(right) {
+		child := left
+		if IsEmpty(left) {
+			child = right
+		}
+		if IsEmpty(child) {
+			return child, nil
+		}
+		childNode, err := s.getNode(child)
+		if err != nil {
+			return nil, err
+		}
+		if childNode.leaf {
+			return child, nil
+		}
+	}
+	return s.putNode(&node{left: left, right: right}), nil
+}
+
+func (s *Session) splitLeaves(depth int, leafA, keyA, leafB, keyB []byte) ([]byte, error) {
+	if depth >= maxDepth {
+		return nil, fmt.Errorf("%s, duplicated key hash", ErrNodeCorrupted)
+	}
+	bitA, bitB := bitAt(keyA, depth), bitAt(keyB, depth)
+	if bitA != bitB {
+		if bitA == 0 {
+			return s.putNode(&node{left: leafA, right: leafB}), nil
+		}
+		return s.putNode(&node{left: leafB, right: leafA}), nil
+	}
+	child, err := s.splitLeaves(depth+1, leafA, keyA, leafB, keyB)
+	if err != nil {
+		return nil, err
+	}
+	if bitA == 0 {
+		return s.putNode(&node{left: child, right: EmptyRoot()}), nil
+	}
+	return s.putNode(&node{left: EmptyRoot(), right: child}), nil
+}
@@ -0,0 +1,227 @@
+package statetree
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+)
+
+func newTestTree(t *testing.T) (*Tree, kvdb.Database, func()) {
+	workspace, err := ioutil.TempDir("", "state-tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldb, err := kvdb.CreateKVInstance(&kvdb.KVParameter{
+		DBPath:                filepath.Join(workspace, "state"),
+		KVEngineType:          "leveldb",
+		MemCacheSize:          16,
+		FileHandlersCacheSize: 16,
+		StorageType:           "single",
+	})
+	if err != nil {
+		os.RemoveAll(workspace)
+		t.Fatal(err)
+	}
+	return NewTree(ldb), ldb, func() {
+		ldb.Close()
+		os.RemoveAll(workspace)
+	}
+}
+
+func commit(t *testing.T, ldb kvdb.Database, s *Session) {
+	batch := ldb.NewBatch()
+	s.Commit(batch)
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func countNodes(t *testing.T, ldb kvdb.Database) int {
+	it := ldb.NewIteratorWithPrefix([]byte(pb.StateTreeNodePrefix))
+	defer it.Release()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Error() != nil {
+		t.Fatal(it.Error())
+	}
+	return count
+}
+
+func TestTreeUpdate(t *testing.T) {
+	tree, ldb, clean := newTestTree(t)
+	defer clean()
+
+	root, err := tree.Root()
+	if err != nil || root != nil {
+		t.Fatalf("unexpected root before init, root:%x, err:%v", root, err)
+	}
+
+	kvs := make(map[string]string)
+	keys := make([]string, 0)
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("key%d", i)
+		kvs[key] = fmt.Sprintf("value%d", i)
+		keys = append(keys, key)
+	}
+
+	// 插入顺序不影响树根
+	s := tree.NewSession(EmptyRoot())
+	for _, key := range keys {
+		if err := s.Put([]byte(key), []byte(kvs[key])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := s.Root()
+	rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	s2 := tree.NewSession(EmptyRoot())
+	for _, key := range keys {
+		if err := s2.Put([]byte("tmp"+key), []byte("tmp")); err != nil {
+			t.Fatal(err)
+		}
+		if err := s2.Put([]byte(key), []byte(kvs[key])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, key := range keys {
+		if err := s2.Delete([]byte("tmp" + key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(s2.Root(), expect) {
+		t.Fatalf("root depends on update order, %x != %x", s2.Root(), expect)
+	}
+	commit(t, ldb, s2)
+	root, err = tree.Root()
+	if err != nil || !bytes.Equal(root, expect) {
+		t.Fatalf("unexpected committed root, root:%x, err:%v", root, err)
+	}
+	// 中间插入又删除的key不残留节点
+	if n := countNodes(t, ldb); n != len(s.nodes) {
+		t.Fatalf("unexpected node count %d", n)
+	}
+
+	for key, value := range kvs {
+		valueHash, err := tree.Get(root, []byte(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(valueHash, ValueHash([]byte(value))) {
+			t.Fatalf("unexpected value of %s", key)
+		}
+	}
+	valueHash, err := tree.Get(root, []byte("not-exist"))
+	if err != nil || valueHash != nil {
+		t.Fatalf("unexpected value of missing key, err:%v", err)
+	}
+
+	// 修改后再改回原值，树根恢复
+	s3 := tree.NewSession(root)
+	if err := s3.Put([]byte("key1"), []byte("changed")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s3.Delete([]byte("key2")); err != nil {
+		t.Fatal(err)
+	}
+	commit(t, ldb, s3)
+	s4 := tree.NewSession(s3.Root())
+	if err := s4.Put([]byte("key1"), []byte(kvs["key1"])); err != nil {
+		t.Fatal(err)
+	}
+	if err := s4.Put([]byte("key2"), []byte(kvs["key2"])); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s4.Root(), root) {
+		t.Fatalf("root not restored")
+	}
+	commit(t, ldb, s4)
+
+	// 删除全部key后为空树，并且不残留节点
+	s5 := tree.NewSession(root)
+	for key := range kvs {
+		if err := s5.Delete([]byte(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !IsEmpty(s5.Root()) {
+		t.Fatalf("tree not empty after delete all keys")
+	}
+	commit(t, ldb, s5)
+	if n := countNodes(t, ldb); n != 0 {
+		t.Fatalf("%d nodes left after delete all keys", n)
+	}
+}
+
+func TestProof(t *testing.T) {
+	tree, ldb, clean := newTestTree(t)
+	defer clean()
+
+	// 空树的不存在证明
+	proof, err := tree.Prove(EmptyRoot(), []byte("key0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyProof(EmptyRoot(), []byte("key0"), nil, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	s := tree.NewSession(EmptyRoot())
+	for i := 0; i < 100; i++ {
+		if err := s.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit(t, ldb, s)
+	root := s.Root()
+
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		value := []byte(fmt.Sprintf("value%d", i))
+		proof, err := tree.Prove(root, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyProof(root, key, value, proof); err != nil {
+			t.Fatalf("verify proof of %s failed, err:%v", key, err)
+		}
+		if VerifyProof(root, key, []byte("fake"), proof) == nil {
+			t.Fatalf("verify proof of %s with wrong value should fail", key)
+		}
+		if VerifyProof(root, key, nil, proof) == nil {
+			t.Fatalf("non-existence proof of %s should fail", key)
+		}
+	}
+
+	for i := 100; i < 200; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		proof, err := tree.Prove(root, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyProof(root, key, nil, proof); err != nil {
+			t.Fatalf("verify non-existence proof of %s failed, err:%v", key, err)
+		}
+		if VerifyProof(root, key, []byte("value"), proof) == nil {
+			t.Fatalf("existence proof of %s should fail", key)
+		}
+	}
+
+	// 篡改兄弟节点
+	proof, err = tree.Prove(root, []byte("key1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof.Siblings[0] = EmptyRoot()
+	if VerifyProof(root, []byte("key1"), []byte("value1"), proof) == nil {
+		t.Fatal("verify tampered proof should fail")
+	}
+}
@@ -30,17 +30,28 @@ import (
 
 // ImmediateVerifyTx verify tx Immediately
 // Transaction verification workflow:
-//   1. verify transaction ID is the same with data hash
-//   2. verify all signatures of initiator and auth requires
-//   3. verify the utxo input, there are three kinds of input validation
-//		1). PKI technology for transferring from address
-//		2). Account ACL for transferring from account
-//		3). Contract logic transferring from contract
-//   4. verify the contract requests' permission
-//   5. verify the permission of contract RWSet (WriteSet could including unauthorized data change)
-//   6. run contract requests and verify if the RWSet result is the same with preExed RWSet (heavy
-//      operation, keep it at last)
+//  1. verify transaction ID is the same with data hash
+//  2. verify all signatures of initiator and auth requires
+//  3. verify the utxo input, there are three kinds of input validation
+//     1). PKI technology for transferring from address
+//     2). Account ACL for transferring from account
+//     3). Contract logic transferring from contract
+//  4. verify the contract requests' permission
+//  5. verify the permission of contract RWSet (WriteSet could including unauthorized data change)
+//  6. run contract requests and verify if the RWSet result is the same with preExed RWSet (heavy
+//     operation, keep it at last)
+//
+// 交易池中的交易按下一个待执行区块执行合约
 func (t *State) ImmediateVerifyTx(tx *pb.Transaction, isRootTx bool) (bool, error) {
+	blk, err := t.pendingExecBlock()
+	if err != nil {
+		return false, err
+	}
+	return t.immediateVerifyTx(tx, isRootTx, blk)
+}
+
+// immediateVerifyTx 校验交易，合约在指定区块中执行
+func (t *State) immediateVerifyTx(tx *pb.Transaction, isRootTx bool, blk *execBlock) (bool, error) {
 	beginTime := time.Now()
 	code := "InvalidTx"
 	defer func() {
@@ -126,7 +137,7 @@ func (t *State) ImmediateVerifyTx(tx *pb.Transaction, isRootTx bool) (bool, erro
 			return ok, ErrACLNotEnough
 		}
 		// verify RWSet(run contracts and compare RWSet)
-		ok, err = t.verifyTxRWSets(tx)
+		ok, err = t.verifyTxRWSets(tx, blk)
 		if err != nil {
 			t.log.Warn("ImmediateVerifyTx: verifyTxRWSets failed", "error", err)
 			// reset error message
@@ -149,13 +160,21 @@ func (t *State) ImmediateVerifyTx(tx *pb.Transaction, isRootTx bool) (bool, erro
 
 // ImmediateVerifyTx verify auto tx Immediately
 // Transaction verification workflow:
-//	 0. 其实可以直接判断二者的txid，相同，则包括读写集在内的内容都相同
-//   1. verify transaction ID is the same with data hash
-//   2. run contract requests and verify if the RWSet result is the same with preExed RWSet (heavy
-//      operation, keep it at last)
-func (t *State) ImmediateVerifyAutoTx(blockHeight int64, tx *pb.Transaction, isRootTx bool) (bool, error) {
+//  0. 其实可以直接判断二者的txid，相同，则包括读写集在内的内容都相同
+//  1. verify transaction ID is the same with data hash
+//  2. run contract requests and verify if the RWSet result is the same with preExed RWSet (heavy
+//     operation, keep it at last)
+func (t *State) ImmediateVerifyAutoTx(block *pb.InternalBlock, tx *pb.Transaction, isRootTx bool) (bool, error) {
+	blk, err := t.execBlockOf(block)
+	if err != nil {
+		return false, err
+	}
+	return t.immediateVerifyAutoTx(blk, tx, isRootTx)
+}
+
+func (t *State) immediateVerifyAutoTx(blk *execBlock, tx *pb.Transaction, isRootTx bool) (bool, error) {
 	// 获取该区块触发的定时交易
-	autoTx, genErr := t.GetTimerTx(blockHeight)
+	autoTx, genErr := t.getTimerTx(blk)
 	if genErr != nil || autoTx == nil {
 		t.log.Warn("get timer tasks failed", "err", genErr)
 		return false, genErr
@@ -331,6 +350,7 @@ func (t *State) verifyXuperSign(tx *pb.Transaction, digestHash []byte) (bool, ma
 }
 
 // verify utxo inputs, there are three kinds of input validation
+//
 //	1). PKI technology for transferring from address
 //	2). Account ACL for transferring from account
 //	3). Contract logic transferring from contract
@@ -540,7 +560,7 @@ func (t *State) verifyContractTxAmount(tx *pb.Transaction) (bool, error) {
 }
 
 // verifyTxRWSets verify tx read sets and write sets
-func (t *State) verifyTxRWSets(tx *pb.Transaction) (bool, error) {
+func (t *State) verifyTxRWSets(tx *pb.Transaction, blk *execBlock) (bool, error) {
 	if t.VerifyReservedWhitelist(tx) {
 		t.log.Info("verifyReservedWhitelist true", "txid", fmt.Sprintf("%x", tx.GetTxid()))
 		return true, nil
@@ -566,6 +586,17 @@ func (t *State) verifyTxRWSets(tx *pb.Transaction) (bool, error) {
 		return true, nil
 	}
 
+	// 读取过区块信息的交易只能在预执行时的区块高度执行
+	readHeight, err := sandbox.ParseBlockRead(tx)
+	if err != nil {
+		return false, err
+	}
+	if readHeight >= 0 && readHeight != blk.height {
+		t.log.Warn("verifyTxRWSets block read height mismatch", "txid", fmt.Sprintf("%x", tx.GetTxid()),
+			"readHeight", readHeight, "height", blk.height)
+		return false, fmt.Errorf("block read at height %d, executed at height %d", readHeight, blk.height)
+	}
+
 	rset, wset, err := t.GenRWSetFromTx(tx)
 	if err != nil {
 		t.log.Error("verifyTxRWSets GenRWSetFromTx error", "err", err)
@@ -577,14 +608,32 @@ func (t *State) verifyTxRWSets(tx *pb.Transaction) (bool, error) {
 	}
 
 	reader := sandbox.XMReaderFromRWSet(rwSet)
+	if t.sctx.Ledger.GetGenesisBlock().GetConfig().ContractFreeze.Active(blk.height) {
+		reader = &frozenCheckedReader{XMReader: reader}
+	}
 	utxoInput, err := xmodel.ParseContractUtxoInputs(tx)
 	if err != nil {
 		return false, err
 	}
-	utxoReader := sandbox.NewUTXOReaderFromInput(utxoInput)
+	// 交易记录的余额必须与父区块的余额一致，合约按顺序读取记录的余额
+	balances, err := sandbox.ParseBalanceReads(tx)
+	if err != nil {
+		return false, err
+	}
+	if err := t.verifyBalanceReads(balances); err != nil {
+		return false, err
+	}
+	utxoReader := sandbox.NewUTXOReaderWithBalances(utxoInput, balances)
+	// 交易记录的跨链查询按顺序重新查询，请求和结果都必须与记录一致
+	crossQueries, err := sandbox.ParseCrossQuery(tx)
+	if err != nil {
+		return false, err
+	}
+	crossQueryVerifier := sandbox.NewCrossQueryVerifier(crossQueries, t)
 	sandBoxConfig := &contract.SandboxConfig{
-		XMReader:   reader,
-		UTXOReader: utxoReader,
+		XMReader:         reader,
+		UTXOReader:       utxoReader,
+		CrossQueryReader: crossQueryVerifier,
 	}
 	sandBox, err := t.sctx.ContractMgr.NewStateSandbox(sandBoxConfig)
 	if err != nil {
@@ -598,9 +647,12 @@ func (t *State) verifyTxRWSets(tx *pb.Transaction) (bool, error) {
 	}
 
 	contextConfig := &contract.ContextConfig{
-		State:       sandBox,
-		Initiator:   tx.GetInitiator(),
-		AuthRequire: tx.GetAuthRequire(),
+		State:        sandBox,
+		Initiator:    tx.GetInitiator(),
+		AuthRequire:  tx.GetAuthRequire(),
+		BlockHeight:  blk.height,
+		BlockTime:    blk.time,
+		BlockPreHash: blk.preHash,
 	}
 	gasLimit, err := getGasLimitFromTx(tx)
 	if err != nil {
@@ -655,6 +707,9 @@ func (t *State) verifyTxRWSets(tx *pb.Transaction) (bool, error) {
 
 		ctx.Release()
 	}
+	if err := crossQueryVerifier.Verify(); err != nil {
+		return false, err
+	}
 
 	err = sandBox.Flush()
 	if err != nil {
@@ -671,6 +726,20 @@ func (t *State) verifyTxRWSets(tx *pb.Transaction) (bool, error) {
 	return true, nil
 }
 
+// frozenCheckedReader 要求合约冻结状态记录在交易读集中。读集版本按区块中的交易顺序校验，
+// 同一区块中先执行的冻结交易会使后续调用交易的读集失效，省略该状态不能绕过冻结
+type frozenCheckedReader struct {
+	kledger.XMReader
+}
+
+func (r *frozenCheckedReader) Get(bucket string, key []byte) (*kledger.VersionedData, error) {
+	data, err := r.XMReader.Get(bucket, key)
+	if err == sandbox.ErrNotFound && bucket == "contract" && bytes.HasSuffix(key, contract.ContractStatusKey("")) {
+		return nil, fmt.Errorf("contract status %s not in read set", key)
+	}
+	return data, err
+}
+
 // verifyAutoTxRWSets verify auto tx read sets and write sets
 func (t *State) verifyAutoTxRWSets(tx, autoTx *pb.Transaction) (bool, error) {
 	txRsets := tx.GetTxInputsExt()
@@ -862,6 +931,36 @@ func (t *State) VerifyContractOwnerPermission(contractName string, authRequire [
 	return nil
 }
 
+// VerifyAccountPermission implement Contract ChainCore, used to verify whether authRequire satisfies the acl of account
+func (t *State) VerifyAccountPermission(accountName string, authRequire []string) error {
+	ok, err := aclu.IdentifyAccount(t.sctx.AclMgr, accountName, authRequire)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("verify account permission failed")
+	}
+	return nil
+}
+
+// CrossQuery implement Contract ChainCore, query other chains by the injected cross query reader
+func (t *State) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	if t.sctx.CrossQueryReader == nil {
+		return nil, contract.Limits{}, sandbox.ErrCrossQueryNotSupported
+	}
+	return t.sctx.CrossQueryReader.CrossQuery(req, limits)
+}
+
+// CrossQueryAtBlock query other chains at the recorded block by the injected cross query reader
+func (t *State) CrossQueryAtBlock(req *protos.CrossQueryRequest, blockid []byte,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	if t.sctx.CrossQueryReader == nil {
+		return nil, contract.Limits{}, sandbox.ErrCrossQueryNotSupported
+	}
+	return t.sctx.CrossQueryReader.CrossQueryAtBlock(req, blockid, limits)
+}
+
 func (t *State) MaxTxSizePerBlock() (int, error) {
 	maxBlkSize := t.GetMaxBlockSize()
 	return int(float64(maxBlkSize) * TxSizePercent), nil
@@ -879,15 +978,18 @@ func (t *State) queryAccountACL(accountName string) (*protos.Acl, error) {
 }
 
 func (t *State) verifyBlockTxs(block *pb.InternalBlock, isRootTx bool, unconfirmToConfirm map[string]bool) error {
-	var err error
+	blk, err := t.execBlockOf(block)
+	if err != nil {
+		return err
+	}
 	var once sync.Once
 	wg := sync.WaitGroup{}
-	dags := txn.SplitToDags(block)
+	dags := txn.SplitToConflictFreeDags(block)
 	for _, txs := range dags {
 		wg.Add(1)
 		go func(txs []*pb.Transaction) {
 			defer wg.Done()
-			verifyErr := t.verifyDAGTxs(block.Height, txs, isRootTx, unconfirmToConfirm)
+			verifyErr := t.verifyDAGTxs(blk, txs, isRootTx, unconfirmToConfirm)
 			onceBody := func() {
 				err = verifyErr
 			}
@@ -901,7 +1003,7 @@ func (t *State) verifyBlockTxs(block *pb.InternalBlock, isRootTx bool, unconfirm
 	return err
 }
 
-func (t *State) verifyDAGTxs(blockHeight int64, txs []*pb.Transaction, isRootTx bool, unconfirmToConfirm map[string]bool) error {
+func (t *State) verifyDAGTxs(blk *execBlock, txs []*pb.Transaction, isRootTx bool, unconfirmToConfirm map[string]bool) error {
 	for _, tx := range txs {
 		if tx == nil {
 			return errors.New("verifyTx error, tx is nil")
@@ -910,14 +1012,14 @@ func (t *State) verifyDAGTxs(blockHeight int64, txs []*pb.Transaction, isRootTx
 		if unconfirmToConfirm[txid] == false {
 			if t.verifyAutogenTxValid(tx) {
 				// 校验auto tx
-				if ok, err := t.ImmediateVerifyAutoTx(blockHeight, tx, isRootTx); !ok {
+				if ok, err := t.immediateVerifyAutoTx(blk, tx, isRootTx); !ok {
 					t.log.Warn("dotx failed to ImmediateVerifyAutoTx", "txid", fmt.Sprintf("%x", tx.Txid), "err", err)
 					return errors.New("dotx failed to ImmediateVerifyAutoTx error")
 				}
 			}
 			if !tx.Autogen && !tx.Coinbase {
 				// 校验用户交易
-				if ok, err := t.ImmediateVerifyTx(tx, isRootTx); !ok {
+				if ok, err := t.immediateVerifyTx(tx, isRootTx, blk); !ok {
 					t.log.Warn("dotx failed to ImmediateVerifyTx", "txid", fmt.Sprintf("%x", tx.Txid), "err", err)
 					ok, isRelyOnMarkedTx, err := t.verifyMarked(tx)
 					if isRelyOnMarkedTx {
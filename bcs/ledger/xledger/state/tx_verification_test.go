@@ -0,0 +1,32 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	kledger "github.com/xuperchain/xupercore/kernel/ledger"
+)
+
+func TestFrozenCheckedReader(t *testing.T) {
+	rwset := &contract.RWSet{
+		RSet: []*kledger.VersionedData{
+			{PureData: &kledger.PureData{Bucket: "contract", Key: contract.ContractStatusKey("c1")}},
+			{PureData: &kledger.PureData{Bucket: "c1", Key: []byte("k1"), Value: []byte("v1")}, RefTxid: []byte("txid")},
+		},
+	}
+	reader := &frozenCheckedReader{XMReader: sandbox.XMReaderFromRWSet(rwset)}
+
+	// 读集中记录了合约状态不存在
+	data, err := reader.Get("contract", contract.ContractStatusKey("c1"))
+	if err != nil || !sandbox.IsEmptyVersionedData(data) {
+		t.Fatalf("expect empty status, got %v %v", data, err)
+	}
+	if _, err := reader.Get("contract", contract.ContractStatusKey("c2")); err == nil || err == sandbox.ErrNotFound {
+		t.Fatalf("expect status not in read set error, got %v", err)
+	}
+	// 其他key不受影响
+	if _, err := reader.Get("c1", []byte("k2")); err != sandbox.ErrNotFound {
+		t.Fatalf("expect ErrNotFound, got %v", err)
+	}
+}
@@ -35,9 +35,15 @@ func (uv *UtxoVM) QueryContractStatData() (*protos.ContractStatData, error) {
 		return &protos.ContractStatData{}, contractCountErr
 	}
 
+	destroyedCount, destroyedCountErr := uv.queryContractStatData(utils.GetContractTombstoneBucket())
+	if destroyedCountErr != nil {
+		return &protos.ContractStatData{}, destroyedCountErr
+	}
+
 	data := &protos.ContractStatData{
-		AccountCount:  accountCount,
-		ContractCount: contractCount,
+		AccountCount:           accountCount,
+		ContractCount:          contractCount,
+		DestroyedContractCount: destroyedCount,
 	}
 
 	return data, nil
@@ -117,6 +117,12 @@ func (uv *UtxoVM) CleanBatchCache(newBatch kvdb.Batch) {
 	}
 }
 
+// SwitchBatchCache 将batch cache转交给新的batch，当前batch不是oldBatch时清理缓存
+func (uv *UtxoVM) SwitchBatchCache(oldBatch, newBatch kvdb.Batch) {
+	uv.CleanBatchCache(oldBatch)
+	uv.lastBatch = newBatch
+}
+
 // InsertBatchCache inser key value to batch cache.
 func (uv *UtxoVM) InsertBatchCache(key, value interface{}) {
 	uv.batchCache.Store(key, value)
@@ -11,12 +11,19 @@ type UTXOSandbox struct {
 	inputCache  []*protos.TxInput
 	outputCache []*protos.TxOutput
 	utxoReader  contract.UtxoReader
+	// 读取过的地址在最新确认区块的余额，按读取顺序记录在交易中
+	balances     map[string]*big.Int
+	balanceReads []*protos.TxOutput
+	// 当前交易中各地址转入减去转出的金额
+	transferred map[string]*big.Int
 }
 
 func NewUTXOSandbox(cfg *contract.SandboxConfig) *UTXOSandbox {
 	return &UTXOSandbox{
 		outputCache: []*protos.TxOutput{},
 		utxoReader:  cfg.UTXOReader,
+		balances:    make(map[string]*big.Int),
+		transferred: make(map[string]*big.Int),
 	}
 }
 
@@ -40,9 +47,47 @@ func (u *UTXOSandbox) Transfer(from, to string, amount *big.Int) error {
 			ToAddr: []byte(from),
 		})
 	}
+	u.addTransferred(from, new(big.Int).Neg(amount))
+	u.addTransferred(to, amount)
 	return nil
 }
 
+func (u *UTXOSandbox) addTransferred(addr string, amount *big.Int) {
+	if v, ok := u.transferred[addr]; ok {
+		v.Add(v, amount)
+		return
+	}
+	u.transferred[addr] = new(big.Int).Set(amount)
+}
+
+// Balance 读取地址在最新确认区块的余额，加上当前交易中该地址的转入和转出。
+// 读取不花费地址的utxo，确认区块的余额记录在交易中，验证时与父区块的状态比对
+func (u *UTXOSandbox) Balance(addr string) (*big.Int, error) {
+	balance, ok := u.balances[addr]
+	if !ok {
+		var err error
+		balance, err = u.utxoReader.GetBalance(addr)
+		if err != nil {
+			return nil, err
+		}
+		u.balances[addr] = balance
+		u.balanceReads = append(u.balanceReads, &protos.TxOutput{
+			Amount: balance.Bytes(),
+			ToAddr: []byte(addr),
+		})
+	}
+	total := new(big.Int).Set(balance)
+	if v, ok := u.transferred[addr]; ok {
+		total.Add(total, v)
+	}
+	return total, nil
+}
+
+// BalanceReads 返回按读取顺序记录的地址余额，ToAddr为地址，Amount为余额
+func (u *UTXOSandbox) BalanceReads() []*protos.TxOutput {
+	return u.balanceReads
+}
+
 func (uc *UTXOSandbox) GetUTXORWSets() *contract.UTXORWSet {
 	return &contract.UTXORWSet{
 		Rset: uc.inputCache,
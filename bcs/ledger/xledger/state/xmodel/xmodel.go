@@ -322,6 +322,12 @@ func (s *XModel) CleanCache() {
 	s.cleanCache(nil)
 }
 
+// SwitchBatchCache 将batch cache转交给新的batch，当前batch不是oldBatch时清理缓存
+func (s *XModel) SwitchBatchCache(oldBatch, newBatch kvdb.Batch) {
+	s.cleanCache(oldBatch)
+	s.lastBatch = newBatch
+}
+
 func (s *XModel) cleanCache(newBatch kvdb.Batch) {
 	if newBatch != s.lastBatch {
 		s.batchCache = &sync.Map{}
@@ -334,9 +340,9 @@ func (s *XModel) bucketCache(bucket string) *cache.LRUCache {
 	if ok {
 		return icache.(*cache.LRUCache)
 	}
-	cache := cache.NewLRUCache(bucketExtUTXOCacheSize)
-	s.extUtxoCache.Store(bucket, cache)
-	return cache
+	// 同一个区块的交易会并发执行，使用LoadOrStore避免缓存被覆盖
+	icache, _ = s.extUtxoCache.LoadOrStore(bucket, cache.NewLRUCache(bucketExtUTXOCacheSize))
+	return icache.(*cache.LRUCache)
 }
 
 func (s *XModel) bucketCacheStore(bucket, version string, value *kledger.VersionedData) {
@@ -77,8 +77,22 @@ func (t *xModSnapshot) Get(bucket string, key []byte) (*kledger.VersionedData, e
 	return verValue, nil
 }
 
+// Select 遍历快照高度时bucket中的kv，区间为[startKey, endKey)
+// 先遍历最新状态中的key，再逐个回溯到快照高度的版本，快照高度时尚未写入的key会被跳过
 func (t *xModSnapshot) Select(bucket string, startKey []byte, endKey []byte) (kledger.XMIterator, error) {
-	return nil, fmt.Errorf("xmodel snapshot temporarily not supported select")
+	if !t.isInit() || bucket == "" {
+		return nil, fmt.Errorf("xmod snapshot not init or param set error")
+	}
+
+	iter, err := t.xmod.Select(bucket, startKey, endKey)
+	if err != nil {
+		return nil, fmt.Errorf("select newest version data fail.err:%v", err)
+	}
+	return &xModSnapshotIterator{
+		snapshot: t,
+		bucket:   bucket,
+		iter:     iter,
+	}, nil
 }
 
 func (t *xModSnapshot) isInit() bool {
@@ -90,7 +104,8 @@ func (t *xModSnapshot) isInit() bool {
 }
 
 func (t *xModSnapshot) getBlockHeight(blockid []byte) (int64, error) {
-	blkInfo, err := t.xmod.QueryBlock(blockid)
+	// 只需要区块头，裁剪后的区块也可以查询
+	blkInfo, err := t.xmod.ledger.QueryBlockHeader(blockid)
 	if err != nil {
 		return 0, fmt.Errorf("query block info fail. block_id:%s err:%v",
 			hex.EncodeToString(blockid), err)
@@ -129,6 +144,55 @@ func (t *xModSnapshot) getPreOutExt(inputsExt []*protos.TxInputExt,
 	return nil, 0, fmt.Errorf("bucket and key not exist.bucket:%s key:%s", bucket, string(key))
 }
 
+// xModSnapshotIterator 把最新状态的迭代器转换为快照高度的迭代器
+type xModSnapshotIterator struct {
+	snapshot *xModSnapshot
+	bucket   string
+	iter     kledger.XMIterator
+	value    *kledger.VersionedData
+	err      error
+}
+
+func (t *xModSnapshotIterator) Next() bool {
+	if t.err != nil {
+		return false
+	}
+	for t.iter.Next() {
+		verData, err := t.snapshot.Get(t.bucket, t.iter.Key())
+		if err != nil {
+			t.err = err
+			return false
+		}
+		// 快照高度时key还不存在
+		if len(verData.RefTxid) < 1 {
+			continue
+		}
+		t.value = verData
+		return true
+	}
+	return false
+}
+
+func (t *xModSnapshotIterator) Key() []byte {
+	return t.value.GetPureData().GetKey()
+}
+
+func (t *xModSnapshotIterator) Value() *kledger.VersionedData {
+	return t.value
+}
+
+func (t *xModSnapshotIterator) Error() error {
+	if t.err != nil {
+		return t.err
+	}
+	return t.iter.Error()
+}
+
+func (t *xModSnapshotIterator) Close() {
+	t.iter.Close()
+	t.value = nil
+}
+
 type xMSnapshotReader struct {
 	xMReader kledger.XMReader
 }
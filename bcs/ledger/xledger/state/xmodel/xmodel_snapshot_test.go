@@ -25,13 +25,13 @@ const (
 	BobAddress = "WNWk3ekXeM5M2232dY2uCJmEqWhfQiDYT"
 )
 
-func TestGet(t *testing.T) {
+// newSnapshotTestEnv 创建只包含创世块的账本和对应的XModel
+func newSnapshotTestEnv(t *testing.T) (*XModel, *ledger_pkg.Ledger, func()) {
 	workspace, dirErr := ioutil.TempDir("/tmp", "")
 	if dirErr != nil {
 		t.Fatal(dirErr)
 	}
 	os.RemoveAll(workspace)
-	defer os.RemoveAll(workspace)
 	econf, err := mock.NewEnvConfForTest()
 	if err != nil {
 		t.Fatal(err)
@@ -94,6 +94,15 @@ func TestGet(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	return xmod, ledger, func() {
+		ledger.Close()
+		os.RemoveAll(workspace)
+	}
+}
+
+func TestGet(t *testing.T) {
+	xmod, ledger, clean := newSnapshotTestEnv(t)
+	defer clean()
 
 	blkId, err := ledger.QueryBlockByHeight(0)
 	if err != nil {
@@ -112,6 +121,38 @@ func TestGet(t *testing.T) {
 
 	fmt.Println(vData)
 	fmt.Println(hex.EncodeToString(vData.RefTxid))
+}
+
+func TestSnapshotSelect(t *testing.T) {
+	xmod, ledger, clean := newSnapshotTestEnv(t)
+	defer clean()
+
+	blkId, err := ledger.QueryBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xmsp, err := xmod.CreateSnapshot(blkId.Blockid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iter, err := xmsp.Select("proftestc", []byte("key_0"), []byte("key_9"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for iter.Next() {
+		if len(iter.Value().RefTxid) < 1 {
+			t.Errorf("unexpected empty version of key %s", iter.Key())
+		}
+	}
+	if iter.Error() != nil {
+		t.Error(iter.Error())
+	}
+	iter.Close()
+
+	if _, err := xmsp.Select("", nil, nil); err == nil {
+		t.Error("expect error for empty bucket")
+	}
 
-	ledger.Close()
 }
@@ -0,0 +1,126 @@
+package tx
+
+import (
+	"fmt"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+)
+
+const (
+	// 与xmodel.TransientBucket一致，临时bucket的读写不会落盘，不产生冲突
+	transientBucket = "$transient"
+	// coinbase交易会更新系统总资产，所有coinbase交易需要串行执行
+	coinbaseConflictKey = "coinbase"
+)
+
+// conflictSet 使用并查集维护交易之间的冲突关系
+type conflictSet struct {
+	parent []int
+}
+
+func newConflictSet(n int) *conflictSet {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &conflictSet{
+		parent: parent,
+	}
+}
+
+func (c *conflictSet) find(i int) int {
+	for c.parent[i] != i {
+		c.parent[i] = c.parent[c.parent[i]]
+		i = c.parent[i]
+	}
+	return i
+}
+
+// union 合并两个集合，总是以下标较小的交易作为根，保证划分结果确定
+func (c *conflictSet) union(i, j int) {
+	ri, rj := c.find(i), c.find(j)
+	if ri == rj {
+		return
+	}
+	if ri < rj {
+		c.parent[rj] = ri
+	} else {
+		c.parent[ri] = rj
+	}
+}
+
+// conflictKeys 返回交易读写的状态，读写相同状态的交易之间存在冲突
+// 由于xmodel要求写集中的key必须出现在读集中，这里只需要遍历TxInputsExt
+func conflictKeys(tx *pb.Transaction) []string {
+	var keys []string
+	if tx.Coinbase {
+		keys = append(keys, coinbaseConflictKey)
+	}
+	for _, input := range tx.TxInputs {
+		keys = append(keys, fmt.Sprintf("utxo/%x_%d", input.RefTxid, input.RefOffset))
+	}
+	for _, input := range tx.TxInputsExt {
+		if input.Bucket == transientBucket {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("xmodel/%s/%x", input.Bucket, input.Key))
+	}
+	for _, output := range tx.TxOutputsExt {
+		if output.Bucket == transientBucket {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("xmodel/%s/%x", output.Bucket, output.Key))
+	}
+	return keys
+}
+
+// SplitToConflictFreeDags 根据交易的读写集构建冲突图，将区块内的交易划分为互不冲突的多个DAG
+// 以下交易会被划分到同一个DAG中：
+// 1. 引用了区块内其他交易输出的交易
+// 2. 花费了同一个utxo的交易
+// 3. 读写了相同bucket和key的交易
+// 4. coinbase交易
+// 每个DAG内的交易保持在区块中的顺序，DAG按照其第一个交易在区块中的顺序排列，
+// 不同DAG读写的状态互不相交，可以并发执行，且结果与串行执行一致
+func SplitToConflictFreeDags(block *pb.InternalBlock) [][]*pb.Transaction {
+	txs := block.Transactions
+	set := newConflictSet(len(txs))
+	txIndex := make(map[string]int, len(txs))
+	keyOwner := make(map[string]int)
+	for i, tx := range txs {
+		txIndex[string(tx.Txid)] = i
+	}
+	for i, tx := range txs {
+		for _, input := range tx.TxInputs {
+			if j, ok := txIndex[string(input.RefTxid)]; ok {
+				set.union(i, j)
+			}
+		}
+		for _, input := range tx.TxInputsExt {
+			if j, ok := txIndex[string(input.RefTxid)]; ok {
+				set.union(i, j)
+			}
+		}
+		for _, key := range conflictKeys(tx) {
+			if j, ok := keyOwner[key]; ok {
+				set.union(i, j)
+				continue
+			}
+			keyOwner[key] = i
+		}
+	}
+
+	dags := [][]*pb.Transaction{}
+	dagIndex := make(map[int]int)
+	for i, tx := range txs {
+		root := set.find(i)
+		idx, ok := dagIndex[root]
+		if !ok {
+			idx = len(dags)
+			dagIndex[root] = idx
+			dags = append(dags, []*pb.Transaction{})
+		}
+		dags[idx] = append(dags[idx], tx)
+	}
+	return dags
+}
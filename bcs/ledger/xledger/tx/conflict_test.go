@@ -0,0 +1,50 @@
+package tx
+
+import (
+	"testing"
+
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func TestSplitToConflictFreeDags(t *testing.T) {
+	readKey := func(bucket, key string) []*protos.TxInputExt {
+		return []*protos.TxInputExt{{Bucket: bucket, Key: []byte(key)}}
+	}
+	txs := []*pb.Transaction{
+		{Txid: []byte("tx0"), Coinbase: true},
+		{Txid: []byte("tx1"), TxInputsExt: readKey("counter", "a")},
+		{Txid: []byte("tx2"), TxInputsExt: readKey("counter", "b")},
+		{Txid: []byte("tx3"), TxInputs: []*protos.TxInput{{RefTxid: []byte("tx0")}}},
+		{Txid: []byte("tx4"), TxInputsExt: readKey("counter", "a")},
+		{Txid: []byte("tx5"), TxInputs: []*protos.TxInput{{RefTxid: []byte("tx777"), RefOffset: 1}}},
+		{Txid: []byte("tx6"), TxInputs: []*protos.TxInput{{RefTxid: []byte("tx777"), RefOffset: 1}}},
+		{Txid: []byte("tx7"), TxInputsExt: readKey(transientBucket, "a")},
+		{Txid: []byte("tx8"), TxInputsExt: readKey(transientBucket, "a")},
+		{Txid: []byte("tx9"), Coinbase: true},
+		// 读取b，写入a，连接了tx1和tx2所在的DAG
+		{Txid: []byte("tx10"), TxInputsExt: readKey("counter", "b"),
+			TxOutputsExt: []*protos.TxOutputExt{{Bucket: "counter", Key: []byte("a")}}},
+	}
+	dags := SplitToConflictFreeDags(&pb.InternalBlock{Transactions: txs})
+	expect := [][]string{
+		{"tx0", "tx3", "tx9"},
+		{"tx1", "tx2", "tx4", "tx10"},
+		{"tx5", "tx6"},
+		{"tx7"},
+		{"tx8"},
+	}
+	if len(dags) != len(expect) {
+		t.Fatalf("expect %d dags, got %d", len(expect), len(dags))
+	}
+	for i, dag := range dags {
+		if len(dag) != len(expect[i]) {
+			t.Fatalf("dag %d expect size %d, got %d", i, len(expect[i]), len(dag))
+		}
+		for j, tx := range dag {
+			if string(tx.Txid) != expect[i][j] {
+				t.Errorf("dag %d expect tx %s at %d, got %s", i, expect[i][j], j, tx.Txid)
+			}
+		}
+	}
+}
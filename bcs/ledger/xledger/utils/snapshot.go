@@ -0,0 +1,341 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/def"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo/txhash"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/tx"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	xconf "github.com/xuperchain/xupercore/kernel/common/xconfig"
+	"github.com/xuperchain/xupercore/lib/crypto/client"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	"github.com/xuperchain/xupercore/lib/utils"
+)
+
+const snapshotBatchSize = 4 << 20
+
+// ExportSnapshot 导出已停止节点的状态快照，运行中的节点使用State.ExportSnapshot
+func ExportSnapshot(bcName string, w io.Writer, envCfg *xconf.EnvConf) (*state.SnapshotHeader, error) {
+	if bcName == "" || w == nil || envCfg == nil {
+		return nil, fmt.Errorf("param set error")
+	}
+	xledger, handleState, err := openLedgerAndState(bcName, envCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer xledger.Close()
+	defer handleState.Close()
+	return handleState.ExportSnapshot(w)
+}
+
+func openLedgerAndState(bcName string, envCfg *xconf.EnvConf) (*ledger.Ledger, *state.State, error) {
+	lctx, err := ledger.NewLedgerCtx(envCfg, bcName)
+	if err != nil {
+		return nil, nil, err
+	}
+	xledger, err := ledger.OpenLedger(lctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	crypt, err := client.CreateCryptoClient(xledger.GetGenesisBlock().GetConfig().GetCryptoType())
+	if err != nil {
+		xledger.Close()
+		return nil, nil, err
+	}
+	sctx, err := context.NewStateCtx(envCfg, bcName, xledger, crypt)
+	if err != nil {
+		xledger.Close()
+		return nil, nil, err
+	}
+	handleState, err := state.NewState(sctx)
+	if err != nil {
+		xledger.Close()
+		return nil, nil, err
+	}
+	return xledger, handleState, nil
+}
+
+// ImportSnapshot 通过状态快照创建账本，新节点导入后从快照高度开始同步区块。
+// 快照区块ID必须与trustedBlockid一致，trustedBlockid需要从可信的节点获取；快照中的区块头从快照区块
+// 逐个回溯到创世块，校验区块ID、高度和哈希链，创世块必须与genesisConf生成的一致；交易校验交易ID；
+// 快照区块必须带有状态树根，导入的状态重建状态树后与其比较。任何校验失败都会删除已经导入的数据。
+func ImportSnapshot(bcName, genesisConf string, snapshot io.Reader, trustedBlockid []byte, envCfg *xconf.EnvConf) error {
+	if bcName == "" || genesisConf == "" || snapshot == nil || len(trustedBlockid) == 0 || envCfg == nil {
+		return fmt.Errorf("param set error")
+	}
+	data, err := ioutil.ReadFile(genesisConf)
+	if err != nil {
+		return err
+	}
+
+	dataDir := envCfg.GenDataAbsPath(envCfg.ChainDir)
+	fullpath := filepath.Join(dataDir, bcName)
+	if utils.PathExists(fullpath) {
+		return ErrBlockChainExist
+	}
+	err = os.MkdirAll(fullpath, 0755)
+	if err != nil {
+		return err
+	}
+	stateRoot, err := importSnapshot(bcName, fullpath, data, snapshot, trustedBlockid, envCfg)
+	if err == nil {
+		// 打开状态机时重建状态树，与快照区块的状态树根比较
+		err = verifyImportedStateRoot(bcName, envCfg, stateRoot)
+	}
+	if err != nil {
+		os.RemoveAll(fullpath)
+		return err
+	}
+	return nil
+}
+
+func verifyImportedStateRoot(bcName string, envCfg *xconf.EnvConf, stateRoot []byte) error {
+	xledger, handleState, err := openLedgerAndState(bcName, envCfg)
+	if err != nil {
+		return err
+	}
+	defer xledger.Close()
+	defer handleState.Close()
+	root, err := handleState.GetStateRoot()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, stateRoot) {
+		return fmt.Errorf("%s, state root mismatch, expect:%x, imported:%x", state.ErrSnapshotCorrupted, stateRoot, root)
+	}
+	return nil
+}
+
+// importSnapshot 导入快照中的账本和状态机数据，返回快照区块的状态树根
+func importSnapshot(bcName, fullpath string, genesisData []byte, snapshot io.Reader,
+	trustedBlockid []byte, envCfg *xconf.EnvConf) ([]byte, error) {
+	rootTx, err := tx.GenerateRootTx(genesisData)
+	if err != nil {
+		return nil, err
+	}
+	rootBlock, err := ledger.MakeRootBlock([]*pb.Transaction{rootTx})
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := state.NewSnapshotReader(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	header := reader.Header
+	if header.BCName != bcName || header.Blockid != hex.EncodeToString(trustedBlockid) {
+		return nil, fmt.Errorf("snapshot not trusted, bcname:%s, blockid:%s", header.BCName, header.Blockid)
+	}
+	if header.RootBlockid != hex.EncodeToString(rootBlock.Blockid) {
+		return nil, fmt.Errorf("genesis block of snapshot mismatch, root_blockid:%s", header.RootBlockid)
+	}
+
+	rootfile := filepath.Join(fullpath, fmt.Sprintf("%s.json", bcName))
+	err = ioutil.WriteFile(rootfile, genesisData, 0666)
+	if err != nil {
+		return nil, err
+	}
+	lctx, err := ledger.NewLedgerCtx(envCfg, bcName)
+	if err != nil {
+		return nil, err
+	}
+	ledgerDB, err := openSnapshotDB(lctx, filepath.Join(fullpath, def.LedgerStrgDirName))
+	if err != nil {
+		return nil, err
+	}
+	defer ledgerDB.Close()
+	stateDB, err := openSnapshotDB(lctx, filepath.Join(fullpath, def.StateStrgDirName))
+	if err != nil {
+		return nil, err
+	}
+	defer stateDB.Close()
+
+	importer := &snapshotImporter{
+		ledgerBatch: ledgerDB.NewBatch(),
+		stateBatch:  stateDB.NewBatch(),
+		nextBlockid: trustedBlockid,
+		nextHeight:  header.Height,
+		tipHeight:   header.Height,
+		rootBlockid: rootBlock.Blockid,
+	}
+	for {
+		kind, key, value, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if kind == state.SnapshotRecordLedger {
+			err = importer.putLedger(key, value)
+		} else {
+			err = importer.putState(key, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(importer.stateRoot) == 0 {
+		return nil, fmt.Errorf("snapshot block has no state root, can not verify imported state")
+	}
+	if importer.nextHeight != -1 {
+		return nil, fmt.Errorf("%s, block headers not reach genesis block", state.ErrSnapshotCorrupted)
+	}
+	if !bytes.Equal(importer.latestBlockid, trustedBlockid) {
+		return nil, fmt.Errorf("%s, state not at snapshot block", state.ErrSnapshotCorrupted)
+	}
+
+	// 账本元数据，快照区块以下除创世块外的区块只有区块头
+	meta := &pb.LedgerMeta{
+		RootBlockid: rootBlock.Blockid,
+		TipBlockid:  trustedBlockid,
+		TrunkHeight: header.Height,
+	}
+	metaBuf, err := proto.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	importer.ledgerBatch.Put([]byte(pb.MetaTablePrefix), metaBuf)
+	importer.ledgerBatch.Put(append([]byte(pb.BranchInfoPrefix), trustedBlockid...),
+		[]byte(strconv.FormatInt(header.Height, 10)))
+	if header.Height > 1 {
+		importer.ledgerBatch.Put(append([]byte(pb.MetaTablePrefix), ledger.PrunedHeightKey...),
+			[]byte(strconv.FormatInt(header.Height-1, 10)))
+	}
+	if err := importer.ledgerBatch.Write(); err != nil {
+		return nil, err
+	}
+	return importer.stateRoot, importer.stateBatch.Write()
+}
+
+func openSnapshotDB(lctx *ledger.LedgerCtx, dbPath string) (kvdb.Database, error) {
+	kvParam := &kvdb.KVParameter{
+		DBPath:                dbPath,
+		KVEngineType:          lctx.LedgerCfg.KVEngineType,
+		MemCacheSize:          ledger.MemCacheSize,
+		FileHandlersCacheSize: ledger.FileHandlersCacheSize,
+		OtherPaths:            lctx.LedgerCfg.OtherPaths,
+		StorageType:           lctx.LedgerCfg.StorageType,
+		Options:               lctx.LedgerCfg.KVOptions,
+	}
+	return kvdb.CreateKVInstance(kvParam)
+}
+
+type snapshotImporter struct {
+	ledgerBatch kvdb.Batch
+	stateBatch  kvdb.Batch
+	// 下一个区块头应当是的区块ID和高度，区块头按照高度从高到低排列
+	nextBlockid   []byte
+	nextHeight    int64
+	tipHeight     int64
+	rootBlockid   []byte
+	latestBlockid []byte
+	// 快照区块的状态树根
+	stateRoot []byte
+}
+
+func (s *snapshotImporter) putLedger(key, value []byte) error {
+	switch {
+	case bytes.HasPrefix(key, []byte(pb.BlocksTablePrefix)):
+		block := &pb.InternalBlock{}
+		if err := proto.Unmarshal(value, block); err != nil {
+			return err
+		}
+		if err := s.verifyBlock(key[len(pb.BlocksTablePrefix):], block); err != nil {
+			return err
+		}
+		// 区块头按快照中的顺序从高到低校验，区块ID、高度和主干标记以校验结果为准
+		block.InTrunk = true
+		block.Height = s.nextHeight
+		if s.nextHeight == s.tipHeight {
+			block.NextHash = nil
+			s.stateRoot = block.StateRoot
+		}
+		buf, err := proto.Marshal(block)
+		if err != nil {
+			return err
+		}
+		s.ledgerBatch.Put(key, buf)
+		sHeight := []byte(fmt.Sprintf("%020d", block.Height))
+		s.ledgerBatch.Put(append([]byte(pb.BlockHeightPrefix), sHeight...), block.Blockid)
+		s.nextBlockid = block.PreHash
+		s.nextHeight--
+	case bytes.HasPrefix(key, []byte(pb.ConfirmedTablePrefix)):
+		transaction := &pb.Transaction{}
+		if err := proto.Unmarshal(value, transaction); err != nil {
+			return err
+		}
+		txid, err := txhash.MakeTransactionID(transaction)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(txid, key[len(pb.ConfirmedTablePrefix):]) || !bytes.Equal(txid, transaction.Txid) {
+			return fmt.Errorf("%s, txid mismatch, txid:%x", state.ErrSnapshotCorrupted, transaction.Txid)
+		}
+		s.ledgerBatch.Put(key, value)
+	default:
+		return fmt.Errorf("%s, unexpected ledger key %q", state.ErrSnapshotCorrupted, key)
+	}
+	return s.flush()
+}
+
+func (s *snapshotImporter) verifyBlock(blockid []byte, block *pb.InternalBlock) error {
+	if s.nextHeight < 0 || !bytes.Equal(blockid, s.nextBlockid) || !bytes.Equal(block.Blockid, blockid) {
+		return fmt.Errorf("%s, unexpected block %x", state.ErrSnapshotCorrupted, blockid)
+	}
+	realBlockid, err := ledger.MakeBlockID(block)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(realBlockid, blockid) {
+		return fmt.Errorf("%s, blockid mismatch, blockid:%x", state.ErrSnapshotCorrupted, blockid)
+	}
+	if (s.nextHeight == 0) != (len(block.PreHash) == 0) {
+		return fmt.Errorf("%s, bad block height, blockid:%x", state.ErrSnapshotCorrupted, blockid)
+	}
+	if s.nextHeight == 0 && !bytes.Equal(blockid, s.rootBlockid) {
+		return fmt.Errorf("%s, genesis block mismatch, blockid:%x", state.ErrSnapshotCorrupted, blockid)
+	}
+	return nil
+}
+
+func (s *snapshotImporter) putState(key, value []byte) error {
+	if !state.IsSnapshotStateKey(key) {
+		return fmt.Errorf("%s, unexpected state key %q", state.ErrSnapshotCorrupted, key)
+	}
+	if bytes.Equal(key, []byte(pb.MetaTablePrefix+utxo.LatestBlockKey)) {
+		s.latestBlockid = append([]byte{}, value...)
+	}
+	s.stateBatch.Put(key, value)
+	return s.flush()
+}
+
+func (s *snapshotImporter) flush() error {
+	if s.ledgerBatch.ValueSize() > snapshotBatchSize {
+		if err := s.ledgerBatch.Write(); err != nil {
+			return err
+		}
+		s.ledgerBatch.Reset()
+	}
+	if s.stateBatch.ValueSize() > snapshotBatchSize {
+		if err := s.stateBatch.Write(); err != nil {
+			return err
+		}
+		s.stateBatch.Reset()
+	}
+	return nil
+}
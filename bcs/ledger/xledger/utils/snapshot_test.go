@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/context"
+	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/mock"
+	"github.com/xuperchain/xupercore/lib/crypto/client"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	"github.com/xuperchain/xupercore/lib/utils"
+)
+
+func openState(t *testing.T, lctx *ledger.LedgerCtx) (*ledger.Ledger, *state.State) {
+	xledger, err := ledger.OpenLedger(lctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crypt, err := client.CreateCryptoClient(client.CryptoTypeDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sctx, err := context.NewStateCtx(lctx.EnvCfg, lctx.BCName, xledger, crypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handleState, err := state.NewState(sctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return xledger, handleState
+}
+
+const minerPrivateKey = `{"Curvname":"P-256","X":74695617477160058757747208220371236837474210247114418775262229497812962582435,"Y":51348715319124770392993866417088542497927816017012182211244120852620959209571,"D":29079635126530934056640915735344231956621504557963207107451663058887647996601}`
+
+// stateRootGenesis 在测试创世块配置中从高度1启用状态树根
+func stateRootGenesis(t *testing.T, genesisConf, workspace string) string {
+	data, err := ioutil.ReadFile(genesisConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	config["state_root"] = map[string]interface{}{"enable": true, "height": 1}
+	data, err = json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(workspace, "xuper.json")
+	if err := ioutil.WriteFile(path, data, 0666); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// playEmptyBlock 在账本末尾追加一个空区块并执行
+func playEmptyBlock(t *testing.T, xledger *ledger.Ledger, handleState *state.State) {
+	crypt, err := client.CreateCryptoClient(client.CryptoTypeDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaPk, err := crypt.GetEcdsaPrivateKeyFromJsonStr(minerPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := xledger.GetMeta()
+	block, err := xledger.FormatMinerBlock([]*pb.Transaction{}, []byte("miner"), ecdsaPk, time.Now().UnixNano(),
+		0, 0, meta.GetTipBlockid(), 0, handleState.GetTotal(), nil, nil, meta.GetTrunkHeight()+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !xledger.ConfirmBlock(block, false).Succ {
+		t.Fatal("confirm block fail")
+	}
+	if err := handleState.Play(block.Blockid); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportSnapshot(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+	econf, err := mock.NewEnvConfForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisConf := stateRootGenesis(t, econf.GenDataAbsPath("genesis/xuper.json"), workspace)
+	econf.ChainDir = filepath.Join(workspace, "source")
+	err = CreateLedger("xuper", genesisConf, econf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 创世块不带状态树根，不能导出快照
+	buf := new(bytes.Buffer)
+	_, err = ExportSnapshot("xuper", buf, econf)
+	if err == nil {
+		t.Fatal("expect error without state root")
+	}
+
+	lctx, err := ledger.NewLedgerCtx(econf, "xuper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xledger, handleState := openState(t, lctx)
+	playEmptyBlock(t, xledger, handleState)
+	tipBlockid := xledger.GetMeta().GetTipBlockid()
+	total := handleState.GetTotal()
+	handleState.Close()
+	xledger.Close()
+
+	buf.Reset()
+	header, err := ExportSnapshot("xuper", buf, econf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Height != 1 {
+		t.Fatalf("unexpected snapshot height %d", header.Height)
+	}
+
+	econf.ChainDir = filepath.Join(workspace, "target")
+	// 不可信的区块
+	err = ImportSnapshot("xuper", genesisConf, bytes.NewReader(buf.Bytes()), []byte("bad blockid"), econf)
+	if err == nil {
+		t.Fatal("expect error with untrusted blockid")
+	}
+	// 快照损坏，导入失败后不残留数据
+	corrupted := append([]byte{}, buf.Bytes()...)
+	corrupted[len(corrupted)-10] ^= 0xff
+	err = ImportSnapshot("xuper", genesisConf, bytes.NewReader(corrupted), tipBlockid, econf)
+	if err == nil {
+		t.Fatal("expect error with corrupted snapshot")
+	}
+	if utils.PathExists(filepath.Join(econf.GenDataAbsPath(econf.ChainDir), "xuper")) {
+		t.Fatal("chain dir should be removed after import failed")
+	}
+
+	err = ImportSnapshot("xuper", genesisConf, bytes.NewReader(buf.Bytes()), tipBlockid, econf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lctx, err = ledger.NewLedgerCtx(econf, "xuper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xledger, handleState = openState(t, lctx)
+	defer xledger.Close()
+	defer handleState.Close()
+	if !bytes.Equal(xledger.GetMeta().GetTipBlockid(), tipBlockid) ||
+		!bytes.Equal(handleState.GetLatestBlockid(), tipBlockid) {
+		t.Fatal("imported ledger not at snapshot block")
+	}
+	if handleState.GetTotal().Cmp(total) != 0 {
+		t.Fatalf("unexpected total %s, expect %s", handleState.GetTotal(), total)
+	}
+}
+
+func TestSnapshotUnknownStateKey(t *testing.T) {
+	importer := &snapshotImporter{}
+	for _, key := range [][]byte{[]byte("Xfoo"), []byte("Ntxid"), []byte("ZTnode")} {
+		if err := importer.putState(key, nil); err == nil {
+			t.Fatalf("expect error with state key %q", key)
+		}
+	}
+}
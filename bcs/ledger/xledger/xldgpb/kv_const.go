@@ -12,4 +12,8 @@ const (
 	ExtUtxoTablePrefix       = "ZU"
 	BlockHeightPrefix        = "ZH"
 	BranchInfoPrefix         = "ZI"
+	ContractEventIndexPrefix = "ZE"
+	ContractCallStatPrefix   = "ZS"
+	PruneRetainedTxPrefix    = "ZR"
+	StateTreeNodePrefix      = "ZT"
 )
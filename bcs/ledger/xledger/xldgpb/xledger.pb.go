@@ -867,6 +867,8 @@ type InternalBlock struct {
 	TargetBits  int32             `protobuf:"varint,19,opt,name=targetBits,proto3" json:"targetBits,omitempty"`
 	// Justify used in chained-bft
 	Justify *QuorumCert `protobuf:"bytes,20,opt,name=Justify,proto3" json:"Justify,omitempty"`
+	// 执行本区块后的状态树根，覆盖XModel和UTXO
+	StateRoot []byte `protobuf:"bytes,21,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
 	// 下面的属性会动态变化
 	// If the block is on the trunk
 	InTrunk bool `protobuf:"varint,14,opt,name=in_trunk,json=inTrunk,proto3" json:"in_trunk,omitempty"`
@@ -1028,6 +1030,13 @@ func (m *InternalBlock) GetJustify() *QuorumCert {
 	return nil
 }
 
+func (m *InternalBlock) GetStateRoot() []byte {
+	if m != nil {
+		return m.StateRoot
+	}
+	return nil
+}
+
 func (m *InternalBlock) GetInTrunk() bool {
 	if m != nil {
 		return m.InTrunk
@@ -1538,129 +1547,130 @@ func init() {
 }
 
 var fileDescriptor_b639a3762518476d = []byte{
-	// 1983 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x58, 0xeb, 0x52, 0x1b, 0xc9,
-	0x15, 0xb6, 0x10, 0x20, 0xe9, 0xe8, 0x82, 0x68, 0x3b, 0xde, 0x59, 0xbc, 0xb6, 0xb1, 0xec, 0xd4,
-	0x12, 0x97, 0x03, 0x15, 0xa7, 0xb2, 0xd9, 0xcd, 0xad, 0x0a, 0x24, 0xb1, 0x28, 0x80, 0xc0, 0xcd,
-	0x60, 0x53, 0xa9, 0x54, 0x4d, 0x8d, 0x46, 0x2d, 0xa9, 0x0b, 0x69, 0x5a, 0xe9, 0xee, 0x81, 0xc1,
-	0x8f, 0x90, 0x47, 0xc8, 0x23, 0xe4, 0x41, 0xf2, 0x2f, 0xaf, 0x93, 0xdf, 0xa9, 0xbe, 0x8d, 0x46,
-	0xb8, 0xf0, 0x2f, 0xf5, 0xf9, 0xfa, 0x9c, 0xd3, 0x7d, 0xee, 0x3d, 0x82, 0xef, 0x07, 0x91, 0xd8,
-	0x9b, 0x92, 0xe1, 0x98, 0xf0, 0xbd, 0x34, 0xfb, 0x1d, 0x8e, 0xe7, 0x03, 0x47, 0xee, 0xce, 0x39,
-	0x93, 0x0c, 0xad, 0x1b, 0x74, 0xeb, 0x65, 0x9a, 0xcc, 0x09, 0x8f, 0x18, 0x27, 0x7b, 0x7a, 0x43,
-	0xec, 0x45, 0x2c, 0x96, 0x3c, 0x8c, 0xa4, 0x61, 0xdc, 0x7a, 0xfe, 0x05, 0x43, 0x5e, 0xcf, 0xd6,
-	0xab, 0x2f, 0xb6, 0xe7, 0x84, 0xcf, 0xa8, 0x10, 0x94, 0xc5, 0x86, 0xa5, 0xb5, 0x0f, 0xd5, 0x0f,
-	0xed, 0x0b, 0x3a, 0x8e, 0x7b, 0xf1, 0x88, 0x09, 0xf4, 0x7e, 0x89, 0xf4, 0x0a, 0xdb, 0xc5, 0x9d,
-	0xea, 0xfb, 0xe6, 0xae, 0xb9, 0xcf, 0xae, 0xdb, 0xc0, 0x79, 0xa6, 0xd6, 0x47, 0x28, 0x3b, 0x02,
-	0x79, 0x50, 0xda, 0x1f, 0x0e, 0x39, 0x11, 0x4a, 0xb6, 0xb0, 0x53, 0xc1, 0x8e, 0x44, 0xdf, 0x41,
-	0xe5, 0x3c, 0x19, 0x4c, 0x69, 0x74, 0x4c, 0xee, 0xbc, 0x15, 0xbd, 0xb7, 0x00, 0x10, 0x82, 0x55,
-	0xa5, 0xc3, 0x2b, 0x6e, 0x17, 0x76, 0x6a, 0x58, 0xaf, 0x5b, 0xff, 0x29, 0x00, 0x7c, 0x48, 0x18,
-	0x4f, 0x66, 0x6d, 0xc2, 0x25, 0x7a, 0x01, 0x70, 0xce, 0xd9, 0x9c, 0x89, 0x70, 0xda, 0x1b, 0x6a,
-	0xed, 0x35, 0x9c, 0x43, 0xd0, 0x36, 0x54, 0x1d, 0x75, 0x2a, 0xc6, 0xfa, 0x88, 0x1a, 0xce, 0x43,
-	0xe8, 0x35, 0xac, 0xfa, 0x77, 0x73, 0xa2, 0x0f, 0x69, 0xbc, 0xdf, 0x70, 0x56, 0x7d, 0x68, 0x5f,
-	0xc8, 0x50, 0x12, 0xac, 0x37, 0xd5, 0x31, 0x1f, 0x29, 0xb9, 0xed, 0x27, 0xb3, 0x01, 0xe1, 0xde,
-	0xea, 0x76, 0x61, 0xa7, 0x88, 0x73, 0x08, 0xfa, 0x0d, 0x54, 0x16, 0xfe, 0x59, 0xdb, 0x2e, 0xec,
-	0x54, 0xdf, 0x3f, 0xce, 0x69, 0x72, 0x5b, 0x78, 0xc1, 0xd5, 0xfa, 0x00, 0xeb, 0x47, 0x1d, 0xed,
-	0x9e, 0x16, 0xd4, 0x27, 0xc3, 0x60, 0xae, 0xcd, 0x0e, 0xae, 0xc9, 0x9d, 0x35, 0xa3, 0x3a, 0x19,
-	0x2e, 0x5c, 0xf1, 0x1a, 0xea, 0x8c, 0xd3, 0x31, 0x8d, 0xc3, 0x69, 0x30, 0x09, 0xc5, 0xc4, 0x5a,
-	0x52, 0x73, 0xe0, 0x51, 0x28, 0x26, 0xad, 0x33, 0x68, 0x5c, 0xa9, 0xd8, 0xaa, 0x43, 0x42, 0x99,
-	0x70, 0x82, 0x5e, 0x42, 0x75, 0xa1, 0xd7, 0x44, 0xae, 0x86, 0x61, 0xee, 0xd4, 0xea, 0x00, 0x08,
-	0xc7, 0x6d, 0x75, 0x2e, 0x80, 0xd6, 0xff, 0xd6, 0xa1, 0xea, 0xf3, 0x30, 0x16, 0x61, 0x24, 0x29,
-	0x8b, 0x55, 0x40, 0x64, 0x4a, 0x9d, 0x9f, 0xf5, 0x5a, 0x05, 0x77, 0x30, 0x65, 0xd1, 0x35, 0x1d,
-	0x5a, 0x79, 0x47, 0xa2, 0x77, 0x50, 0x91, 0x69, 0x40, 0xe3, 0x79, 0x22, 0x85, 0x57, 0xd4, 0x49,
-	0xb3, 0x61, 0x12, 0x4c, 0xec, 0xfa, 0x69, 0x4f, 0xe1, 0xb8, 0x2c, 0xcd, 0x42, 0xa0, 0x3d, 0x00,
-	0x99, 0x06, 0x2c, 0x91, 0x9a, 0x7d, 0xd5, 0xe6, 0x58, 0xc6, 0x7e, 0xa6, 0x37, 0x70, 0x45, 0xda,
-	0x95, 0x50, 0x97, 0x19, 0x12, 0x11, 0x79, 0xeb, 0xe6, 0x32, 0x6a, 0x8d, 0xb6, 0xa0, 0x1c, 0x31,
-	0x1a, 0x0f, 0x42, 0x41, 0xbc, 0xd2, 0x76, 0x61, 0xa7, 0x8c, 0x33, 0x1a, 0x3d, 0x81, 0xb5, 0x98,
-	0xc5, 0x11, 0xf1, 0xca, 0x3a, 0xcf, 0x0c, 0xa1, 0x1c, 0x20, 0xe9, 0x8c, 0x08, 0x19, 0xce, 0xe6,
-	0x5e, 0x45, 0x07, 0x76, 0x01, 0x28, 0xe3, 0x6e, 0x08, 0x57, 0x95, 0xe1, 0xc1, 0x76, 0x61, 0x67,
-	0x0d, 0x3b, 0x52, 0xed, 0x84, 0x89, 0x64, 0x63, 0x12, 0x7b, 0x55, 0x7d, 0x90, 0x23, 0xd1, 0x0f,
-	0x50, 0xcf, 0xcc, 0x0e, 0x48, 0x2a, 0xbd, 0x6f, 0xb4, 0x2d, 0xe8, 0x9e, 0xe9, 0xdd, 0x54, 0xe2,
-	0xaa, 0xb3, 0xbe, 0x9b, 0x4a, 0xf4, 0x13, 0x34, 0x16, 0x0e, 0xd0, 0x82, 0x9e, 0x16, 0x7c, 0x7c,
-	0xdf, 0x09, 0x4a, 0xb2, 0x96, 0xf9, 0x41, 0x89, 0x1e, 0xc0, 0xa6, 0xeb, 0x01, 0x01, 0x27, 0xff,
-	0x48, 0x88, 0x90, 0xc2, 0xfb, 0x56, 0x4b, 0xff, 0xc2, 0x49, 0xf7, 0xe2, 0x1b, 0x76, 0x4d, 0xb0,
-	0xd9, 0xc5, 0x4d, 0xc7, 0x6f, 0x01, 0x9d, 0x09, 0x34, 0xa6, 0x92, 0x86, 0x92, 0x71, 0x6f, 0xcb,
-	0x94, 0x62, 0x06, 0xa0, 0x57, 0x50, 0x0b, 0x13, 0x39, 0xd1, 0xda, 0x29, 0x27, 0xde, 0xb3, 0xed,
-	0xe2, 0x4e, 0x05, 0x57, 0x15, 0x86, 0x0d, 0x84, 0xfe, 0x02, 0x1b, 0x19, 0x7f, 0xa0, 0x72, 0x48,
-	0x78, 0xdf, 0x2d, 0x5f, 0x21, 0xcb, 0x4b, 0xdd, 0x2e, 0x1a, 0x19, 0xb7, 0xc2, 0x05, 0x6a, 0x03,
-	0xca, 0x1f, 0x61, 0x55, 0x3c, 0xff, 0x9a, 0x8a, 0x66, 0xee, 0x7c, 0xa3, 0xe4, 0xd7, 0x80, 0x38,
-	0x89, 0x08, 0xbd, 0x21, 0xc3, 0x60, 0x11, 0xd7, 0x17, 0x3a, 0xae, 0x9b, 0x6e, 0xc7, 0xcf, 0xe2,
-	0xfb, 0x3b, 0x00, 0xdd, 0x0d, 0xf5, 0x61, 0xde, 0x4b, 0x5d, 0xb8, 0x4f, 0x5d, 0xe1, 0x2e, 0xd7,
-	0x12, 0xae, 0xa4, 0x8e, 0x46, 0x3f, 0x40, 0x6d, 0xc6, 0x86, 0x74, 0x74, 0x17, 0xe8, 0x5c, 0xf7,
-	0xb6, 0x97, 0x2b, 0xfe, 0x54, 0xef, 0x1d, 0xa8, 0x2d, 0x5c, 0x9d, 0x2d, 0x08, 0xf4, 0x3d, 0x94,
-	0x8e, 0x3a, 0x01, 0x8d, 0x47, 0xcc, 0x7b, 0xa5, 0x45, 0x1a, 0x4e, 0xc4, 0xb4, 0x02, 0x6c, 0x5b,
-	0x42, 0x4b, 0x00, 0x9c, 0xe8, 0x9e, 0x7d, 0x4a, 0x64, 0xa8, 0x9c, 0xcf, 0x19, 0x93, 0x81, 0xab,
-	0x33, 0xdb, 0x1f, 0x14, 0x76, 0x60, 0x6b, 0xed, 0x25, 0x54, 0x25, 0x9d, 0x07, 0xcb, 0x95, 0x08,
-	0x92, 0xce, 0x1d, 0xc3, 0x2b, 0xa8, 0x49, 0x9e, 0xc4, 0xd7, 0xc1, 0x84, 0xd0, 0xf1, 0x44, 0xea,
-	0x76, 0x57, 0xc4, 0x55, 0x8d, 0x1d, 0x69, 0xa8, 0xf5, 0xaf, 0x35, 0x28, 0x5f, 0xca, 0x94, 0xe9,
-	0x33, 0x7f, 0x09, 0x8d, 0x69, 0x28, 0x89, 0xb8, 0x7f, 0x6a, 0xdd, 0xa0, 0x4e, 0x6d, 0x0b, 0xea,
-	0x6a, 0xa5, 0xda, 0x4b, 0x30, 0xa5, 0x42, 0x7a, 0x2b, 0x26, 0x31, 0x14, 0x78, 0x4c, 0xee, 0x4e,
-	0xa8, 0x90, 0xe8, 0x39, 0x40, 0x22, 0x53, 0x16, 0x48, 0x26, 0xc3, 0xa9, 0x3e, 0xb8, 0x82, 0x2b,
-	0x0a, 0xf1, 0x15, 0xa0, 0x6a, 0x36, 0xbc, 0x19, 0x77, 0xc8, 0x34, 0xbc, 0xb3, 0x9d, 0x35, 0xa3,
-	0xd1, 0x3b, 0xd8, 0x4c, 0xe2, 0x88, 0xc5, 0x23, 0xca, 0x67, 0x7e, 0xba, 0x3f, 0x63, 0x49, 0x2c,
-	0x75, 0x7f, 0x2d, 0xe2, 0x2f, 0x37, 0xd0, 0x1b, 0x68, 0xcc, 0xc2, 0xd4, 0x5c, 0x38, 0x10, 0xf4,
-	0x33, 0xd1, 0xbd, 0xa1, 0x88, 0x6b, 0xb3, 0x30, 0xd5, 0x17, 0xbe, 0xa0, 0x9f, 0x09, 0xea, 0xa8,
-	0x14, 0x11, 0x84, 0xab, 0x14, 0x71, 0x55, 0x20, 0xbc, 0xd2, 0xd7, 0xaa, 0x65, 0xd3, 0x09, 0xb4,
-	0x1d, 0xbf, 0xd2, 0x32, 0x62, 0x7c, 0x40, 0x87, 0x43, 0x12, 0x67, 0x6a, 0x74, 0x6b, 0x79, 0x58,
-	0x4b, 0x26, 0xe0, 0xd4, 0xa0, 0x3f, 0xc3, 0xb3, 0x98, 0xdc, 0x06, 0x61, 0x14, 0x29, 0x03, 0x02,
-	0x4e, 0x04, 0x4b, 0x78, 0x44, 0x82, 0xd0, 0x58, 0x6a, 0xfa, 0x91, 0x17, 0x93, 0xdb, 0x7d, 0xc3,
-	0x81, 0x2d, 0x83, 0x35, 0xf8, 0x47, 0xf8, 0x86, 0x72, 0x4e, 0x74, 0x4f, 0x1a, 0x4c, 0x89, 0xb6,
-	0xd1, 0x04, 0x53, 0xb7, 0xab, 0x22, 0x7e, 0x68, 0xfb, 0xbe, 0xe4, 0xc5, 0x94, 0x0e, 0xc9, 0x27,
-	0x1a, 0x0f, 0xd9, 0xad, 0x6e, 0x67, 0xf7, 0x24, 0x73, 0xdb, 0xe8, 0x1d, 0x94, 0xc7, 0xa1, 0x38,
-	0xe7, 0x34, 0x22, 0x5e, 0x4d, 0x9b, 0x9b, 0x75, 0xe9, 0x9f, 0x2d, 0x8e, 0x33, 0x0e, 0xf4, 0x33,
-	0x3c, 0x19, 0x73, 0x96, 0xcc, 0x83, 0x68, 0x12, 0xd2, 0x9c, 0xa3, 0xea, 0x5f, 0x73, 0x14, 0xd2,
-	0x22, 0x6d, 0x25, 0xe1, 0x3c, 0xd5, 0xfa, 0xef, 0x1a, 0xd4, 0x7b, 0xb1, 0x24, 0x3c, 0x0e, 0xa7,
-	0xa6, 0x98, 0x72, 0xbd, 0xb9, 0xb0, 0xdc, 0x9b, 0xb3, 0x4e, 0xbf, 0xa2, 0x71, 0xdb, 0xe9, 0x73,
-	0x83, 0xaa, 0xb8, 0x3c, 0xa8, 0xbe, 0x85, 0xf2, 0x9c, 0x13, 0x33, 0x57, 0x57, 0xcd, 0xd6, 0x9c,
-	0x13, 0x35, 0x52, 0x55, 0x72, 0xce, 0xf5, 0x63, 0x81, 0x70, 0x9d, 0x77, 0x35, 0x9c, 0xd1, 0x6a,
-	0x00, 0xe9, 0xb6, 0x61, 0x07, 0x90, 0x5a, 0xa3, 0xa7, 0xb0, 0x3e, 0x4f, 0x06, 0x6a, 0x88, 0x97,
-	0x34, 0x6a, 0x29, 0x55, 0x9f, 0x33, 0xc2, 0xaf, 0xa7, 0x24, 0x50, 0x55, 0xab, 0xf3, 0xa4, 0x86,
-	0xc1, 0x40, 0x98, 0x31, 0xa9, 0x04, 0x6d, 0x65, 0x9a, 0xa0, 0x5b, 0x6a, 0x79, 0x3e, 0xc1, 0xfd,
-	0xf9, 0xf4, 0x7b, 0x55, 0xd5, 0xd9, 0x7c, 0x16, 0x5e, 0xd5, 0x4e, 0x0c, 0xdb, 0x55, 0x72, 0xb3,
-	0x1b, 0x2f, 0x31, 0x2a, 0x93, 0x65, 0x1a, 0xe8, 0x9c, 0xd2, 0x51, 0x5c, 0xc3, 0x25, 0x99, 0xb6,
-	0x75, 0x52, 0x2d, 0xae, 0x2a, 0x39, 0x21, 0x5e, 0xdd, 0xbc, 0x19, 0x0c, 0xe4, 0x73, 0xa2, 0x1d,
-	0x19, 0x25, 0xdc, 0x27, 0x7c, 0xe6, 0x35, 0xf5, 0x85, 0x1c, 0xa9, 0x5e, 0x5b, 0x51, 0xc2, 0x75,
-	0x78, 0xfa, 0xc9, 0xcc, 0xdb, 0x34, 0x3d, 0x26, 0x07, 0xa1, 0x36, 0xc0, 0x28, 0xa4, 0x53, 0xd5,
-	0x9d, 0x53, 0xe1, 0x21, 0x7d, 0xdd, 0x37, 0xee, 0xba, 0x4b, 0xf1, 0xdd, 0x3d, 0xd4, 0x7c, 0x7e,
-	0x2a, 0xba, 0xb1, 0xe4, 0x77, 0xb8, 0x32, 0x72, 0xb4, 0x7a, 0x8d, 0xc9, 0x90, 0x8f, 0x89, 0x3c,
-	0xa0, 0x52, 0x78, 0x8f, 0xf5, 0xf5, 0x73, 0x08, 0x7a, 0x07, 0xa5, 0xbf, 0x26, 0x42, 0xd2, 0xd1,
-	0x9d, 0xf7, 0x44, 0xe7, 0x19, 0xca, 0xde, 0x62, 0xd9, 0xcb, 0x11, 0x3b, 0x16, 0xe5, 0x0a, 0x1a,
-	0x07, 0xba, 0x11, 0x7a, 0x0d, 0x33, 0xca, 0x69, 0xec, 0x2b, 0x12, 0x3d, 0x83, 0x4a, 0x4c, 0x52,
-	0x69, 0x32, 0x63, 0xc3, 0x84, 0x5f, 0x01, 0x2a, 0x35, 0xb6, 0xfe, 0x04, 0x8d, 0xe5, 0x2b, 0xa2,
-	0x26, 0x14, 0xdd, 0xf3, 0xad, 0x82, 0xd5, 0x52, 0x65, 0xe2, 0x4d, 0x38, 0x4d, 0x88, 0x7d, 0xdb,
-	0x1a, 0xe2, 0x0f, 0x2b, 0x3f, 0x16, 0x5a, 0xff, 0x2c, 0xc0, 0xaa, 0x6a, 0xb6, 0x2a, 0xf0, 0xb6,
-	0xda, 0x4d, 0x83, 0xb5, 0x94, 0xc2, 0x25, 0x53, 0xef, 0x64, 0xdb, 0xcc, 0x2d, 0xa5, 0x32, 0x52,
-	0xb2, 0x73, 0x93, 0x63, 0x26, 0x8f, 0x33, 0x5a, 0x45, 0x86, 0x93, 0x91, 0xaf, 0x9e, 0x68, 0x36,
-	0x8f, 0x2d, 0xa9, 0xd2, 0x88, 0x93, 0xd1, 0xd9, 0x68, 0x24, 0x88, 0x69, 0xa0, 0x6b, 0x78, 0x01,
-	0xb4, 0xfe, 0x5d, 0x80, 0x6a, 0x6e, 0x68, 0xa9, 0xe6, 0x4f, 0x46, 0x23, 0x12, 0x49, 0x7a, 0x43,
-	0x82, 0xec, 0xc5, 0x57, 0xc1, 0xf5, 0x0c, 0xd5, 0x4a, 0x9f, 0xc2, 0xfa, 0x2c, 0xe4, 0xd7, 0xc4,
-	0xcc, 0x9b, 0x32, 0xb6, 0x14, 0xfa, 0x15, 0x34, 0x17, 0xe2, 0x4b, 0xf3, 0x66, 0x23, 0xc3, 0x6d,
-	0x1f, 0x7a, 0x0e, 0x90, 0x7b, 0xf8, 0xae, 0x9a, 0xd9, 0x30, 0xcf, 0x7f, 0x01, 0xe8, 0x12, 0x5b,
-	0xd3, 0x1b, 0x7a, 0xdd, 0x1a, 0x41, 0xdd, 0x4f, 0x3b, 0xa1, 0x0c, 0x6d, 0x4f, 0xd4, 0x4f, 0xb1,
-	0xe5, 0xcf, 0x0b, 0x4b, 0xe6, 0x7c, 0x6b, 0xfc, 0xef, 0x7c, 0xfb, 0x1a, 0xea, 0x23, 0xce, 0x3e,
-	0x93, 0x78, 0xf9, 0x76, 0x35, 0x03, 0xda, 0x71, 0xc8, 0x00, 0x54, 0x80, 0x30, 0x89, 0x18, 0xd7,
-	0x0e, 0x54, 0x23, 0xab, 0x9d, 0x45, 0xca, 0xce, 0x30, 0x53, 0x33, 0x2f, 0xcc, 0x88, 0xdb, 0xcf,
-	0x1f, 0x96, 0x43, 0xd4, 0x47, 0x06, 0x95, 0x64, 0x96, 0xbd, 0x82, 0x6d, 0x3a, 0x2a, 0xfd, 0xc7,
-	0xe4, 0x0e, 0xeb, 0xcd, 0xd6, 0x05, 0x94, 0x2c, 0x90, 0x0f, 0xa4, 0x35, 0xc9, 0x05, 0xf2, 0x29,
-	0xac, 0x33, 0x13, 0x45, 0x6b, 0x92, 0xa1, 0x72, 0xa6, 0x16, 0xf3, 0xa6, 0xaa, 0xd0, 0x36, 0x17,
-	0x66, 0x74, 0x88, 0x0c, 0xe9, 0x14, 0xed, 0x42, 0x99, 0xcd, 0x49, 0xac, 0x70, 0xad, 0x3f, 0x57,
-	0x21, 0x0b, 0x5e, 0x9c, 0xf1, 0xa0, 0xf7, 0x00, 0x2a, 0x2f, 0xc8, 0x50, 0x4b, 0xac, 0x3c, 0x28,
-	0x91, 0xe3, 0x52, 0x32, 0xc6, 0x9d, 0x5a, 0xa6, 0xf8, 0xb0, 0xcc, 0x82, 0xab, 0xd5, 0x83, 0xcd,
-	0x83, 0x70, 0x1a, 0xc6, 0x11, 0x31, 0x17, 0x75, 0x5f, 0x8f, 0x03, 0x03, 0x3a, 0x5f, 0x58, 0x52,
-	0x95, 0x02, 0x15, 0x87, 0x5a, 0xdc, 0x66, 0x60, 0x46, 0xb7, 0xfe, 0x6e, 0xa2, 0x67, 0x1e, 0xc9,
-	0x68, 0x07, 0xca, 0x2a, 0x1a, 0xea, 0x39, 0x62, 0x3f, 0x5f, 0x6b, 0x4b, 0x57, 0xc9, 0x76, 0xd1,
-	0x1b, 0xa8, 0xeb, 0x77, 0xca, 0x05, 0x99, 0x92, 0x48, 0xda, 0xd4, 0xae, 0xe0, 0x65, 0xf0, 0xed,
-	0x2d, 0x6c, 0xe6, 0x7a, 0xab, 0xfa, 0x52, 0x4c, 0x04, 0xda, 0x80, 0xaa, 0x7f, 0x15, 0x5c, 0xf6,
-	0x3b, 0xdd, 0xc3, 0x5e, 0xbf, 0xdb, 0x7c, 0x84, 0x1a, 0x00, 0xfe, 0x55, 0xd0, 0x3f, 0xeb, 0x5e,
-	0xf5, 0x2e, 0xfc, 0x66, 0xc1, 0xd2, 0xed, 0xb3, 0xfe, 0x61, 0x0f, 0x9f, 0x36, 0x57, 0x50, 0x13,
-	0x6a, 0xfe, 0x55, 0x70, 0x78, 0x89, 0xdb, 0xfb, 0x7e, 0xef, 0xac, 0xdf, 0x2c, 0x5a, 0xe4, 0xb2,
-	0xef, 0x78, 0x56, 0x51, 0x1d, 0x2a, 0x8a, 0x67, 0xbf, 0x77, 0xd2, 0xed, 0x34, 0xd7, 0xde, 0xfa,
-	0x50, 0x35, 0x2f, 0x99, 0xec, 0xc8, 0x83, 0x93, 0xb3, 0xf6, 0x71, 0xd0, 0xc5, 0xf8, 0x0c, 0x37,
-	0x1f, 0x2d, 0x00, 0x1f, 0x5f, 0xf6, 0x8f, 0x9b, 0x05, 0xa5, 0xd1, 0x00, 0x07, 0x78, 0xbf, 0xdf,
-	0x3e, 0x6a, 0xae, 0xa0, 0x4d, 0xa8, 0x1b, 0xc4, 0x5d, 0xac, 0xf8, 0xf6, 0x04, 0x4a, 0xf6, 0x7b,
-	0x17, 0xd5, 0xa0, 0xdc, 0xef, 0x7e, 0x0a, 0x3e, 0xf6, 0xba, 0x9f, 0x9a, 0x8f, 0x50, 0x15, 0x4a,
-	0xe7, 0xb8, 0x7b, 0xbe, 0x8f, 0xbb, 0xe6, 0xfa, 0xe7, 0xb8, 0x1b, 0xb4, 0xcf, 0x4e, 0x4f, 0x7b,
-	0x7e, 0x73, 0x05, 0x01, 0xac, 0xdb, 0x75, 0x51, 0xad, 0x3b, 0xdd, 0x76, 0xaf, 0xd3, 0x6d, 0xae,
-	0x1e, 0xfc, 0xf1, 0x6f, 0x3f, 0x8d, 0xa9, 0x9c, 0x24, 0x83, 0xdd, 0x88, 0xcd, 0xf6, 0xcc, 0xbf,
-	0x0d, 0x6a, 0x96, 0xef, 0x2d, 0xfe, 0x78, 0x78, 0xf0, 0x3f, 0x8f, 0xc1, 0xba, 0x7e, 0x11, 0xfc,
-	0xf6, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x36, 0xac, 0x5e, 0x88, 0x17, 0x11, 0x00, 0x00,
+	// 1988 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x58, 0xeb, 0x6e, 0x1b, 0xb9,
+	0x15, 0x8e, 0x2c, 0x5f, 0xa4, 0xa3, 0x8b, 0x65, 0x26, 0x9b, 0x9d, 0x38, 0x9b, 0x8d, 0xa2, 0x4d,
+	0xb1, 0x6e, 0x90, 0xda, 0x68, 0x8a, 0x6e, 0x77, 0x7b, 0x03, 0x6c, 0x49, 0xd9, 0xa8, 0x89, 0x65,
+	0x87, 0x9e, 0x5c, 0x50, 0x14, 0x18, 0x8c, 0x46, 0x94, 0x44, 0x58, 0x1a, 0xaa, 0x24, 0xc7, 0x19,
+	0xe7, 0x11, 0xfa, 0x08, 0x7d, 0x84, 0x3e, 0x48, 0x9f, 0xa6, 0xbf, 0xfb, 0x7b, 0x71, 0x48, 0xce,
+	0x68, 0x94, 0xc0, 0xf9, 0x25, 0x9e, 0xef, 0x9c, 0x43, 0xf2, 0xf0, 0x5c, 0x47, 0xf0, 0xfd, 0x28,
+	0x52, 0x47, 0x73, 0x36, 0x9e, 0x32, 0x79, 0x94, 0xe6, 0xbf, 0xe3, 0xe9, 0x72, 0x94, 0x91, 0x87,
+	0x4b, 0x29, 0xb4, 0x20, 0xdb, 0x16, 0xdd, 0x7f, 0x98, 0x26, 0x4b, 0x26, 0x23, 0x21, 0xd9, 0x91,
+	0x61, 0xa8, 0xa3, 0x48, 0xc4, 0x5a, 0x86, 0x91, 0xb6, 0x82, 0xfb, 0x0f, 0x3e, 0x13, 0x28, 0xee,
+	0xb3, 0xff, 0xe8, 0x33, 0xf6, 0x92, 0xc9, 0x05, 0x57, 0x8a, 0x8b, 0xd8, 0x8a, 0x74, 0x8e, 0xa1,
+	0xf6, 0xba, 0x7b, 0xc1, 0xa7, 0xf1, 0x20, 0x9e, 0x08, 0x45, 0x9e, 0xad, 0x91, 0x5e, 0xa9, 0x5d,
+	0x3e, 0xa8, 0x3d, 0x6b, 0x1d, 0xda, 0xfb, 0x1c, 0x66, 0x0c, 0x5a, 0x14, 0xea, 0xbc, 0x85, 0x4a,
+	0x46, 0x10, 0x0f, 0x76, 0x8e, 0xc7, 0x63, 0xc9, 0x14, 0xea, 0x96, 0x0e, 0xaa, 0x34, 0x23, 0xc9,
+	0x37, 0x50, 0x3d, 0x4f, 0x46, 0x73, 0x1e, 0xbd, 0x64, 0xd7, 0xde, 0x86, 0xe1, 0xad, 0x00, 0x42,
+	0x60, 0x13, 0xf7, 0xf0, 0xca, 0xed, 0xd2, 0x41, 0x9d, 0x9a, 0x75, 0xe7, 0xbf, 0x25, 0x80, 0xd7,
+	0x89, 0x90, 0xc9, 0xa2, 0xcb, 0xa4, 0x26, 0xdf, 0x02, 0x9c, 0x4b, 0xb1, 0x14, 0x2a, 0x9c, 0x0f,
+	0xc6, 0x66, 0xf7, 0x3a, 0x2d, 0x20, 0xa4, 0x0d, 0xb5, 0x8c, 0x3a, 0x55, 0x53, 0x73, 0x44, 0x9d,
+	0x16, 0x21, 0xf2, 0x1d, 0x6c, 0xfa, 0xd7, 0x4b, 0x66, 0x0e, 0x69, 0x3e, 0xdb, 0xcd, 0xac, 0x7a,
+	0xdd, 0xbd, 0xd0, 0xa1, 0x66, 0xd4, 0x30, 0xf1, 0x98, 0xb7, 0x9c, 0x7d, 0x18, 0x26, 0x8b, 0x11,
+	0x93, 0xde, 0x66, 0xbb, 0x74, 0x50, 0xa6, 0x05, 0x84, 0xfc, 0x16, 0xaa, 0xab, 0xf7, 0xd9, 0x6a,
+	0x97, 0x0e, 0x6a, 0xcf, 0x6e, 0x17, 0x76, 0xca, 0x58, 0x74, 0x25, 0xd5, 0x79, 0x0d, 0xdb, 0x2f,
+	0x7a, 0xb8, 0x24, 0x1d, 0x68, 0xcc, 0xc6, 0xc1, 0xd2, 0x98, 0x1d, 0x5c, 0xb2, 0x6b, 0x67, 0x46,
+	0x6d, 0x36, 0x5e, 0x3d, 0xc5, 0x77, 0xd0, 0x10, 0x92, 0x4f, 0x79, 0x1c, 0xce, 0x83, 0x59, 0xa8,
+	0x66, 0xce, 0x92, 0x7a, 0x06, 0xbe, 0x08, 0xd5, 0xac, 0x73, 0x06, 0xcd, 0xf7, 0xe8, 0x5b, 0x3c,
+	0x24, 0xd4, 0x89, 0x64, 0xe4, 0x21, 0xd4, 0x56, 0xfb, 0x5a, 0xcf, 0xd5, 0x29, 0x2c, 0xb3, 0x6d,
+	0x8d, 0x03, 0x54, 0x26, 0xed, 0xf6, 0x5c, 0x01, 0x9d, 0xff, 0x6f, 0x43, 0xcd, 0x97, 0x61, 0xac,
+	0xc2, 0x48, 0x73, 0x11, 0xa3, 0x43, 0x74, 0xca, 0xb3, 0x77, 0x36, 0x6b, 0x74, 0xee, 0x68, 0x2e,
+	0xa2, 0x4b, 0x3e, 0x76, 0xfa, 0x19, 0x49, 0x9e, 0x42, 0x55, 0xa7, 0x01, 0x8f, 0x97, 0x89, 0x56,
+	0x5e, 0xd9, 0x04, 0xcd, 0xae, 0x0d, 0x30, 0x75, 0xe8, 0xa7, 0x03, 0xc4, 0x69, 0x45, 0xdb, 0x85,
+	0x22, 0x47, 0x00, 0x3a, 0x0d, 0x44, 0xa2, 0x8d, 0xf8, 0xa6, 0x8b, 0xb1, 0x5c, 0xfc, 0xcc, 0x30,
+	0x68, 0x55, 0xbb, 0x95, 0xc2, 0xcb, 0x8c, 0x99, 0x8a, 0xbc, 0x6d, 0x7b, 0x19, 0x5c, 0x93, 0x7d,
+	0xa8, 0x44, 0x82, 0xc7, 0xa3, 0x50, 0x31, 0x6f, 0xa7, 0x5d, 0x3a, 0xa8, 0xd0, 0x9c, 0x26, 0x77,
+	0x60, 0x2b, 0x16, 0x71, 0xc4, 0xbc, 0x8a, 0x89, 0x33, 0x4b, 0xe0, 0x03, 0x68, 0xbe, 0x60, 0x4a,
+	0x87, 0x8b, 0xa5, 0x57, 0x35, 0x8e, 0x5d, 0x01, 0x68, 0xdc, 0x15, 0x93, 0x98, 0x19, 0x1e, 0xb4,
+	0x4b, 0x07, 0x5b, 0x34, 0x23, 0x91, 0x13, 0x26, 0x5a, 0x4c, 0x59, 0xec, 0xd5, 0xcc, 0x41, 0x19,
+	0x49, 0x7e, 0x80, 0x46, 0x6e, 0x76, 0xc0, 0x52, 0xed, 0x7d, 0x6d, 0x6c, 0x21, 0x9f, 0x98, 0xde,
+	0x4f, 0x35, 0xad, 0x65, 0xd6, 0xf7, 0x53, 0x4d, 0x7e, 0x82, 0xe6, 0xea, 0x01, 0x8c, 0xa2, 0x67,
+	0x14, 0x6f, 0x7f, 0xfa, 0x08, 0xa8, 0x59, 0xcf, 0xdf, 0x01, 0x55, 0x4f, 0x60, 0x2f, 0xab, 0x01,
+	0x81, 0x64, 0xff, 0x4c, 0x98, 0xd2, 0xca, 0xbb, 0x67, 0xb4, 0xbf, 0xca, 0xb4, 0x07, 0xf1, 0x95,
+	0xb8, 0x64, 0xd4, 0x72, 0x69, 0x2b, 0x93, 0x77, 0x80, 0x89, 0x04, 0x1e, 0x73, 0xcd, 0x43, 0x2d,
+	0xa4, 0xb7, 0x6f, 0x53, 0x31, 0x07, 0xc8, 0x23, 0xa8, 0x87, 0x89, 0x9e, 0x99, 0xdd, 0xb9, 0x64,
+	0xde, 0xfd, 0x76, 0xf9, 0xa0, 0x4a, 0x6b, 0x88, 0x51, 0x0b, 0x91, 0xbf, 0xc2, 0x6e, 0x2e, 0x1f,
+	0x60, 0x0c, 0x29, 0xef, 0x9b, 0xf5, 0x2b, 0xe4, 0x71, 0x69, 0xca, 0x45, 0x33, 0x97, 0x46, 0x5c,
+	0x91, 0x2e, 0x90, 0xe2, 0x11, 0x6e, 0x8b, 0x07, 0x5f, 0xda, 0xa2, 0x55, 0x38, 0xdf, 0x6e, 0xf2,
+	0x1b, 0x20, 0x92, 0x45, 0x8c, 0x5f, 0xb1, 0x71, 0xb0, 0xf2, 0xeb, 0xb7, 0xc6, 0xaf, 0x7b, 0x19,
+	0xc7, 0xcf, 0xfd, 0xfb, 0x7b, 0x00, 0x53, 0x0d, 0xcd, 0x61, 0xde, 0x43, 0x93, 0xb8, 0x77, 0xb3,
+	0xc4, 0x5d, 0xcf, 0x25, 0x5a, 0x4d, 0x33, 0x9a, 0xfc, 0x00, 0xf5, 0x85, 0x18, 0xf3, 0xc9, 0x75,
+	0x60, 0x62, 0xdd, 0x6b, 0xaf, 0x67, 0xfc, 0xa9, 0xe1, 0x9d, 0x20, 0x8b, 0xd6, 0x16, 0x2b, 0x82,
+	0x7c, 0x0f, 0x3b, 0x2f, 0x7a, 0x01, 0x8f, 0x27, 0xc2, 0x7b, 0x64, 0x54, 0x9a, 0x99, 0x8a, 0x2d,
+	0x05, 0xd4, 0x95, 0x84, 0x8e, 0x02, 0x78, 0x65, 0x6a, 0xf6, 0x29, 0xd3, 0x21, 0x3e, 0xbe, 0x14,
+	0x42, 0x07, 0x59, 0x9e, 0xb9, 0xfa, 0x80, 0xd8, 0x89, 0x85, 0x30, 0xd1, 0x35, 0x5f, 0x06, 0xeb,
+	0x99, 0x08, 0x9a, 0x2f, 0x33, 0x81, 0x47, 0x50, 0xd7, 0x32, 0x89, 0x2f, 0x83, 0x19, 0xe3, 0xd3,
+	0x99, 0x36, 0xe5, 0xae, 0x4c, 0x6b, 0x06, 0x7b, 0x61, 0xa0, 0xce, 0xbf, 0xb7, 0xa0, 0xf2, 0x46,
+	0xa7, 0xc2, 0x9c, 0xf9, 0x2b, 0x68, 0xce, 0x43, 0xcd, 0xd4, 0xa7, 0xa7, 0x36, 0x2c, 0x9a, 0x6d,
+	0xdb, 0x81, 0x06, 0xae, 0xb0, 0xbc, 0x04, 0x73, 0xae, 0xb4, 0xb7, 0x61, 0x03, 0x03, 0xc1, 0x97,
+	0xec, 0xfa, 0x15, 0x57, 0x9a, 0x3c, 0x00, 0x48, 0x74, 0x2a, 0x02, 0x2d, 0x74, 0x38, 0x37, 0x07,
+	0x57, 0x69, 0x15, 0x11, 0x1f, 0x01, 0xcc, 0xd9, 0xf0, 0x6a, 0xda, 0x63, 0xf3, 0xf0, 0xda, 0x55,
+	0xd6, 0x9c, 0x26, 0x4f, 0x61, 0x2f, 0x89, 0x23, 0x11, 0x4f, 0xb8, 0x5c, 0xf8, 0xe9, 0xf1, 0x42,
+	0x24, 0xb1, 0x36, 0xf5, 0xb5, 0x4c, 0x3f, 0x67, 0x90, 0xc7, 0xd0, 0x5c, 0x84, 0xa9, 0xbd, 0x70,
+	0xa0, 0xf8, 0x47, 0x66, 0x6a, 0x43, 0x99, 0xd6, 0x17, 0x61, 0x6a, 0x2e, 0x7c, 0xc1, 0x3f, 0x32,
+	0xd2, 0xc3, 0x10, 0x51, 0x4c, 0x62, 0x88, 0x64, 0x59, 0xa0, 0xbc, 0x9d, 0x2f, 0x65, 0xcb, 0x5e,
+	0xa6, 0xd0, 0xcd, 0xe4, 0x71, 0x97, 0x89, 0x90, 0x23, 0x3e, 0x1e, 0xb3, 0x38, 0xdf, 0xc6, 0x94,
+	0x96, 0x9b, 0x77, 0xc9, 0x15, 0xb2, 0x6d, 0xc8, 0x5f, 0xe0, 0x7e, 0xcc, 0x3e, 0x04, 0x61, 0x14,
+	0xa1, 0x01, 0x81, 0x64, 0x4a, 0x24, 0x32, 0x62, 0x41, 0x68, 0x2d, 0xb5, 0xf5, 0xc8, 0x8b, 0xd9,
+	0x87, 0x63, 0x2b, 0x41, 0x9d, 0x80, 0x33, 0xf8, 0x47, 0xf8, 0x9a, 0x4b, 0xc9, 0x4c, 0x4d, 0x1a,
+	0xcd, 0x99, 0xb1, 0xd1, 0x3a, 0xd3, 0x94, 0xab, 0x32, 0xbd, 0x89, 0xfd, 0xa9, 0xe6, 0xc5, 0x9c,
+	0x8f, 0xd9, 0x3b, 0x1e, 0x8f, 0xc5, 0x07, 0xaf, 0xf6, 0xb9, 0x66, 0x81, 0x4d, 0x9e, 0x42, 0x65,
+	0x1a, 0xaa, 0x73, 0xc9, 0x23, 0xe6, 0xd5, 0xdb, 0xa5, 0x62, 0x95, 0xfe, 0xd9, 0xe1, 0x34, 0x97,
+	0x20, 0x3f, 0xc3, 0x9d, 0xa9, 0x14, 0xc9, 0x32, 0x88, 0x66, 0x21, 0x2f, 0x3c, 0x54, 0xe3, 0x4b,
+	0x0f, 0x45, 0x8c, 0x4a, 0x17, 0x35, 0xb2, 0x97, 0xea, 0xfc, 0x6f, 0x0b, 0x1a, 0x83, 0x58, 0x33,
+	0x19, 0x87, 0x73, 0x9b, 0x4c, 0x85, 0xda, 0x5c, 0x5a, 0xaf, 0xcd, 0x79, 0xa5, 0xdf, 0x30, 0xb8,
+	0x25, 0x8a, 0x8d, 0xaa, 0xbc, 0xde, 0xa8, 0xee, 0x41, 0x65, 0x29, 0x99, 0xed, 0xab, 0x9b, 0x96,
+	0xb5, 0x94, 0x0c, 0x5b, 0x2a, 0x06, 0xe7, 0xd2, 0x0c, 0x0b, 0x4c, 0x9a, 0xb8, 0xab, 0xd3, 0x9c,
+	0xc6, 0x06, 0x64, 0xca, 0x86, 0x6b, 0x40, 0xb8, 0x26, 0x77, 0x61, 0x7b, 0x99, 0x8c, 0xb0, 0x89,
+	0xef, 0x18, 0xd4, 0x51, 0x98, 0x9f, 0x0b, 0x26, 0x2f, 0xe7, 0x2c, 0xc0, 0xac, 0x35, 0x71, 0x52,
+	0xa7, 0x60, 0x21, 0x2a, 0x84, 0x46, 0x45, 0x97, 0x99, 0xd6, 0xe9, 0x8e, 0x5a, 0xef, 0x4f, 0xf0,
+	0x69, 0x7f, 0xfa, 0x03, 0x66, 0x75, 0xde, 0x9f, 0x95, 0x57, 0x73, 0x1d, 0xc3, 0x55, 0x95, 0x42,
+	0xef, 0xa6, 0x6b, 0x82, 0x68, 0xb2, 0x4e, 0x03, 0x13, 0x53, 0xc6, 0x8b, 0x5b, 0x74, 0x47, 0xa7,
+	0x5d, 0x24, 0x0b, 0x57, 0xd5, 0x92, 0x31, 0xaf, 0x61, 0x67, 0x06, 0x0b, 0xf9, 0x92, 0x99, 0x87,
+	0x8c, 0x12, 0xe9, 0x33, 0xb9, 0xf0, 0x5a, 0xe6, 0x42, 0x19, 0x89, 0xd3, 0x56, 0x94, 0x48, 0xe3,
+	0x9e, 0x61, 0xb2, 0xf0, 0xf6, 0x0c, 0xb7, 0x08, 0x91, 0x2e, 0xc0, 0x24, 0xe4, 0x73, 0xac, 0xce,
+	0xa9, 0xf2, 0x88, 0xb9, 0xee, 0xe3, 0xec, 0xba, 0x6b, 0xfe, 0x3d, 0x7c, 0x6e, 0xe4, 0xfc, 0x54,
+	0xf5, 0x63, 0x2d, 0xaf, 0x69, 0x75, 0x92, 0xd1, 0x38, 0x8d, 0xe9, 0x50, 0x4e, 0x99, 0x3e, 0xe1,
+	0x5a, 0x79, 0xb7, 0xcd, 0xf5, 0x0b, 0x08, 0x79, 0x0a, 0x3b, 0x7f, 0x4b, 0x94, 0xe6, 0x93, 0x6b,
+	0xef, 0x8e, 0x89, 0x33, 0x92, 0xcf, 0x62, 0xf9, 0xe4, 0x48, 0x33, 0x11, 0x2c, 0x4f, 0x4a, 0x87,
+	0xda, 0x79, 0xe6, 0x2b, 0x37, 0x03, 0x21, 0x62, 0x1c, 0x73, 0x0f, 0x2a, 0x3c, 0x0e, 0x4c, 0x9d,
+	0xf4, 0x9a, 0xb6, 0xd3, 0xf3, 0xd8, 0x47, 0x92, 0xdc, 0x87, 0x6a, 0xcc, 0x52, 0x6d, 0x03, 0x67,
+	0xd7, 0x46, 0x07, 0x02, 0x18, 0x39, 0xfb, 0x7f, 0x86, 0xe6, 0xba, 0x05, 0xa4, 0x05, 0xe5, 0x6c,
+	0xba, 0xab, 0x52, 0x5c, 0x62, 0xa0, 0x5e, 0x85, 0xf3, 0x84, 0xb9, 0xd1, 0xd7, 0x12, 0x7f, 0xdc,
+	0xf8, 0xb1, 0xd4, 0xf9, 0x57, 0x09, 0x36, 0xb1, 0x16, 0x63, 0x5c, 0xb8, 0x62, 0x60, 0xeb, 0xaf,
+	0xa3, 0x10, 0xd7, 0x02, 0xc7, 0x68, 0x57, 0xeb, 0x1d, 0x85, 0x01, 0xab, 0xc5, 0xb9, 0x0d, 0x41,
+	0x1b, 0xe6, 0x39, 0x8d, 0x8e, 0x93, 0x6c, 0xe2, 0xe3, 0x04, 0xe7, 0xc2, 0xdc, 0x91, 0x18, 0x65,
+	0x92, 0x4d, 0xce, 0x26, 0x13, 0xc5, 0x6c, 0x7d, 0xdd, 0xa2, 0x2b, 0xa0, 0xf3, 0x9f, 0x12, 0xd4,
+	0x0a, 0x3d, 0x0d, 0x7b, 0x03, 0x9b, 0x4c, 0x58, 0xa4, 0xf9, 0x15, 0x0b, 0xf2, 0x81, 0xb0, 0x4a,
+	0x1b, 0x39, 0x6a, 0x36, 0xbd, 0x0b, 0xdb, 0x8b, 0x50, 0x5e, 0x32, 0xdb, 0x8e, 0x2a, 0xd4, 0x51,
+	0xe4, 0xd7, 0xd0, 0x5a, 0xa9, 0xaf, 0xb5, 0xa3, 0xdd, 0x1c, 0x77, 0x65, 0xea, 0x01, 0x40, 0x61,
+	0x2e, 0xde, 0xb4, 0xad, 0x63, 0x59, 0xfc, 0x40, 0x30, 0x19, 0xb8, 0x65, 0x18, 0x66, 0xdd, 0x99,
+	0x40, 0xc3, 0x4f, 0x7b, 0xa1, 0x0e, 0x5d, 0xc9, 0x34, 0x93, 0xda, 0xfa, 0xd7, 0x87, 0x23, 0x0b,
+	0x6f, 0x6b, 0xdf, 0xdf, 0x51, 0x38, 0x6c, 0x4f, 0xa4, 0xf8, 0xc8, 0xe2, 0xf5, 0xdb, 0xd5, 0x2d,
+	0xe8, 0xba, 0xa5, 0x00, 0x40, 0x07, 0x51, 0x16, 0x09, 0x69, 0x1e, 0x10, 0x3b, 0x5a, 0x37, 0xf7,
+	0x54, 0x95, 0xae, 0x00, 0x0c, 0x58, 0x24, 0x8e, 0x8b, 0x87, 0x15, 0x10, 0xfc, 0x06, 0xe1, 0x9a,
+	0x2d, 0xf2, 0x21, 0xd9, 0x45, 0x2b, 0xee, 0xff, 0x92, 0x5d, 0x53, 0xc3, 0xec, 0x5c, 0xc0, 0x8e,
+	0x03, 0x8a, 0x8e, 0x74, 0x26, 0x39, 0x12, 0x4d, 0x12, 0xd6, 0x8b, 0xce, 0x24, 0x4b, 0x15, 0x4c,
+	0x2d, 0x17, 0x4d, 0x45, 0xd7, 0xb6, 0x56, 0x66, 0xf4, 0x98, 0x0e, 0xf9, 0x9c, 0x1c, 0x42, 0x45,
+	0x2c, 0x59, 0x8c, 0xb8, 0x57, 0x5a, 0x4f, 0xa0, 0x95, 0x2c, 0xcd, 0x65, 0xc8, 0x33, 0x00, 0x8c,
+	0x0b, 0x36, 0x36, 0x1a, 0x1b, 0x37, 0x6a, 0x14, 0xa4, 0x50, 0xc7, 0x3e, 0xa7, 0xd1, 0x29, 0xdf,
+	0xac, 0xb3, 0x92, 0xea, 0x0c, 0x60, 0xef, 0x24, 0x9c, 0x87, 0x71, 0xc4, 0xec, 0x45, 0xb3, 0x8f,
+	0xcb, 0x91, 0x05, 0xb3, 0xb7, 0x70, 0x24, 0xa6, 0x02, 0x57, 0xcf, 0x8d, 0xba, 0x8b, 0xc0, 0x9c,
+	0xee, 0xfc, 0xc3, 0x7a, 0xcf, 0xce, 0xd0, 0xe4, 0x00, 0x2a, 0xe8, 0x0d, 0x9c, 0x56, 0xdc, 0xd7,
+	0x6d, 0x7d, 0xed, 0x2a, 0x39, 0x97, 0x3c, 0x86, 0x86, 0x19, 0x63, 0x2e, 0xd8, 0x9c, 0x45, 0xda,
+	0x85, 0x76, 0x95, 0xae, 0x83, 0x4f, 0x3e, 0xc0, 0x5e, 0xa1, 0xf4, 0xe2, 0x87, 0x64, 0xa2, 0xc8,
+	0x2e, 0xd4, 0xfc, 0xf7, 0xc1, 0x9b, 0x61, 0xaf, 0xff, 0x7c, 0x30, 0xec, 0xb7, 0x6e, 0x91, 0x26,
+	0x80, 0xff, 0x3e, 0x18, 0x9e, 0xf5, 0xdf, 0x0f, 0x2e, 0xfc, 0x56, 0xc9, 0xd1, 0xdd, 0xb3, 0xe1,
+	0xf3, 0x01, 0x3d, 0x6d, 0x6d, 0x90, 0x16, 0xd4, 0xfd, 0xf7, 0xc1, 0xf3, 0x37, 0xb4, 0x7b, 0xec,
+	0x0f, 0xce, 0x86, 0xad, 0xb2, 0x43, 0xde, 0x0c, 0x33, 0x99, 0x4d, 0xd2, 0x80, 0x2a, 0xca, 0x1c,
+	0x0f, 0x5e, 0xf5, 0x7b, 0xad, 0xad, 0x27, 0x3e, 0xd4, 0xec, 0xa0, 0x93, 0x1f, 0x79, 0xf2, 0xea,
+	0xac, 0xfb, 0x32, 0xe8, 0x53, 0x7a, 0x46, 0x5b, 0xb7, 0x56, 0x80, 0x4f, 0xdf, 0x0c, 0x5f, 0xb6,
+	0x4a, 0xb8, 0xa3, 0x05, 0x4e, 0xe8, 0xf1, 0xb0, 0xfb, 0xa2, 0xb5, 0x41, 0xf6, 0xa0, 0x61, 0x91,
+	0xec, 0x62, 0xe5, 0x27, 0xaf, 0x60, 0xc7, 0x7d, 0x0e, 0x93, 0x3a, 0x54, 0x86, 0xfd, 0x77, 0xc1,
+	0xdb, 0x41, 0xff, 0x5d, 0xeb, 0x16, 0xa9, 0xc1, 0xce, 0x39, 0xed, 0x9f, 0x1f, 0xd3, 0xbe, 0xbd,
+	0xfe, 0x39, 0xed, 0x07, 0xdd, 0xb3, 0xd3, 0xd3, 0x81, 0xdf, 0xda, 0x20, 0x00, 0xdb, 0x6e, 0x5d,
+	0xc6, 0x75, 0xaf, 0xdf, 0x1d, 0xf4, 0xfa, 0xad, 0xcd, 0x93, 0x3f, 0xfd, 0xfd, 0xa7, 0x29, 0xd7,
+	0xb3, 0x64, 0x74, 0x18, 0x89, 0xc5, 0x91, 0xfd, 0x33, 0x02, 0x5b, 0xfd, 0xd1, 0xea, 0x7f, 0x89,
+	0x1b, 0xff, 0x12, 0x19, 0x6d, 0x9b, 0x81, 0xe1, 0x77, 0xbf, 0x0c, 0x00, 0x85, 0xca, 0x3a, 0x5a,
+	0x36, 0x11, 0x00, 0x00,
 }
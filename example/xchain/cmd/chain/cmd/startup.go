@@ -28,6 +28,7 @@ import (
 	_ "github.com/xuperchain/xupercore/kernel/contract/manager"
 	_ "github.com/xuperchain/xupercore/lib/crypto/client"
 	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/pebble"
 
 	"github.com/spf13/cobra"
 )
@@ -10,6 +10,7 @@ import (
 	xdef "github.com/xuperchain/xupercore/example/xchain/common/def"
 	"github.com/xuperchain/xupercore/example/xchain/common/xchainpb"
 	"github.com/xuperchain/xupercore/lib/utils"
+	"github.com/xuperchain/xupercore/protos"
 
 	"google.golang.org/grpc"
 )
@@ -55,6 +56,101 @@ func (t *XchainClient) PreExec() (*xchainpb.PreExecResp, error) {
 	return nil, fmt.Errorf("not impl")
 }
 
+func (t *XchainClient) QueryContract(request *protos.InvokeRequest, blockId string) (*xchainpb.QueryContractResp, error) {
+	req := &xchainpb.QueryContractReq{
+		Header:  t.genReqHeader(),
+		Bcname:  global.GFlagBCName,
+		Request: request,
+		BlockId: utils.DecodeId(blockId),
+	}
+
+	ctx := context.TODO()
+	resp, err := t.xclient.QueryContract(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetHeader().GetErrCode() != 0 {
+		return nil, fmt.Errorf("ErrCode:%d ErrMsg:%s LogId:%s TraceId:%s", resp.GetHeader().GetErrCode(),
+			resp.GetHeader().GetErrMsg(), resp.GetHeader().GetLogId(), resp.GetHeader().GetTraceId())
+	}
+
+	return resp, nil
+}
+
+func (t *XchainClient) QueryStateAtHeight(bucket, key string,
+	height int64) (*xchainpb.QueryStateAtHeightResp, error) {
+	req := &xchainpb.QueryStateAtHeightReq{
+		Header: t.genReqHeader(),
+		Bcname: global.GFlagBCName,
+		Bucket: bucket,
+		Key:    []byte(key),
+		Height: height,
+	}
+
+	ctx := context.TODO()
+	resp, err := t.xclient.QueryStateAtHeight(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetHeader().GetErrCode() != 0 {
+		return nil, fmt.Errorf("ErrCode:%d ErrMsg:%s LogId:%s TraceId:%s", resp.GetHeader().GetErrCode(),
+			resp.GetHeader().GetErrMsg(), resp.GetHeader().GetLogId(), resp.GetHeader().GetTraceId())
+	}
+
+	return resp, nil
+}
+
+func (t *XchainClient) SelectStateAtHeight(bucket, startKey, endKey string,
+	height, limit int64) (*xchainpb.SelectStateAtHeightResp, error) {
+	req := &xchainpb.SelectStateAtHeightReq{
+		Header:   t.genReqHeader(),
+		Bcname:   global.GFlagBCName,
+		Bucket:   bucket,
+		StartKey: []byte(startKey),
+		EndKey:   []byte(endKey),
+		Height:   height,
+		Limit:    limit,
+	}
+
+	ctx := context.TODO()
+	resp, err := t.xclient.SelectStateAtHeight(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetHeader().GetErrCode() != 0 {
+		return nil, fmt.Errorf("ErrCode:%d ErrMsg:%s LogId:%s TraceId:%s", resp.GetHeader().GetErrCode(),
+			resp.GetHeader().GetErrMsg(), resp.GetHeader().GetLogId(), resp.GetHeader().GetTraceId())
+	}
+
+	return resp, nil
+}
+
+func (t *XchainClient) QueryContractEvents(contractName, eventName string, startHeight, endHeight int64,
+	cursor []byte, limit int64) (*xchainpb.QueryContractEventsResp, error) {
+	req := &xchainpb.QueryContractEventsReq{
+		Header:       t.genReqHeader(),
+		Bcname:       global.GFlagBCName,
+		ContractName: contractName,
+		EventName:    eventName,
+		StartHeight:  startHeight,
+		EndHeight:    endHeight,
+		Cursor:       cursor,
+		Limit:        limit,
+	}
+
+	ctx := context.TODO()
+	resp, err := t.xclient.QueryContractEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetHeader().GetErrCode() != 0 {
+		return nil, fmt.Errorf("ErrCode:%d ErrMsg:%s LogId:%s TraceId:%s", resp.GetHeader().GetErrCode(),
+			resp.GetHeader().GetErrMsg(), resp.GetHeader().GetLogId(), resp.GetHeader().GetTraceId())
+	}
+
+	return resp, nil
+}
+
 func (t *XchainClient) SelectUtxo(need *big.Int) (*xchainpb.SelectUtxoResp, error) {
 	addr, err := global.LoadAccount(global.GFlagCrypto, global.GFlagKeys)
 	if err != nil {
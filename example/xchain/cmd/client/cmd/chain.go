@@ -27,6 +27,8 @@ func GetChainCmd() *ChainCmd {
 	chainCmdIns.Cmd.AddCommand(chaincmd.GetChainStatusCmd().GetCmd())
 	// create chain
 	chainCmdIns.Cmd.AddCommand(chaincmd.GetCreateChainCmd().GetCmd())
+	// export or import state snapshot
+	chainCmdIns.Cmd.AddCommand(chaincmd.GetSnapshotCmd().GetCmd())
 
 	return chainCmdIns
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/xuperchain/xupercore/kernel/common/xconfig"
 	"github.com/xuperchain/xupercore/lib/logs"
 	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/pebble"
 	xutils "github.com/xuperchain/xupercore/lib/utils"
 
 	"github.com/spf13/cobra"
@@ -0,0 +1,137 @@
+package chain
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/utils"
+	"github.com/xuperchain/xupercore/example/xchain/cmd/client/common/global"
+	xdef "github.com/xuperchain/xupercore/example/xchain/common/def"
+	"github.com/xuperchain/xupercore/kernel/common/xconfig"
+	"github.com/xuperchain/xupercore/lib/logs"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/pebble"
+	xutils "github.com/xuperchain/xupercore/lib/utils"
+
+	"github.com/spf13/cobra"
+)
+
+type SnapshotCmd struct {
+	global.BaseCmd
+	// 快照文件
+	File string
+	// 创世块配置文件
+	GenesisConf string
+	// 可信的快照区块ID
+	Blockid string
+	// 环境配置文件
+	EnvConf string
+}
+
+func GetSnapshotCmd() *SnapshotCmd {
+	snapshotCmdIns := new(SnapshotCmd)
+
+	subCmd := &cobra.Command{
+		Use:           "snapshot",
+		Short:         "export or import state snapshot, node must be stopped.",
+		Example:       xdef.CmdLineName + " chain snapshot export -f xuper.snapshot",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+	snapshotCmdIns.SetCmd(subCmd)
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "export state snapshot at latest block.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshotCmdIns.exportSnapshot()
+		},
+	}
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "create chain from state snapshot.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snapshotCmdIns.importSnapshot()
+		},
+	}
+	subCmd.AddCommand(exportCmd)
+	subCmd.AddCommand(importCmd)
+
+	// 设置命令行参数并绑定变量
+	subCmd.PersistentFlags().StringVarP(&snapshotCmdIns.File,
+		"file", "f", "./snapshot.dat", "snapshot file path")
+	subCmd.PersistentFlags().StringVarP(&snapshotCmdIns.EnvConf,
+		"env_conf", "e", "./conf/env.yaml", "env config file path")
+	importCmd.Flags().StringVarP(&snapshotCmdIns.GenesisConf,
+		"genesis_conf", "g", "./data/genesis/single.json", "genesis config file path")
+	importCmd.Flags().StringVarP(&snapshotCmdIns.Blockid,
+		"blockid", "b", "", "trusted blockid of snapshot, get from trusted node")
+
+	return snapshotCmdIns
+}
+
+func (t *SnapshotCmd) loadEnvConf() (*xconfig.EnvConf, error) {
+	if !xutils.FileIsExist(t.EnvConf) {
+		log.Printf("config file not exist.env_conf:%s\n", t.EnvConf)
+		return nil, fmt.Errorf("config file not exist")
+	}
+	econf, err := xconfig.LoadEnvConf(t.EnvConf)
+	if err != nil {
+		log.Printf("load env config failed.env_conf:%s err:%v\n", t.EnvConf, err)
+		return nil, fmt.Errorf("load env config failed")
+	}
+	logs.InitLog(econf.GenConfFilePath(econf.LogConf), econf.GenDirAbsPath(econf.LogDir))
+	return econf, nil
+}
+
+func (t *SnapshotCmd) exportSnapshot() error {
+	econf, err := t.loadEnvConf()
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(t.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header, err := utils.ExportSnapshot(global.GFlagBCName, file, econf)
+	if err != nil {
+		log.Printf("export snapshot failed.err:%v\n", err)
+		os.Remove(t.File)
+		return fmt.Errorf("export snapshot failed")
+	}
+	log.Printf("export snapshot succ.bc_name:%s height:%d blockid:%s\n",
+		header.BCName, header.Height, header.Blockid)
+	return nil
+}
+
+func (t *SnapshotCmd) importSnapshot() error {
+	if !xutils.FileIsExist(t.GenesisConf) {
+		log.Printf("config file not exist.genesis_conf:%s\n", t.GenesisConf)
+		return fmt.Errorf("config file not exist")
+	}
+	blockid, err := hex.DecodeString(t.Blockid)
+	if err != nil || len(blockid) == 0 {
+		return fmt.Errorf("trusted blockid invalid")
+	}
+	econf, err := t.loadEnvConf()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(t.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	err = utils.ImportSnapshot(global.GFlagBCName, t.GenesisConf, file, blockid, econf)
+	if err != nil {
+		log.Printf("import snapshot failed.err:%v\n", err)
+		return fmt.Errorf("import snapshot failed")
+	}
+	log.Printf("import snapshot succ.bc_name:%s blockid:%s\n", global.GFlagBCName, t.Blockid)
+	return nil
+}
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: xchain.proto
+// source: example/xchain/common/xchainpb/xchain.proto
 
 package xchainpb
 
@@ -41,7 +41,7 @@ func (m *ReqHeader) Reset()         { *m = ReqHeader{} }
 func (m *ReqHeader) String() string { return proto.CompactTextString(m) }
 func (*ReqHeader) ProtoMessage()    {}
 func (*ReqHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{0}
+	return fileDescriptor_91e6c379b4dcad93, []int{0}
 }
 
 func (m *ReqHeader) XXX_Unmarshal(b []byte) error {
@@ -95,7 +95,7 @@ func (m *RespHeader) Reset()         { *m = RespHeader{} }
 func (m *RespHeader) String() string { return proto.CompactTextString(m) }
 func (*RespHeader) ProtoMessage()    {}
 func (*RespHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{1}
+	return fileDescriptor_91e6c379b4dcad93, []int{1}
 }
 
 func (m *RespHeader) XXX_Unmarshal(b []byte) error {
@@ -155,7 +155,7 @@ func (m *BaseReq) Reset()         { *m = BaseReq{} }
 func (m *BaseReq) String() string { return proto.CompactTextString(m) }
 func (*BaseReq) ProtoMessage()    {}
 func (*BaseReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{2}
+	return fileDescriptor_91e6c379b4dcad93, []int{2}
 }
 
 func (m *BaseReq) XXX_Unmarshal(b []byte) error {
@@ -194,7 +194,7 @@ func (m *BaseResp) Reset()         { *m = BaseResp{} }
 func (m *BaseResp) String() string { return proto.CompactTextString(m) }
 func (*BaseResp) ProtoMessage()    {}
 func (*BaseResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{3}
+	return fileDescriptor_91e6c379b4dcad93, []int{3}
 }
 
 func (m *BaseResp) XXX_Unmarshal(b []byte) error {
@@ -236,7 +236,7 @@ func (m *SubmitTxReq) Reset()         { *m = SubmitTxReq{} }
 func (m *SubmitTxReq) String() string { return proto.CompactTextString(m) }
 func (*SubmitTxReq) ProtoMessage()    {}
 func (*SubmitTxReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{4}
+	return fileDescriptor_91e6c379b4dcad93, []int{4}
 }
 
 func (m *SubmitTxReq) XXX_Unmarshal(b []byte) error {
@@ -286,21 +286,25 @@ func (m *SubmitTxReq) GetTx() *xldgpb.Transaction {
 }
 
 type PreExecReq struct {
-	Header               *ReqHeader              `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Bcname               string                  `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
-	Requests             []*protos.InvokeRequest `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"`
-	Initiator            string                  `protobuf:"bytes,4,opt,name=initiator,proto3" json:"initiator,omitempty"`
-	AuthRequire          []string                `protobuf:"bytes,5,rep,name=authRequire,proto3" json:"authRequire,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Header      *ReqHeader              `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname      string                  `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Requests    []*protos.InvokeRequest `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"`
+	Initiator   string                  `protobuf:"bytes,4,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire []string                `protobuf:"bytes,5,rep,name=authRequire,proto3" json:"authRequire,omitempty"`
+	// 是否返回每个请求的调用树
+	Trace bool `protobuf:"varint,6,opt,name=trace,proto3" json:"trace,omitempty"`
+	// 是否允许调试器暂停合约，只对节点调试白名单中的合约生效，调试模式下不返回调用树
+	Debug                bool     `protobuf:"varint,7,opt,name=debug,proto3" json:"debug,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PreExecReq) Reset()         { *m = PreExecReq{} }
 func (m *PreExecReq) String() string { return proto.CompactTextString(m) }
 func (*PreExecReq) ProtoMessage()    {}
 func (*PreExecReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{5}
+	return fileDescriptor_91e6c379b4dcad93, []int{5}
 }
 
 func (m *PreExecReq) XXX_Unmarshal(b []byte) error {
@@ -356,6 +360,20 @@ func (m *PreExecReq) GetAuthRequire() []string {
 	return nil
 }
 
+func (m *PreExecReq) GetTrace() bool {
+	if m != nil {
+		return m.Trace
+	}
+	return false
+}
+
+func (m *PreExecReq) GetDebug() bool {
+	if m != nil {
+		return m.Debug
+	}
+	return false
+}
+
 type PreExecResp struct {
 	Header               *RespHeader            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Bcname               string                 `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
@@ -369,7 +387,7 @@ func (m *PreExecResp) Reset()         { *m = PreExecResp{} }
 func (m *PreExecResp) String() string { return proto.CompactTextString(m) }
 func (*PreExecResp) ProtoMessage()    {}
 func (*PreExecResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{6}
+	return fileDescriptor_91e6c379b4dcad93, []int{6}
 }
 
 func (m *PreExecResp) XXX_Unmarshal(b []byte) error {
@@ -411,6 +429,141 @@ func (m *PreExecResp) GetResponse() *protos.InvokeResponse {
 	return nil
 }
 
+type QueryContractReq struct {
+	Header      *ReqHeader            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname      string                `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Request     *protos.InvokeRequest `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	Initiator   string                `protobuf:"bytes,4,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire []string              `protobuf:"bytes,5,rep,name=authRequire,proto3" json:"authRequire,omitempty"`
+	// 查询指定区块的状态快照，为空时查询最新确认状态
+	BlockId              []byte   `protobuf:"bytes,6,opt,name=blockId,proto3" json:"blockId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryContractReq) Reset()         { *m = QueryContractReq{} }
+func (m *QueryContractReq) String() string { return proto.CompactTextString(m) }
+func (*QueryContractReq) ProtoMessage()    {}
+func (*QueryContractReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{7}
+}
+
+func (m *QueryContractReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryContractReq.Unmarshal(m, b)
+}
+func (m *QueryContractReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryContractReq.Marshal(b, m, deterministic)
+}
+func (m *QueryContractReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryContractReq.Merge(m, src)
+}
+func (m *QueryContractReq) XXX_Size() int {
+	return xxx_messageInfo_QueryContractReq.Size(m)
+}
+func (m *QueryContractReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryContractReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryContractReq proto.InternalMessageInfo
+
+func (m *QueryContractReq) GetHeader() *ReqHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *QueryContractReq) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *QueryContractReq) GetRequest() *protos.InvokeRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *QueryContractReq) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+func (m *QueryContractReq) GetAuthRequire() []string {
+	if m != nil {
+		return m.AuthRequire
+	}
+	return nil
+}
+
+func (m *QueryContractReq) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+type QueryContractResp struct {
+	Header               *RespHeader              `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname               string                   `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Response             *protos.ContractResponse `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *QueryContractResp) Reset()         { *m = QueryContractResp{} }
+func (m *QueryContractResp) String() string { return proto.CompactTextString(m) }
+func (*QueryContractResp) ProtoMessage()    {}
+func (*QueryContractResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{8}
+}
+
+func (m *QueryContractResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryContractResp.Unmarshal(m, b)
+}
+func (m *QueryContractResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryContractResp.Marshal(b, m, deterministic)
+}
+func (m *QueryContractResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryContractResp.Merge(m, src)
+}
+func (m *QueryContractResp) XXX_Size() int {
+	return xxx_messageInfo_QueryContractResp.Size(m)
+}
+func (m *QueryContractResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryContractResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryContractResp proto.InternalMessageInfo
+
+func (m *QueryContractResp) GetHeader() *RespHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *QueryContractResp) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *QueryContractResp) GetResponse() *protos.ContractResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 type SelectUtxoReq struct {
 	Header               *ReqHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Bcname               string     `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
@@ -426,7 +579,7 @@ func (m *SelectUtxoReq) Reset()         { *m = SelectUtxoReq{} }
 func (m *SelectUtxoReq) String() string { return proto.CompactTextString(m) }
 func (*SelectUtxoReq) ProtoMessage()    {}
 func (*SelectUtxoReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{7}
+	return fileDescriptor_91e6c379b4dcad93, []int{9}
 }
 
 func (m *SelectUtxoReq) XXX_Unmarshal(b []byte) error {
@@ -495,7 +648,7 @@ func (m *SelectUtxoResp) Reset()         { *m = SelectUtxoResp{} }
 func (m *SelectUtxoResp) String() string { return proto.CompactTextString(m) }
 func (*SelectUtxoResp) ProtoMessage()    {}
 func (*SelectUtxoResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{8}
+	return fileDescriptor_91e6c379b4dcad93, []int{10}
 }
 
 func (m *SelectUtxoResp) XXX_Unmarshal(b []byte) error {
@@ -550,7 +703,7 @@ func (m *QueryTxReq) Reset()         { *m = QueryTxReq{} }
 func (m *QueryTxReq) String() string { return proto.CompactTextString(m) }
 func (*QueryTxReq) ProtoMessage()    {}
 func (*QueryTxReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{9}
+	return fileDescriptor_91e6c379b4dcad93, []int{11}
 }
 
 func (m *QueryTxReq) XXX_Unmarshal(b []byte) error {
@@ -606,7 +759,7 @@ func (m *QueryTxResp) Reset()         { *m = QueryTxResp{} }
 func (m *QueryTxResp) String() string { return proto.CompactTextString(m) }
 func (*QueryTxResp) ProtoMessage()    {}
 func (*QueryTxResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{10}
+	return fileDescriptor_91e6c379b4dcad93, []int{12}
 }
 
 func (m *QueryTxResp) XXX_Unmarshal(b []byte) error {
@@ -669,7 +822,7 @@ func (m *QueryBlockReq) Reset()         { *m = QueryBlockReq{} }
 func (m *QueryBlockReq) String() string { return proto.CompactTextString(m) }
 func (*QueryBlockReq) ProtoMessage()    {}
 func (*QueryBlockReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{11}
+	return fileDescriptor_91e6c379b4dcad93, []int{13}
 }
 
 func (m *QueryBlockReq) XXX_Unmarshal(b []byte) error {
@@ -731,7 +884,7 @@ func (m *QueryBlockResp) Reset()         { *m = QueryBlockResp{} }
 func (m *QueryBlockResp) String() string { return proto.CompactTextString(m) }
 func (*QueryBlockResp) ProtoMessage()    {}
 func (*QueryBlockResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{12}
+	return fileDescriptor_91e6c379b4dcad93, []int{14}
 }
 
 func (m *QueryBlockResp) XXX_Unmarshal(b []byte) error {
@@ -786,7 +939,7 @@ func (m *QueryChainStatusReq) Reset()         { *m = QueryChainStatusReq{} }
 func (m *QueryChainStatusReq) String() string { return proto.CompactTextString(m) }
 func (*QueryChainStatusReq) ProtoMessage()    {}
 func (*QueryChainStatusReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{13}
+	return fileDescriptor_91e6c379b4dcad93, []int{15}
 }
 
 func (m *QueryChainStatusReq) XXX_Unmarshal(b []byte) error {
@@ -843,7 +996,7 @@ func (m *QueryChainStatusResp) Reset()         { *m = QueryChainStatusResp{} }
 func (m *QueryChainStatusResp) String() string { return proto.CompactTextString(m) }
 func (*QueryChainStatusResp) ProtoMessage()    {}
 func (*QueryChainStatusResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db0991b9525664ca, []int{14}
+	return fileDescriptor_91e6c379b4dcad93, []int{16}
 }
 
 func (m *QueryChainStatusResp) XXX_Unmarshal(b []byte) error {
@@ -899,6 +1052,541 @@ func (m *QueryChainStatusResp) GetBranchBlockId() []string {
 	return nil
 }
 
+type StateKV struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key    []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value  []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// 写入该值的交易及其在TxOutputsExt中的序号
+	RefTxid              []byte   `protobuf:"bytes,4,opt,name=refTxid,proto3" json:"refTxid,omitempty"`
+	RefOffset            int32    `protobuf:"varint,5,opt,name=refOffset,proto3" json:"refOffset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateKV) Reset()         { *m = StateKV{} }
+func (m *StateKV) String() string { return proto.CompactTextString(m) }
+func (*StateKV) ProtoMessage()    {}
+func (*StateKV) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{17}
+}
+
+func (m *StateKV) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateKV.Unmarshal(m, b)
+}
+func (m *StateKV) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateKV.Marshal(b, m, deterministic)
+}
+func (m *StateKV) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateKV.Merge(m, src)
+}
+func (m *StateKV) XXX_Size() int {
+	return xxx_messageInfo_StateKV.Size(m)
+}
+func (m *StateKV) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateKV.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateKV proto.InternalMessageInfo
+
+func (m *StateKV) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *StateKV) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateKV) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateKV) GetRefTxid() []byte {
+	if m != nil {
+		return m.RefTxid
+	}
+	return nil
+}
+
+func (m *StateKV) GetRefOffset() int32 {
+	if m != nil {
+		return m.RefOffset
+	}
+	return 0
+}
+
+type QueryStateAtHeightReq struct {
+	Header               *ReqHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname               string     `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Bucket               string     `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key                  []byte     `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Height               int64      `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *QueryStateAtHeightReq) Reset()         { *m = QueryStateAtHeightReq{} }
+func (m *QueryStateAtHeightReq) String() string { return proto.CompactTextString(m) }
+func (*QueryStateAtHeightReq) ProtoMessage()    {}
+func (*QueryStateAtHeightReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{18}
+}
+
+func (m *QueryStateAtHeightReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateAtHeightReq.Unmarshal(m, b)
+}
+func (m *QueryStateAtHeightReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateAtHeightReq.Marshal(b, m, deterministic)
+}
+func (m *QueryStateAtHeightReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateAtHeightReq.Merge(m, src)
+}
+func (m *QueryStateAtHeightReq) XXX_Size() int {
+	return xxx_messageInfo_QueryStateAtHeightReq.Size(m)
+}
+func (m *QueryStateAtHeightReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateAtHeightReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateAtHeightReq proto.InternalMessageInfo
+
+func (m *QueryStateAtHeightReq) GetHeader() *ReqHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *QueryStateAtHeightReq) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *QueryStateAtHeightReq) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *QueryStateAtHeightReq) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *QueryStateAtHeightReq) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type QueryStateAtHeightResp struct {
+	Header  *RespHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname  string      `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Height  int64       `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	BlockId []byte      `protobuf:"bytes,4,opt,name=blockId,proto3" json:"blockId,omitempty"`
+	// key在查询高度不存在或已删除时为空
+	State                *StateKV `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryStateAtHeightResp) Reset()         { *m = QueryStateAtHeightResp{} }
+func (m *QueryStateAtHeightResp) String() string { return proto.CompactTextString(m) }
+func (*QueryStateAtHeightResp) ProtoMessage()    {}
+func (*QueryStateAtHeightResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{19}
+}
+
+func (m *QueryStateAtHeightResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryStateAtHeightResp.Unmarshal(m, b)
+}
+func (m *QueryStateAtHeightResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryStateAtHeightResp.Marshal(b, m, deterministic)
+}
+func (m *QueryStateAtHeightResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStateAtHeightResp.Merge(m, src)
+}
+func (m *QueryStateAtHeightResp) XXX_Size() int {
+	return xxx_messageInfo_QueryStateAtHeightResp.Size(m)
+}
+func (m *QueryStateAtHeightResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStateAtHeightResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStateAtHeightResp proto.InternalMessageInfo
+
+func (m *QueryStateAtHeightResp) GetHeader() *RespHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *QueryStateAtHeightResp) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *QueryStateAtHeightResp) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *QueryStateAtHeightResp) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *QueryStateAtHeightResp) GetState() *StateKV {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type SelectStateAtHeightReq struct {
+	Header *ReqHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname string     `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Bucket string     `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// 遍历区间[startKey, endKey)
+	StartKey             []byte   `protobuf:"bytes,4,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	EndKey               []byte   `protobuf:"bytes,5,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	Height               int64    `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	Limit                int64    `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelectStateAtHeightReq) Reset()         { *m = SelectStateAtHeightReq{} }
+func (m *SelectStateAtHeightReq) String() string { return proto.CompactTextString(m) }
+func (*SelectStateAtHeightReq) ProtoMessage()    {}
+func (*SelectStateAtHeightReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{20}
+}
+
+func (m *SelectStateAtHeightReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectStateAtHeightReq.Unmarshal(m, b)
+}
+func (m *SelectStateAtHeightReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectStateAtHeightReq.Marshal(b, m, deterministic)
+}
+func (m *SelectStateAtHeightReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectStateAtHeightReq.Merge(m, src)
+}
+func (m *SelectStateAtHeightReq) XXX_Size() int {
+	return xxx_messageInfo_SelectStateAtHeightReq.Size(m)
+}
+func (m *SelectStateAtHeightReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectStateAtHeightReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectStateAtHeightReq proto.InternalMessageInfo
+
+func (m *SelectStateAtHeightReq) GetHeader() *ReqHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SelectStateAtHeightReq) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *SelectStateAtHeightReq) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *SelectStateAtHeightReq) GetStartKey() []byte {
+	if m != nil {
+		return m.StartKey
+	}
+	return nil
+}
+
+func (m *SelectStateAtHeightReq) GetEndKey() []byte {
+	if m != nil {
+		return m.EndKey
+	}
+	return nil
+}
+
+func (m *SelectStateAtHeightReq) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SelectStateAtHeightReq) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SelectStateAtHeightResp struct {
+	Header               *RespHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname               string      `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Height               int64       `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	BlockId              []byte      `protobuf:"bytes,4,opt,name=blockId,proto3" json:"blockId,omitempty"`
+	States               []*StateKV  `protobuf:"bytes,5,rep,name=states,proto3" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SelectStateAtHeightResp) Reset()         { *m = SelectStateAtHeightResp{} }
+func (m *SelectStateAtHeightResp) String() string { return proto.CompactTextString(m) }
+func (*SelectStateAtHeightResp) ProtoMessage()    {}
+func (*SelectStateAtHeightResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{21}
+}
+
+func (m *SelectStateAtHeightResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectStateAtHeightResp.Unmarshal(m, b)
+}
+func (m *SelectStateAtHeightResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectStateAtHeightResp.Marshal(b, m, deterministic)
+}
+func (m *SelectStateAtHeightResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectStateAtHeightResp.Merge(m, src)
+}
+func (m *SelectStateAtHeightResp) XXX_Size() int {
+	return xxx_messageInfo_SelectStateAtHeightResp.Size(m)
+}
+func (m *SelectStateAtHeightResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectStateAtHeightResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectStateAtHeightResp proto.InternalMessageInfo
+
+func (m *SelectStateAtHeightResp) GetHeader() *RespHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *SelectStateAtHeightResp) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *SelectStateAtHeightResp) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SelectStateAtHeightResp) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *SelectStateAtHeightResp) GetStates() []*StateKV {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+type QueryContractEventsReq struct {
+	Header       *ReqHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname       string     `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	ContractName string     `protobuf:"bytes,3,opt,name=contractName,proto3" json:"contractName,omitempty"`
+	EventName    string     `protobuf:"bytes,4,opt,name=eventName,proto3" json:"eventName,omitempty"`
+	// 查询区块高度区间[startHeight, endHeight]
+	StartHeight int64 `protobuf:"varint,5,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	EndHeight   int64 `protobuf:"varint,6,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
+	// 上一页返回的游标，为空时从startHeight开始查询
+	Cursor               []byte   `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit                int64    `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryContractEventsReq) Reset()         { *m = QueryContractEventsReq{} }
+func (m *QueryContractEventsReq) String() string { return proto.CompactTextString(m) }
+func (*QueryContractEventsReq) ProtoMessage()    {}
+func (*QueryContractEventsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{22}
+}
+
+func (m *QueryContractEventsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryContractEventsReq.Unmarshal(m, b)
+}
+func (m *QueryContractEventsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryContractEventsReq.Marshal(b, m, deterministic)
+}
+func (m *QueryContractEventsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryContractEventsReq.Merge(m, src)
+}
+func (m *QueryContractEventsReq) XXX_Size() int {
+	return xxx_messageInfo_QueryContractEventsReq.Size(m)
+}
+func (m *QueryContractEventsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryContractEventsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryContractEventsReq proto.InternalMessageInfo
+
+func (m *QueryContractEventsReq) GetHeader() *ReqHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *QueryContractEventsReq) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *QueryContractEventsReq) GetContractName() string {
+	if m != nil {
+		return m.ContractName
+	}
+	return ""
+}
+
+func (m *QueryContractEventsReq) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *QueryContractEventsReq) GetStartHeight() int64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *QueryContractEventsReq) GetEndHeight() int64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *QueryContractEventsReq) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *QueryContractEventsReq) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type QueryContractEventsResp struct {
+	Header *RespHeader                 `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bcname string                      `protobuf:"bytes,2,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Events []*protos.ContractEventInfo `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// 下一页的游标，为空表示没有更多事件
+	Cursor               []byte   `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryContractEventsResp) Reset()         { *m = QueryContractEventsResp{} }
+func (m *QueryContractEventsResp) String() string { return proto.CompactTextString(m) }
+func (*QueryContractEventsResp) ProtoMessage()    {}
+func (*QueryContractEventsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91e6c379b4dcad93, []int{23}
+}
+
+func (m *QueryContractEventsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryContractEventsResp.Unmarshal(m, b)
+}
+func (m *QueryContractEventsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryContractEventsResp.Marshal(b, m, deterministic)
+}
+func (m *QueryContractEventsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryContractEventsResp.Merge(m, src)
+}
+func (m *QueryContractEventsResp) XXX_Size() int {
+	return xxx_messageInfo_QueryContractEventsResp.Size(m)
+}
+func (m *QueryContractEventsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryContractEventsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryContractEventsResp proto.InternalMessageInfo
+
+func (m *QueryContractEventsResp) GetHeader() *RespHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *QueryContractEventsResp) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *QueryContractEventsResp) GetEvents() []*protos.ContractEventInfo {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *QueryContractEventsResp) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ReqHeader)(nil), "xchainpb.ReqHeader")
 	proto.RegisterType((*RespHeader)(nil), "xchainpb.RespHeader")
@@ -907,6 +1595,8 @@ func init() {
 	proto.RegisterType((*SubmitTxReq)(nil), "xchainpb.SubmitTxReq")
 	proto.RegisterType((*PreExecReq)(nil), "xchainpb.PreExecReq")
 	proto.RegisterType((*PreExecResp)(nil), "xchainpb.PreExecResp")
+	proto.RegisterType((*QueryContractReq)(nil), "xchainpb.QueryContractReq")
+	proto.RegisterType((*QueryContractResp)(nil), "xchainpb.QueryContractResp")
 	proto.RegisterType((*SelectUtxoReq)(nil), "xchainpb.SelectUtxoReq")
 	proto.RegisterType((*SelectUtxoResp)(nil), "xchainpb.SelectUtxoResp")
 	proto.RegisterType((*QueryTxReq)(nil), "xchainpb.QueryTxReq")
@@ -915,67 +1605,106 @@ func init() {
 	proto.RegisterType((*QueryBlockResp)(nil), "xchainpb.QueryBlockResp")
 	proto.RegisterType((*QueryChainStatusReq)(nil), "xchainpb.QueryChainStatusReq")
 	proto.RegisterType((*QueryChainStatusResp)(nil), "xchainpb.QueryChainStatusResp")
+	proto.RegisterType((*StateKV)(nil), "xchainpb.StateKV")
+	proto.RegisterType((*QueryStateAtHeightReq)(nil), "xchainpb.QueryStateAtHeightReq")
+	proto.RegisterType((*QueryStateAtHeightResp)(nil), "xchainpb.QueryStateAtHeightResp")
+	proto.RegisterType((*SelectStateAtHeightReq)(nil), "xchainpb.SelectStateAtHeightReq")
+	proto.RegisterType((*SelectStateAtHeightResp)(nil), "xchainpb.SelectStateAtHeightResp")
+	proto.RegisterType((*QueryContractEventsReq)(nil), "xchainpb.QueryContractEventsReq")
+	proto.RegisterType((*QueryContractEventsResp)(nil), "xchainpb.QueryContractEventsResp")
 }
 
-func init() { proto.RegisterFile("xchain.proto", fileDescriptor_db0991b9525664ca) }
-
-var fileDescriptor_db0991b9525664ca = []byte{
-	// 876 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xee, 0xc6, 0x8d, 0xbd, 0x3e, 0x4e, 0x42, 0x99, 0xd6, 0xed, 0xd6, 0xfc, 0x59, 0x0b, 0x17,
-	0x96, 0x52, 0x39, 0x8a, 0x11, 0xd0, 0x3b, 0x94, 0x44, 0x48, 0x58, 0x4a, 0x2b, 0x98, 0x14, 0x89,
-	0xbb, 0x68, 0xbc, 0x7b, 0xb0, 0x57, 0x59, 0xcf, 0x38, 0x33, 0xe3, 0x6a, 0xb9, 0x47, 0x42, 0x82,
-	0x1b, 0x2e, 0x79, 0x04, 0xee, 0x78, 0x09, 0x24, 0x5e, 0x84, 0x07, 0x41, 0x33, 0xfb, 0x37, 0x59,
-	0xd2, 0x08, 0x4b, 0xee, 0xd5, 0xee, 0xf9, 0x3f, 0xe7, 0x3b, 0x3f, 0x03, 0x7b, 0x59, 0xb4, 0x60,
-	0x09, 0x1f, 0xaf, 0xa4, 0xd0, 0x82, 0xf8, 0x39, 0xb5, 0x9a, 0x0d, 0x8e, 0xb3, 0xf5, 0x0a, 0x65,
-	0x24, 0x24, 0x1e, 0xcd, 0x22, 0x75, 0x94, 0x62, 0x3c, 0x47, 0x79, 0x94, 0x55, 0xdf, 0x78, 0xbe,
-	0x9a, 0x95, 0x64, 0x6e, 0x3c, 0xf8, 0xa8, 0x36, 0xb1, 0x0c, 0x75, 0x14, 0x09, 0xae, 0x25, 0x8b,
-	0x74, 0xae, 0x10, 0x7e, 0x09, 0x5d, 0x8a, 0xd7, 0x5f, 0x23, 0x8b, 0x51, 0x92, 0x3e, 0xb4, 0x53,
-	0x31, 0xbf, 0x4c, 0xe2, 0xc0, 0x1b, 0x7a, 0xa3, 0x2e, 0xdd, 0x4d, 0xc5, 0x7c, 0x1a, 0x93, 0xf7,
-	0xa0, 0xab, 0x30, 0xfd, 0xe1, 0x92, 0xb3, 0x25, 0x06, 0x3b, 0x56, 0xe2, 0x1b, 0xc6, 0x4b, 0xb6,
-	0xc4, 0x50, 0x02, 0x50, 0x54, 0xab, 0xbb, 0x3d, 0x3c, 0x05, 0x1f, 0xa5, 0xbc, 0x8c, 0x44, 0x9c,
-	0x3b, 0x68, 0xd1, 0x0e, 0x4a, 0x79, 0x26, 0x62, 0x24, 0x4f, 0xc0, 0xfc, 0x5e, 0x2e, 0xd5, 0x3c,
-	0x68, 0x59, 0x93, 0x36, 0x4a, 0xf9, 0x42, 0xcd, 0x8d, 0x8d, 0x49, 0x14, 0x8d, 0xb3, 0xfb, 0x56,
-	0xd2, 0xb1, 0xf4, 0x34, 0x0e, 0x3f, 0x87, 0xce, 0x29, 0x53, 0x48, 0xf1, 0x9a, 0x1c, 0x42, 0x7b,
-	0x61, 0x43, 0xdb, 0x80, 0xbd, 0xc9, 0xc3, 0x71, 0x09, 0xd7, 0xb8, 0xaa, 0x8b, 0x16, 0x2a, 0xe1,
-	0x73, 0xf0, 0x73, 0x3b, 0xb5, 0x22, 0xcf, 0x1a, 0x86, 0x8f, 0x5c, 0xc3, 0xb2, 0x9e, 0xca, 0xf2,
-	0x57, 0x0f, 0x7a, 0x17, 0xeb, 0xd9, 0x32, 0xd1, 0xaf, 0xb2, 0x4d, 0xc3, 0x92, 0xc7, 0xd0, 0x9e,
-	0x45, 0x0e, 0x78, 0x05, 0x45, 0x08, 0xdc, 0xd7, 0x59, 0x12, 0xdb, 0xba, 0xf7, 0xa8, 0xfd, 0x27,
-	0x1f, 0xc3, 0x8e, 0xce, 0x6c, 0xbd, 0xd6, 0xa9, 0xed, 0xe9, 0xf8, 0x95, 0x64, 0x5c, 0xb1, 0x48,
-	0x27, 0x82, 0xd3, 0x1d, 0x9d, 0x85, 0x7f, 0x79, 0x00, 0xdf, 0x48, 0xfc, 0x2a, 0xc3, 0x68, 0x6b,
-	0xc9, 0x1c, 0x83, 0x2f, 0xf1, 0x7a, 0x8d, 0x4a, 0xab, 0xa0, 0x35, 0x6c, 0x8d, 0x7a, 0x93, 0x7e,
-	0x3e, 0x22, 0x6a, 0x3c, 0xe5, 0xaf, 0xc5, 0x95, 0x41, 0xdb, 0x48, 0x69, 0xa5, 0x46, 0xde, 0x87,
-	0x6e, 0xc2, 0x13, 0x9d, 0x30, 0x2d, 0x64, 0xd1, 0xa2, 0x9a, 0x41, 0x86, 0xd0, 0x63, 0x6b, 0xbd,
-	0x30, 0x66, 0x89, 0xc4, 0x60, 0x77, 0xd8, 0x1a, 0x75, 0xa9, 0xcb, 0x0a, 0x7f, 0xf6, 0xa0, 0x57,
-	0x95, 0xb1, 0x69, 0x4b, 0xde, 0x58, 0xc8, 0xc4, 0x14, 0xa2, 0x56, 0x82, 0x2b, 0xb4, 0xc8, 0xf6,
-	0x26, 0x8f, 0x9b, 0x85, 0xe4, 0x52, 0x5a, 0xe9, 0x85, 0x7f, 0x78, 0xb0, 0x7f, 0x81, 0x29, 0x46,
-	0xfa, 0x3b, 0x9d, 0x89, 0xad, 0x61, 0x1a, 0x40, 0x87, 0xc5, 0xb1, 0x44, 0xa5, 0x8a, 0xd9, 0x2e,
-	0x49, 0x03, 0x9d, 0x16, 0x9a, 0xa5, 0x2f, 0x11, 0xe3, 0x60, 0x37, 0x87, 0xae, 0x62, 0x90, 0x01,
-	0xf8, 0x1c, 0x31, 0x3e, 0x17, 0xd1, 0x55, 0xd0, 0x1e, 0x7a, 0x23, 0x9f, 0x56, 0x74, 0xf8, 0x8b,
-	0x07, 0x07, 0x6e, 0xaa, 0x1b, 0xe3, 0x36, 0x02, 0x7f, 0xad, 0x33, 0x71, 0x9e, 0x28, 0x1d, 0xec,
-	0xd8, 0x46, 0xef, 0x95, 0x73, 0x66, 0x3d, 0x56, 0x52, 0xd3, 0x41, 0x9b, 0xd3, 0xc9, 0x52, 0xac,
-	0xb9, 0x2e, 0x4a, 0x70, 0x59, 0x21, 0x02, 0x7c, 0xbb, 0x46, 0xf9, 0xe3, 0xdb, 0x5d, 0x8a, 0xf0,
-	0x4f, 0x0f, 0x7a, 0x55, 0x9c, 0x8d, 0x0b, 0x3e, 0x86, 0xb6, 0xd2, 0x4c, 0xaf, 0x95, 0x8d, 0x74,
-	0x30, 0x79, 0x7a, 0xcb, 0x5a, 0x5d, 0x58, 0x05, 0x5a, 0x28, 0x9a, 0x06, 0xc4, 0x89, 0xd2, 0x8c,
-	0x47, 0xf9, 0x0c, 0xb5, 0x68, 0x45, 0xff, 0xbf, 0x0d, 0xfd, 0xcd, 0x83, 0x7d, 0x9b, 0xf1, 0x69,
-	0x2a, 0xa2, 0xab, 0x6d, 0x0e, 0xd4, 0xcc, 0x38, 0x9c, 0x96, 0xf8, 0x94, 0xa4, 0xe9, 0x95, 0x19,
-	0x91, 0x33, 0xc1, 0x35, 0x72, 0x6d, 0xd3, 0xf3, 0xa9, 0xcb, 0x0a, 0x7f, 0xf7, 0xe0, 0xc0, 0x4d,
-	0x69, 0x63, 0x1c, 0x0f, 0x1b, 0x38, 0x56, 0xc5, 0x5b, 0x87, 0x0d, 0x04, 0x0f, 0x61, 0xd7, 0xa6,
-	0x56, 0xac, 0x60, 0xbf, 0xd4, 0x9d, 0x72, 0x8d, 0x92, 0xb3, 0x34, 0x4f, 0x22, 0xd7, 0x09, 0x7f,
-	0xf2, 0xe0, 0xa1, 0x4d, 0xed, 0xcc, 0x44, 0x2f, 0x3c, 0x6d, 0x0b, 0xb3, 0x11, 0xbc, 0x63, 0x60,
-	0x38, 0x95, 0x8c, 0x47, 0x8b, 0xd3, 0x2a, 0x27, 0x9f, 0x36, 0xd9, 0xe1, 0x3f, 0x1e, 0x3c, 0xfa,
-	0x6f, 0x1a, 0x5b, 0x3c, 0x4c, 0x90, 0xbf, 0xcd, 0x2f, 0x50, 0xb3, 0x02, 0x17, 0x52, 0xe2, 0x72,
-	0x5e, 0x49, 0xa8, 0xa3, 0x45, 0x9e, 0xe5, 0xcb, 0x6a, 0x2d, 0xf2, 0x91, 0x7b, 0xe0, 0x2e, 0xab,
-	0xd5, 0xaf, 0x34, 0xc8, 0x27, 0xb0, 0x3f, 0xab, 0xeb, 0x99, 0xc6, 0xc5, 0xd1, 0xbd, 0xc9, 0x9c,
-	0xfc, 0xdd, 0x82, 0xf6, 0xf7, 0x36, 0x7f, 0xf2, 0x19, 0xc0, 0xd9, 0x02, 0xa3, 0xab, 0x93, 0x34,
-	0x79, 0x8d, 0xe4, 0xdd, 0xba, 0xac, 0xe2, 0x79, 0x1d, 0x90, 0x26, 0x4b, 0xad, 0xc2, 0x7b, 0xe4,
-	0x0b, 0xf0, 0xcb, 0xc7, 0x90, 0xf4, 0x6b, 0x0d, 0xe7, 0x81, 0x7c, 0x83, 0xe1, 0x73, 0xe8, 0x14,
-	0x07, 0x9f, 0x38, 0x18, 0xd6, 0x4f, 0xd9, 0xa0, 0x7f, 0x0b, 0xd7, 0x5a, 0x9e, 0x00, 0xd4, 0x57,
-	0x8f, 0x3c, 0x71, 0x82, 0xba, 0x67, 0x7b, 0x10, 0xdc, 0x2e, 0x28, 0x83, 0x17, 0x47, 0xc4, 0x0d,
-	0x5e, 0xdf, 0x2f, 0x37, 0xb8, 0x73, 0x6d, 0xf2, 0xe0, 0xf5, 0xe6, 0xb8, 0xc1, 0x6f, 0xac, 0xb8,
-	0x1b, 0xfc, 0xe6, 0xa2, 0x85, 0xf7, 0xc8, 0x05, 0x3c, 0x68, 0x8e, 0x16, 0xf9, 0xa0, 0xa1, 0x7f,
-	0x73, 0xfa, 0x07, 0x1f, 0xde, 0x25, 0x36, 0x4e, 0x67, 0x6d, 0xfb, 0xae, 0x7d, 0xfa, 0x6f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xb0, 0x44, 0x04, 0xda, 0x31, 0x0a, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("example/xchain/common/xchainpb/xchain.proto", fileDescriptor_91e6c379b4dcad93)
+}
+
+var fileDescriptor_91e6c379b4dcad93 = []byte{
+	// 1353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x17, 0x5b, 0x6f, 0x1b, 0x45,
+	0xf7, 0xdb, 0x38, 0xb6, 0x37, 0xc7, 0x6e, 0xbf, 0x76, 0x9a, 0xcb, 0x76, 0x5b, 0xa8, 0xbb, 0x20,
+	0x11, 0x94, 0x2a, 0x56, 0xcc, 0xad, 0x6f, 0x28, 0xa9, 0x2a, 0xc5, 0xf4, 0x02, 0x4c, 0x0a, 0x02,
+	0x09, 0x29, 0x5a, 0xef, 0x9e, 0x38, 0x2b, 0xdb, 0xbb, 0xee, 0xcc, 0x38, 0x72, 0x1f, 0x91, 0x90,
+	0x90, 0x40, 0x08, 0x1e, 0x79, 0xe2, 0x19, 0xf5, 0x85, 0x67, 0x24, 0xfe, 0x07, 0xef, 0x88, 0x1f,
+	0xc0, 0x4f, 0x40, 0x33, 0x3b, 0xbb, 0x3b, 0xde, 0x38, 0x29, 0x11, 0x06, 0x9e, 0xec, 0x73, 0xbf,
+	0x9f, 0x3d, 0x03, 0x5b, 0x38, 0xf5, 0x47, 0xe3, 0x21, 0xb6, 0xa7, 0xc1, 0xb1, 0x1f, 0xc5, 0xed,
+	0x20, 0x19, 0x8d, 0x92, 0x58, 0x43, 0xe3, 0x9e, 0xfe, 0xb3, 0x3d, 0x66, 0x89, 0x48, 0x88, 0x9d,
+	0xa1, 0xdd, 0x9d, 0xe9, 0x64, 0x8c, 0x2c, 0x48, 0x18, 0xb6, 0x7b, 0x01, 0x6f, 0x0f, 0x31, 0xec,
+	0x23, 0x6b, 0x4f, 0xf3, 0xdf, 0xb0, 0x3f, 0xee, 0x65, 0x60, 0x2a, 0xec, 0xde, 0x2a, 0x44, 0x14,
+	0x82, 0xb7, 0x83, 0x24, 0x16, 0xcc, 0x0f, 0x84, 0x66, 0xb8, 0x79, 0x8a, 0x01, 0x4f, 0x30, 0xd6,
+	0x54, 0xef, 0x5d, 0x58, 0xa1, 0xf8, 0x74, 0x1f, 0xfd, 0x10, 0x19, 0x59, 0x83, 0xda, 0x30, 0xe9,
+	0x1f, 0x46, 0xa1, 0x63, 0xb5, 0xac, 0xcd, 0x15, 0x5a, 0x1d, 0x26, 0xfd, 0x6e, 0x48, 0x6e, 0xc0,
+	0x0a, 0xc7, 0xe1, 0xd1, 0x61, 0xec, 0x8f, 0xd0, 0x59, 0x52, 0x14, 0x5b, 0x22, 0x1e, 0xfb, 0x23,
+	0xf4, 0x18, 0x00, 0x45, 0x3e, 0x3e, 0x5f, 0xc3, 0x75, 0xb0, 0x91, 0xb1, 0xc3, 0x20, 0x09, 0x53,
+	0x05, 0x15, 0x5a, 0x47, 0xc6, 0xee, 0x25, 0x21, 0x92, 0x0d, 0x90, 0x7f, 0x0f, 0x47, 0xbc, 0xef,
+	0x54, 0x94, 0x48, 0x0d, 0x19, 0x7b, 0xc4, 0xfb, 0x52, 0x46, 0x86, 0x81, 0x52, 0xd9, 0xb2, 0xa2,
+	0xd4, 0x15, 0xdc, 0x0d, 0xbd, 0xb7, 0xa1, 0xbe, 0xe7, 0x73, 0xa4, 0xf8, 0x94, 0x6c, 0x41, 0xed,
+	0x58, 0x99, 0x56, 0x06, 0x1b, 0x9d, 0x6b, 0xdb, 0x59, 0x32, 0xb7, 0xf3, 0xb8, 0xa8, 0x66, 0xf1,
+	0xee, 0x82, 0x9d, 0xca, 0xf1, 0x31, 0xb9, 0x53, 0x12, 0x5c, 0x35, 0x05, 0xf9, 0xb8, 0x24, 0xf9,
+	0xb5, 0x05, 0x8d, 0x83, 0x49, 0x6f, 0x14, 0x89, 0x27, 0xd3, 0x8b, 0x9a, 0x25, 0xeb, 0x50, 0xeb,
+	0x05, 0x46, 0xf2, 0x34, 0x44, 0x08, 0x2c, 0x8b, 0x69, 0x14, 0xaa, 0xb8, 0x9b, 0x54, 0xfd, 0x27,
+	0xaf, 0xc0, 0x92, 0x98, 0x3a, 0xcb, 0x99, 0x52, 0x55, 0xf1, 0xed, 0x27, 0xcc, 0x8f, 0xb9, 0x1f,
+	0x88, 0x28, 0x89, 0xe9, 0x92, 0x98, 0x7a, 0x7f, 0x58, 0x00, 0x1f, 0x30, 0xbc, 0x3f, 0xc5, 0x60,
+	0x61, 0xce, 0xec, 0x80, 0xcd, 0xf0, 0xe9, 0x04, 0xb9, 0xe0, 0x4e, 0xa5, 0x55, 0xd9, 0x6c, 0x74,
+	0xd6, 0xd2, 0x16, 0xe1, 0xdb, 0xdd, 0xf8, 0x24, 0x19, 0x20, 0x4d, 0xa9, 0x34, 0x67, 0x23, 0x37,
+	0x61, 0x25, 0x8a, 0x23, 0x11, 0xf9, 0x22, 0x61, 0xba, 0x44, 0x05, 0x82, 0xb4, 0xa0, 0xe1, 0x4f,
+	0xc4, 0xb1, 0x14, 0x8b, 0x18, 0x3a, 0xd5, 0x56, 0x65, 0x73, 0x85, 0x9a, 0x28, 0xb2, 0x0a, 0x55,
+	0x55, 0x51, 0xa7, 0xd6, 0xb2, 0x36, 0x6d, 0x9a, 0x02, 0x12, 0x1b, 0x62, 0x6f, 0xd2, 0x77, 0xea,
+	0x29, 0x56, 0x01, 0xde, 0x97, 0x16, 0x34, 0xf2, 0x90, 0x2f, 0x5a, 0xbe, 0x33, 0x83, 0xee, 0xc8,
+	0xa0, 0xf9, 0x38, 0x89, 0x39, 0xaa, 0x2a, 0x34, 0x3a, 0xeb, 0xe5, 0xa0, 0x53, 0x2a, 0xcd, 0xf9,
+	0xbc, 0xdf, 0x2c, 0xb8, 0xf2, 0xe1, 0x04, 0xd9, 0xb3, 0x7b, 0x7a, 0xce, 0x16, 0x56, 0x82, 0x36,
+	0xd4, 0x75, 0x6e, 0xb5, 0x33, 0x67, 0x54, 0x20, 0xe3, 0xfa, 0xdb, 0x05, 0x70, 0xa0, 0xde, 0x1b,
+	0x26, 0xc1, 0xa0, 0x1b, 0xaa, 0x12, 0x34, 0x69, 0x06, 0x7a, 0xdf, 0x5a, 0x70, 0xb5, 0x14, 0xe4,
+	0xc2, 0x92, 0xfe, 0xe6, 0xa9, 0xa4, 0x3b, 0x59, 0x9c, 0xa6, 0xb5, 0x52, 0xda, 0x7f, 0xb4, 0xe0,
+	0xd2, 0x01, 0x0e, 0x31, 0x10, 0x1f, 0x89, 0x69, 0xb2, 0xb0, 0x9c, 0x3b, 0x50, 0xf7, 0xc3, 0x90,
+	0x21, 0xe7, 0x7a, 0xfd, 0x64, 0xa0, 0x4c, 0xae, 0x48, 0x84, 0x3f, 0x7c, 0x8c, 0x18, 0x3a, 0xd5,
+	0x34, 0xb9, 0x39, 0x82, 0xb8, 0x60, 0xc7, 0x88, 0xe1, 0xc3, 0x24, 0x18, 0xe8, 0xf6, 0xcd, 0x61,
+	0xef, 0x2b, 0x0b, 0x2e, 0x9b, 0xae, 0x5e, 0x38, 0x73, 0x9b, 0x60, 0x4f, 0xc4, 0x34, 0x79, 0x18,
+	0x71, 0xe1, 0x2c, 0xa9, 0x59, 0x6c, 0x66, 0xab, 0x40, 0x69, 0xcc, 0xa9, 0xb2, 0xc6, 0xca, 0xa7,
+	0xdd, 0x51, 0x32, 0x89, 0x85, 0x0e, 0xc1, 0x44, 0x79, 0x08, 0xa0, 0x0a, 0xf9, 0xcf, 0xee, 0x2d,
+	0xef, 0x27, 0x0b, 0x1a, 0xb9, 0x9d, 0x0b, 0x07, 0xbc, 0x03, 0x35, 0x2e, 0x7c, 0x31, 0xe1, 0xca,
+	0xd2, 0xe5, 0xce, 0xf5, 0x39, 0x9b, 0xef, 0x40, 0x31, 0x50, 0xcd, 0x28, 0x0b, 0x10, 0x46, 0x5c,
+	0xf8, 0x71, 0x90, 0x76, 0x51, 0x85, 0xe6, 0xf0, 0x5f, 0x5b, 0xa2, 0xdf, 0x59, 0x70, 0x49, 0x79,
+	0xbc, 0x27, 0x7b, 0x7e, 0x91, 0x0d, 0x95, 0xcd, 0x54, 0x65, 0x66, 0xa6, 0x64, 0xad, 0x64, 0x8b,
+	0xc8, 0x1e, 0xc7, 0x58, 0x28, 0xf7, 0x6c, 0x6a, 0xa2, 0xbc, 0xef, 0x2d, 0xb8, 0x6c, 0xba, 0x74,
+	0xe1, 0x3c, 0x6e, 0x95, 0xf2, 0x98, 0x07, 0xaf, 0x14, 0x96, 0x32, 0xb8, 0x05, 0x55, 0xe5, 0x5a,
+	0xbe, 0x6c, 0x34, 0x6f, 0x37, 0x16, 0xc8, 0x62, 0x7f, 0x98, 0x3a, 0x91, 0xf2, 0x78, 0x5f, 0x58,
+	0x70, 0x2d, 0x5d, 0x08, 0xd2, 0xba, 0xd6, 0xb4, 0xa8, 0x9c, 0x6d, 0xc2, 0xff, 0x65, 0x1a, 0xf6,
+	0x98, 0x1f, 0x07, 0xc7, 0x7b, 0xb9, 0x4f, 0x36, 0x2d, 0xa3, 0xbd, 0xdf, 0x2d, 0x58, 0x3d, 0xed,
+	0xc6, 0x02, 0xbf, 0x07, 0x90, 0x1e, 0x57, 0x8f, 0x50, 0xf8, 0x3a, 0x2f, 0x24, 0xcb, 0xcb, 0xc3,
+	0x9c, 0x42, 0x0d, 0x2e, 0x72, 0x27, 0x1d, 0x56, 0x25, 0x91, 0xb6, 0xdc, 0x15, 0x73, 0x58, 0x15,
+	0x7f, 0xce, 0x41, 0x5e, 0x85, 0x4b, 0xbd, 0x22, 0x9e, 0x6e, 0xa8, 0xd7, 0xf2, 0x2c, 0xd2, 0xfb,
+	0xdc, 0x82, 0xba, 0x0c, 0x0e, 0x1f, 0x7c, 0xac, 0x7c, 0x9d, 0x04, 0x03, 0x14, 0xfa, 0xa4, 0xd2,
+	0x10, 0xb9, 0x02, 0x95, 0x01, 0x3e, 0x53, 0x01, 0x34, 0xa9, 0xfc, 0x2b, 0xbf, 0x9c, 0x27, 0xfe,
+	0x70, 0x82, 0xba, 0xf1, 0x52, 0x40, 0x36, 0x24, 0xc3, 0xa3, 0x27, 0x53, 0x7d, 0x46, 0x35, 0x69,
+	0x06, 0xca, 0x0d, 0xc7, 0xf0, 0xe8, 0xfd, 0xa3, 0x23, 0x8e, 0x42, 0x6d, 0xb8, 0x2a, 0x2d, 0x10,
+	0xde, 0x0f, 0x16, 0xac, 0xa9, 0x54, 0x2b, 0x47, 0x76, 0xc5, 0x3e, 0x46, 0xfd, 0xe3, 0xc5, 0x7d,
+	0xec, 0x8a, 0xb0, 0x2a, 0xf3, 0xc2, 0x5a, 0x2e, 0xc2, 0x5a, 0x97, 0xe6, 0xa4, 0x6d, 0xe5, 0x63,
+	0x85, 0x6a, 0xc8, 0xfb, 0xd9, 0x82, 0xf5, 0x79, 0x0e, 0x2e, 0xac, 0x1b, 0x0a, 0xc3, 0x15, 0xd3,
+	0xb0, 0x39, 0xe2, 0xcb, 0xb3, 0x23, 0xfe, 0x1a, 0x54, 0xe5, 0x70, 0xa1, 0xf2, 0xb4, 0xd1, 0xb9,
+	0x5a, 0x98, 0xd5, 0xd5, 0xa4, 0x29, 0xdd, 0xfb, 0xd5, 0x82, 0xf5, 0xf4, 0x13, 0xf1, 0xef, 0x66,
+	0xd7, 0x05, 0x9b, 0x0b, 0x9f, 0x89, 0x07, 0x79, 0x8a, 0x73, 0x58, 0xca, 0x60, 0x1c, 0x4a, 0x4a,
+	0x55, 0x51, 0x34, 0x64, 0xa4, 0xa1, 0x36, 0x93, 0x86, 0x55, 0xa8, 0x0e, 0xa3, 0x51, 0x24, 0xd4,
+	0xa1, 0x56, 0xa1, 0x29, 0xe0, 0xfd, 0x62, 0xc1, 0xc6, 0xdc, 0xc8, 0xfe, 0xc3, 0xb2, 0xbc, 0x9e,
+	0xae, 0x45, 0xe4, 0x6a, 0xda, 0xe6, 0xd6, 0x45, 0x33, 0x78, 0xdf, 0x2c, 0xe9, 0xa6, 0xca, 0x4e,
+	0x91, 0xfb, 0xf2, 0xb1, 0xb4, 0xb8, 0x55, 0xe7, 0x41, 0x33, 0x7b, 0x9f, 0xc9, 0xe7, 0x93, 0x2e,
+	0xcf, 0x0c, 0x4e, 0xce, 0xa5, 0x7a, 0xa2, 0x29, 0x06, 0x7d, 0xd6, 0xe5, 0x08, 0xf9, 0x19, 0x51,
+	0x25, 0xdb, 0x37, 0x67, 0xc2, 0x44, 0x29, 0xf9, 0x38, 0xdc, 0x37, 0x6b, 0x56, 0x20, 0xa4, 0x67,
+	0xc1, 0x84, 0xf1, 0x84, 0xa9, 0xba, 0x35, 0xa9, 0x86, 0x8a, 0x72, 0xda, 0x66, 0x39, 0x9f, 0x5b,
+	0xb0, 0x31, 0x37, 0x1f, 0x0b, 0x2b, 0xe7, 0x0e, 0xd4, 0x54, 0x70, 0xd9, 0xb3, 0xe3, 0x7a, 0xf9,
+	0x18, 0x54, 0x16, 0xbb, 0xf1, 0x51, 0x42, 0x35, 0xa3, 0x11, 0xc2, 0xb2, 0x19, 0x42, 0xe7, 0x79,
+	0x0d, 0x6a, 0x9f, 0x28, 0x17, 0xc8, 0x5b, 0x00, 0xf7, 0x8e, 0x31, 0x18, 0xec, 0x0e, 0xa3, 0x13,
+	0x24, 0x46, 0xc1, 0xf5, 0xc3, 0xd1, 0x25, 0x65, 0x14, 0x1f, 0x7b, 0xff, 0x23, 0xef, 0x80, 0x9d,
+	0x3d, 0xf3, 0xc8, 0x9a, 0xd1, 0x25, 0xc5, 0xd3, 0xef, 0x0c, 0xc1, 0xbb, 0x50, 0xd7, 0xcf, 0x13,
+	0x62, 0xa4, 0xa1, 0x78, 0xa4, 0xb9, 0x6b, 0x73, 0xb0, 0x4a, 0xf2, 0x3d, 0x7d, 0x86, 0x64, 0xe1,
+	0x12, 0xb7, 0xe0, 0x2c, 0xbf, 0x33, 0xdc, 0x1b, 0x67, 0xd2, 0x94, 0xae, 0x5d, 0x80, 0xe2, 0xf0,
+	0x24, 0x1b, 0x46, 0x00, 0xe6, 0xe5, 0xec, 0x3a, 0xf3, 0x09, 0x59, 0x20, 0xfa, 0x8e, 0x33, 0x03,
+	0x29, 0x4e, 0x48, 0x77, 0x6d, 0x0e, 0x36, 0x33, 0x5e, 0x1c, 0x2f, 0xa6, 0xf1, 0x99, 0x2b, 0xcb,
+	0x75, 0xe6, 0x13, 0x94, 0x8a, 0x83, 0xec, 0x69, 0x55, 0x7c, 0xdd, 0xc9, 0x4b, 0xe5, 0x90, 0x67,
+	0x0e, 0x10, 0xf7, 0xe5, 0xf3, 0xc8, 0x4a, 0xe9, 0xa7, 0x40, 0x4e, 0x7f, 0x26, 0xc8, 0xad, 0x92,
+	0x5c, 0x79, 0x0f, 0xbb, 0xad, 0xf3, 0x19, 0x94, 0xea, 0xcf, 0xe0, 0xda, 0x9c, 0x5d, 0x47, 0x5a,
+	0xe5, 0xfc, 0x9e, 0x52, 0x7e, 0xfb, 0x05, 0x1c, 0x99, 0xf6, 0x39, 0xa3, 0x47, 0x5a, 0x67, 0xf4,
+	0x40, 0xbe, 0xa9, 0xdc, 0xdb, 0x2f, 0xe0, 0x90, 0xda, 0x7b, 0x35, 0x35, 0x66, 0x6f, 0xfc, 0x39,
+	0x00, 0xc1, 0x0e, 0x3c, 0xb0, 0xab, 0x12, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -996,6 +1725,8 @@ type XchainClient interface {
 	SubmitTx(ctx context.Context, in *SubmitTxReq, opts ...grpc.CallOption) (*BaseResp, error)
 	// 合约预执行
 	PreExec(ctx context.Context, in *PreExecReq, opts ...grpc.CallOption) (*PreExecResp, error)
+	// 只读合约查询，不生成交易
+	QueryContract(ctx context.Context, in *QueryContractReq, opts ...grpc.CallOption) (*QueryContractResp, error)
 	// 选择utxo
 	SelectUtxo(ctx context.Context, in *SelectUtxoReq, opts ...grpc.CallOption) (*SelectUtxoResp, error)
 	// 查询交易信息
@@ -1004,6 +1735,12 @@ type XchainClient interface {
 	QueryBlock(ctx context.Context, in *QueryBlockReq, opts ...grpc.CallOption) (*QueryBlockResp, error)
 	// 查询区块链状态
 	QueryChainStatus(ctx context.Context, in *QueryChainStatusReq, opts ...grpc.CallOption) (*QueryChainStatusResp, error)
+	// 查询指定区块高度时的合约状态
+	QueryStateAtHeight(ctx context.Context, in *QueryStateAtHeightReq, opts ...grpc.CallOption) (*QueryStateAtHeightResp, error)
+	// 遍历指定区块高度时bucket中的合约状态
+	SelectStateAtHeight(ctx context.Context, in *SelectStateAtHeightReq, opts ...grpc.CallOption) (*SelectStateAtHeightResp, error)
+	// 从事件索引中分页查询合约事件
+	QueryContractEvents(ctx context.Context, in *QueryContractEventsReq, opts ...grpc.CallOption) (*QueryContractEventsResp, error)
 }
 
 type xchainClient struct {
@@ -1041,6 +1778,15 @@ func (c *xchainClient) PreExec(ctx context.Context, in *PreExecReq, opts ...grpc
 	return out, nil
 }
 
+func (c *xchainClient) QueryContract(ctx context.Context, in *QueryContractReq, opts ...grpc.CallOption) (*QueryContractResp, error) {
+	out := new(QueryContractResp)
+	err := c.cc.Invoke(ctx, "/xchainpb.Xchain/QueryContract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *xchainClient) SelectUtxo(ctx context.Context, in *SelectUtxoReq, opts ...grpc.CallOption) (*SelectUtxoResp, error) {
 	out := new(SelectUtxoResp)
 	err := c.cc.Invoke(ctx, "/xchainpb.Xchain/SelectUtxo", in, out, opts...)
@@ -1077,6 +1823,33 @@ func (c *xchainClient) QueryChainStatus(ctx context.Context, in *QueryChainStatu
 	return out, nil
 }
 
+func (c *xchainClient) QueryStateAtHeight(ctx context.Context, in *QueryStateAtHeightReq, opts ...grpc.CallOption) (*QueryStateAtHeightResp, error) {
+	out := new(QueryStateAtHeightResp)
+	err := c.cc.Invoke(ctx, "/xchainpb.Xchain/QueryStateAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *xchainClient) SelectStateAtHeight(ctx context.Context, in *SelectStateAtHeightReq, opts ...grpc.CallOption) (*SelectStateAtHeightResp, error) {
+	out := new(SelectStateAtHeightResp)
+	err := c.cc.Invoke(ctx, "/xchainpb.Xchain/SelectStateAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *xchainClient) QueryContractEvents(ctx context.Context, in *QueryContractEventsReq, opts ...grpc.CallOption) (*QueryContractEventsResp, error) {
+	out := new(QueryContractEventsResp)
+	err := c.cc.Invoke(ctx, "/xchainpb.Xchain/QueryContractEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // XchainServer is the server API for Xchain service.
 type XchainServer interface {
 	// 示例接口
@@ -1085,6 +1858,8 @@ type XchainServer interface {
 	SubmitTx(context.Context, *SubmitTxReq) (*BaseResp, error)
 	// 合约预执行
 	PreExec(context.Context, *PreExecReq) (*PreExecResp, error)
+	// 只读合约查询，不生成交易
+	QueryContract(context.Context, *QueryContractReq) (*QueryContractResp, error)
 	// 选择utxo
 	SelectUtxo(context.Context, *SelectUtxoReq) (*SelectUtxoResp, error)
 	// 查询交易信息
@@ -1093,6 +1868,12 @@ type XchainServer interface {
 	QueryBlock(context.Context, *QueryBlockReq) (*QueryBlockResp, error)
 	// 查询区块链状态
 	QueryChainStatus(context.Context, *QueryChainStatusReq) (*QueryChainStatusResp, error)
+	// 查询指定区块高度时的合约状态
+	QueryStateAtHeight(context.Context, *QueryStateAtHeightReq) (*QueryStateAtHeightResp, error)
+	// 遍历指定区块高度时bucket中的合约状态
+	SelectStateAtHeight(context.Context, *SelectStateAtHeightReq) (*SelectStateAtHeightResp, error)
+	// 从事件索引中分页查询合约事件
+	QueryContractEvents(context.Context, *QueryContractEventsReq) (*QueryContractEventsResp, error)
 }
 
 // UnimplementedXchainServer can be embedded to have forward compatible implementations.
@@ -1108,6 +1889,9 @@ func (*UnimplementedXchainServer) SubmitTx(ctx context.Context, req *SubmitTxReq
 func (*UnimplementedXchainServer) PreExec(ctx context.Context, req *PreExecReq) (*PreExecResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PreExec not implemented")
 }
+func (*UnimplementedXchainServer) QueryContract(ctx context.Context, req *QueryContractReq) (*QueryContractResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryContract not implemented")
+}
 func (*UnimplementedXchainServer) SelectUtxo(ctx context.Context, req *SelectUtxoReq) (*SelectUtxoResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SelectUtxo not implemented")
 }
@@ -1120,6 +1904,15 @@ func (*UnimplementedXchainServer) QueryBlock(ctx context.Context, req *QueryBloc
 func (*UnimplementedXchainServer) QueryChainStatus(ctx context.Context, req *QueryChainStatusReq) (*QueryChainStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryChainStatus not implemented")
 }
+func (*UnimplementedXchainServer) QueryStateAtHeight(ctx context.Context, req *QueryStateAtHeightReq) (*QueryStateAtHeightResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryStateAtHeight not implemented")
+}
+func (*UnimplementedXchainServer) SelectStateAtHeight(ctx context.Context, req *SelectStateAtHeightReq) (*SelectStateAtHeightResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelectStateAtHeight not implemented")
+}
+func (*UnimplementedXchainServer) QueryContractEvents(ctx context.Context, req *QueryContractEventsReq) (*QueryContractEventsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryContractEvents not implemented")
+}
 
 func RegisterXchainServer(s *grpc.Server, srv XchainServer) {
 	s.RegisterService(&_Xchain_serviceDesc, srv)
@@ -1179,6 +1972,24 @@ func _Xchain_PreExec_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Xchain_QueryContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryContractReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(XchainServer).QueryContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xchainpb.Xchain/QueryContract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(XchainServer).QueryContract(ctx, req.(*QueryContractReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Xchain_SelectUtxo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SelectUtxoReq)
 	if err := dec(in); err != nil {
@@ -1251,6 +2062,60 @@ func _Xchain_QueryChainStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Xchain_QueryStateAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStateAtHeightReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(XchainServer).QueryStateAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xchainpb.Xchain/QueryStateAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(XchainServer).QueryStateAtHeight(ctx, req.(*QueryStateAtHeightReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Xchain_SelectStateAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectStateAtHeightReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(XchainServer).SelectStateAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xchainpb.Xchain/SelectStateAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(XchainServer).SelectStateAtHeight(ctx, req.(*SelectStateAtHeightReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Xchain_QueryContractEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryContractEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(XchainServer).QueryContractEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xchainpb.Xchain/QueryContractEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(XchainServer).QueryContractEvents(ctx, req.(*QueryContractEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Xchain_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "xchainpb.Xchain",
 	HandlerType: (*XchainServer)(nil),
@@ -1267,6 +2132,10 @@ var _Xchain_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PreExec",
 			Handler:    _Xchain_PreExec_Handler,
 		},
+		{
+			MethodName: "QueryContract",
+			Handler:    _Xchain_QueryContract_Handler,
+		},
 		{
 			MethodName: "SelectUtxo",
 			Handler:    _Xchain_SelectUtxo_Handler,
@@ -1283,7 +2152,19 @@ var _Xchain_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryChainStatus",
 			Handler:    _Xchain_QueryChainStatus_Handler,
 		},
+		{
+			MethodName: "QueryStateAtHeight",
+			Handler:    _Xchain_QueryStateAtHeight_Handler,
+		},
+		{
+			MethodName: "SelectStateAtHeight",
+			Handler:    _Xchain_SelectStateAtHeight_Handler,
+		},
+		{
+			MethodName: "QueryContractEvents",
+			Handler:    _Xchain_QueryContractEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "xchain.proto",
+	Metadata: "example/xchain/common/xchainpb/xchain.proto",
 }
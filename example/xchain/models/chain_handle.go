@@ -48,6 +48,21 @@ func (t *ChainHandle) PreExec(req []*protos.InvokeRequest,
 	return t.chain.PreExec(t.genXctx(), req, initiator, authRequires)
 }
 
+func (t *ChainHandle) PreExecWithTrace(req []*protos.InvokeRequest,
+	initiator string, authRequires []string) (*protos.InvokeResponse, error) {
+	return t.chain.PreExecWithTrace(t.genXctx(), req, initiator, authRequires)
+}
+
+func (t *ChainHandle) PreExecWithDebug(req []*protos.InvokeRequest,
+	initiator string, authRequires []string) (*protos.InvokeResponse, error) {
+	return t.chain.PreExecWithDebug(t.genXctx(), req, initiator, authRequires)
+}
+
+func (t *ChainHandle) QueryContract(req *protos.InvokeRequest, initiator string,
+	authRequires []string, blockId []byte) (*protos.ContractResponse, error) {
+	return t.chain.QueryContract(t.genXctx(), req, initiator, authRequires, blockId)
+}
+
 func (t *ChainHandle) QueryTx(txId []byte) (*xpb.TxInfo, error) {
 	return reader.NewLedgerReader(t.chain.Context(), t.genXctx()).QueryTx(txId)
 }
@@ -66,6 +81,23 @@ func (t *ChainHandle) QueryChainStatus(needBranch bool) (*xpb.ChainStatus, error
 	return reader.NewChainReader(t.chain.Context(), t.genXctx()).GetChainStatus()
 }
 
+func (t *ChainHandle) QueryStateAtHeight(bucket string, key []byte,
+	height int64) (*xpb.StateInfo, error) {
+	return reader.NewStateReader(t.chain.Context(), t.genXctx()).GetStateAtHeight(bucket, key, height)
+}
+
+func (t *ChainHandle) SelectStateAtHeight(bucket string, startKey, endKey []byte,
+	height int64, limit int) (*xpb.StateInfo, error) {
+	return reader.NewStateReader(t.chain.Context(), t.genXctx()).SelectStateAtHeight(bucket,
+		startKey, endKey, height, limit)
+}
+
+func (t *ChainHandle) QueryContractEvents(contractName, eventName string, startHeight, endHeight int64,
+	cursor []byte, limit int) ([]*protos.ContractEventInfo, []byte, error) {
+	return reader.NewContractReader(t.chain.Context(), t.genXctx()).QueryContractEvents(contractName,
+		eventName, startHeight, endHeight, cursor, limit)
+}
+
 func (t *ChainHandle) genXctx() xctx.XContext {
 	return &xctx.BaseCtx{
 		XLog:  t.reqCtx.GetLog(),
@@ -8,7 +8,9 @@ import (
 	pb "github.com/xuperchain/xupercore/example/xchain/common/xchainpb"
 	"github.com/xuperchain/xupercore/example/xchain/models"
 	ecom "github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/xpb"
 	"github.com/xuperchain/xupercore/lib/utils"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 // 注意：
@@ -72,7 +74,15 @@ func (t *RpcServ) PreExec(gctx context.Context, req *pb.PreExecReq) (*pb.PreExec
 		rctx.GetLog().Warn("new chain handle failed", "err", err.Error())
 		return resp, err
 	}
-	res, err := handle.PreExec(req.GetRequests(), req.GetInitiator(), req.GetAuthRequire())
+	var res *protos.InvokeResponse
+	switch {
+	case req.GetDebug():
+		res, err = handle.PreExecWithDebug(req.GetRequests(), req.GetInitiator(), req.GetAuthRequire())
+	case req.GetTrace():
+		res, err = handle.PreExecWithTrace(req.GetRequests(), req.GetInitiator(), req.GetAuthRequire())
+	default:
+		res, err = handle.PreExec(req.GetRequests(), req.GetInitiator(), req.GetAuthRequire())
+	}
 	rctx.GetLog().SetInfoField("bc_name", req.GetBcname())
 	rctx.GetLog().SetInfoField("initiator", req.GetInitiator())
 	// 设置响应
@@ -84,6 +94,38 @@ func (t *RpcServ) PreExec(gctx context.Context, req *pb.PreExecReq) (*pb.PreExec
 	return resp, err
 }
 
+// 只读合约查询
+func (t *RpcServ) QueryContract(gctx context.Context, req *pb.QueryContractReq) (*pb.QueryContractResp, error) {
+	// 默认响应
+	resp := &pb.QueryContractResp{}
+	// 获取请求上下文，对内传递rctx
+	rctx := sctx.ValueReqCtx(gctx)
+
+	// 校验参数
+	if req == nil || req.GetBcname() == "" || req.GetRequest() == nil {
+		rctx.GetLog().Warn("param error,some param unset")
+		return resp, ecom.ErrParameter
+	}
+
+	// 合约查询
+	handle, err := models.NewChainHandle(req.GetBcname(), rctx)
+	if err != nil {
+		rctx.GetLog().Warn("new chain handle failed", "err", err.Error())
+		return resp, err
+	}
+	res, err := handle.QueryContract(req.GetRequest(), req.GetInitiator(), req.GetAuthRequire(), req.GetBlockId())
+	rctx.GetLog().SetInfoField("bc_name", req.GetBcname())
+	rctx.GetLog().SetInfoField("contract", req.GetRequest().GetContractName())
+	rctx.GetLog().SetInfoField("method", req.GetRequest().GetMethodName())
+	// 设置响应
+	if err == nil {
+		resp.Bcname = req.GetBcname()
+		resp.Response = res
+	}
+
+	return resp, err
+}
+
 // 选择utxo
 func (t *RpcServ) SelectUtxo(gctx context.Context, req *pb.SelectUtxoReq) (*pb.SelectUtxoResp, error) {
 	// 默认响应
@@ -212,3 +254,123 @@ func (t *RpcServ) QueryChainStatus(gctx context.Context,
 
 	return resp, err
 }
+
+// 查询指定区块高度时的合约状态
+func (t *RpcServ) QueryStateAtHeight(gctx context.Context,
+	req *pb.QueryStateAtHeightReq) (*pb.QueryStateAtHeightResp, error) {
+	// 默认响应
+	resp := &pb.QueryStateAtHeightResp{}
+	// 获取请求上下文，对内传递rctx
+	rctx := sctx.ValueReqCtx(gctx)
+
+	// 校验参数
+	if req == nil || req.GetBcname() == "" || req.GetBucket() == "" || len(req.GetKey()) < 1 {
+		rctx.GetLog().Warn("param error,some param unset")
+		return resp, ecom.ErrParameter
+	}
+
+	// 查询状态
+	handle, err := models.NewChainHandle(req.GetBcname(), rctx)
+	if err != nil {
+		rctx.GetLog().Warn("new chain handle failed", "err", err.Error())
+		return resp, err
+	}
+	res, err := handle.QueryStateAtHeight(req.GetBucket(), req.GetKey(), req.GetHeight())
+	rctx.GetLog().SetInfoField("bc_name", req.GetBcname())
+	rctx.GetLog().SetInfoField("bucket", req.GetBucket())
+	rctx.GetLog().SetInfoField("height", req.GetHeight())
+	// 设置响应
+	if err == nil {
+		resp.Bcname = req.GetBcname()
+		resp.Height = res.GetHeight()
+		resp.BlockId = res.GetBlockid()
+		if len(res.GetStates()) > 0 {
+			resp.State = toStateKV(res.GetStates()[0])
+		}
+	}
+
+	return resp, err
+}
+
+// 遍历指定区块高度时bucket中的合约状态
+func (t *RpcServ) SelectStateAtHeight(gctx context.Context,
+	req *pb.SelectStateAtHeightReq) (*pb.SelectStateAtHeightResp, error) {
+	// 默认响应
+	resp := &pb.SelectStateAtHeightResp{}
+	// 获取请求上下文，对内传递rctx
+	rctx := sctx.ValueReqCtx(gctx)
+
+	// 校验参数
+	if req == nil || req.GetBcname() == "" || req.GetBucket() == "" {
+		rctx.GetLog().Warn("param error,some param unset")
+		return resp, ecom.ErrParameter
+	}
+
+	// 遍历状态
+	handle, err := models.NewChainHandle(req.GetBcname(), rctx)
+	if err != nil {
+		rctx.GetLog().Warn("new chain handle failed", "err", err.Error())
+		return resp, err
+	}
+	res, err := handle.SelectStateAtHeight(req.GetBucket(), req.GetStartKey(), req.GetEndKey(),
+		req.GetHeight(), int(req.GetLimit()))
+	rctx.GetLog().SetInfoField("bc_name", req.GetBcname())
+	rctx.GetLog().SetInfoField("bucket", req.GetBucket())
+	rctx.GetLog().SetInfoField("height", req.GetHeight())
+	// 设置响应
+	if err == nil {
+		resp.Bcname = req.GetBcname()
+		resp.Height = res.GetHeight()
+		resp.BlockId = res.GetBlockid()
+		for _, state := range res.GetStates() {
+			resp.States = append(resp.States, toStateKV(state))
+		}
+	}
+
+	return resp, err
+}
+
+// 从事件索引中分页查询合约事件
+func (t *RpcServ) QueryContractEvents(gctx context.Context,
+	req *pb.QueryContractEventsReq) (*pb.QueryContractEventsResp, error) {
+	// 默认响应
+	resp := &pb.QueryContractEventsResp{}
+	// 获取请求上下文，对内传递rctx
+	rctx := sctx.ValueReqCtx(gctx)
+
+	// 校验参数
+	if req == nil || req.GetBcname() == "" || req.GetContractName() == "" || req.GetEventName() == "" {
+		rctx.GetLog().Warn("param error,some param unset")
+		return resp, ecom.ErrParameter
+	}
+
+	// 查询事件
+	handle, err := models.NewChainHandle(req.GetBcname(), rctx)
+	if err != nil {
+		rctx.GetLog().Warn("new chain handle failed", "err", err.Error())
+		return resp, err
+	}
+	events, cursor, err := handle.QueryContractEvents(req.GetContractName(), req.GetEventName(),
+		req.GetStartHeight(), req.GetEndHeight(), req.GetCursor(), int(req.GetLimit()))
+	rctx.GetLog().SetInfoField("bc_name", req.GetBcname())
+	rctx.GetLog().SetInfoField("contract_name", req.GetContractName())
+	rctx.GetLog().SetInfoField("event_name", req.GetEventName())
+	// 设置响应
+	if err == nil {
+		resp.Bcname = req.GetBcname()
+		resp.Events = events
+		resp.Cursor = cursor
+	}
+
+	return resp, err
+}
+
+func toStateKV(state *xpb.StateData) *pb.StateKV {
+	return &pb.StateKV{
+		Bucket:    state.GetBucket(),
+		Key:       state.GetKey(),
+		Value:     state.GetValue(),
+		RefTxid:   state.GetRefTxid(),
+		RefOffset: state.GetRefOffset(),
+	}
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/xuperchain/xupercore/kernel/engines"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos"
 	ecom "github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/event"
 	"github.com/xuperchain/xupercore/lib/logs"
+	"github.com/xuperchain/xupercore/protos"
 
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
@@ -99,6 +101,7 @@ func (t *RpcServMG) runRpcServ() error {
 
 	t.servHD = grpc.NewServer(rpcOptions...)
 	pb.RegisterXchainServer(t.servHD, t.rpcServ)
+	protos.RegisterEventServiceServer(t.servHD, event.NewEngineService(t.engine))
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", t.scfg.RpcPort))
 	if err != nil {
@@ -290,6 +290,18 @@ func (c *FakeKContext) AuthRequire() []string {
 	return []string{"TeyyPLpp9L7QAcxHangtcHTu7HUZ6iydY", "SmJG3rH2ZzYQ9ojxhbRCPwFiE9y6pD1Co"}
 }
 
+func (c *FakeKContext) BlockHeight() int64 {
+	return 0
+}
+
+func (c *FakeKContext) BlockTime() int64 {
+	return 0
+}
+
+func (c *FakeKContext) BlockPreHash() []byte {
+	return nil
+}
+
 func (c *FakeKContext) GetAccountAddresses(accountName string) ([]string, error) {
 	return nil, nil
 }
@@ -308,6 +320,8 @@ func (c *FakeKContext) RWSet() *contract.RWSet {
 
 func (c *FakeKContext) AddEvent(events ...*protos.ContractEvent) {}
 
+func (c *FakeKContext) RecordBlockRead(height int64) {}
+
 func (c *FakeKContext) Flush() error {
 	return nil
 }
@@ -361,12 +375,19 @@ func (c *FakeKContext) UTXORWSet() *contract.UTXORWSet {
 func (c *FakeKContext) Transfer(from string, to string, amount *big.Int) error {
 	return nil
 }
+func (c *FakeKContext) Balance(addr string) (*big.Int, error) {
+	return new(big.Int), nil
+}
 func (c *FakeKContext) QueryBlock(blockid []byte) (*xldgpb.InternalBlock, error) {
 	return &xldgpb.InternalBlock{}, nil
 }
 func (c *FakeKContext) QueryTransaction(txid []byte) (*pb.Transaction, error) {
 	return &pb.Transaction{}, nil
 }
+func (c *FakeKContext) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.ContractResponse, contract.Limits, error) {
+	return &protos.ContractResponse{}, contract.Limits{}, nil
+}
 
 type FakeManager struct {
 	R *FakeRegistry
@@ -380,6 +401,14 @@ func (m *FakeManager) NewStateSandbox(cfg *contract.SandboxConfig) (contract.Sta
 	return nil, nil
 }
 
+func (m *FakeManager) Query(cfg *contract.QueryConfig, req *protos.InvokeRequest) (*contract.Response, error) {
+	return nil, nil
+}
+
+func (m *FakeManager) WarmUpCache(contractNames []string) error {
+	return nil
+}
+
 func (m *FakeManager) GetKernRegistry() contract.KernRegistry {
 	return m.R
 }
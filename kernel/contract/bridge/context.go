@@ -33,6 +33,11 @@ type Context struct {
 
 	CanInitialize bool
 
+	CanMigrate bool
+
+	// Whether contract versions are recorded at the executing block
+	ContractVersionEnabled bool
+
 	Core contract.ChainCore
 
 	TransferAmount string
@@ -53,6 +58,24 @@ type Context struct {
 
 	// Write by contract
 	Output *pb.Response
+
+	// Call trace of contract, nil if not in trace mode
+	Trace *protos.CallTrace
+
+	// Whether contract can be paused by vm debugger
+	Debug bool
+
+	// Whether the result is not part of consensus, such as pre-execution and query
+	PreExec bool
+
+	// Whether code of nested calls is fetched from State
+	ContractCodeFromState bool
+
+	// Height of the block in which the contract is executed,
+	// timestamp and id of its parent block
+	BlockHeight  int64
+	BlockTime    int64
+	BlockPreHash []byte
 }
 
 // DiskUsed returns the bytes written to xmodel
@@ -34,6 +34,9 @@ func (v *vmContextImpl) Invoke(method string, args map[string][]byte) (*contract
 	if !v.ctx.CanInitialize && method == initMethod {
 		return nil, errors.New("invalid contract method " + method)
 	}
+	if !v.ctx.CanMigrate && method == migrateMethod && isMigrateReserved(v.ctx) {
+		return nil, errors.New("invalid contract method " + method)
+	}
 
 	v.ctx.Method = method
 	v.ctx.Args = args
@@ -0,0 +1,136 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// isContractFrozen 读取合约状态，读取会记录到读写集中，保证冻结交易和调用交易之间的冲突可以被检测到。
+// 状态不存在或已删除表示合约正常，其他读取错误需要返回给调用方
+func isContractFrozen(state stateReader, contractName string) (bool, error) {
+	status, err := state.Get("contract", contract.ContractStatusKey(contractName))
+	if err == sandbox.ErrNotFound || err == sandbox.ErrHasDel {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return string(status) == contract.ContractStatusFrozen, nil
+}
+
+// FreezeContract 冻结合约，冻结后合约不能被调用，直到被解冻
+func (c *contractManager) FreezeContract(kctx contract.KContext) (*contract.Response, contract.Limits, error) {
+	contractName, _, err := c.lifecycleContract(kctx)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	frozen, err := isContractFrozen(kctx, contractName)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if frozen {
+		return nil, contract.Limits{}, fmt.Errorf("contract %s already frozen", contractName)
+	}
+	err = kctx.Put("contract", contract.ContractStatusKey(contractName), []byte(contract.ContractStatusFrozen))
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	return lifecycleResponse("freeze success", kctx)
+}
+
+// UnfreezeContract 解冻合约
+func (c *contractManager) UnfreezeContract(kctx contract.KContext) (*contract.Response, contract.Limits, error) {
+	contractName, _, err := c.lifecycleContract(kctx)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	frozen, err := isContractFrozen(kctx, contractName)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if !frozen {
+		return nil, contract.Limits{}, fmt.Errorf("contract %s not frozen", contractName)
+	}
+	err = kctx.Del("contract", contract.ContractStatusKey(contractName))
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	return lifecycleResponse("unfreeze success", kctx)
+}
+
+// DestroyContract 销毁合约，删除合约代码和合约与账户的关联关系，并记录墓碑。
+// 合约bucket中的数据不在交易中删除，由后续的压缩根据墓碑清理，销毁后的合约名不能再被部署。
+// 合约的版本记录会被保留，用于审计，节点本地的合约调用统计在墓碑所在的区块中清理。
+func (c *contractManager) DestroyContract(kctx contract.KContext) (*contract.Response, contract.Limits, error) {
+	contractName, desc, err := c.lifecycleContract(kctx)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+
+	keys := [][]byte{
+		ContractCodeDescKey(contractName),
+		contractCodeKey(contractName),
+		contractAbiKey(contractName),
+		contract.ContractStatusKey(contractName),
+	}
+	for _, key := range keys {
+		if _, err := kctx.Get("contract", key); err != nil {
+			continue
+		}
+		if err := kctx.Del("contract", key); err != nil {
+			return nil, contract.Limits{}, err
+		}
+	}
+
+	account, err := kctx.Get(utils.GetContract2AccountBucket(), []byte(contractName))
+	if err != nil {
+		return nil, contract.Limits{}, fmt.Errorf("get account of contract %s error:%s", contractName, err)
+	}
+	if err := kctx.Del(utils.GetContract2AccountBucket(), []byte(contractName)); err != nil {
+		return nil, contract.Limits{}, err
+	}
+	key := utils.MakeAccountContractKey(string(account), contractName)
+	if err := kctx.Del(utils.GetAccount2ContractBucket(), []byte(key)); err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if err := kctx.Put(utils.GetContractTombstoneBucket(), []byte(contractName), account); err != nil {
+		return nil, contract.Limits{}, err
+	}
+
+	contractType, err := getContractType(desc)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if creator := c.xbridge.getCreator(contractType); creator != nil {
+		creator.RemoveCache(contractName)
+	}
+	return lifecycleResponse("destroy success", kctx)
+}
+
+// lifecycleContract 检查合约是否存在，内核合约没有合约代码，不能被冻结和销毁
+func (c *contractManager) lifecycleContract(kctx contract.KContext) (string, *protos.WasmCodeDesc, error) {
+	name := kctx.Args()["contract_name"]
+	if name == nil {
+		return "", nil, errors.New("bad contract name")
+	}
+	contractName := string(name)
+	desc, err := newCodeProvider(kctx).GetContractCodeDesc(contractName)
+	if err != nil {
+		return "", nil, fmt.Errorf("contract %s not exists", contractName)
+	}
+	return contractName, desc, nil
+}
+
+func lifecycleResponse(msg string, kctx contract.KContext) (*contract.Response, contract.Limits, error) {
+	return &contract.Response{
+		Status: contract.StatusOK,
+		Body:   []byte(msg),
+	}, contract.Limits{
+		Disk: modelCacheDiskUsed(kctx),
+	}, nil
+}
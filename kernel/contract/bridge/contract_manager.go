@@ -7,6 +7,7 @@ import (
 
 	"github.com/xuperchain/crypto/core/hash"
 	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
 	"github.com/xuperchain/xupercore/protos"
 
 	"github.com/golang/protobuf/proto"
@@ -30,6 +31,9 @@ func (c *contractManager) DeployContract(kctx contract.KContext) (*contract.Resp
 	if err == nil {
 		return nil, contract.Limits{}, fmt.Errorf("contract %s already exists", contractName)
 	}
+	if _, err := state.Get(utils.GetContractTombstoneBucket(), name); err == nil {
+		return nil, contract.Limits{}, fmt.Errorf("contract %s has been destroyed", contractName)
+	}
 
 	code := args["contract_code"]
 	if code == nil {
@@ -62,6 +66,12 @@ func (c *contractManager) DeployContract(kctx contract.KContext) (*contract.Resp
 
 	}
 
+	if c.xbridge.contractVersion.Active(kctx.BlockHeight()) {
+		if err := recordContractVersion(state, contractName, &desc, kctx.Initiator(), 1); err != nil {
+			return nil, contract.Limits{}, err
+		}
+	}
+
 	if desc.ContractType == string(TypeEvm) {
 		abiBuf := args["contract_abi"]
 		if err := state.Put("contract", contractAbiKey(contractName), abiBuf); err != nil {
@@ -99,6 +109,9 @@ func (c *contractManager) DeployContract(kctx contract.KContext) (*contract.Resp
 		ContractName:          contractName,
 		CanInitialize:         true,
 		ContractCodeFromCache: true,
+		BlockHeight:           kctx.BlockHeight(),
+		BlockTime:             kctx.BlockTime(),
+		BlockPreHash:          kctx.BlockPreHash(),
 	}
 	initConfig.ContractName = contractName
 	initConfig.CanInitialize = true
@@ -154,6 +167,21 @@ func (c *contractManager) UpgradeContract(kctx contract.KContext) (*contract.Res
 	store := kctx
 	store.Put("contract", ContractCodeDescKey(contractName), descbuf)
 	store.Put("contract", contractCodeKey(contractName), code)
+	// 启用版本记录前部署的合约没有版本记录，升级时也不记录，且不支持迁移
+	versionEnabled := c.xbridge.contractVersion.Active(kctx.BlockHeight())
+	var version int64
+	if versionEnabled {
+		latest, err := latestContractVersion(store, contractName)
+		if err != nil {
+			return nil, contract.Limits{}, err
+		}
+		if latest > 0 {
+			version = latest + 1
+			if err := recordContractVersion(store, contractName, desc, kctx.Initiator(), version); err != nil {
+				return nil, contract.Limits{}, err
+			}
+		}
+	}
 
 	cp := newCodeProvider(store)
 
@@ -175,12 +203,24 @@ func (c *contractManager) UpgradeContract(kctx contract.KContext) (*contract.Res
 	}
 	instance.Release()
 
+	// 可选的数据迁移，由升级交易传入migrate_args时执行
+	limits := contract.Limits{}
+	if migrateArgs := args["migrate_args"]; migrateArgs != nil && versionEnabled {
+		if version == 0 {
+			return nil, contract.Limits{}, fmt.Errorf("contract %s has no version record, can not migrate", contractName)
+		}
+		_, limits, err = c.migrateContract(kctx, contractName, migrateArgs)
+		if err != nil {
+			creator.RemoveCache(contractName)
+			return nil, contract.Limits{}, err
+		}
+	}
+	limits.Disk = modelCacheDiskUsed(store)
+
 	return &contract.Response{
-			Status: 200,
-			Body:   []byte("upgrade success"),
-		}, contract.Limits{
-			Disk: modelCacheDiskUsed(store),
-		}, nil
+		Status: 200,
+		Body:   []byte("upgrade success"),
+	}, limits, nil
 }
 
 func modelCacheDiskUsed(store contract.KContext) int64 {
@@ -0,0 +1,113 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+const (
+	migrateMethod = "migrate"
+)
+
+// ContractLatestVersionKey 保存合约的最新版本号
+func ContractLatestVersionKey(contractName string) []byte {
+	return []byte(contractName + "." + "version")
+}
+
+// ContractVersionKey 保存合约某个版本的记录
+func ContractVersionKey(contractName string, version int64) []byte {
+	return []byte(fmt.Sprintf("%s.version.%020d", contractName, version))
+}
+
+// ParseContractVersion 解析版本号，空值表示合约没有版本记录
+func ParseContractVersion(value []byte) (int64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// latestContractVersion 返回合约的最新版本号，合约没有版本记录时返回0，其他读取错误需要返回给调用方
+func latestContractVersion(state contract.StateSandbox, contractName string) (int64, error) {
+	value, err := state.Get("contract", ContractLatestVersionKey(contractName))
+	if err == sandbox.ErrNotFound || err == sandbox.ErrHasDel {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	latest, err := ParseContractVersion(value)
+	if err != nil {
+		return 0, fmt.Errorf("bad version of contract %s:%s", contractName, err)
+	}
+	return latest, nil
+}
+
+// recordContractVersion 在contract bucket中记录合约代码的版本，只保存代码的哈希，
+// 代码本身可以从写入版本记录的部署或升级交易中获取
+func recordContractVersion(state contract.StateSandbox, contractName string,
+	desc *protos.WasmCodeDesc, deployer string, version int64) error {
+	record := &protos.ContractVersion{
+		ContractName: contractName,
+		Version:      version,
+		CodeHash:     desc.GetDigest(),
+		Deployer:     deployer,
+		Desc:         desc,
+	}
+	recordBuf, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := state.Put("contract", ContractVersionKey(contractName, version), recordBuf); err != nil {
+		return err
+	}
+	return state.Put("contract", ContractLatestVersionKey(contractName), []byte(strconv.FormatInt(version, 10)))
+}
+
+// isMigrateReserved 返回合约的migrate方法是否只能在升级时调用，
+// 只有启用版本记录后部署的合约才有版本记录，之前部署的合约的migrate方法仍是普通方法
+func isMigrateReserved(ctx *Context) bool {
+	if !ctx.ContractVersionEnabled {
+		return false
+	}
+	_, err := ctx.State.Get("contract", ContractLatestVersionKey(ctx.ContractName))
+	return err == nil
+}
+
+// migrateContract 使用升级后的代码调用合约的migrate方法，和升级在同一个沙盒中执行
+func (c *contractManager) migrateContract(kctx contract.KContext, contractName string, argsBuf []byte) (*contract.Response, contract.Limits, error) {
+	var args map[string][]byte
+	if err := json.Unmarshal(argsBuf, &args); err != nil {
+		return nil, contract.Limits{}, fmt.Errorf("bad migrate args:%s", err)
+	}
+	ctx, err := c.xbridge.NewContext(&contract.ContextConfig{
+		State:                 kctx,
+		Initiator:             kctx.Initiator(),
+		AuthRequire:           kctx.AuthRequire(),
+		ContractName:          contractName,
+		ResourceLimits:        kctx.ResourceLimit(),
+		CanMigrate:            true,
+		ContractCodeFromCache: true,
+		BlockHeight:           kctx.BlockHeight(),
+		BlockTime:             kctx.BlockTime(),
+		BlockPreHash:          kctx.BlockPreHash(),
+	})
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	defer ctx.Release()
+	out, err := ctx.Invoke(migrateMethod, args)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if out.Status >= contract.StatusErrorThreshold {
+		return nil, contract.Limits{}, fmt.Errorf("migrate contract %s failed:%s", contractName, out.Message)
+	}
+	return out, ctx.ResourceUsed(), nil
+}
@@ -0,0 +1,71 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+const (
+	// CrossQueryScheme is the uri scheme of chains in the same engine
+	CrossQueryScheme = "xuper"
+
+	// crossQueryKeyArg is the arg name of the key when querying a bucket directly
+	crossQueryKeyArg = "key"
+)
+
+// ParseCrossQueryURI parse cross query uri into CrossQueryRequest
+// uri of contract query: xuper://<chain_name>?module=wasm&contract=counter&method=get&height=100
+// uri of state query: xuper://<chain_name>?bucket=counter&height=100, key is passed by args
+func ParseCrossQueryURI(uri string, args []*pb.ArgPair) (*protos.CrossQueryRequest, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != CrossQueryScheme {
+		return nil, fmt.Errorf("unsupported cross query scheme:%s", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("empty chain name in cross query uri")
+	}
+
+	query := u.Query()
+	height, err := strconv.ParseInt(query.Get("height"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad height in cross query uri:%s", err)
+	}
+
+	argsMap := make(map[string][]byte, len(args))
+	for _, arg := range args {
+		argsMap[arg.GetKey()] = arg.GetValue()
+	}
+
+	req := &protos.CrossQueryRequest{
+		ChainName: u.Host,
+		Height:    height,
+	}
+	if bucket := query.Get("bucket"); bucket != "" {
+		key, ok := argsMap[crossQueryKeyArg]
+		if !ok {
+			return nil, errors.New("missing key in cross query args")
+		}
+		req.Bucket = bucket
+		req.Key = key
+		return req, nil
+	}
+
+	req.Request = &protos.InvokeRequest{
+		ModuleName:   query.Get("module"),
+		ContractName: query.Get("contract"),
+		MethodName:   query.Get("method"),
+		Args:         argsMap,
+	}
+	if req.Request.ModuleName == "" || req.Request.ContractName == "" || req.Request.MethodName == "" {
+		return nil, errors.New("module, contract and method are required in cross query uri")
+	}
+	return req, nil
+}
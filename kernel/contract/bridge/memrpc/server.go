@@ -111,3 +111,17 @@ func (s *Server) CallMethod(ctx context.Context, ctxid int64, method string, req
 	}
 	return responseBuf, nil
 }
+
+// DecodeRequest 根据方法名反序列化系统调用的请求，用于调试时展示请求内容
+func (s *Server) DecodeRequest(method string, requestBuf []byte) (proto.Message, error) {
+	m, ok := s.methods[method]
+	if !ok {
+		return nil, ErrMethodNotFound
+	}
+	reqmsg := reflect.New(m.Type.In(2).Elem()).Interface().(proto.Message)
+	err := proto.Unmarshal(requestBuf, reqmsg)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal request error:%s", err)
+	}
+	return reqmsg, nil
+}
@@ -161,6 +161,13 @@ func (c *SyscallService) ContractCall(ctx context.Context, in *pb.ContractCallRe
 		args[arg.GetKey()] = arg.GetValue()
 	}
 
+	// 跟踪模式下嵌套调用记录为调用方的子节点
+	var trace *protos.CallTrace
+	if nctx.Trace != nil {
+		trace = contract.NewCallTrace(in.GetModule(), in.GetContract(), in.GetMethod(), args)
+		nctx.Trace.Calls = append(nctx.Trace.Calls, trace)
+	}
+
 	nctx.ContractSet[in.GetContract()] = true
 	cfg := &contract.ContextConfig{
 		Module:         in.GetModule(),
@@ -172,9 +179,19 @@ func (c *SyscallService) ContractCall(ctx context.Context, in *pb.ContractCallRe
 		Caller:         nctx.ContractName,
 		ResourceLimits: *limits,
 		ContractSet:    nctx.ContractSet,
+		Trace:          trace,
+		Debug:          nctx.Debug,
+		PreExec:        nctx.PreExec,
+		BlockHeight:    nctx.BlockHeight,
+		BlockTime:      nctx.BlockTime,
+		BlockPreHash:   nctx.BlockPreHash,
+
+		// 在历史快照上查询时，嵌套调用的合约代码也从快照中读取
+		ContractCodeFromState: nctx.ContractCodeFromState,
 	}
 	vctx, err := c.bridge.NewContext(cfg)
 	if err != nil {
+		contract.FinishCallTrace(trace, nil, contract.Limits{}, err)
 		return nil, err
 	}
 	defer func() {
@@ -183,6 +200,7 @@ func (c *SyscallService) ContractCall(ctx context.Context, in *pb.ContractCallRe
 	}()
 
 	vresp, err := vctx.Invoke(in.GetMethod(), args)
+	contract.FinishCallTrace(trace, vresp, vctx.ResourceUsed(), err)
 	if err != nil {
 		return nil, err
 	}
@@ -198,39 +216,33 @@ func (c *SyscallService) ContractCall(ctx context.Context, in *pb.ContractCallRe
 
 // CrossContractQuery implements Syscall interface
 func (c *SyscallService) CrossContractQuery(ctx context.Context, in *pb.CrossContractQueryRequest) (*pb.CrossContractQueryResponse, error) {
-	return nil, ErrNotImplementation
-	// nctx, ok := c.ctxmgr.Context(in.GetHeader().Ctxid)
-	// if !ok {
-	// 	return nil, fmt.Errorf("bad ctx id:%d", in.Header.Ctxid)
-	// }
-
-	// crossChainURI, err := ParseCrossChainURI(in.GetUri())
-	// if err != nil {
-	// 	return nil, fmt.Errorf("ParseCrossChainURI error, err:%s ctx id:%d", err.Error(), in.Header.Ctxid)
-	// }
-
-	// crossQueryMeta, err := nctx.Core.ResolveChain(crossChainURI.GetChainName())
-	// if err != nil {
-	// 	return nil, fmt.Errorf("ResolveChain error, err:%s ctx id:%d", err.Error(), in.Header.Ctxid)
-	// }
-
-	// // Assemble crossQueryRequest
-	// crossQueryRequest := &xchainpb.CrossQueryRequest{}
-	// crossScheme := GetChainScheme(crossChainURI.GetScheme())
-	// crossQueryRequest, err = crossScheme.GetCrossQueryRequest(crossChainURI, in.GetArgs(), nctx.GetInitiator(), nctx.GetAuthRequire())
-	// if err != nil {
-	// 	return nil, fmt.Errorf("GetCrossQueryRequest error, err:%s ctx id: %d", err.Error(), in.Header.Ctxid)
-	// }
-
-	// // CrossQuery cross query from other chain
-	// contractResponse, err := nctx.Store.CrossQuery(crossQueryRequest, crossQueryMeta)
-	// return &pb.CrossContractQueryResponse{
-	// 	Response: &pb.Response{
-	// 		Status:  contractResponse.GetStatus(),
-	// 		Message: contractResponse.GetMessage(),
-	// 		Body:    contractResponse.GetBody(),
-	// 	},
-	// }, err
+	nctx, ok := c.ctxmgr.Context(in.GetHeader().Ctxid)
+	if !ok {
+		return nil, fmt.Errorf("bad ctx id:%d", in.Header.Ctxid)
+	}
+
+	crossQueryRequest, err := ParseCrossQueryURI(in.GetUri(), in.GetArgs())
+	if err != nil {
+		return nil, fmt.Errorf("ParseCrossQueryURI error, err:%s ctx id:%d", err, in.Header.Ctxid)
+	}
+	crossQueryRequest.Initiator = nctx.Initiator
+	crossQueryRequest.AuthRequire = nctx.AuthRequire
+
+	// 跨链查询合约使用调用方剩余的资源，消耗的资源计入调用方
+	limits := new(contract.Limits).Add(nctx.ResourceLimits).Sub(nctx.ResourceUsed())
+	limits.Disk = nctx.ResourceLimits.Disk
+	contractResponse, used, err := nctx.State.CrossQuery(crossQueryRequest, *limits)
+	if err != nil {
+		return nil, err
+	}
+	nctx.SubResourceUsed.Add(used)
+	return &pb.CrossContractQueryResponse{
+		Response: &pb.Response{
+			Status:  contractResponse.GetStatus(),
+			Message: contractResponse.GetMessage(),
+			Body:    contractResponse.GetBody(),
+		},
+	}, nil
 }
 
 // PutObject implements Syscall interface
@@ -370,6 +382,9 @@ func (c *SyscallService) PostLog(ctx context.Context, in *pb.PostLogRequest) (*p
 	}
 	nctx.Logger.SetCommField("contract_name", nctx.ContractName)
 	nctx.Logger.Info(in.GetEntry())
+	if nctx.Trace != nil {
+		nctx.Trace.Logs = append(nctx.Trace.Logs, in.GetEntry())
+	}
 	return &pb.PostLogResponse{}, nil
 }
 
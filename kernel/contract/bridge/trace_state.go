@@ -0,0 +1,91 @@
+package bridge
+
+import (
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// traceState 在预执行的跟踪模式下包装合约的StateSandbox，
+// 将合约自身的状态读写和事件记录到调用节点中，所有操作仍然由底层沙盒执行
+type traceState struct {
+	contract.StateSandbox
+	trace *protos.CallTrace
+}
+
+// newTraceState 嵌套调用共享调用方的沙盒，这里先去掉调用方的包装，保证读写只记录到当前节点
+func newTraceState(state contract.StateSandbox, trace *protos.CallTrace) *traceState {
+	if ts, ok := state.(*traceState); ok {
+		state = ts.StateSandbox
+	}
+	return &traceState{
+		StateSandbox: state,
+		trace:        trace,
+	}
+}
+
+func (s *traceState) Get(bucket string, key []byte) ([]byte, error) {
+	value, err := s.StateSandbox.Get(bucket, key)
+	if err == nil || err == sandbox.ErrNotFound || err == sandbox.ErrHasDel {
+		s.trace.Reads = append(s.trace.Reads, newTraceKV(bucket, key, value, err != nil))
+	}
+	return value, err
+}
+
+func (s *traceState) Select(bucket string, startKey []byte, endKey []byte) (contract.Iterator, error) {
+	iter, err := s.StateSandbox.Select(bucket, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &traceIterator{
+		Iterator: iter,
+		bucket:   bucket,
+		trace:    s.trace,
+	}, nil
+}
+
+func (s *traceState) Put(bucket string, key, value []byte) error {
+	err := s.StateSandbox.Put(bucket, key, value)
+	if err == nil {
+		s.trace.Writes = append(s.trace.Writes, newTraceKV(bucket, key, value, false))
+	}
+	return err
+}
+
+func (s *traceState) Del(bucket string, key []byte) error {
+	err := s.StateSandbox.Del(bucket, key)
+	if err == nil {
+		s.trace.Writes = append(s.trace.Writes, newTraceKV(bucket, key, nil, true))
+	}
+	return err
+}
+
+func (s *traceState) AddEvent(events ...*protos.ContractEvent) {
+	s.trace.Events = append(s.trace.Events, events...)
+	s.StateSandbox.AddEvent(events...)
+}
+
+// traceIterator 将遍历到的kv记录为读操作
+type traceIterator struct {
+	contract.Iterator
+	bucket string
+	trace  *protos.CallTrace
+}
+
+func (i *traceIterator) Next() bool {
+	if !i.Iterator.Next() {
+		return false
+	}
+	i.trace.Reads = append(i.trace.Reads, newTraceKV(i.bucket, i.Iterator.Key(), i.Iterator.Value(), false))
+	return true
+}
+
+// newTraceKV 合约可能复用key和value的内存，这里复制一份
+func newTraceKV(bucket string, key, value []byte, deleted bool) *protos.TraceKV {
+	return &protos.TraceKV{
+		Bucket:  bucket,
+		Key:     append([]byte{}, key...),
+		Value:   append([]byte{}, value...),
+		Deleted: deleted,
+	}
+}
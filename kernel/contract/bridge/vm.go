@@ -35,6 +35,12 @@ type InstanceCreator interface {
 	RemoveCache(name string)
 }
 
+// CodeCacheWarmer is implemented by InstanceCreator which can prepare the code cache of contract in advance
+type CodeCacheWarmer interface {
+	// WarmUpCache loads and compiles the code of contract so that the first call won't be slowed down
+	WarmUpCache(name string, cp ContractCodeProvider) error
+}
+
 // Instance is a contract virtual machine instance which can run a single contract call
 type Instance interface {
 	Exec() error
@@ -21,6 +21,8 @@ type XBridge struct {
 	xmodel         ledger.XMReader
 	config         contract.ContractConfig
 	core           contract.ChainCore
+	// 合约版本记录的启用高度
+	contractVersion *contract.ForkConfig
 
 	debugLogger logs.Logger
 
@@ -34,6 +36,8 @@ type XBridgeConfig struct {
 	Config    contract.ContractConfig
 	LogDriver logs.Logger
 	Core      contract.ChainCore
+	// ContractVersion 合约版本记录的启用高度，为空时不记录合约版本
+	ContractVersion *contract.ForkConfig
 }
 
 // New instances a new XBridge
@@ -48,6 +52,8 @@ func New(cfg *XBridgeConfig) (*XBridge, error) {
 		core:        cfg.Core,
 		config:      cfg.Config,
 		debugLogger: cfg.LogDriver,
+
+		contractVersion: cfg.ContractVersion,
 	}
 	xbridge.contractManager = &contractManager{
 		xbridge:      xbridge,
@@ -93,20 +99,63 @@ func (v *XBridge) getCreator(tp ContractType) InstanceCreator {
 	return v.creators[tp]
 }
 
+// WarmUpCache 预热合约的代码缓存，合约代码从最新的状态中读取，
+// 预热失败的合约会在首次调用时重新加载，返回第一个错误
+func (v *XBridge) WarmUpCache(contractNames []string) error {
+	var firstErr error
+	for _, name := range contractNames {
+		err := v.warmUpContract(name)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("warm up contract %s error:%s", name, err)
+		}
+	}
+	return firstErr
+}
+
+func (v *XBridge) warmUpContract(name string) error {
+	desc, err := v.codeProvider.GetContractCodeDesc(name)
+	if err != nil {
+		return err
+	}
+	tp, err := getContractType(desc)
+	if err != nil {
+		return err
+	}
+	warmer, ok := v.getCreator(tp).(CodeCacheWarmer)
+	if !ok {
+		return nil
+	}
+	return warmer.WarmUpCache(name, newDescProvider(v.codeProvider, desc))
+}
+
 func (v *XBridge) NewContext(ctxCfg *contract.ContextConfig) (contract.Context, error) {
 	var desc *protos.WasmCodeDesc
 	var err error
 
+	state := ctxCfg.State
+	if ctxCfg.Trace != nil {
+		state = newTraceState(ctxCfg.State, ctxCfg.Trace)
+	}
 	if ctxCfg.Module == string(TypeKernel) {
 		desc = &protos.WasmCodeDesc{
 			ContractType: ctxCfg.Module,
 		}
 	} else {
 		// test if contract exists
-		desc, err = newCodeProvider(ctxCfg.State).GetContractCodeDesc(ctxCfg.ContractName)
+		desc, err = newCodeProvider(state).GetContractCodeDesc(ctxCfg.ContractName)
 		if err != nil {
 			return nil, err
 		}
+		frozen, err := isContractFrozen(state, ctxCfg.ContractName)
+		if err != nil {
+			return nil, err
+		}
+		if frozen {
+			return nil, &ContractError{
+				Status:  contract.StatusFrozen,
+				Message: fmt.Sprintf("contract %s is frozen", ctxCfg.ContractName),
+			}
+		}
 	}
 	tp, err := getContractType(desc)
 	if err != nil {
@@ -117,16 +166,16 @@ func (v *XBridge) NewContext(ctxCfg *contract.ContextConfig) (contract.Context,
 		return nil, fmt.Errorf("vm for contract type %s not supported", tp)
 	}
 	var cp ContractCodeProvider
-	// 如果当前在部署合约，合约代码从cache获取
+	// 如果当前在部署合约，合约代码从cache获取，在历史快照上查询时从快照获取
 	// 合约调用的情况则从model中拿取合约代码，避免交易中包含合约代码的引用。
-	if ctxCfg.ContractCodeFromCache {
-		cp = newCodeProvider(ctxCfg.State)
+	if ctxCfg.ContractCodeFromCache || ctxCfg.ContractCodeFromState {
+		cp = newCodeProvider(state)
 	} else {
 		cp = newDescProvider(v.codeProvider, desc)
 	}
 
 	ctx := v.ctxmgr.MakeContext()
-	ctx.State = ctxCfg.State
+	ctx.State = state
 	ctx.Core = v.core
 	ctx.Module = ctxCfg.Module
 	ctx.ContractName = ctxCfg.ContractName
@@ -135,8 +184,17 @@ func (v *XBridge) NewContext(ctxCfg *contract.ContextConfig) (contract.Context,
 	ctx.AuthRequire = ctxCfg.AuthRequire
 	ctx.ResourceLimits = ctxCfg.ResourceLimits
 	ctx.CanInitialize = ctxCfg.CanInitialize
+	ctx.CanMigrate = ctxCfg.CanMigrate
+	ctx.ContractVersionEnabled = v.contractVersion.Active(ctxCfg.BlockHeight)
 	ctx.TransferAmount = ctxCfg.TransferAmount
 	ctx.ContractSet = ctxCfg.ContractSet
+	ctx.Trace = ctxCfg.Trace
+	ctx.Debug = ctxCfg.Debug
+	ctx.PreExec = ctxCfg.PreExec
+	ctx.ContractCodeFromState = ctxCfg.ContractCodeFromState
+	ctx.BlockHeight = ctxCfg.BlockHeight
+	ctx.BlockTime = ctxCfg.BlockTime
+	ctx.BlockPreHash = ctxCfg.BlockPreHash
 	if ctx.ContractSet == nil {
 		ctx.ContractSet = make(map[string]bool)
 		ctx.ContractSet[ctx.ContractName] = true
@@ -10,7 +10,13 @@ type NativeConfig struct {
 	// Timeout (in seconds) to stop native code process
 	StopTimeout int
 	Docker      NativeDockerConfig
+	Sandbox     NativeSandboxConfig
 	Enable      bool
+	// 预执行和查询时单次合约调用的最长执行秒数，超时中止调用并重启合约进程，0表示使用默认值
+	ExecTimeout int
+
+	// GasMetering 按系统调用计量合约资源消耗的启用高度，由链的创世配置填充，为空时使用原有计量
+	GasMetering *ForkConfig `yaml:"-"`
 }
 
 func (n *NativeConfig) DriverName() string {
@@ -33,12 +39,39 @@ type NativeDockerConfig struct {
 	Memory    string
 }
 
+// NativeSandboxConfig native contract runs in linux namespaces and cgroups without docker
+type NativeSandboxConfig struct {
+	Enable bool
+	Cpus   float32
+	Memory string
+	// 合约进程cgroup中的最大进程和线程数，0表示使用默认值
+	Pids int64
+	// 合约进程cgroup的父目录，需要挂载cgroup v2并开启cpu、memory和pids控制器
+	CgroupRoot string
+	// 合约进程运行使用的uid和gid，不能为root，0表示使用nobody(65534)
+	Uid int
+	Gid int
+}
+
 // XVMConfig contains the xvm configuration
 type XVMConfig struct {
 	// From 0 to 3
 	// The higher the number, the faster the program runs,
 	// but the compilation speed will be slower
 	OptLevel int `yaml:"optlevel"`
+	// 编译产物磁盘缓存的最大MB数，超过后按最近最少使用淘汰，0表示不限制
+	CacheSizeMB int64 `yaml:"cacheSizeMB"`
+	// Debug 解释器(ixvm)的调试模式，只能在开发环境中开启
+	Debug XVMDebugConfig `yaml:"debug"`
+}
+
+// XVMDebugConfig contains the config of xvm step debugger
+type XVMDebugConfig struct {
+	Enable bool `yaml:"enable"`
+	// 调试器监听的本地地址，如127.0.0.1:37101，每条链需要使用不同的地址
+	Listen string `yaml:"listen"`
+	// 可以被调试器暂停的合约，为空时不暂停任何合约
+	Contracts []string `yaml:"contracts"`
 }
 
 // WasmConfig wasm config
@@ -59,6 +92,15 @@ func (w *WasmConfig) IsEnable() bool {
 type EVMConfig struct {
 	Enable bool
 	Driver string
+	// abi磁盘缓存的最大MB数，超过后按最近最少使用淘汰，0表示不限制
+	CacheSizeMB int64 `yaml:"cacheSizeMB"`
+
+	// EventJSON 合约事件使用结构化JSON编码的启用高度，由链的创世配置填充，为空时使用原有编码
+	EventJSON *ForkConfig `yaml:"-"`
+	// Account 合约读取账户余额和映射合约账户调用者的启用高度，由链的创世配置填充，为空时使用原有逻辑
+	Account *ForkConfig `yaml:"-"`
+	// BlockContext 合约读取区块信息和调用超级链预编译合约的启用高度，由链的创世配置填充，为空时不启用
+	BlockContext *ForkConfig `yaml:"-"`
 }
 
 func (e *EVMConfig) DriverName() string {
@@ -103,6 +145,11 @@ type ContractConfig struct {
 	EnableUpgrade  bool
 	LogDriver      logs.Logger
 
+	// 链启动时在后台预热代码缓存的合约数，按本地统计的调用次数选择，0表示不预热
+	WarmUpContracts int
+	// 只读查询合约时调用方未指定资源限制时使用的默认限制
+	QueryLimits Limits `yaml:"queryLimits"`
+
 	Native  NativeConfig
 	Wasm    WasmConfig
 	Xkernel XkernelConfig
@@ -113,6 +160,7 @@ func DefaultContractConfig() *ContractConfig {
 	return &ContractConfig{
 		EnableDebugLog: true,
 		EnableUpgrade:  true,
+		QueryLimits:    DefaultQueryLimits,
 		Native: NativeConfig{
 			Enable: true,
 			Driver: "native",
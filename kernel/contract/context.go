@@ -1,5 +1,7 @@
 package contract
 
+import "github.com/xuperchain/xupercore/protos"
+
 const (
 	// StatusOK is used when contract successfully ends.
 	StatusOK = 200
@@ -7,6 +9,8 @@ const (
 	StatusErrorThreshold = 400
 	// StatusError is used when contract fails.
 	StatusError = 500
+	// StatusFrozen is used when calling a frozen contract.
+	StatusFrozen = 423
 )
 
 // Context define context interface
@@ -43,6 +47,9 @@ type ContextConfig struct {
 	// Whether contract can be initialized
 	CanInitialize bool
 
+	// Whether contract can be migrated, only set when upgrading contract
+	CanMigrate bool
+
 	// The amount transfer to contract
 	TransferAmount string
 
@@ -52,4 +59,26 @@ type ContextConfig struct {
 
 	// ContractCodeFromCache control whether fetch contract code from XMCache
 	ContractCodeFromCache bool
+
+	// ContractCodeFromState fetches code of the contract and its nested calls from State,
+	// used when querying on a history snapshot whose code may differ from the latest one
+	ContractCodeFromState bool
+
+	// Trace records the call tree of contract when not nil, only used in pre-execution
+	Trace *protos.CallTrace
+
+	// Debug allows the contract to be paused by vm debugger, only used in pre-execution
+	Debug bool
+
+	// PreExec marks executions whose result is not part of consensus, such as pre-execution and query,
+	// only these executions can be aborted by the local execution timeout of vm
+	PreExec bool
+
+	// BlockHeight is the height of the block in which the contract is executed
+	BlockHeight int64
+	// BlockTime is the timestamp in nanoseconds of the parent of the block in which the contract is executed,
+	// which is known before the block is produced
+	BlockTime int64
+	// BlockPreHash is the id of the parent of the block in which the contract is executed
+	BlockPreHash []byte
 }
@@ -16,6 +16,10 @@ type KContext interface {
 	Initiator() string
 	Caller() string
 	AuthRequire() []string
+	// 交易执行所在区块的高度，父区块的时间和id
+	BlockHeight() int64
+	BlockTime() int64
+	BlockPreHash() []byte
 
 	// 状态修改接口
 	StateSandbox
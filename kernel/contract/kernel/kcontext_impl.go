@@ -45,6 +45,18 @@ func (k *kcontextImpl) AuthRequire() []string {
 	return k.ctx.AuthRequire
 }
 
+func (k *kcontextImpl) BlockHeight() int64 {
+	return k.ctx.BlockHeight
+}
+
+func (k *kcontextImpl) BlockTime() int64 {
+	return k.ctx.BlockTime
+}
+
+func (k *kcontextImpl) BlockPreHash() []byte {
+	return k.ctx.BlockPreHash
+}
+
 func (k *kcontextImpl) AddResourceUsed(delta contract.Limits) {
 	k.used.Add(delta)
 }
@@ -81,6 +93,18 @@ func (k *kcontextImpl) Call(module, contractName, method string, args map[string
 	}, nil
 }
 
+// CrossQuery 跨链查询经由StateSandbox执行，以便将查询结果记录到读写集，
+// 查询消耗的资源计入当前合约
+func (k *kcontextImpl) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.ContractResponse, contract.Limits, error) {
+	resp, used, err := k.StateSandbox.CrossQuery(req, limits)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	k.AddResourceUsed(used)
+	return resp, used, nil
+}
+
 // EmitAsyncTask 异步发送订阅事件
 func (k *kcontextImpl) EmitAsyncTask(event string, args interface{}) (err error) {
 	var rawBytes []byte
@@ -7,6 +7,7 @@ import (
 
 	"github.com/xuperchain/xupercore/kernel/common/xconfig"
 	"github.com/xuperchain/xupercore/kernel/ledger"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 var (
@@ -19,9 +20,32 @@ type NewManagerFunc func(cfg *ManagerConfig) (Manager, error)
 type Manager interface {
 	NewContext(cfg *ContextConfig) (Context, error)
 	NewStateSandbox(cfg *SandboxConfig) (StateSandbox, error)
+	// Query 在只读沙盒中调用合约，不生成读写集，任何写状态的操作都会失败
+	Query(cfg *QueryConfig, req *protos.InvokeRequest) (*Response, error)
+	// WarmUpCache 预热合约的代码缓存，contractNames按优先级排列，最多预热配置的合约数
+	WarmUpCache(contractNames []string) error
 	GetKernRegistry() KernRegistry
 }
 
+// QueryConfig define the config of read-only contract query
+type QueryConfig struct {
+	// XMReader 查询使用的状态快照
+	XMReader ledger.XMReader
+	// UTXOReader 用于合约读取余额，为空时合约无法读取余额
+	UTXOReader UtxoReader
+
+	Initiator   string
+	AuthRequire []string
+
+	// ResourceLimits 为空时使用合约配置的QueryLimits
+	ResourceLimits *Limits
+
+	// 快照对应的区块高度，以及父区块的时间和id，合约按在该区块中执行查询
+	BlockHeight  int64
+	BlockTime    int64
+	BlockPreHash []byte
+}
+
 type ManagerConfig struct {
 	Basedir  string
 	BCName   string
@@ -30,6 +54,30 @@ type ManagerConfig struct {
 	XMReader ledger.XMReader
 
 	Config *ContractConfig // used by testing
+
+	// StorageRent 合约存储的配额和租金，为空时不统计合约存储
+	StorageRent *StorageRentConfig
+	// ContractVersion 合约版本记录的启用高度，为空时不记录合约版本
+	ContractVersion *ForkConfig
+	// EvmEventJSON evm合约事件使用结构化JSON编码的启用高度，为空时使用原有编码
+	EvmEventJSON *ForkConfig
+	// NativeGas native合约按系统调用计量资源消耗的启用高度，为空时使用原有计量
+	NativeGas *ForkConfig
+	// EvmAccount evm合约读取账户余额和映射合约账户调用者的启用高度，为空时使用原有逻辑
+	EvmAccount *ForkConfig
+	// EvmBlockContext evm合约读取区块信息和调用超级链预编译合约的启用高度，为空时不启用
+	EvmBlockContext *ForkConfig
+}
+
+// ForkConfig 链上新功能的启用高度，为空表示功能不启用
+type ForkConfig struct {
+	// 功能从该高度的区块开始生效
+	Height int64
+}
+
+// Active 返回功能在区块高度height是否生效
+func (f *ForkConfig) Active(height int64) bool {
+	return f != nil && height >= f.Height
 }
 
 // ChainCore is the interface of chain service
@@ -40,13 +88,16 @@ type ChainCore interface {
 	VerifyContractPermission(initiator string, authRequire []string, contractName, methodName string) (bool, error)
 	// VerifyContractOwnerPermission verify contract ownership permisson
 	VerifyContractOwnerPermission(contractName string, authRequire []string) error
+	// VerifyAccountPermission verify whether authRequire satisfies the acl of account
+	VerifyAccountPermission(accountName string, authRequire []string) error
 	// QueryTransaction query confirmed tx
 	QueryTransaction(txid []byte) (*pb.Transaction, error)
 	// QueryBlock query block
 	QueryBlock(blockid []byte) (ledger.BlockHandle, error)
-
-	// ResolveChain resolve chain endorsorinfos
-	// ResolveChain(chainName string) (*pb.CrossQueryMeta, error)
+	// QueryBlockByHeight query block on trunk by height
+	QueryBlockByHeight(height int64) (ledger.BlockHandle, error)
+	// CrossQuery query state or read-only contract of another chain in the same engine
+	CrossQuery(req *protos.CrossQueryRequest, limits Limits) (*protos.CrossQueryResponse, Limits, error)
 }
 
 func Register(name string, f NewManagerFunc) {
@@ -11,11 +11,17 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/crypto/core/hash"
 	log15 "github.com/xuperchain/log15"
 	_ "github.com/xuperchain/xupercore/bcs/contract/native"
 	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 	_ "github.com/xuperchain/xupercore/kernel/contract/kernel"
 	"github.com/xuperchain/xupercore/kernel/contract/mock"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 const (
@@ -184,6 +190,46 @@ func TestContractCall(t *testing.T) {
 			t.Errorf("want %s,got %s\n", mock.FeaturesContractName, string(resp.Body))
 		}
 	})
+	t.Run("TraceCall", func(t *testing.T) {
+		resp, trace, err := th.InvokeWithTrace("native", mock.FeaturesContractName, "Invoke", map[string][]byte{
+			"contract": []byte(callerContractName),
+			"method":   []byte("Migrate"),
+			"version":  []byte("2"),
+		}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Status >= contract.StatusErrorThreshold {
+			t.Fatalf("invoke error:%s", resp.Message)
+		}
+		if trace.GetResponse().GetStatus() != int32(resp.Status) || len(trace.GetResourceUsed()) == 0 {
+			t.Fatalf("bad root trace:%v", trace)
+		}
+		if len(trace.GetCalls()) != 1 {
+			t.Fatalf("want 1 nested call, got %d", len(trace.GetCalls()))
+		}
+		call := trace.GetCalls()[0]
+		if call.GetContractName() != callerContractName || call.GetMethodName() != "Migrate" ||
+			call.GetResponse().GetStatus() != contract.StatusOK {
+			t.Fatalf("bad nested trace:%v", call)
+		}
+		// 写操作只记录在被调用合约的节点中
+		if len(call.GetWrites()) != 1 || string(call.GetWrites()[0].GetKey()) != "migrated" ||
+			string(call.GetWrites()[0].GetValue()) != "2" {
+			t.Fatalf("bad nested writes:%v", call.GetWrites())
+		}
+		if len(trace.GetWrites()) != 0 {
+			t.Fatalf("caller should not record writes of callee:%v", trace.GetWrites())
+		}
+
+		_, trace, err = th.InvokeWithTrace("native", mock.FeaturesContractName, "Logging", nil, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.GetLogs()) != 1 || !strings.Contains(trace.GetLogs()[0], "log from contract") {
+			t.Fatalf("bad trace logs:%v", trace.GetLogs())
+		}
+	})
 	t.Run("RecursiongCall", func(t *testing.T) {
 		resp, err := th.Invoke("native", mock.FeaturesContractName, "Invoke", map[string][]byte{
 			"contract": []byte(mock.FeaturesContractName),
@@ -198,3 +244,197 @@ func TestContractCall(t *testing.T) {
 		}
 	})
 }
+
+func TestContractUpgradeAndMigrate(t *testing.T) {
+	var contractConfig = &contract.ContractConfig{
+		EnableUpgrade: true,
+		Xkernel: contract.XkernelConfig{
+			Enable: true,
+			Driver: "default",
+		},
+		Native: contract.NativeConfig{
+			Enable: true,
+			Driver: "native",
+		},
+		LogDriver: mock.NewMockLogger(),
+	}
+	th := mock.NewTestHelperWithManagerConfig(contractConfig, contract.ManagerConfig{
+		ContractVersion: &contract.ForkConfig{},
+	})
+	defer th.Close()
+
+	bin, err := compile(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = th.Deploy("native", "go", mock.FeaturesContractName, bin, map[string][]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getState := func(bucket string, key []byte) []byte {
+		value, err := th.State().Get(bucket, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value.GetPureData().GetValue()
+	}
+	checkVersion := func(version int64, deployer string) {
+		latest, err := bridge.ParseContractVersion(getState("contract", bridge.ContractLatestVersionKey(mock.FeaturesContractName)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latest != version {
+			t.Fatalf("expect version %d, got %d", version, latest)
+		}
+		record := new(protos.ContractVersion)
+		err = proto.Unmarshal(getState("contract", bridge.ContractVersionKey(mock.FeaturesContractName, version)), record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.GetVersion() != version || record.GetDeployer() != deployer ||
+			!bytes.Equal(record.GetCodeHash(), hash.DoubleSha256(bin)) {
+			t.Errorf("unexpected version record %v", record)
+		}
+	}
+	checkVersion(1, mock.ContractAccount)
+
+	_, err = th.Invoke("native", mock.FeaturesContractName, "migrate", map[string][]byte{
+		"version": []byte("1"),
+	})
+	if err == nil {
+		t.Error("expect error when calling migrate directly")
+	}
+
+	err = th.UpgradeAndMigrate(mock.FeaturesContractName, bin, map[string][]byte{
+		"version": []byte("2"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkVersion(2, "")
+	if migrated := getState(mock.FeaturesContractName, []byte("migrated")); string(migrated) != "2" {
+		t.Errorf("expect migrated 2, got %s", migrated)
+	}
+}
+
+func TestContractVersionDisabled(t *testing.T) {
+	var contractConfig = &contract.ContractConfig{
+		EnableUpgrade: true,
+		Xkernel: contract.XkernelConfig{
+			Enable: true,
+			Driver: "default",
+		},
+		Native: contract.NativeConfig{
+			Enable: true,
+			Driver: "native",
+		},
+		LogDriver: mock.NewMockLogger(),
+	}
+	th := mock.NewTestHelper(contractConfig)
+	defer th.Close()
+
+	bin, err := compile(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = th.Deploy("native", "go", mock.FeaturesContractName, bin, map[string][]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = th.UpgradeAndMigrate(mock.FeaturesContractName, bin, map[string][]byte{
+		"version": []byte("2"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 未启用版本记录时不写入版本，也不执行迁移
+	if _, err := th.State().Get("contract", bridge.ContractLatestVersionKey(mock.FeaturesContractName)); err == nil {
+		t.Error("unexpected version record")
+	}
+	if _, err := th.State().Get(mock.FeaturesContractName, []byte("migrated")); err == nil {
+		t.Error("unexpected migrate")
+	}
+
+	// 没有版本记录的合约的migrate方法是普通方法
+	resp, err := th.Invoke("native", mock.FeaturesContractName, "migrate", map[string][]byte{
+		"version": []byte("1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status >= contract.StatusErrorThreshold {
+		t.Fatalf("invoke migrate error:%s", resp.Message)
+	}
+}
+
+func TestContractLifecycle(t *testing.T) {
+	var contractConfig = &contract.ContractConfig{
+		Xkernel: contract.XkernelConfig{
+			Enable: true,
+			Driver: "default",
+		},
+		Native: contract.NativeConfig{
+			Enable: true,
+			Driver: "native",
+		},
+		LogDriver: mock.NewMockLogger(),
+	}
+	th := mock.NewTestHelper(contractConfig)
+	defer th.Close()
+
+	bin, err := compile(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = th.Deploy("native", "go", mock.FeaturesContractName, bin, map[string][]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycle := func(method string) error {
+		_, err := th.Invoke("xkernel", "$contract", method, map[string][]byte{
+			"contract_name": []byte(mock.FeaturesContractName),
+		})
+		return err
+	}
+	call := func() error {
+		_, err := th.Invoke("native", mock.FeaturesContractName, "Caller", map[string][]byte{})
+		return err
+	}
+
+	if err := lifecycle("unfreezeContract"); err == nil {
+		t.Error("expect error when unfreezing a contract not frozen")
+	}
+	if err := lifecycle("freezeContract"); err != nil {
+		t.Fatal(err)
+	}
+	err = call()
+	if cerr, ok := err.(*bridge.ContractError); !ok || cerr.Status != contract.StatusFrozen {
+		t.Fatalf("expect frozen error, got %v", err)
+	}
+	if err := lifecycle("unfreezeContract"); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lifecycle("destroyContract"); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(); err == nil {
+		t.Error("expect error when calling a destroyed contract")
+	}
+	if _, err := th.State().Get(utils.GetContractTombstoneBucket(), []byte(mock.FeaturesContractName)); err != nil {
+		t.Errorf("expect tombstone of destroyed contract, got %v", err)
+	}
+	verData, err := th.State().Get(utils.GetContract2AccountBucket(), []byte(mock.FeaturesContractName))
+	if err != nil || !sandbox.IsDelFlag(verData.GetPureData().GetValue()) {
+		t.Error("expect contract account mapping deleted")
+	}
+	_, err = th.Deploy("native", "go", mock.FeaturesContractName, bin, map[string][]byte{})
+	if err == nil {
+		t.Error("expect error when deploying a destroyed contract")
+	}
+}
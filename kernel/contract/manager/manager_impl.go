@@ -10,12 +10,19 @@ import (
 	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
 	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 type managerImpl struct {
 	core      contract.ChainCore
 	xbridge   *bridge.XBridge
 	kregistry registryImpl
+	// 预热代码缓存的合约数
+	warmUpContracts int
+	// 合约存储的配额和租金
+	storageRent *contract.StorageRentConfig
+	// 只读查询的默认资源限制
+	queryLimits contract.Limits
 }
 
 func newManagerImpl(cfg *contract.ManagerConfig) (contract.Manager, error) {
@@ -46,8 +53,20 @@ func newManagerImpl(cfg *contract.ManagerConfig) (contract.Manager, error) {
 	}
 
 	m := &managerImpl{
-		core: cfg.Core,
+		core:            cfg.Core,
+		warmUpContracts: xcfg.WarmUpContracts,
+		storageRent:     cfg.StorageRent,
+		queryLimits:     xcfg.QueryLimits,
 	}
+	if m.queryLimits.Cpu <= 0 {
+		m.queryLimits = contract.DefaultQueryLimits
+	}
+	evmConfig := xcfg.EVM
+	evmConfig.EventJSON = cfg.EvmEventJSON
+	evmConfig.Account = cfg.EvmAccount
+	evmConfig.BlockContext = cfg.EvmBlockContext
+	nativeConfig := xcfg.Native
+	nativeConfig.GasMetering = cfg.NativeGas
 	var logDriver logs.Logger
 	if cfg.Config != nil {
 		logDriver = cfg.Config.LogDriver
@@ -56,8 +75,8 @@ func newManagerImpl(cfg *contract.ManagerConfig) (contract.Manager, error) {
 		Basedir: cfg.Basedir,
 		VMConfigs: map[bridge.ContractType]bridge.VMConfig{
 			bridge.TypeWasm:   &xcfg.Wasm,
-			bridge.TypeNative: &xcfg.Native,
-			bridge.TypeEvm:    &xcfg.EVM,
+			bridge.TypeNative: &nativeConfig,
+			bridge.TypeEvm:    &evmConfig,
 			bridge.TypeKernel: &contract.XkernelConfig{
 				Driver:   xcfg.Xkernel.Driver,
 				Enable:   xcfg.Xkernel.Enable,
@@ -68,6 +87,8 @@ func newManagerImpl(cfg *contract.ManagerConfig) (contract.Manager, error) {
 		XModel:    cfg.XMReader,
 		Core:      cfg.Core,
 		LogDriver: logDriver,
+
+		ContractVersion: cfg.ContractVersion,
 	})
 	if err != nil {
 		return nil, err
@@ -78,6 +99,11 @@ func newManagerImpl(cfg *contract.ManagerConfig) (contract.Manager, error) {
 	registry.RegisterKernMethod("$contract", "upgradeContract", m.upgradeContract)
 	registry.RegisterShortcut("Deploy", "$contract", "deployContract")
 	registry.RegisterShortcut("Upgrade", "$contract", "upgradeContract")
+	registry.RegisterKernMethod("$contract", "freezeContract", m.freezeContract)
+	registry.RegisterKernMethod("$contract", "unfreezeContract", m.unfreezeContract)
+	registry.RegisterKernMethod("$contract", "destroyContract", m.destroyContract)
+	registry.RegisterKernMethod("$storage", "prepayStorage", m.prepayStorage)
+	registry.RegisterKernMethod("$storage", "queryStorage", m.queryStorage)
 	return m, nil
 }
 
@@ -86,9 +112,65 @@ func (m *managerImpl) NewContext(cfg *contract.ContextConfig) (contract.Context,
 }
 
 func (m *managerImpl) NewStateSandbox(cfg *contract.SandboxConfig) (contract.StateSandbox, error) {
+	if cfg.CrossQueryReader == nil || cfg.StorageRent == nil {
+		sandboxCfg := *cfg
+		if sandboxCfg.CrossQueryReader == nil {
+			// 默认通过ChainCore访问同一引擎中的其他链
+			sandboxCfg.CrossQueryReader = m.core
+		}
+		if sandboxCfg.StorageRent == nil {
+			sandboxCfg.StorageRent = m.storageRent
+		}
+		cfg = &sandboxCfg
+	}
 	return sandbox.NewXModelCache(cfg), nil
 }
 
+func (m *managerImpl) Query(cfg *contract.QueryConfig, req *protos.InvokeRequest) (*contract.Response, error) {
+	if cfg.XMReader == nil {
+		return nil, errors.New("nil xmodel reader when query contract")
+	}
+	state := sandbox.NewReadOnlySandbox(&contract.SandboxConfig{
+		XMReader:         cfg.XMReader,
+		UTXOReader:       cfg.UTXOReader,
+		CrossQueryReader: m.core,
+	})
+	limits := m.queryLimits
+	if cfg.ResourceLimits != nil {
+		limits = *cfg.ResourceLimits
+	}
+	ctx, err := m.NewContext(&contract.ContextConfig{
+		State:          state,
+		Initiator:      cfg.Initiator,
+		AuthRequire:    cfg.AuthRequire,
+		Module:         req.GetModuleName(),
+		ContractName:   req.GetContractName(),
+		ResourceLimits: limits,
+		PreExec:        true,
+		BlockHeight:    cfg.BlockHeight,
+		BlockTime:      cfg.BlockTime,
+		BlockPreHash:   cfg.BlockPreHash,
+
+		// 合约代码与状态来自同一个快照
+		ContractCodeFromState: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	return ctx.Invoke(req.GetMethodName(), req.GetArgs())
+}
+
+func (m *managerImpl) WarmUpCache(contractNames []string) error {
+	if m.warmUpContracts <= 0 {
+		return nil
+	}
+	if len(contractNames) > m.warmUpContracts {
+		contractNames = contractNames[:m.warmUpContracts]
+	}
+	return m.xbridge.WarmUpCache(contractNames)
+}
+
 func (m *managerImpl) GetKernRegistry() contract.KernRegistry {
 	return &m.kregistry
 }
@@ -147,6 +229,39 @@ func (m *managerImpl) upgradeContract(ctx contract.KContext) (*contract.Response
 	return resp, nil
 }
 
+func (m *managerImpl) freezeContract(ctx contract.KContext) (*contract.Response, error) {
+	return m.changeContractLifecycle(ctx, m.xbridge.FreezeContract)
+}
+
+func (m *managerImpl) unfreezeContract(ctx contract.KContext) (*contract.Response, error) {
+	return m.changeContractLifecycle(ctx, m.xbridge.UnfreezeContract)
+}
+
+func (m *managerImpl) destroyContract(ctx contract.KContext) (*contract.Response, error) {
+	return m.changeContractLifecycle(ctx, m.xbridge.DestroyContract)
+}
+
+// changeContractLifecycle 只有合约的所有者可以冻结、解冻和销毁合约
+func (m *managerImpl) changeContractLifecycle(ctx contract.KContext,
+	change func(contract.KContext) (*contract.Response, contract.Limits, error)) (*contract.Response, error) {
+	contractName := ctx.Args()["contract_name"]
+	if contractName == nil {
+		return nil, errors.New("invoke contract lifecycle method error, contract name is nil")
+	}
+
+	err := m.core.VerifyContractOwnerPermission(string(contractName), ctx.AuthRequire())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, limit, err := change(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx.AddResourceUsed(limit)
+	return resp, nil
+}
+
 func init() {
 	contract.Register("default", newManagerImpl)
 }
@@ -1,12 +1,14 @@
 package manager
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/xuperchain/xupercore/kernel/contract"
 	_ "github.com/xuperchain/xupercore/kernel/contract/kernel"
 	"github.com/xuperchain/xupercore/kernel/contract/mock"
 	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 var contractConfig = &contract.ContractConfig{
@@ -67,3 +69,104 @@ func (h *helloContract) Hi(ctx contract.KContext) (*contract.Response, error) {
 		Body: []byte("hello " + string(name)),
 	}, nil
 }
+
+func TestQuery(t *testing.T) {
+	th := mock.NewTestHelper(contractConfig)
+	defer th.Close()
+	m := th.Manager()
+
+	h := new(helloContract)
+	m.GetKernRegistry().RegisterKernMethod("$hello", "Hi", h.Hi)
+	m.GetKernRegistry().RegisterKernMethod("$hello", "Get", h.Get)
+	m.GetKernRegistry().RegisterKernMethod("$hello", "Set", h.Set)
+
+	_, err := th.Invoke("xkernel", "$hello", "Hi", map[string][]byte{
+		"name": []byte("xuper"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &contract.QueryConfig{
+		XMReader:  th.State(),
+		Initiator: mock.ContractAccount,
+	}
+	resp, err := m.Query(cfg, &protos.InvokeRequest{
+		ModuleName:   "xkernel",
+		ContractName: "$hello",
+		MethodName:   "Get",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != "v1" {
+		t.Errorf("expect v1 got %s", resp.Body)
+	}
+
+	_, err = m.Query(cfg, &protos.InvokeRequest{
+		ModuleName:   "xkernel",
+		ContractName: "$hello",
+		MethodName:   "Set",
+	})
+	if err == nil {
+		t.Error("expect write error in query mode")
+	}
+
+	m.GetKernRegistry().RegisterKernMethod("$hello", "Limit", h.Limit)
+	resp, err = m.Query(cfg, &protos.InvokeRequest{
+		ModuleName:   "xkernel",
+		ContractName: "$hello",
+		MethodName:   "Limit",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != strconv.FormatInt(contract.DefaultQueryLimits.Cpu, 10) {
+		t.Errorf("expect default query cpu limit got %s", resp.Body)
+	}
+
+	// 合约看到的是查询的区块
+	m.GetKernRegistry().RegisterKernMethod("$hello", "Height", h.Height)
+	cfg.BlockHeight = 7
+	resp, err = m.Query(cfg, &protos.InvokeRequest{
+		ModuleName:   "xkernel",
+		ContractName: "$hello",
+		MethodName:   "Height",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != "7" {
+		t.Errorf("expect queried block height 7 got %s", resp.Body)
+	}
+}
+
+func (h *helloContract) Height(ctx contract.KContext) (*contract.Response, error) {
+	return &contract.Response{
+		Body: []byte(strconv.FormatInt(ctx.BlockHeight(), 10)),
+	}, nil
+}
+
+func (h *helloContract) Limit(ctx contract.KContext) (*contract.Response, error) {
+	return &contract.Response{
+		Body: []byte(strconv.FormatInt(ctx.ResourceLimit().Cpu, 10)),
+	}, nil
+}
+
+func (h *helloContract) Get(ctx contract.KContext) (*contract.Response, error) {
+	value, err := ctx.Get("test", []byte("k1"))
+	if err != nil {
+		return nil, err
+	}
+	return &contract.Response{
+		Body: value,
+	}, nil
+}
+
+func (h *helloContract) Set(ctx contract.KContext) (*contract.Response, error) {
+	err := ctx.Put("test", []byte("k1"), []byte("v2"))
+	if err != nil {
+		return nil, err
+	}
+	return &contract.Response{}, nil
+}
@@ -0,0 +1,135 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+)
+
+var errStorageRentDisabled = errors.New("contract storage rent disabled")
+
+// contractStorageUsage 是queryStorage返回的合约存储使用情况
+type contractStorageUsage struct {
+	ContractName string `json:"contract_name"`
+	UsedBytes    int64  `json:"used_bytes"`
+	Prepaid      string `json:"prepaid"`
+	// 当前占用的存储需要的租金
+	Rent     string `json:"rent"`
+	MaxBytes int64  `json:"max_bytes"`
+}
+
+func (m *managerImpl) storageUsage(ctx contract.KContext, contractName string) (*contractStorageUsage, error) {
+	storage, err := sandbox.GetContractStorage(ctx, contractName)
+	if err != nil {
+		return nil, err
+	}
+	shards, err := sandbox.GetContractStorageShards(ctx, contractName)
+	if err != nil {
+		return nil, err
+	}
+	return &contractStorageUsage{
+		ContractName: contractName,
+		UsedBytes:    storage.GetUsedBytes(),
+		Prepaid:      storage.GetPrepaid(),
+		Rent:         sandbox.ShardsStorageRent(m.storageRent, shards).String(),
+		MaxBytes:     m.storageRent.MaxBytes,
+	}, nil
+}
+
+// prepayStorage 为合约预付存储租金，租金从发起者转入无法花费的租金地址，任何人都可以为合约预付。
+// 预付的租金平均分配到合约存储记录的各个分片，余数分配给前面的分片
+func (m *managerImpl) prepayStorage(ctx contract.KContext) (*contract.Response, error) {
+	if m.storageRent == nil || !m.storageRent.Enable {
+		return nil, errStorageRentDisabled
+	}
+	contractName := string(ctx.Args()["contract_name"])
+	amount, ok := new(big.Int).SetString(string(ctx.Args()["amount"]), 10)
+	if contractName == "" || !ok || amount.Sign() <= 0 {
+		return nil, errors.New("invoke prepayStorage error, contract name is nil or amount is invalid")
+	}
+	if _, err := ctx.Get(utils.GetContract2AccountBucket(), []byte(contractName)); err != nil {
+		return nil, fmt.Errorf("contract %s not found", contractName)
+	}
+
+	err := ctx.Transfer(ctx.Initiator(), sandbox.StorageRentAddress, amount)
+	if err != nil {
+		return nil, err
+	}
+	shards, err := sandbox.GetContractStorageShards(ctx, contractName)
+	if err != nil {
+		return nil, err
+	}
+	share, remainder := new(big.Int).DivMod(amount, big.NewInt(sandbox.StorageShards), new(big.Int))
+	total := new(big.Int)
+	for i, storage := range shards {
+		prepaid, ok := new(big.Int).SetString(storage.GetPrepaid(), 10)
+		if !ok {
+			return nil, fmt.Errorf("bad prepaid storage rent of contract %s", contractName)
+		}
+		prepaid.Add(prepaid, share)
+		if int64(i) < remainder.Int64() {
+			prepaid.Add(prepaid, big.NewInt(1))
+		}
+		storage.Prepaid = prepaid.String()
+		total.Add(total, prepaid)
+		err = sandbox.PutContractStorageShard(ctx, i, storage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &contract.Response{
+		Status:  contract.StatusOK,
+		Message: "prepay success",
+		Body:    []byte(total.String()),
+	}, nil
+}
+
+// queryStorage 查询合约的存储使用情况，参数为contract_name时查询单个合约，
+// 为account_name时查询账户下的所有合约
+func (m *managerImpl) queryStorage(ctx contract.KContext) (*contract.Response, error) {
+	if m.storageRent == nil || !m.storageRent.Enable {
+		return nil, errStorageRentDisabled
+	}
+	var contractNames []string
+	if contractName := ctx.Args()["contract_name"]; len(contractName) != 0 {
+		contractNames = append(contractNames, string(contractName))
+	} else if accountName := ctx.Args()["account_name"]; len(accountName) != 0 {
+		prefix := string(accountName) + utils.GetACLSeparator()
+		iter, err := ctx.Select(utils.GetAccount2ContractBucket(), []byte(prefix), []byte(prefix+"\xff"))
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next() {
+			contractNames = append(contractNames, string(iter.Key()[len(prefix):]))
+		}
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errors.New("invoke queryStorage error, contract name and account name are both nil")
+	}
+
+	usages := make([]*contractStorageUsage, 0, len(contractNames))
+	for _, contractName := range contractNames {
+		usage, err := m.storageUsage(ctx, contractName)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	body, err := json.Marshal(usages)
+	if err != nil {
+		return nil, err
+	}
+	return &contract.Response{
+		Status: contract.StatusOK,
+		Body:   body,
+	}, nil
+}
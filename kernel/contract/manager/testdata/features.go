@@ -68,6 +68,13 @@ func (c *features) Invoke(ctx code.Context) code.Response {
 func (c *features) Caller(ctx code.Context) code.Response {
 	return code.OK([]byte(ctx.Caller()))
 }
+func (c *features) Migrate(ctx code.Context) code.Response {
+	err := ctx.PutObject([]byte("migrated"), ctx.Args()["version"])
+	if err != nil {
+		return code.Error(err)
+	}
+	return code.OK(nil)
+}
 func main() {
 	driver.Serve(new(features))
 }
@@ -1,10 +1,16 @@
 package mock
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state"
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
 	"github.com/xuperchain/xupercore/kernel/ledger"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 type fakeChainCore struct {
@@ -25,15 +31,33 @@ func (f *fakeChainCore) VerifyContractOwnerPermission(contractName string, authR
 	return nil
 }
 
+// VerifyAccountPermission verify account permission
+func (f *fakeChainCore) VerifyAccountPermission(accountName string, authRequire []string) error {
+	return nil
+}
+
 func (t *fakeChainCore) QueryBlock(blockid []byte) (ledger.BlockHandle, error) {
 	return state.NewBlockAgent(&xldgpb.InternalBlock{
 		Blockid: []byte("testblockid"),
 	}), nil
 }
 
+func (t *fakeChainCore) QueryBlockByHeight(height int64) (ledger.BlockHandle, error) {
+	return state.NewBlockAgent(&xldgpb.InternalBlock{
+		Blockid:   []byte(fmt.Sprintf("testblockid%d", height)),
+		Height:    height,
+		Timestamp: height * int64(time.Second),
+	}), nil
+}
+
 func (t *fakeChainCore) QueryTransaction(txid []byte) (*pb.Transaction, error) {
 	return &pb.Transaction{
 		Txid:    "testtxid",
 		Blockid: "testblockd",
 	}, nil
 }
+
+func (t *fakeChainCore) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	return nil, contract.Limits{}, errors.New("cross query not supported in mock chain core")
+}
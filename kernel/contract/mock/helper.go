@@ -31,6 +31,12 @@ type TestHelper struct {
 }
 
 func NewTestHelper(cfg *contract.ContractConfig) *TestHelper {
+	return NewTestHelperWithManagerConfig(cfg, contract.ManagerConfig{})
+}
+
+// NewTestHelperWithManagerConfig 使用mgCfg中的链配置创建TestHelper，
+// mgCfg的Basedir、BCName、Core、XMReader和Config由TestHelper填充
+func NewTestHelperWithManagerConfig(cfg *contract.ContractConfig, mgCfg contract.ManagerConfig) *TestHelper {
 	basedir, err := ioutil.TempDir("", "contract-test")
 	if err != nil {
 		panic(err)
@@ -38,13 +44,12 @@ func NewTestHelper(cfg *contract.ContractConfig) *TestHelper {
 
 	state := sandbox.NewMemXModel()
 	core := new(fakeChainCore)
-	m, err := contract.CreateManager("default", &contract.ManagerConfig{
-		Basedir:  basedir,
-		BCName:   "xuper",
-		Core:     core,
-		XMReader: state,
-		Config:   cfg,
-	})
+	mgCfg.Basedir = basedir
+	mgCfg.BCName = "xuper"
+	mgCfg.Core = core
+	mgCfg.XMReader = state
+	mgCfg.Config = cfg
+	m, err := contract.CreateManager("default", &mgCfg)
 	if err != nil {
 		panic(err)
 	}
@@ -143,6 +148,11 @@ func (t *TestHelper) Deploy(module, lang, contractName string, bin []byte, args
 }
 
 func (t *TestHelper) Upgrade(contractName string, bin []byte) error {
+	return t.UpgradeAndMigrate(contractName, bin, nil)
+}
+
+// UpgradeAndMigrate 升级合约，args不为nil时使用args调用新合约的migrate方法
+func (t *TestHelper) UpgradeAndMigrate(contractName string, bin []byte, args map[string][]byte) error {
 	m := t.Manager()
 	state, err := m.NewStateSandbox(&contract.SandboxConfig{
 		XMReader:   t.State(),
@@ -162,23 +172,38 @@ func (t *TestHelper) Upgrade(contractName string, bin []byte) error {
 		return err
 	}
 
-	_, err = ctx.Invoke("upgradeContract", map[string][]byte{
+	invokeArgs := map[string][]byte{
 		"contract_name": []byte(contractName),
 		"contract_code": bin,
-	})
+	}
+	if args != nil {
+		invokeArgs["migrate_args"], _ = json.Marshal(args)
+	}
+	_, err = ctx.Invoke("upgradeContract", invokeArgs)
 	ctx.Release()
 	t.Commit(state)
 	return err
 }
 
 func (t *TestHelper) Invoke(module, contractName, method string, args map[string][]byte) (*contract.Response, error) {
+	resp, _, err := t.InvokeWithTrace(module, contractName, method, args, false)
+	return resp, err
+}
+
+// InvokeWithTrace 调用合约，needTrace为true时同时返回调用树
+func (t *TestHelper) InvokeWithTrace(module, contractName, method string, args map[string][]byte,
+	needTrace bool) (*contract.Response, *protos.CallTrace, error) {
+	var trace *protos.CallTrace
+	if needTrace {
+		trace = contract.NewCallTrace(module, contractName, method, args)
+	}
 	m := t.Manager()
 	state, err := m.NewStateSandbox(&contract.SandboxConfig{
 		XMReader:   t.State(),
 		UTXOReader: t.utxoReader,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	ctx, err := m.NewContext(&contract.ContextConfig{
@@ -187,20 +212,22 @@ func (t *TestHelper) Invoke(module, contractName, method string, args map[string
 		State:          state,
 		ResourceLimits: contract.MaxLimits,
 		Initiator:      ContractAccount,
+		Trace:          trace,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer ctx.Release()
 
 	resp, err := ctx.Invoke(method, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	contract.FinishCallTrace(trace, resp, ctx.ResourceUsed(), nil)
 	state.Flush()
 	t.utxo = state.UTXORWSet()
 	t.Commit(state)
-	return resp, nil
+	return resp, trace, nil
 }
 
 func (t *TestHelper) Commit(state contract.StateSandbox) {
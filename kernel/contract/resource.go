@@ -57,6 +57,15 @@ var MaxLimits = Limits{
 	XFee:   maxResourceLimit,
 }
 
+// DefaultQueryLimits describes the default limit of resources when querying contract,
+// cpu is bounded so that a query can not loop forever
+var DefaultQueryLimits = Limits{
+	Cpu:    10000000,
+	Memory: maxResourceLimit,
+	Disk:   maxResourceLimit,
+	XFee:   maxResourceLimit,
+}
+
 // // FromPbLimits converts []*pb.ResourceLimit to Limits
 func FromPbLimits(rlimits []*protos.ResourceLimit) Limits {
 	limits := Limits{}
@@ -0,0 +1,112 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+var (
+	// ErrCrossQueryNotSupported is returned when sandbox has no cross query reader
+	ErrCrossQueryNotSupported = errors.New("cross query not supported")
+	// ErrCrossQueryNotRecorded is returned when tx calls more cross queries than recorded
+	ErrCrossQueryNotRecorded = errors.New("cross query not recorded in tx")
+	// ErrCrossQueryMismatch is returned when cross query differs from the recorded one
+	ErrCrossQueryMismatch = errors.New("cross query mismatch with recorded")
+)
+
+// CrossQueryCache 执行跨链只读查询，并按调用顺序记录查询请求和结果
+type CrossQueryCache struct {
+	reader  contract.CrossQueryReader
+	queries []*protos.CrossQueryInfo
+}
+
+// NewCrossQueryCache new an instance of CrossQueryCache
+func NewCrossQueryCache(reader contract.CrossQueryReader) *CrossQueryCache {
+	return &CrossQueryCache{
+		reader: reader,
+	}
+}
+
+// CrossQuery query other chain and record the result
+func (cc *CrossQueryCache) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.ContractResponse, contract.Limits, error) {
+	if cc.reader == nil {
+		return nil, contract.Limits{}, ErrCrossQueryNotSupported
+	}
+	if req.GetChainName() == "" {
+		return nil, contract.Limits{}, errors.New("cross query chain name is empty")
+	}
+	if req.GetHeight() < 0 {
+		return nil, contract.Limits{}, errors.New("cross query height can not be negative")
+	}
+	if req.GetBucket() == "" && req.GetRequest() == nil {
+		return nil, contract.Limits{}, errors.New("cross query need either bucket or contract request")
+	}
+
+	resp, used, err := cc.reader.CrossQuery(req, limits)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	cc.queries = append(cc.queries, &protos.CrossQueryInfo{
+		Request:  req,
+		Response: resp,
+	})
+	return resp.GetResponse(), used, nil
+}
+
+// GetCrossQueryRWSets returns all the cross queries in call order
+func (cc *CrossQueryCache) GetCrossQueryRWSets() []*protos.CrossQueryInfo {
+	return cc.queries
+}
+
+// CrossQueryVerifier 验证交易时按调用顺序重新执行交易记录的跨链查询，
+// 请求必须与记录一致，查询在记录的区块上重新执行，结果也必须与记录的结果一致
+type CrossQueryVerifier struct {
+	recorded []*protos.CrossQueryInfo
+	reader   contract.CrossQueryBlockReader
+	index    int
+}
+
+// NewCrossQueryVerifier new an instance of CrossQueryVerifier
+func NewCrossQueryVerifier(recorded []*protos.CrossQueryInfo, reader contract.CrossQueryBlockReader) *CrossQueryVerifier {
+	return &CrossQueryVerifier{
+		recorded: recorded,
+		reader:   reader,
+	}
+}
+
+// CrossQuery query other chain again and check the result with the recorded one
+// 查询消耗的资源由重新执行得到，与预执行时一致
+func (cv *CrossQueryVerifier) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	if cv.index >= len(cv.recorded) {
+		return nil, contract.Limits{}, fmt.Errorf("%s, query index:%d", ErrCrossQueryNotRecorded, cv.index)
+	}
+	info := cv.recorded[cv.index]
+	if !proto.Equal(req, info.GetRequest()) {
+		return nil, contract.Limits{}, fmt.Errorf("%s, query index:%d", ErrCrossQueryMismatch, cv.index)
+	}
+	// 不按本地主干的高度查询，本地同步进度不同的节点会得到不同的区块
+	resp, used, err := cv.reader.CrossQueryAtBlock(req, info.GetResponse().GetBlockid(), limits)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if !proto.Equal(resp, info.GetResponse()) {
+		return nil, contract.Limits{}, fmt.Errorf("%s, query index:%d", ErrCrossQueryMismatch, cv.index)
+	}
+	cv.index++
+	return resp, used, nil
+}
+
+// Verify 检查交易记录的跨链查询都被重新执行过
+func (cv *CrossQueryVerifier) Verify() error {
+	if cv.index != len(cv.recorded) {
+		return fmt.Errorf("%s, recorded:%d, queried:%d", ErrCrossQueryMismatch, len(cv.recorded), cv.index)
+	}
+	return nil
+}
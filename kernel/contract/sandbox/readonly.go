@@ -0,0 +1,114 @@
+package sandbox
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/ledger"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+var (
+	// ErrReadOnly is returned when writing state in a read-only sandbox
+	ErrReadOnly = errors.New("state is read-only in query mode")
+	// ErrBalanceUnavailable is returned when reading balance without utxo reader in query mode
+	ErrBalanceUnavailable = errors.New("balance is unavailable in query mode")
+)
+
+var (
+	_ contract.StateSandbox = (*ReadOnlySandbox)(nil)
+)
+
+// ReadOnlySandbox 只读的状态沙盒，用于不生成交易的合约查询
+// 直接读取底层XMReader，不记录读写集，所有的写操作都会返回ErrReadOnly
+type ReadOnlySandbox struct {
+	model           ledger.XMReader
+	utxoReader      contract.UtxoReader
+	crossQueryCache *CrossQueryCache
+}
+
+// NewReadOnlySandbox new an instance of ReadOnlySandbox, UTXOReader in cfg is only used to read balance
+func NewReadOnlySandbox(cfg *contract.SandboxConfig) *ReadOnlySandbox {
+	return &ReadOnlySandbox{
+		model:           cfg.XMReader,
+		utxoReader:      cfg.UTXOReader,
+		crossQueryCache: NewCrossQueryCache(cfg.CrossQueryReader),
+	}
+}
+
+// Get 读取一个key的值
+func (rs *ReadOnlySandbox) Get(bucket string, key []byte) ([]byte, error) {
+	verData, err := rs.model.Get(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if IsEmptyVersionedData(verData) {
+		return nil, ErrNotFound
+	}
+	if IsDelFlag(verData.GetPureData().GetValue()) {
+		return nil, ErrHasDel
+	}
+	return verData.GetPureData().GetValue(), nil
+}
+
+// Select select all kv from a bucket, can set key range, left closed, right opend
+func (rs *ReadOnlySandbox) Select(bucket string, startKey []byte, endKey []byte) (contract.Iterator, error) {
+	iter, err := rs.model.Select(bucket, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return newContractIterator(newStripDelIterator(iter)), nil
+}
+
+// Put always returns ErrReadOnly
+func (rs *ReadOnlySandbox) Put(bucket string, key []byte, value []byte) error {
+	return ErrReadOnly
+}
+
+// Del always returns ErrReadOnly
+func (rs *ReadOnlySandbox) Del(bucket string, key []byte) error {
+	return ErrReadOnly
+}
+
+// Transfer always returns ErrReadOnly
+func (rs *ReadOnlySandbox) Transfer(from string, to string, amount *big.Int) error {
+	return ErrReadOnly
+}
+
+// Balance 返回地址在最新确认区块的余额，没有UTXOReader时(如在历史快照上查询)返回ErrBalanceUnavailable
+func (rs *ReadOnlySandbox) Balance(addr string) (*big.Int, error) {
+	if rs.utxoReader == nil {
+		return nil, ErrBalanceUnavailable
+	}
+	return rs.utxoReader.GetBalance(addr)
+}
+
+// CrossQuery query other chain without recording rwset
+func (rs *ReadOnlySandbox) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.ContractResponse, contract.Limits, error) {
+	return rs.crossQueryCache.CrossQuery(req, limits)
+}
+
+// AddEvent 查询模式不会生成交易，合约事件直接丢弃
+func (rs *ReadOnlySandbox) AddEvent(events ...*protos.ContractEvent) {
+}
+
+// RecordBlockRead 查询模式不会生成交易，不需要记录
+func (rs *ReadOnlySandbox) RecordBlockRead(height int64) {
+}
+
+// Flush does nothing in query mode
+func (rs *ReadOnlySandbox) Flush() error {
+	return nil
+}
+
+// RWSet returns empty rwset
+func (rs *ReadOnlySandbox) RWSet() *contract.RWSet {
+	return &contract.RWSet{}
+}
+
+// UTXORWSet returns empty utxo rwset
+func (rs *ReadOnlySandbox) UTXORWSet() *contract.UTXORWSet {
+	return &contract.UTXORWSet{}
+}
@@ -0,0 +1,71 @@
+package sandbox
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/ledger"
+)
+
+func TestReadOnlySandbox(t *testing.T) {
+	store := NewMemXModel()
+	for _, key := range []string{"k1", "k2", "k3"} {
+		store.Put("b1", []byte(key), &ledger.VersionedData{
+			PureData: &ledger.PureData{
+				Bucket: "b1",
+				Key:    []byte(key),
+				Value:  []byte("v_" + key),
+			},
+			RefTxid: []byte("txid"),
+		})
+	}
+	store.Put("b1", []byte("k4"), &ledger.VersionedData{
+		PureData: &ledger.PureData{
+			Bucket: "b1",
+			Key:    []byte("k4"),
+			Value:  []byte(DelFlag),
+		},
+		RefTxid: []byte("txid"),
+	})
+
+	rs := NewReadOnlySandbox(&contract.SandboxConfig{
+		XMReader: store,
+	})
+	value, err := rs.Get("b1", []byte("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "v_k1" {
+		t.Errorf("expect v_k1 got %s", value)
+	}
+	if _, err := rs.Get("b1", []byte("k4")); err != ErrHasDel {
+		t.Errorf("expect ErrHasDel got %v", err)
+	}
+
+	iter, err := rs.Select("b1", []byte("k1"), []byte("k5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for iter.Next() {
+		n++
+	}
+	iter.Close()
+	if n != 3 {
+		t.Errorf("expect 3 keys got %d", n)
+	}
+
+	if err := rs.Put("b1", []byte("k1"), []byte("v")); err != ErrReadOnly {
+		t.Errorf("expect ErrReadOnly got %v", err)
+	}
+	if err := rs.Del("b1", []byte("k1")); err != ErrReadOnly {
+		t.Errorf("expect ErrReadOnly got %v", err)
+	}
+	if err := rs.Transfer("a", "b", big.NewInt(1)); err != ErrReadOnly {
+		t.Errorf("expect ErrReadOnly got %v", err)
+	}
+	if len(rs.RWSet().RSet) != 0 || len(rs.RWSet().WSet) != 0 {
+		t.Error("expect empty rwset")
+	}
+}
@@ -0,0 +1,253 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// 合约存储统计：沙盒Flush时统计每个合约bucket中key和value长度的增量，更新合约的存储记录，
+// 存储增长时从预付租金中扣除租金，释放时退还，超过配额的交易执行失败。
+// 合约的存储记录按key的哈希分为StorageShards个分片，交易只读写其修改的key所在分片的记录，
+// 修改同一合约不同分片的交易不会因为存储统计而冲突，区块中的交易仍然可以并行执行。
+// 配额、免租金的字节数和预付租金平均分配到各个分片，按分片检查和扣除。
+
+const (
+	// StorageBucket 保存合约存储记录的bucket，key为合约名/分片号
+	StorageBucket = "$storage"
+	// StorageRentAddress 预付的租金转入的地址，该地址没有对应的私钥，转入后无法花费
+	StorageRentAddress = "$storage"
+	// StorageShards 每个合约存储记录的分片数
+	StorageShards = 16
+)
+
+var (
+	// ErrStorageQuotaExceeded is returned when contract storage exceeds max bytes
+	ErrStorageQuotaExceeded = errors.New("contract storage quota exceeded")
+	// ErrStorageRentNotEnough is returned when prepaid rent can't afford the storage
+	ErrStorageRentNotEnough = errors.New("prepaid storage rent not enough")
+	// ErrValueTooLarge is returned when value of contract key exceeds max value size
+	ErrValueTooLarge = errors.New("contract value too large")
+)
+
+// StateGetter reads value from state
+type StateGetter interface {
+	Get(bucket string, key []byte) ([]byte, error)
+}
+
+// StorageShard 返回合约key所在的存储记录分片
+func StorageShard(key []byte) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % StorageShards)
+}
+
+func storageShardKey(contractName string, shard int) []byte {
+	return []byte(fmt.Sprintf("%s/%d", contractName, shard))
+}
+
+// GetContractStorageShard 读取合约存储记录的一个分片，不存在时返回空记录
+func GetContractStorageShard(state StateGetter, contractName string, shard int) (*protos.ContractStorage, error) {
+	storage := &protos.ContractStorage{
+		ContractName: contractName,
+		Prepaid:      "0",
+	}
+	value, err := state.Get(StorageBucket, storageShardKey(contractName, shard))
+	if err == ErrNotFound || err == ErrHasDel {
+		return storage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(value, storage); err != nil {
+		return nil, err
+	}
+	if storage.Prepaid == "" {
+		storage.Prepaid = "0"
+	}
+	return storage, nil
+}
+
+// GetContractStorageShards 读取合约存储记录的所有分片
+func GetContractStorageShards(state StateGetter, contractName string) ([]*protos.ContractStorage, error) {
+	shards := make([]*protos.ContractStorage, StorageShards)
+	for i := range shards {
+		storage, err := GetContractStorageShard(state, contractName, i)
+		if err != nil {
+			return nil, err
+		}
+		shards[i] = storage
+	}
+	return shards, nil
+}
+
+// GetContractStorage 读取合约所有分片的存储记录，返回合计的存储使用量和预付租金
+func GetContractStorage(state StateGetter, contractName string) (*protos.ContractStorage, error) {
+	shards, err := GetContractStorageShards(state, contractName)
+	if err != nil {
+		return nil, err
+	}
+	var used int64
+	prepaid := new(big.Int)
+	for _, shard := range shards {
+		amount, ok := new(big.Int).SetString(shard.GetPrepaid(), 10)
+		if !ok {
+			return nil, fmt.Errorf("bad prepaid storage rent of contract %s", contractName)
+		}
+		used += shard.GetUsedBytes()
+		prepaid.Add(prepaid, amount)
+	}
+	return &protos.ContractStorage{
+		ContractName: contractName,
+		UsedBytes:    used,
+		Prepaid:      prepaid.String(),
+	}, nil
+}
+
+// PutContractStorageShard 写入合约存储记录的一个分片
+func PutContractStorageShard(state contract.StateSandbox, shard int, storage *protos.ContractStorage) error {
+	value, err := proto.Marshal(storage)
+	if err != nil {
+		return err
+	}
+	return state.Put(StorageBucket, storageShardKey(storage.GetContractName(), shard), value)
+}
+
+// StorageShardConfig 返回每个分片的配额和免租金字节数，配额向上取整，免租金字节数向下取整
+func StorageShardConfig(cfg *contract.StorageRentConfig) *contract.StorageRentConfig {
+	shardCfg := *cfg
+	shardCfg.FreeBytes = cfg.FreeBytes / StorageShards
+	shardCfg.MaxBytes = (cfg.MaxBytes + StorageShards - 1) / StorageShards
+	return &shardCfg
+}
+
+// StorageRent 返回占用usedBytes字节需要的租金，分片的租金使用StorageShardConfig计算
+func StorageRent(cfg *contract.StorageRentConfig, usedBytes int64) *big.Int {
+	charged := usedBytes - cfg.FreeBytes
+	if charged <= 0 || cfg.RentRate <= 0 {
+		return new(big.Int)
+	}
+	return big.NewInt((charged + cfg.RentRate - 1) / cfg.RentRate)
+}
+
+// ShardsStorageRent 返回合约各个分片需要的租金之和
+func ShardsStorageRent(cfg *contract.StorageRentConfig, shards []*protos.ContractStorage) *big.Int {
+	shardCfg := StorageShardConfig(cfg)
+	rent := new(big.Int)
+	for _, shard := range shards {
+		rent.Add(rent, StorageRent(shardCfg, shard.GetUsedBytes()))
+	}
+	return rent
+}
+
+func kvSize(key, value []byte) int64 {
+	if IsDelFlag(value) {
+		return 0
+	}
+	return int64(len(key) + len(value))
+}
+
+type bucketUsage struct {
+	deltas       [StorageShards]int64
+	maxValueSize int
+}
+
+// flushContractStorage 统计本次执行中每个合约存储的增量，更新合约的存储记录
+func (xc *XMCache) flushContractStorage() error {
+	cfg := xc.storageRent
+	if cfg == nil || !cfg.Enable || xc.storageFlushed {
+		return nil
+	}
+	xc.storageFlushed = true
+
+	usages := make(map[string]*bucketUsage)
+	iter := xc.outputsCache.NewIterator()
+	for iter.Next() {
+		data := iter.Value().GetPureData()
+		bucket := data.GetBucket()
+		if bucket == TransientBucket || bucket == StorageBucket {
+			continue
+		}
+		// Put之前已经读取过，这里的读不会改变读集
+		old, err := xc.getAndSetFromInputsCache(bucket, data.GetKey())
+		if err != nil && err != ErrNotFound {
+			iter.Close()
+			return err
+		}
+		var oldSize int64
+		if err == nil && !IsEmptyVersionedData(old) {
+			oldSize = kvSize(old.GetPureData().GetKey(), old.GetPureData().GetValue())
+		}
+		usage, ok := usages[bucket]
+		if !ok {
+			usage = new(bucketUsage)
+			usages[bucket] = usage
+		}
+		usage.deltas[StorageShard(data.GetKey())] += kvSize(data.GetKey(), data.GetValue()) - oldSize
+		if !IsDelFlag(data.GetValue()) && len(data.GetValue()) > usage.maxValueSize {
+			usage.maxValueSize = len(data.GetValue())
+		}
+	}
+	iter.Close()
+
+	buckets := make([]string, 0, len(usages))
+	for bucket := range usages {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	for _, bucket := range buckets {
+		// 只统计已经部署的合约的bucket
+		if _, err := xc.Get(utils.GetContract2AccountBucket(), []byte(bucket)); err != nil {
+			continue
+		}
+		usage := usages[bucket]
+		if cfg.MaxValueSize > 0 && int64(usage.maxValueSize) > cfg.MaxValueSize {
+			return fmt.Errorf("%s, contract:%s, max:%d", ErrValueTooLarge, bucket, cfg.MaxValueSize)
+		}
+		for shard, delta := range usage.deltas {
+			if delta == 0 {
+				continue
+			}
+			if err := xc.updateContractStorage(bucket, shard, delta); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateContractStorage 只读写修改的key所在分片的存储记录，按分片的配额检查并扣除分片的预付租金
+func (xc *XMCache) updateContractStorage(contractName string, shard int, delta int64) error {
+	cfg := StorageShardConfig(xc.storageRent)
+	storage, err := GetContractStorageShard(xc, contractName, shard)
+	if err != nil {
+		return err
+	}
+	used := storage.GetUsedBytes() + delta
+	if used < 0 {
+		used = 0
+	}
+	if delta > 0 && cfg.MaxBytes > 0 && used > cfg.MaxBytes {
+		return fmt.Errorf("%s, contract:%s, shard:%d, used:%d, max:%d", ErrStorageQuotaExceeded, contractName, shard, used, cfg.MaxBytes)
+	}
+	prepaid, ok := new(big.Int).SetString(storage.GetPrepaid(), 10)
+	if !ok {
+		return fmt.Errorf("bad prepaid storage rent of contract %s", contractName)
+	}
+	cost := new(big.Int).Sub(StorageRent(cfg, used), StorageRent(cfg, storage.GetUsedBytes()))
+	prepaid.Sub(prepaid, cost)
+	if prepaid.Sign() < 0 {
+		return fmt.Errorf("%s, contract:%s, shard:%d, need:%s", ErrStorageRentNotEnough, contractName, shard, cost)
+	}
+	storage.UsedBytes = used
+	storage.Prepaid = prepaid.String()
+	return PutContractStorageShard(xc, shard, storage)
+}
@@ -0,0 +1,189 @@
+package sandbox
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/kernel/permission/acl/utils"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// 每个分片免租金10字节，配额100字节
+var testStorageRent = &contract.StorageRentConfig{
+	Enable:       true,
+	RentRate:     10,
+	FreeBytes:    10 * StorageShards,
+	MaxBytes:     100 * StorageShards,
+	MaxValueSize: 50,
+}
+
+// sameShardKeys 返回n个落在同一个存储分片的key
+func sameShardKeys(n int) ([][]byte, int) {
+	shard := StorageShard([]byte{'a'})
+	keys := [][]byte{{'a'}}
+	for c := byte('b'); len(keys) < n; c++ {
+		if StorageShard([]byte{c}) == shard {
+			keys = append(keys, []byte{c})
+		}
+	}
+	return keys, shard
+}
+
+func putStorageShard(store *MemXModel, shard int, storage *protos.ContractStorage) {
+	buf, _ := proto.Marshal(storage)
+	putVersionedData(store, StorageBucket, storageShardKey(storage.GetContractName(), shard), buf)
+}
+
+func newStorageTestCache(store *MemXModel) *XMCache {
+	return NewXModelCache(&contract.SandboxConfig{
+		XMReader:    store,
+		StorageRent: testStorageRent,
+	})
+}
+
+func TestContractStorageRent(t *testing.T) {
+	store := NewMemXModel()
+	putVersionedData(store, utils.GetContract2AccountBucket(), []byte("counter"), []byte("XC1111111111111111@xuper"))
+
+	shard := StorageShard([]byte("k1"))
+	mc := newStorageTestCache(store)
+	err := PutContractStorageShard(mc, shard, &protos.ContractStorage{ContractName: "counter", Prepaid: "5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc.Put("counter", []byte("k1"), bytes.Repeat([]byte("v"), 20))
+	// 不是合约的bucket不统计
+	mc.Put("other", []byte("k1"), bytes.Repeat([]byte("v"), 20))
+	if err := mc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// 22字节，超出分片免费的10字节，租金为ceil(12/10)=2
+	storage, err := GetContractStorageShard(mc, "counter", shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storage.UsedBytes != 22 || storage.Prepaid != "3" {
+		t.Fatalf("unexpected storage:%v", storage)
+	}
+	total, err := GetContractStorage(mc, "counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.UsedBytes != 22 || total.Prepaid != "3" {
+		t.Fatalf("unexpected total storage:%v", total)
+	}
+	// 重复Flush不会重复统计
+	mc.Flush()
+	storage, _ = GetContractStorage(mc, "counter")
+	if storage.UsedBytes != 22 {
+		t.Fatalf("unexpected storage after flush twice:%v", storage)
+	}
+	if _, err := GetContractStorage(mc, "other"); err != nil {
+		t.Fatal(err)
+	}
+	value, _ := mc.Get(StorageBucket, storageShardKey("other", StorageShard([]byte("k1"))))
+	if value != nil {
+		t.Fatal("expect no storage record for other bucket")
+	}
+
+	// 提交后删除key，退还租金
+	putStorageShard(store, shard, storage)
+	putVersionedData(store, "counter", []byte("k1"), bytes.Repeat([]byte("v"), 20))
+	mc = newStorageTestCache(store)
+	mc.Del("counter", []byte("k1"))
+	if err := mc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	storage, _ = GetContractStorageShard(mc, "counter", shard)
+	if storage.UsedBytes != 0 || storage.Prepaid != "5" {
+		t.Fatalf("unexpected storage after delete:%v", storage)
+	}
+}
+
+func TestContractStorageQuota(t *testing.T) {
+	store := NewMemXModel()
+	putVersionedData(store, utils.GetContract2AccountBucket(), []byte("counter"), []byte("XC1111111111111111@xuper"))
+	keys, shard := sameShardKeys(3)
+	putStorageShard(store, shard, &protos.ContractStorage{ContractName: "counter", Prepaid: "100"})
+
+	testCases := []struct {
+		name  string
+		sizes []int
+		err   error
+	}{
+		{"value too large", []int{60}, ErrValueTooLarge},
+		{"quota exceeded", []int{40, 40, 40}, ErrStorageQuotaExceeded},
+		{"ok", []int{40, 40}, nil},
+	}
+	for _, tc := range testCases {
+		mc := newStorageTestCache(store)
+		for i, size := range tc.sizes {
+			mc.Put("counter", keys[i], bytes.Repeat([]byte("v"), size))
+		}
+		err := mc.Flush()
+		if tc.err == nil && err != nil {
+			t.Fatalf("%s: unexpected error:%s", tc.name, err)
+		}
+		if tc.err != nil && (err == nil || !strings.Contains(err.Error(), tc.err.Error())) {
+			t.Fatalf("%s: expect error %s, got %v", tc.name, tc.err, err)
+		}
+	}
+
+	// 预付租金不足
+	putStorageShard(store, shard, &protos.ContractStorage{ContractName: "counter", Prepaid: "0"})
+	mc := newStorageTestCache(store)
+	mc.Put("counter", keys[0], bytes.Repeat([]byte("v"), 30))
+	err := mc.Flush()
+	if err == nil || !strings.Contains(err.Error(), ErrStorageRentNotEnough.Error()) {
+		t.Fatalf("expect rent not enough, got %v", err)
+	}
+}
+
+func TestContractStorageShards(t *testing.T) {
+	store := NewMemXModel()
+	putVersionedData(store, utils.GetContract2AccountBucket(), []byte("counter"), []byte("XC1111111111111111@xuper"))
+
+	// 找到两个落在不同分片的key
+	k1 := []byte("k1")
+	var k2 []byte
+	for c := byte('a'); ; c++ {
+		if StorageShard([]byte{c}) != StorageShard(k1) {
+			k2 = []byte{c}
+			break
+		}
+	}
+	storageKeys := func(key []byte) map[string]bool {
+		mc := newStorageTestCache(store)
+		mc.Put("counter", key, []byte("v"))
+		if err := mc.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		keys := make(map[string]bool)
+		rwset := mc.RWSet()
+		for _, r := range rwset.RSet {
+			if r.GetPureData().GetBucket() == StorageBucket {
+				keys[string(r.GetPureData().GetKey())] = true
+			}
+		}
+		for _, w := range rwset.WSet {
+			if w.GetBucket() == StorageBucket {
+				keys[string(w.GetKey())] = true
+			}
+		}
+		return keys
+	}
+	keys1, keys2 := storageKeys(k1), storageKeys(k2)
+	if len(keys1) != 1 || len(keys2) != 1 {
+		t.Fatalf("expect only one storage shard read and written, got %v %v", keys1, keys2)
+	}
+	// 修改不同分片的交易读写的存储记录不相交，不会冲突
+	for key := range keys1 {
+		if keys2[key] {
+			t.Fatalf("expect disjoint storage records, both touch %s", key)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 type UTXOReader struct {
 	inputCache []*protos.TxInput
 	inputIdx   int
+	balances   []*protos.TxOutput
+	balanceIdx int
 }
 
 func NewUTXOReaderFromInput(input []*protos.TxInput) contract.UtxoReader {
@@ -20,6 +22,14 @@ func NewUTXOReaderFromInput(input []*protos.TxInput) contract.UtxoReader {
 	}
 }
 
+// NewUTXOReaderWithBalances 除了utxo输入，还按顺序返回交易中记录的地址余额
+func NewUTXOReaderWithBalances(input []*protos.TxInput, balances []*protos.TxOutput) contract.UtxoReader {
+	return &UTXOReader{
+		inputCache: input,
+		balances:   balances,
+	}
+}
+
 func (r *UTXOReader) SelectUtxo(from string, amount *big.Int, lock bool, excludeUnconfirmed bool) ([]*protos.TxInput, [][]byte, *big.Int, error) {
 	fromBytes := []byte(from)
 	inputCache := r.inputCache[r.inputIdx:]
@@ -42,3 +52,16 @@ func (r *UTXOReader) SelectUtxo(from string, amount *big.Int, lock bool, exclude
 	r.inputIdx += n
 	return inputCache[:n], nil, sum, nil
 }
+
+// GetBalance 按顺序返回交易中记录的地址余额，对应预执行时读取的余额
+func (r *UTXOReader) GetBalance(addr string) (*big.Int, error) {
+	if r.balanceIdx >= len(r.balances) {
+		return nil, errors.New("balance not found in utxo cache")
+	}
+	record := r.balances[r.balanceIdx]
+	if !bytes.Equal(record.GetToAddr(), []byte(addr)) {
+		return nil, errors.New("balance address mismatch in utxo cache")
+	}
+	r.balanceIdx++
+	return new(big.Int).SetBytes(record.GetAmount()), nil
+}
@@ -0,0 +1,161 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// memUtxoReader 模拟预执行时的UtxoVM，每个地址有若干个已确认的utxo
+type memUtxoReader struct {
+	utxos     map[string][]*protos.TxInput
+	confirmed map[string]*big.Int
+}
+
+func newMemUtxoReader(amounts map[string][]int64) *memUtxoReader {
+	r := &memUtxoReader{
+		utxos:     make(map[string][]*protos.TxInput),
+		confirmed: make(map[string]*big.Int),
+	}
+	for addr, list := range amounts {
+		r.confirmed[addr] = new(big.Int)
+		for i, amount := range list {
+			r.utxos[addr] = append(r.utxos[addr], &protos.TxInput{
+				RefTxid:   []byte(fmt.Sprintf("%s-%d", addr, i)),
+				FromAddr:  []byte(addr),
+				Amount:    big.NewInt(amount).Bytes(),
+				RefOffset: int32(i),
+			})
+			r.confirmed[addr].Add(r.confirmed[addr], big.NewInt(amount))
+		}
+	}
+	return r
+}
+
+func (r *memUtxoReader) SelectUtxo(from string, amount *big.Int, lock bool, excludeUnconfirmed bool) ([]*protos.TxInput, [][]byte, *big.Int, error) {
+	sum := new(big.Int)
+	for i, input := range r.utxos[from] {
+		sum.Add(sum, new(big.Int).SetBytes(input.GetAmount()))
+		if sum.Cmp(amount) >= 0 {
+			inputs := r.utxos[from][:i+1]
+			r.utxos[from] = r.utxos[from][i+1:]
+			return inputs, nil, sum, nil
+		}
+	}
+	return nil, nil, nil, errors.New("utxo not enough")
+}
+
+// GetBalance 返回确认的余额，不受交易中选择utxo的影响
+func (r *memUtxoReader) GetBalance(addr string) (*big.Int, error) {
+	balance, ok := r.confirmed[addr]
+	if !ok {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Set(balance), nil
+}
+
+func expectBalance(t *testing.T, mc *XMCache, addr string, expect int64) {
+	balance, err := mc.Balance(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != expect {
+		t.Fatalf("expect %s balance %d, got %s", addr, expect, balance)
+	}
+}
+
+func runUtxoBalanceOps(t *testing.T, reader contract.UtxoReader) *XMCache {
+	mc := NewXModelCache(&contract.SandboxConfig{
+		XMReader:   NewMemXModel(),
+		UTXOReader: reader,
+	})
+	if err := mc.Transfer("alice", "bob", big.NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+	// 确认的余额加上交易中的转账，读取余额不花费utxo
+	expectBalance(t, mc, "bob", 8)
+	expectBalance(t, mc, "alice", 12)
+	if err := mc.Transfer("bob", "carol", big.NewInt(2)); err != nil {
+		t.Fatal(err)
+	}
+	expectBalance(t, mc, "bob", 6)
+	expectBalance(t, mc, "carol", 2)
+	return mc
+}
+
+func TestUTXOBalance(t *testing.T) {
+	reader := newMemUtxoReader(map[string][]int64{
+		"alice": {3, 4, 10},
+		"bob":   {1, 2},
+	})
+	mc := runUtxoBalanceOps(t, reader)
+	rwset := mc.UTXORWSet()
+
+	expectOutputs := []*protos.TxOutput{
+		{ToAddr: []byte("bob"), Amount: big.NewInt(5).Bytes()},
+		{ToAddr: []byte("alice"), Amount: big.NewInt(2).Bytes()},
+		{ToAddr: []byte("carol"), Amount: big.NewInt(2).Bytes()},
+		{ToAddr: []byte("bob"), Amount: big.NewInt(1).Bytes()},
+	}
+	if !reflect.DeepEqual(rwset.WSet, expectOutputs) {
+		t.Fatalf("unexpected outputs:%v", rwset.WSet)
+	}
+	if len(rwset.Rset) != 4 {
+		t.Fatalf("expect 4 inputs, got %d", len(rwset.Rset))
+	}
+
+	if err := mc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &lpb.Transaction{}
+	for _, w := range mc.RWSet().WSet {
+		tx.TxOutputsExt = append(tx.TxOutputsExt, &protos.TxOutputExt{
+			Bucket: w.Bucket,
+			Key:    w.Key,
+			Value:  w.Value,
+		})
+	}
+	balances, err := ParseBalanceReads(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 每个地址只读取一次确认的余额
+	expectBalances := []struct {
+		addr   string
+		amount int64
+	}{
+		{"bob", 3},
+		{"alice", 17},
+		{"carol", 0},
+	}
+	if len(balances) != len(expectBalances) {
+		t.Fatalf("expect %d balance reads, got %d", len(expectBalances), len(balances))
+	}
+	for i, expect := range expectBalances {
+		amount := new(big.Int).SetBytes(balances[i].GetAmount())
+		if string(balances[i].GetToAddr()) != expect.addr || amount.Int64() != expect.amount {
+			t.Fatalf("unexpected balance read %s:%s", balances[i].GetToAddr(), amount)
+		}
+	}
+
+	// 验证时按照输入和记录的余额重放得到相同的读写集
+	replay := runUtxoBalanceOps(t, NewUTXOReaderWithBalances(rwset.Rset, balances))
+	if err := replay.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replay.UTXORWSet(), rwset) {
+		t.Fatalf("replay rwset mismatch, expect %v, got %v", rwset, replay.UTXORWSet())
+	}
+
+	// 记录的余额与读取的地址不一致
+	mismatch := NewUTXOReaderWithBalances(nil, balances[1:])
+	if _, err := mismatch.GetBalance("bob"); err == nil {
+		t.Fatal("expect balance address mismatch")
+	}
+}
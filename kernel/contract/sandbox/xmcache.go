@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"math/big"
+	"strconv"
 
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo"
 
@@ -26,6 +27,8 @@ var (
 	contractUtxoInputKey  = []byte("ContractUtxo.Inputs")
 	contractUtxoOutputKey = []byte("ContractUtxo.Outputs")
 	crossQueryInfosKey    = []byte("CrossQueryInfos")
+	balanceReadsKey       = []byte("BalanceReads")
+	blockReadKey          = []byte("BlockRead")
 	contractEventKey      = []byte("contractEvent")
 )
 
@@ -36,6 +39,7 @@ var (
 // UtxoReader manages utxos
 type UtxoReader interface {
 	SelectUtxo(string, *big.Int, bool, bool) ([]*protos.TxInput, [][]byte, *big.Int, error)
+	GetBalance(string) (*big.Int, error)
 }
 
 // XMCache data structure for XModel Cache
@@ -47,20 +51,27 @@ type XMCache struct {
 
 	model ledger.XMReader
 
-	utxoSandbox *utxo.UTXOSandbox
-	// crossQueryCache *CrossQueryCache
-	events []*protos.ContractEvent
+	utxoSandbox     *utxo.UTXOSandbox
+	crossQueryCache *CrossQueryCache
+	events          []*protos.ContractEvent
+	// 合约读取的区块信息所在的区块高度，-1表示没有读取
+	blockReadHeight int64
+
+	// 合约存储统计的配置，为空时不统计
+	storageRent    *contract.StorageRentConfig
+	storageFlushed bool
 }
 
 // NewXModelCache new an instance of XModel Cache
 func NewXModelCache(cfg *contract.SandboxConfig) *XMCache {
 	return &XMCache{
-		model:        cfg.XMReader,
-		inputsCache:  NewMemXModel(),
-		outputsCache: NewMemXModel(),
-		utxoSandbox:  utxo.NewUTXOSandbox(cfg),
-
-		// crossQueryCache: NewCrossQueryCache(),
+		model:           cfg.XMReader,
+		inputsCache:     NewMemXModel(),
+		outputsCache:    NewMemXModel(),
+		utxoSandbox:     utxo.NewUTXOSandbox(cfg),
+		crossQueryCache: NewCrossQueryCache(cfg.CrossQueryReader),
+		storageRent:     cfg.StorageRent,
+		blockReadHeight: -1,
 	}
 }
 
@@ -217,6 +228,11 @@ func (xc *XMCache) Transfer(from, to string, amount *big.Int) error {
 	return xc.utxoSandbox.Transfer(from, to, amount)
 }
 
+// Balance 读取地址的余额，读取的确认余额会记录在交易中
+func (xc *XMCache) Balance(addr string) (*big.Int, error) {
+	return xc.utxoSandbox.Balance(addr)
+}
+
 //UTXORWSet returns the inputs and outputs of utxo
 func (xc *XMCache) UTXORWSet() *contract.UTXORWSet {
 	return xc.utxoSandbox.GetUTXORWSets()
@@ -367,61 +383,102 @@ func (xc *XMCache) flushUTXORWSet() error {
 //}
 
 // CrossQuery will query contract from other chain
-//func (xc *XMCache) CrossQuery(crossQueryRequest *pb.CrossQueryRequest, queryMeta *pb.CrossQueryMeta) (*pb.ContractResponse, error) {
-//	return xc.crossQueryCache.CrossQuery(crossQueryRequest, queryMeta)
-//}
+func (xc *XMCache) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.ContractResponse, contract.Limits, error) {
+	return xc.crossQueryCache.CrossQuery(req, limits)
+}
 
 // ParseCrossQuery parse cross query from tx
-//func ParseCrossQuery(tx *pb.Transaction) ([]*pb.CrossQueryInfo, error) {
-//	var (
-//		crossQueryInfos []*pb.CrossQueryInfo
-//		queryInfos      []byte
-//	)
-//	for _, out := range tx.GetTxOutputsExt() {
-//		if out.GetBucket() != TransientBucket {
-//			continue
-//		}
-//		if bytes.Equal(out.GetKey(), crossQueryInfosKey) {
-//			queryInfos = out.GetValue()
-//		}
-//	}
-//	if queryInfos != nil {
-//		err := UnmsarshalMessages(queryInfos, &crossQueryInfos)
-//		if err != nil {
-//			return nil, err
-//		}
-//	}
-//	return crossQueryInfos, nil
-//}
+func ParseCrossQuery(tx *lpb.Transaction) ([]*protos.CrossQueryInfo, error) {
+	var crossQueryInfos []*protos.CrossQueryInfo
+	for _, out := range tx.GetTxOutputsExt() {
+		if out.GetBucket() != TransientBucket {
+			continue
+		}
+		if !bytes.Equal(out.GetKey(), crossQueryInfosKey) {
+			continue
+		}
+		err := xmodel.UnmsarshalMessages(out.GetValue(), &crossQueryInfos)
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	return crossQueryInfos, nil
+}
 
-// IsCrossQueryEffective check if crossQueryInfos effective
-// TODO: zq
-//func IsCrossQueryEffective(cqi []*pb.CrossQueryInfo, tx *pb.Transaction) bool {
-//	return true
-//}
+// putCrossQueries put queryInfos to TransientBucket
+func (xc *XMCache) putCrossQueries(queryInfos []*protos.CrossQueryInfo) error {
+	if len(queryInfos) == 0 {
+		return nil
+	}
+	qi, err := xmodel.MarshalMessages(queryInfos)
+	if err != nil {
+		return err
+	}
+	return xc.Put(TransientBucket, crossQueryInfosKey, qi)
+}
 
-// PutCrossQueries put queryInfos to db
-//func (xc *XMCache) putCrossQueries(queryInfos []*pb.CrossQueryInfo) error {
-//	var qi []byte
-//	var err error
-//	if len(queryInfos) != 0 {
-//		qi, err = MarshalMessages(queryInfos)
-//		if err != nil {
-//			return err
-//		}
-//	}
-//	if qi != nil {
-//		err = xc.Put(TransientBucket, crossQueryInfosKey, qi)
-//		if err != nil {
-//			return err
-//		}
-//	}
-//	return nil
-//}
+func (xc *XMCache) writeCrossQueriesRWSet() error {
+	return xc.putCrossQueries(xc.crossQueryCache.GetCrossQueryRWSets())
+}
 
-//func (xc *XMCache) writeCrossQueriesRWSet() error {
-//	return xc.putCrossQueries(xc.crossQueryCache.GetCrossQueryRWSets())
-//}
+// ParseBalanceReads parse the confirmed balances read by contract from tx
+func ParseBalanceReads(tx *lpb.Transaction) ([]*protos.TxOutput, error) {
+	var balances []*protos.TxOutput
+	for _, out := range tx.GetTxOutputsExt() {
+		if out.GetBucket() != TransientBucket {
+			continue
+		}
+		if !bytes.Equal(out.GetKey(), balanceReadsKey) {
+			continue
+		}
+		err := xmodel.UnmsarshalMessages(out.GetValue(), &balances)
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	return balances, nil
+}
+
+func (xc *XMCache) writeBalanceReads() error {
+	balances := xc.utxoSandbox.BalanceReads()
+	if len(balances) == 0 {
+		return nil
+	}
+	buf, err := xmodel.MarshalMessages(balances)
+	if err != nil {
+		return err
+	}
+	return xc.Put(TransientBucket, balanceReadsKey, buf)
+}
+
+// RecordBlockRead 记录合约读取了height高度区块的信息
+func (xc *XMCache) RecordBlockRead(height int64) {
+	xc.blockReadHeight = height
+}
+
+// ParseBlockRead 返回交易中合约读取的区块信息所在的区块高度，没有读取时返回-1
+func ParseBlockRead(tx *lpb.Transaction) (int64, error) {
+	for _, out := range tx.GetTxOutputsExt() {
+		if out.GetBucket() != TransientBucket {
+			continue
+		}
+		if !bytes.Equal(out.GetKey(), blockReadKey) {
+			continue
+		}
+		return strconv.ParseInt(string(out.GetValue()), 10, 64)
+	}
+	return -1, nil
+}
+
+func (xc *XMCache) writeBlockRead() error {
+	if xc.blockReadHeight < 0 {
+		return nil
+	}
+	return xc.Put(TransientBucket, blockReadKey, []byte(strconv.FormatInt(xc.blockReadHeight, 10)))
+}
 
 // ParseContractEvents parse contract events from tx
 func ParseContractEvents(tx *lpb.Transaction) ([]*protos.ContractEvent, error) {
@@ -463,15 +520,30 @@ func (xc *XMCache) writeEventRWSet() error {
 // generated during the execution of the contract, but will not be referenced by other txs.
 func (xc *XMCache) Flush() error {
 	var err error
+	err = xc.flushContractStorage()
+	if err != nil {
+		return err
+	}
+
 	err = xc.flushUTXORWSet()
 	if err != nil {
 		return err
 	}
 
-	// err = xc.writeCrossQueriesRWSet()
-	// if err != nil {
-	// 	return err
-	// }
+	err = xc.writeCrossQueriesRWSet()
+	if err != nil {
+		return err
+	}
+
+	err = xc.writeBalanceReads()
+	if err != nil {
+		return err
+	}
+
+	err = xc.writeBlockRead()
+	if err != nil {
+		return err
+	}
 
 	err = xc.writeEventRWSet()
 	if err != nil {
@@ -1,7 +1,12 @@
 package sandbox
 
 import (
+	"bytes"
+	"errors"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
 	"github.com/xuperchain/xupercore/kernel/contract"
+	"github.com/xuperchain/xupercore/protos"
 	"math/big"
 	"math/rand"
 	"sort"
@@ -104,3 +109,152 @@ func TestXMCacheIterator(t *testing.T) {
 		t.Logf("%s", r.GetPureData().GetKey())
 	}
 }
+
+type fakeCrossQueryReader struct{}
+
+// fakeCrossQueryReader 每次查询消耗1个cpu
+func (fakeCrossQueryReader) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	if limits.Cpu < 1 {
+		return nil, contract.Limits{}, errors.New("resource exceeds")
+	}
+	return &protos.CrossQueryResponse{
+		Response: &protos.ContractResponse{
+			Status: contract.StatusOK,
+			Body:   append([]byte(req.GetChainName()+":"), req.GetKey()...),
+		},
+		Blockid: []byte("blockid"),
+	}, contract.Limits{Cpu: 1}, nil
+}
+
+func (r fakeCrossQueryReader) CrossQueryAtBlock(req *protos.CrossQueryRequest, blockid []byte,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	if !bytes.Equal(blockid, []byte("blockid")) {
+		return nil, contract.Limits{}, errors.New("block not found")
+	}
+	return r.CrossQuery(req, limits)
+}
+
+func TestXMCacheCrossQuery(t *testing.T) {
+	mc := NewXModelCache(&contract.SandboxConfig{
+		XMReader: NewMemXModel(),
+	})
+	_, _, err := mc.CrossQuery(&protos.CrossQueryRequest{ChainName: "sub", Bucket: "b1", Key: []byte("k1")}, contract.MaxLimits)
+	if err != ErrCrossQueryNotSupported {
+		t.Fatalf("expect ErrCrossQueryNotSupported got %v", err)
+	}
+
+	mc = NewXModelCache(&contract.SandboxConfig{
+		XMReader:         NewMemXModel(),
+		CrossQueryReader: fakeCrossQueryReader{},
+	})
+	resp, used, err := mc.CrossQuery(&protos.CrossQueryRequest{ChainName: "sub", Height: 10, Bucket: "b1", Key: []byte("k1")}, contract.MaxLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.GetBody()) != "sub:k1" {
+		t.Fatalf("unexpected cross query body:%s", resp.GetBody())
+	}
+	if used.Cpu != 1 {
+		t.Fatalf("expect cross query resource used returned, got %+v", used)
+	}
+	if err := mc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &lpb.Transaction{}
+	for _, w := range mc.RWSet().WSet {
+		tx.TxOutputsExt = append(tx.TxOutputsExt, &protos.TxOutputExt{
+			Bucket: w.Bucket,
+			Key:    w.Key,
+			Value:  w.Value,
+		})
+	}
+	infos, err := ParseCrossQuery(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("expect 1 cross query info got %d", len(infos))
+	}
+	if infos[0].GetRequest().GetHeight() != 10 || !bytes.Equal(infos[0].GetResponse().GetBlockid(), []byte("blockid")) {
+		t.Fatalf("unexpected cross query info:%v", infos[0])
+	}
+}
+
+func TestCrossQueryVerifier(t *testing.T) {
+	req := &protos.CrossQueryRequest{ChainName: "sub", Height: 10, Bucket: "b1", Key: []byte("k1")}
+	resp, _, _ := fakeCrossQueryReader{}.CrossQuery(req, contract.MaxLimits)
+	recorded := []*protos.CrossQueryInfo{{Request: req, Response: resp}}
+
+	verifier := NewCrossQueryVerifier(recorded, fakeCrossQueryReader{})
+	if err := verifier.Verify(); err == nil {
+		t.Fatal("expect recorded query not verified")
+	}
+	if _, _, err := verifier.CrossQuery(req, contract.Limits{}); err == nil {
+		t.Fatal("expect resource exceeds with the limits of verified tx")
+	}
+	if _, used, err := verifier.CrossQuery(req, contract.MaxLimits); err != nil || used.Cpu != 1 {
+		t.Fatalf("expect cross query verified, used:%+v, err:%v", used, err)
+	}
+	if err := verifier.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := verifier.CrossQuery(req, contract.MaxLimits); err == nil {
+		t.Fatal("expect query not recorded")
+	}
+
+	// 请求与记录不一致
+	verifier = NewCrossQueryVerifier(recorded, fakeCrossQueryReader{})
+	if _, _, err := verifier.CrossQuery(&protos.CrossQueryRequest{ChainName: "sub", Height: 11, Bucket: "b1", Key: []byte("k1")}, contract.MaxLimits); err == nil {
+		t.Fatal("expect request mismatch")
+	}
+
+	// 重新查询的结果与记录不一致
+	forged := []*protos.CrossQueryInfo{{Request: req, Response: &protos.CrossQueryResponse{
+		Response: &protos.ContractResponse{Status: contract.StatusOK, Body: []byte("forged")},
+		Blockid:  []byte("blockid"),
+	}}}
+	verifier = NewCrossQueryVerifier(forged, fakeCrossQueryReader{})
+	if _, _, err := verifier.CrossQuery(req, contract.MaxLimits); err == nil {
+		t.Fatal("expect response mismatch")
+	}
+
+	// 记录的区块不存在
+	unknown := []*protos.CrossQueryInfo{{Request: req, Response: &protos.CrossQueryResponse{
+		Response: resp.GetResponse(),
+		Blockid:  []byte("unknown"),
+	}}}
+	verifier = NewCrossQueryVerifier(unknown, fakeCrossQueryReader{})
+	if _, _, err := verifier.CrossQuery(req, contract.MaxLimits); err == nil {
+		t.Fatal("expect recorded block not found")
+	}
+}
+
+func TestXMCacheBlockRead(t *testing.T) {
+	flushTx := func(mc *XMCache) *lpb.Transaction {
+		if err := mc.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		tx := &lpb.Transaction{}
+		for _, w := range mc.RWSet().WSet {
+			tx.TxOutputsExt = append(tx.TxOutputsExt, &protos.TxOutputExt{
+				Bucket: w.Bucket,
+				Key:    w.Key,
+				Value:  w.Value,
+			})
+		}
+		return tx
+	}
+
+	mc := NewXModelCache(&contract.SandboxConfig{XMReader: NewMemXModel()})
+	if height, err := ParseBlockRead(flushTx(mc)); err != nil || height != -1 {
+		t.Fatalf("expect no block read, got %d, err:%v", height, err)
+	}
+
+	mc = NewXModelCache(&contract.SandboxConfig{XMReader: NewMemXModel()})
+	mc.RecordBlockRead(10)
+	if height, err := ParseBlockRead(flushTx(mc)); err != nil || height != 10 {
+		t.Fatalf("expect block read at height 10, got %d, err:%v", height, err)
+	}
+}
@@ -9,9 +9,42 @@ import (
 type SandboxConfig struct {
 	XMReader   ledger.XMReader
 	UTXOReader UtxoReader
+	// CrossQueryReader 为空时由contract manager使用ChainCore填充
+	CrossQueryReader CrossQueryReader
+	// StorageRent 为空时由contract manager使用链的配置填充
+	StorageRent *StorageRentConfig
+}
+
+// StorageRentConfig 合约存储的配额和租金，来自创世块配置。
+// 合约状态增长时从合约的预付租金中扣除，释放时退还，租金按照gas_price.disk_rate的方式计算，
+// 即每rent_rate个字节收取一个单位，0表示不收取租金
+type StorageRentConfig struct {
+	Enable bool
+	// 每个单位租金对应的存储字节数
+	RentRate int64
+	// 每个合约免租金的存储字节数，平均分配到合约存储记录的各个分片
+	FreeBytes int64
+	// 每个合约最多占用的存储字节数，0表示不限制，平均分配到合约存储记录的各个分片
+	MaxBytes int64
+	// 合约单个key的value最大字节数，0表示不限制
+	MaxValueSize int64
 }
 type UtxoReader interface {
 	SelectUtxo(string, *big.Int, bool, bool) ([]*protos.TxInput, [][]byte, *big.Int, error)
+	// GetBalance 返回地址在最新确认区块的余额，不包含未确认交易，不锁定也不花费utxo
+	GetBalance(string) (*big.Int, error)
+}
+
+// CrossQueryReader 在同一引擎的其他链上按指定高度执行只读查询，
+// 查询合约时使用调用方剩余的资源limits，并返回查询消耗的资源
+type CrossQueryReader interface {
+	CrossQuery(req *protos.CrossQueryRequest, limits Limits) (*protos.CrossQueryResponse, Limits, error)
+}
+
+// CrossQueryBlockReader 在目标链指定区块上重新执行跨链查询，用于验证交易记录的跨链查询
+type CrossQueryBlockReader interface {
+	CrossQueryReader
+	CrossQueryAtBlock(req *protos.CrossQueryRequest, blockid []byte, limits Limits) (*protos.CrossQueryResponse, Limits, error)
 }
 
 // Iterator iterates over key/value pairs in key order
@@ -37,22 +70,34 @@ type XMState interface {
 // XMState 对XuperBridge暴露对账本的UTXO操作能力
 type UTXOState interface {
 	Transfer(from string, to string, amount *big.Int) error
+	// Balance 返回地址在上一个区块确认的余额加上当前交易中的转账，不花费地址的utxo。
+	// 读取的余额记录在交易中，验证时与交易所在区块的父区块状态比对
+	Balance(addr string) (*big.Int, error)
 }
 
-// CrossQueryState 对XuperBridge暴露对跨链只读合约的操作能力
+// CrossQueryState 对XuperBridge暴露对跨链只读合约的操作能力，
+// 查询请求和结果会记录到读写集中，验证节点在相同高度重新查询并比对
 type CrossQueryState interface {
+	CrossQuery(req *protos.CrossQueryRequest, limits Limits) (*protos.ContractResponse, Limits, error)
 }
 
 type ContractEventState interface {
 	AddEvent(events ...*protos.ContractEvent)
 }
 
+// BlockState 记录合约读取了执行区块的信息(高度、时间、区块哈希)，
+// 读取过区块信息的交易只能打包在预执行时的区块高度
+type BlockState interface {
+	RecordBlockRead(height int64)
+}
+
 // State 抽象了链的状态机接口，合约通过State里面的方法来修改状态。
 type State interface {
 	XMState
 	UTXOState
 	CrossQueryState
 	ContractEventState
+	BlockState
 }
 
 // StateSandbox 在沙盒环境里面执行状态修改操作，最终生成读写集
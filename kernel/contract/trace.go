@@ -0,0 +1,33 @@
+package contract
+
+import (
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// NewCallTrace 创建一次合约调用的跟踪节点，ContextConfig.Trace不为空时，
+// bridge会将本次调用的状态读写、事件、日志和嵌套调用记录到该节点
+func NewCallTrace(module, contractName, method string, args map[string][]byte) *protos.CallTrace {
+	return &protos.CallTrace{
+		ModuleName:   module,
+		ContractName: contractName,
+		MethodName:   method,
+		Args:         args,
+	}
+}
+
+// FinishCallTrace 记录合约调用的结果和资源消耗，trace为空时不做任何操作
+func FinishCallTrace(trace *protos.CallTrace, resp *Response, used Limits, err error) {
+	if trace == nil {
+		return
+	}
+	if err != nil {
+		trace.Error = err.Error()
+		return
+	}
+	trace.Response = &protos.ContractResponse{
+		Status:  int32(resp.Status),
+		Message: resp.Message,
+		Body:    resp.Body,
+	}
+	trace.ResourceUsed = ToPbLimits(used)
+}
@@ -12,8 +12,16 @@ var (
 const (
 	contractNameMaxSize = 16
 	contractNameMinSize = 4
+
+	// ContractStatusFrozen 合约被冻结，冻结期间不能调用合约
+	ContractStatusFrozen = "frozen"
 )
 
+// ContractStatusKey 合约bucket中保存合约生命周期状态的key，不存在表示合约正常
+func ContractStatusKey(contractName string) []byte {
+	return []byte(contractName + "." + "status")
+}
+
 // ValidContractName return error when contractName is not a valid contract name.
 func ValidContractName(contractName string) error {
 	// param absence check
@@ -1,10 +1,17 @@
 package agent
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract"
 	"github.com/xuperchain/xupercore/kernel/contract/bridge/pb"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
 	"github.com/xuperchain/xupercore/kernel/ledger"
 	"github.com/xuperchain/xupercore/lib/logs"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 type ChainCoreAgent struct {
@@ -34,6 +41,11 @@ func (t *ChainCoreAgent) VerifyContractOwnerPermission(contractName string, auth
 	return t.chainCtx.State.VerifyContractOwnerPermission(contractName, authRequire)
 }
 
+// 验证authRequire是否满足账户acl
+func (t *ChainCoreAgent) VerifyAccountPermission(accountName string, authRequire []string) error {
+	return t.chainCtx.State.VerifyAccountPermission(accountName, authRequire)
+}
+
 // QueryTransaction query confirmed tx
 func (t *ChainCoreAgent) QueryTransaction(txid []byte) (*pb.Transaction, error) {
 	return t.chainCtx.State.QueryTransaction(txid)
@@ -43,3 +55,171 @@ func (t *ChainCoreAgent) QueryTransaction(txid []byte) (*pb.Transaction, error)
 func (t *ChainCoreAgent) QueryBlock(blockid []byte) (ledger.BlockHandle, error) {
 	return t.chainCtx.State.QueryBlock(blockid)
 }
+
+// QueryBlockByHeight query block on trunk by height
+func (t *ChainCoreAgent) QueryBlockByHeight(height int64) (ledger.BlockHandle, error) {
+	return t.chainCtx.State.QueryBlockByHeight(height)
+}
+
+// CrossQuery 在同一引擎的其他链上按指定高度执行只读查询
+// 只允许查询目标链不可逆高度以内的区块，保证各节点查询到的是同一个区块
+func (t *ChainCoreAgent) CrossQuery(req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	chainCtx, err := t.crossQueryChain(req)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	block, err := chainCtx.Ledger.QueryBlockHeaderByHeight(req.GetHeight())
+	if err != nil {
+		return nil, contract.Limits{}, fmt.Errorf("query block at height %d failed: %v", req.GetHeight(), err)
+	}
+	return t.crossQueryAt(chainCtx, req, block, limits)
+}
+
+// CrossQueryAtBlock 验证交易时在交易记录的区块上重新执行跨链查询
+// 记录的区块必须在本地目标链存在且高度与请求一致；本地已不可逆的高度上，记录的区块必须是主干区块
+func (t *ChainCoreAgent) CrossQueryAtBlock(req *protos.CrossQueryRequest, blockid []byte,
+	limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	chainCtx, err := t.crossQueryChain(req)
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	block, err := chainCtx.Ledger.QueryBlockHeader(blockid)
+	if err != nil {
+		return nil, contract.Limits{}, fmt.Errorf("query recorded block %x of chain %s failed: %v",
+			blockid, req.GetChainName(), err)
+	}
+	if block.GetHeight() != req.GetHeight() || !block.GetInTrunk() {
+		return nil, contract.Limits{}, fmt.Errorf("recorded block %x is not the trunk block at height %d of chain %s",
+			blockid, req.GetHeight(), req.GetChainName())
+	}
+	return t.crossQueryAt(chainCtx, req, block, limits)
+}
+
+// crossQueryChain 获取跨链查询的目标链，并检查查询高度不超过目标链的不可逆高度
+func (t *ChainCoreAgent) crossQueryChain(req *protos.CrossQueryRequest) (*common.ChainCtx, error) {
+	if req.GetChainName() == t.chainCtx.BCName {
+		return nil, errors.New("cross query to current chain is not permitted")
+	}
+	if t.chainCtx.EngCtx == nil || t.chainCtx.EngCtx.ChainM == nil {
+		return nil, errors.New("chain manager not ready")
+	}
+	chain, err := t.chainCtx.EngCtx.ChainM.Get(req.GetChainName())
+	if err != nil {
+		return nil, fmt.Errorf("get chain %s failed: %v", req.GetChainName(), err)
+	}
+	chainCtx := chain.Context()
+
+	// 可逆区块在不同节点上可能属于不同分支，查询结果会依赖各节点的同步状态
+	irreversibleHeight := chainCtx.State.GetMeta().GetIrreversibleBlockHeight()
+	if req.GetHeight() > irreversibleHeight {
+		return nil, fmt.Errorf("cross query height %d exceeds irreversible height %d of chain %s",
+			req.GetHeight(), irreversibleHeight, req.GetChainName())
+	}
+	return chainCtx, nil
+}
+
+func (t *ChainCoreAgent) crossQueryAt(chainCtx *common.ChainCtx, req *protos.CrossQueryRequest,
+	block *xldgpb.InternalBlock, limits contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	snapshot, err := chainCtx.State.CreateSnapshot(block.GetBlockid())
+	if err != nil {
+		return nil, contract.Limits{}, fmt.Errorf("create snapshot at height %d failed: %v", req.GetHeight(), err)
+	}
+
+	var resp *protos.ContractResponse
+	var used contract.Limits
+	if req.GetBucket() != "" {
+		resp, err = t.crossQueryKey(snapshot, req)
+	} else {
+		resp, used, err = t.crossQueryContract(chainCtx, snapshot, block, req, limits)
+	}
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	return &protos.CrossQueryResponse{
+		Response: resp,
+		Blockid:  block.GetBlockid(),
+	}, used, nil
+}
+
+func (t *ChainCoreAgent) crossQueryKey(snapshot ledger.XMReader,
+	req *protos.CrossQueryRequest) (*protos.ContractResponse, error) {
+	verData, err := snapshot.Get(req.GetBucket(), req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	value := verData.GetPureData().GetValue()
+	if sandbox.IsEmptyVersionedData(verData) || sandbox.IsDelFlag(value) {
+		return &protos.ContractResponse{
+			Status:  contract.StatusError,
+			Message: sandbox.ErrNotFound.Error(),
+		}, nil
+	}
+	return &protos.ContractResponse{
+		Status: contract.StatusOK,
+		Body:   value,
+	}, nil
+}
+
+// crossQueryContract 在查询的区块状态上执行只读合约，使用调用方剩余的资源limits，
+// 合约代码从同一个快照读取，合约按在查询的区块中执行
+func (t *ChainCoreAgent) crossQueryContract(chainCtx *common.ChainCtx, snapshot ledger.XMReader,
+	block *xldgpb.InternalBlock, req *protos.CrossQueryRequest,
+	limits contract.Limits) (*protos.ContractResponse, contract.Limits, error) {
+	invokeReq := req.GetRequest()
+	// 跨链查询不允许嵌套，也不允许转账
+	state, err := chainCtx.Contract.NewStateSandbox(&contract.SandboxConfig{
+		XMReader:         snapshot,
+		UTXOReader:       sandbox.NewUTXOReaderFromInput(nil),
+		CrossQueryReader: noCrossQueryReader{},
+	})
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	blockHeight, blockTime, blockPreHash, err := chainCtx.State.ExecBlock(block.GetBlockid())
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	ctx, err := chainCtx.Contract.NewContext(&contract.ContextConfig{
+		State:          state,
+		Initiator:      req.GetInitiator(),
+		AuthRequire:    req.GetAuthRequire(),
+		Module:         invokeReq.GetModuleName(),
+		ContractName:   invokeReq.GetContractName(),
+		ResourceLimits: limits,
+		BlockHeight:    blockHeight,
+		BlockTime:      blockTime,
+		BlockPreHash:   blockPreHash,
+
+		ContractCodeFromState: true,
+	})
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	defer ctx.Release()
+
+	resp, err := ctx.Invoke(invokeReq.GetMethodName(), invokeReq.GetArgs())
+	if err != nil {
+		return nil, contract.Limits{}, err
+	}
+	if len(state.RWSet().WSet) != 0 || len(state.UTXORWSet().WSet) != 0 {
+		return nil, contract.Limits{}, errors.New("cross query contract must be read-only")
+	}
+	return &protos.ContractResponse{
+		Status:  int32(resp.Status),
+		Message: resp.Message,
+		Body:    resp.Body,
+	}, ctx.ResourceUsed(), nil
+}
+
+type noCrossQueryReader struct{}
+
+func (noCrossQueryReader) CrossQuery(*protos.CrossQueryRequest,
+	contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	return nil, contract.Limits{}, errors.New("nested cross query is not permitted")
+}
+
+func (noCrossQueryReader) CrossQueryAtBlock(*protos.CrossQueryRequest, []byte,
+	contract.Limits) (*protos.CrossQueryResponse, contract.Limits, error) {
+	return nil, contract.Limits{}, errors.New("nested cross query is not permitted")
+}
@@ -145,12 +145,12 @@ func (t *LedgerAgent) GetTipXMSnapshotReader() (kledger.XMSnapshotReader, error)
 	return t.chainCtx.State.GetTipXMSnapshotReader()
 }
 
-// 根据指定blockid创建快照（Select方法不可用）
+// 根据指定blockid创建快照
 func (t *LedgerAgent) CreateSnapshot(blkId []byte) (kledger.XMReader, error) {
 	return t.chainCtx.State.CreateSnapshot(blkId)
 }
 
-// 获取最新确认高度快照（Select方法不可用）
+// 获取最新确认高度快照
 func (t *LedgerAgent) GetTipSnapshot() (kledger.XMReader, error) {
 	return t.chainCtx.State.GetTipSnapshot()
 }
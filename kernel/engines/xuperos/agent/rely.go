@@ -137,6 +137,40 @@ func (t *ChainRelyAgentImpl) CreateContract(xmreader kledger.XMReader) (contract
 		Core:     NewChainCoreAgent(ctx),
 		XMReader: xmreader,
 	}
+	if rent := ctx.Ledger.GenesisBlock.GetConfig().StorageRent; rent.Enable {
+		mgCfg.StorageRent = &contract.StorageRentConfig{
+			Enable:       rent.Enable,
+			RentRate:     rent.RentRate,
+			FreeBytes:    rent.FreeBytes,
+			MaxBytes:     rent.MaxBytes,
+			MaxValueSize: rent.MaxValueSize,
+		}
+	}
+	if fork := ctx.Ledger.GenesisBlock.GetConfig().ContractVersion; fork.Enable {
+		mgCfg.ContractVersion = &contract.ForkConfig{
+			Height: fork.Height,
+		}
+	}
+	if fork := ctx.Ledger.GenesisBlock.GetConfig().EvmEventJSON; fork.Enable {
+		mgCfg.EvmEventJSON = &contract.ForkConfig{
+			Height: fork.Height,
+		}
+	}
+	if fork := ctx.Ledger.GenesisBlock.GetConfig().NativeGas; fork.Enable {
+		mgCfg.NativeGas = &contract.ForkConfig{
+			Height: fork.Height,
+		}
+	}
+	if fork := ctx.Ledger.GenesisBlock.GetConfig().EvmAccount; fork.Enable {
+		mgCfg.EvmAccount = &contract.ForkConfig{
+			Height: fork.Height,
+		}
+	}
+	if fork := ctx.Ledger.GenesisBlock.GetConfig().EvmBlockContext; fork.Enable {
+		mgCfg.EvmBlockContext = &contract.ForkConfig{
+			Height: fork.Height,
+		}
+	}
 	contractObj, err := contract.CreateManager("default", mgCfg)
 	if err != nil {
 		return nil, fmt.Errorf("create contract manager failed.err:%v", err)
@@ -160,6 +160,10 @@ func (aw *AsyncWorkerImpl) Start() (err error) {
 				aw.log.Error("couldn't do async task because of eventType error", "have", eventType, "want", protos.SubscribeType_BLOCK)
 				break
 			}
+			// 已经执行的异步任务无法撤销，忽略主干切换产生的回滚事件
+			if iter.Reverted() {
+				continue
+			}
 			// 当且仅当断点有效，且当前高度为断点存储高度时，需要过滤部分已做异步任务
 			if cursor != nil && block.BlockHeight == cursor.BlockHeight {
 				aw.doAsyncTasks(block.Txs, block.BlockHeight, cursor)
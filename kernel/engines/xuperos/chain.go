@@ -96,10 +96,35 @@ func (t *Chain) SetRelyAgent(agent common.ChainRelyAgent) error {
 
 // 阻塞
 func (t *Chain) Start() {
+	// 后台预热合约代码缓存，不阻塞矿工启动
+	go t.warmUpContractCache()
 	// 启动矿工
 	t.miner.Start()
 }
 
+// warmUpContractCache 预热本地调用次数最多的合约的代码缓存，避免重启后首次调用时编译合约
+func (t *Chain) warmUpContractCache() {
+	ctx := t.ctx
+	statData, err := ctx.State.QueryContractStatData()
+	if err != nil {
+		t.log.Warn("query contract stat data failed when warm up contract cache", "err", err)
+		return
+	}
+	contractNames := make([]string, 0, len(statData.GetHotContracts()))
+	for _, stat := range statData.GetHotContracts() {
+		contractNames = append(contractNames, stat.GetContractName())
+	}
+	if len(contractNames) == 0 {
+		return
+	}
+	begin := time.Now()
+	err = ctx.Contract.WarmUpCache(contractNames)
+	if err != nil {
+		t.log.Warn("warm up contract cache failed", "err", err)
+	}
+	t.log.Info("warm up contract cache done", "contracts", len(contractNames), "cost", time.Since(begin))
+}
+
 func (t *Chain) Stop() {
 	// 停止矿工等其余组件
 	t.miner.Stop()
@@ -116,6 +141,22 @@ func (t *Chain) Context() *common.ChainCtx {
 
 // 交易预执行
 func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiator string, authRequires []string) (*protos.InvokeResponse, error) {
+	return t.preExec(ctx, reqs, initiator, authRequires, false, false)
+}
+
+// 跟踪模式的合约预执行，除了合并后的读写集，还返回每个请求的调用树，
+// 调用树的节点记录了每次调用自身的状态读写、事件、日志和资源消耗
+func (t *Chain) PreExecWithTrace(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiator string, authRequires []string) (*protos.InvokeResponse, error) {
+	return t.preExec(ctx, reqs, initiator, authRequires, true, false)
+}
+
+// 调试模式的合约预执行，节点开启了合约调试器时，调试白名单中的合约可以被调试器暂停
+func (t *Chain) PreExecWithDebug(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiator string, authRequires []string) (*protos.InvokeResponse, error) {
+	return t.preExec(ctx, reqs, initiator, authRequires, false, true)
+}
+
+func (t *Chain) preExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiator string,
+	authRequires []string, needTrace, debug bool) (*protos.InvokeResponse, error) {
 	if ctx == nil || ctx.GetLog() == nil {
 		return nil, common.ErrParameter
 	}
@@ -146,11 +187,22 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 		return nil, common.ErrContractNewSandboxFailed
 	}
 
+	// 预执行的交易将被打包到下一个区块
+	blockHeight, blockTime, blockPreHash, err := t.ctx.State.PendingBlock()
+	if err != nil {
+		t.log.Error("PreExec get pending block error", "error", err)
+		return nil, common.ErrInternal.More("%v", err)
+	}
 	contextConfig := &contract.ContextConfig{
 		State:          sandbox,
 		Initiator:      initiator,
 		AuthRequire:    authRequires,
 		ResourceLimits: contract.MaxLimits,
+		Debug:          debug,
+		PreExec:        true,
+		BlockHeight:    blockHeight,
+		BlockTime:      blockTime,
+		BlockPreHash:   blockPreHash,
 	}
 
 	gasPrice := t.ctx.State.GetMeta().GetGasPrice()
@@ -158,6 +210,7 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 	responseBodes := make([][]byte, 0, len(reqs))
 	requests := make([]*protos.InvokeRequest, 0, len(reqs))
 	responses := make([]*protos.ContractResponse, 0, len(reqs))
+	var traces []*protos.CallTrace
 	for i, req := range reqs {
 		if req == nil {
 			continue
@@ -176,10 +229,16 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 		} else {
 			contextConfig.TransferAmount = ""
 		}
+		contextConfig.Trace = nil
+		if needTrace {
+			contextConfig.Trace = contract.NewCallTrace(req.ModuleName, req.ContractName, req.MethodName, req.Args)
+			traces = append(traces, contextConfig.Trace)
+		}
 
 		context, err := t.ctx.Contract.NewContext(contextConfig)
 		if err != nil {
 			ctx.GetLog().Error("PreExec NewContext error", "error", err, "contractName", req.ContractName)
+			contract.FinishCallTrace(contextConfig.Trace, nil, contract.Limits{}, err)
 			if i < len(reservedRequests) && strings.HasSuffix(err.Error(), "not found") {
 				requests = append(requests, req)
 				continue
@@ -204,6 +263,7 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 
 		metrics.ContractInvokeCounter.WithLabelValues(t.ctx.BCName, req.ModuleName, req.ContractName, req.MethodName, "OK").Inc()
 		resourceUsed := context.ResourceUsed()
+		contract.FinishCallTrace(contextConfig.Trace, resp, resourceUsed, nil)
 		if i >= len(reservedRequests) {
 			gasUsed += resourceUsed.TotalGas(gasPrice)
 		}
@@ -242,11 +302,65 @@ func (t *Chain) PreExec(ctx xctx.XContext, reqs []*protos.InvokeRequest, initiat
 		Responses:   responses,
 		UtxoInputs:  utxoRWSet.Rset,
 		UtxoOutputs: utxoRWSet.WSet,
+		Traces:      traces,
 	}
 
 	return invokeResponse, nil
 }
 
+// 只读合约查询，不构造沙盒读写集和utxo，合约中的写操作都会失败
+// blockid为空时在状态机最新确认的快照上查询，否则在指定区块的快照上查询
+func (t *Chain) QueryContract(ctx xctx.XContext, req *protos.InvokeRequest, initiator string,
+	authRequires []string, blockid []byte) (*protos.ContractResponse, error) {
+	if ctx == nil || ctx.GetLog() == nil || req == nil || req.GetContractName() == "" {
+		return nil, common.ErrParameter
+	}
+
+	queryTip := len(blockid) == 0
+	if queryTip {
+		blockid = t.ctx.State.GetLatestBlockid()
+	}
+	reader, err := t.ctx.State.CreateSnapshot(blockid)
+	if err != nil {
+		ctx.GetLog().Warn("QueryContract create snapshot error", "error", err, "blockid", utils.F(blockid))
+		return nil, common.ErrBlockNotExist.More("%v", err)
+	}
+	// 合约按在查询的区块中执行，区块信息与状态一致
+	blockHeight, blockTime, blockPreHash, err := t.ctx.State.ExecBlock(blockid)
+	if err != nil {
+		ctx.GetLog().Warn("QueryContract query block error", "error", err, "blockid", utils.F(blockid))
+		return nil, common.ErrBlockNotExist.More("%v", err)
+	}
+
+	beginTime := time.Now()
+	queryConfig := &contract.QueryConfig{
+		XMReader:     reader,
+		Initiator:    initiator,
+		AuthRequire:  authRequires,
+		BlockHeight:  blockHeight,
+		BlockTime:    blockTime,
+		BlockPreHash: blockPreHash,
+	}
+	// utxo没有历史快照，只有在最新状态上查询时合约可以读取余额
+	if queryTip {
+		queryConfig.UTXOReader = t.ctx.State.CreateUtxoReader()
+	}
+	resp, err := t.ctx.Contract.Query(queryConfig, req)
+	if err != nil {
+		ctx.GetLog().Error("QueryContract Invoke error", "error", err, "contractName", req.ContractName)
+		metrics.ContractInvokeCounter.WithLabelValues(t.ctx.BCName, req.ModuleName, req.ContractName, req.MethodName, "InvokeError").Inc()
+		return nil, common.ErrContractInvokeFailed.More("%v", err)
+	}
+	metrics.ContractInvokeCounter.WithLabelValues(t.ctx.BCName, req.ModuleName, req.ContractName, req.MethodName, "OK").Inc()
+	metrics.ContractInvokeHistogram.WithLabelValues(t.ctx.BCName, req.ModuleName, req.ContractName, req.MethodName).Observe(time.Since(beginTime).Seconds())
+
+	return &protos.ContractResponse{
+		Status:  int32(resp.Status),
+		Message: resp.Message,
+		Body:    resp.Body,
+	}, nil
+}
+
 // 提交交易到交易池(xuperos引擎同时更新到状态机和交易池)
 func (t *Chain) SubmitTx(ctx xctx.XContext, tx *lpb.Transaction) error {
 	if tx == nil || ctx == nil || ctx.GetLog() == nil || len(tx.GetTxid()) <= 0 {
@@ -374,6 +488,8 @@ func (t *Chain) initChainCtx() error {
 	t.ctx.Contract = contractObj
 	// 设置合约manager到状态机
 	t.ctx.State.SetContractMG(t.ctx.Contract)
+	// 设置跨链查询到状态机，验证交易时重新执行记录的跨链查询
+	t.ctx.State.SetCrossQueryReader(agent.NewChainCoreAgent(t.ctx))
 	t.log.Trace("create contract manager succ", "bcName", t.ctx.BCName)
 
 	// 6.Acl
@@ -100,6 +100,8 @@ var (
 	ErrContractNewCtxFailed     = &Error{ErrStatusInternalErr, 50500, "contract new context failed"}
 	ErrContractInvokeFailed     = &Error{ErrStatusInternalErr, 50501, "contract invoke failed"}
 	ErrContractNewSandboxFailed = &Error{ErrStatusInternalErr, 50502, "contract new sandbox failed"}
+	ErrContractVersionNotExist  = &Error{ErrStatusInternalErr, 50503, "contract version not exist"}
+	ErrEventIndexDisabled       = &Error{ErrStatusInternalErr, 50504, "contract event index disabled"}
 
 	// net
 	ErrNewNetEventFailed = &Error{ErrStatusInternalErr, 50600, "new net event failed"}
@@ -28,6 +28,12 @@ type Chain interface {
 	Stop()
 	// 合约预执行
 	PreExec(xctx.XContext, []*protos.InvokeRequest, string, []string) (*protos.InvokeResponse, error)
+	// 跟踪模式的合约预执行，返回每个请求的调用树
+	PreExecWithTrace(xctx.XContext, []*protos.InvokeRequest, string, []string) (*protos.InvokeResponse, error)
+	// 调试模式的合约预执行，合约可以被节点的调试器暂停
+	PreExecWithDebug(xctx.XContext, []*protos.InvokeRequest, string, []string) (*protos.InvokeResponse, error)
+	// 只读合约查询，blockid为空时查询最新确认状态
+	QueryContract(xctx.XContext, *protos.InvokeRequest, string, []string, []byte) (*protos.ContractResponse, error)
 	// 提交交易
 	SubmitTx(xctx.XContext, *lpb.Transaction) error
 	// 处理新区块
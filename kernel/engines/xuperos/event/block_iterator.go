@@ -1,11 +1,14 @@
 package event
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
 	pb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
 )
 
 var _ Iterator = (*BlockIterator)(nil)
@@ -16,6 +19,12 @@ type BlockIterator struct {
 	endNum     int64
 	blockStore BlockStore
 	block      *pb.InternalBlock
+	reverted   bool
+
+	// 最近一个推送的主干区块，用于发现主干切换
+	last *pb.InternalBlock
+	// 主干切换后待推送的回滚区块，按高度从高到低排列
+	reverts []*pb.InternalBlock
 
 	closed bool
 	err    error
@@ -29,23 +38,107 @@ func NewBlockIterator(blockStore BlockStore, startNum, endNum int64) *BlockItera
 	}
 }
 
-func (b *BlockIterator) Next() bool {
-	if b.closed && b.err != nil {
-		return false
+// newCursorBlockIterator make a BlockIterator which resumes from the block of cursor.
+// 游标所在区块仍在主干上时从该区块开始推送，否则先推送该区块及其分叉上祖先区块的回滚事件
+func newCursorBlockIterator(blockStore BlockStore, cursor *protos.EventCursor, endNum int64) (*BlockIterator, error) {
+	block, err := blockStore.QueryBlock(cursor.GetBlockid())
+	if err != nil {
+		return nil, fmt.Errorf("query cursor block error: %s", err)
 	}
-	if b.endNum != -1 && b.currNum >= b.endNum {
-		return false
+	if block.GetHeight() != cursor.GetBlockHeight() {
+		return nil, fmt.Errorf("bad cursor, block height mismatch")
 	}
 
-	block, err := b.fetchBlock(b.currNum)
+	b := NewBlockIterator(blockStore, block.GetHeight(), endNum)
+	inTrunk, err := b.inTrunk(block)
 	if err != nil {
-		b.err = err
+		return nil, err
+	}
+	if !inTrunk {
+		b.last = block
+		return b, b.rollback()
+	}
+	if block.GetHeight() > 0 {
+		b.last, err = blockStore.QueryBlock(block.GetPreHash())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (b *BlockIterator) Next() bool {
+	if b.closed || b.err != nil {
 		return false
 	}
+	for {
+		if len(b.reverts) > 0 {
+			b.block = b.reverts[0]
+			b.reverts = b.reverts[1:]
+			b.reverted = true
+			return true
+		}
+
+		if b.endNum != -1 && b.currNum >= b.endNum {
+			return false
+		}
+
+		block, err := b.fetchBlock(b.currNum)
+		if err != nil {
+			b.err = err
+			return false
+		}
+
+		// 新区块不是上一个区块的后继，说明主干发生了切换
+		// 新主干的高度超过已推送的区块之后才能发现主干切换
+		if b.last != nil && !bytes.Equal(block.GetPreHash(), b.last.GetBlockid()) {
+			err = b.rollback()
+			if err != nil {
+				b.err = err
+				return false
+			}
+			continue
+		}
+
+		b.block = block
+		b.reverted = false
+		b.last = block
+		b.currNum += 1
+		return true
+	}
+}
+
+// rollback 从最近推送的区块向前回溯到主干上的公共祖先，记录需要回滚的区块
+func (b *BlockIterator) rollback() error {
+	block := b.last
+	for {
+		inTrunk, err := b.inTrunk(block)
+		if err != nil {
+			return err
+		}
+		if inTrunk {
+			break
+		}
+		b.reverts = append(b.reverts, block)
+		block, err = b.blockStore.QueryBlock(block.GetPreHash())
+		if err != nil {
+			return fmt.Errorf("query reverted block error: %s", err)
+		}
+	}
+	b.last = block
+	b.currNum = block.GetHeight() + 1
+	return nil
+}
 
-	b.block = block
-	b.currNum += 1
-	return true
+func (b *BlockIterator) inTrunk(block *pb.InternalBlock) (bool, error) {
+	trunkBlock, err := b.blockStore.QueryBlockByHeight(block.GetHeight())
+	if err == ledger.ErrBlockNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(trunkBlock.GetBlockid(), block.GetBlockid()), nil
 }
 
 func (b *BlockIterator) fetchBlock(num int64) (*pb.InternalBlock, error) {
@@ -73,6 +166,16 @@ func (b *BlockIterator) Data() interface{} {
 	return b.Block()
 }
 
+// Reverted reports whether current block is rolled back from trunk
+func (b *BlockIterator) Reverted() bool {
+	return b.reverted
+}
+
+// Cursor returns the cursor of current block
+func (b *BlockIterator) Cursor() []byte {
+	return makeCursor(b.block, -1, -1, b.reverted)
+}
+
 func (b *BlockIterator) Error() error {
 	return b.err
 }
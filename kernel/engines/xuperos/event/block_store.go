@@ -23,6 +23,10 @@ type BlockStore interface {
 	WaitBlockHeight(target int64) int64
 	// QueryBlockByHeight returns block at given height
 	QueryBlockByHeight(int64) (*pb.InternalBlock, error)
+	// QueryBlockByTxid returns the block header which contains the confirmed tx
+	QueryBlockByTxid(txid []byte) (*pb.InternalBlock, error)
+	// QueryBlock returns block by blockid, including blocks on branch
+	QueryBlock(blockid []byte) (*pb.InternalBlock, error)
 }
 
 type chainManager struct {
@@ -66,3 +70,11 @@ func (b *blockStore) TipBlockHeight() (int64, error) {
 	}
 	return block.GetHeight(), nil
 }
+
+func (b *blockStore) QueryBlock(blockid []byte) (*pb.InternalBlock, error) {
+	return b.Ledger.QueryBlock(blockid)
+}
+
+func (b *blockStore) QueryBlockByHeight(height int64) (*pb.InternalBlock, error) {
+	return b.Ledger.QueryBlockByHeight(height)
+}
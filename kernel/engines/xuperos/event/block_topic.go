@@ -29,7 +29,7 @@ func (b *BlockTopic) NewFilterIterator(pbfilter *protos.BlockFilter) (Iterator,
 	if err != nil {
 		return nil, err
 	}
-	return b.newIterator(filter)
+	return b.newIterator(filter, nil)
 }
 
 // ParseFilter 从指定的bytes buffer反序列化topic过滤器
@@ -52,6 +52,12 @@ func (b *BlockTopic) MarshalEvent(x interface{}) ([]byte, error) {
 
 // NewIterator make a new Iterator base on filter
 func (b *BlockTopic) NewIterator(ifilter interface{}) (Iterator, error) {
+	return b.NewCursorIterator(ifilter, nil)
+}
+
+// NewCursorIterator make a new Iterator base on filter,
+// non-empty cursor resumes from the event after cursor
+func (b *BlockTopic) NewCursorIterator(ifilter interface{}, buf []byte) (Iterator, error) {
 	pbfilter, ok := ifilter.(*protos.BlockFilter)
 	if !ok {
 		return nil, errors.New("bad filter type for block event")
@@ -60,44 +66,60 @@ func (b *BlockTopic) NewIterator(ifilter interface{}) (Iterator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return b.newIterator(filter)
+	cursor, err := parseCursor(buf)
+	if err != nil {
+		return nil, err
+	}
+	return b.newIterator(filter, cursor)
 }
 
-func (b *BlockTopic) newIterator(filter *blockFilter) (Iterator, error) {
+func (b *BlockTopic) newIterator(filter *blockFilter, cursor *protos.EventCursor) (Iterator, error) {
 
 	blockStore, err := b.chainmg.GetBlockStore(filter.GetBcname())
 	if err != nil {
 		return nil, err
 	}
 
+	biter, err := newRangeBlockIterator(blockStore, filter.GetRange(), cursor)
+	if err != nil {
+		return nil, err
+	}
+	return newFilteredBlockIterator(biter, filter, cursor), nil
+}
+
+// newRangeBlockIterator make a BlockIterator base on BlockRange,
+// empty start means tip block, empty end means no end.
+// 游标非空时忽略start，从游标所在区块开始迭代
+func newRangeBlockIterator(blockStore BlockStore, blockRange *protos.BlockRange,
+	cursor *protos.EventCursor) (*BlockIterator, error) {
 	var startBlockNum, endBlockNum int64
-	if filter.GetRange().GetStart() == "" {
+	if blockRange.GetEnd() == "" {
+		endBlockNum = -1
+	} else {
+		n, err := strconv.ParseInt(blockRange.GetEnd(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error %s when parse end block number", err)
+		}
+		endBlockNum = n
+	}
+
+	if cursor != nil {
+		return newCursorBlockIterator(blockStore, cursor, endBlockNum)
+	}
+
+	if blockRange.GetStart() == "" {
 		n, err := blockStore.TipBlockHeight()
 		if err != nil {
 			return nil, err
 		}
 		startBlockNum = n
 	} else {
-		n, err := strconv.ParseInt(filter.GetRange().GetStart(), 10, 64)
+		n, err := strconv.ParseInt(blockRange.GetStart(), 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("error %s when parse start block number", err)
 		}
 		startBlockNum = n
 	}
 
-	if filter.GetRange().GetEnd() == "" {
-		endBlockNum = -1
-	} else {
-		n, err := strconv.ParseInt(filter.GetRange().GetEnd(), 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error %s when parse end block number", err)
-		}
-		endBlockNum = n
-	}
-
-	biter := NewBlockIterator(blockStore, startBlockNum, endBlockNum)
-	return &filteredBlockIterator{
-		biter:  biter,
-		filter: filter,
-	}, nil
+	return NewBlockIterator(blockStore, startBlockNum, endBlockNum), nil
 }
@@ -0,0 +1,86 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+// parseCursor 反序列化订阅请求中的游标，空游标返回nil
+func parseCursor(buf []byte) (*protos.EventCursor, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	cursor := new(protos.EventCursor)
+	err := proto.Unmarshal(buf, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("parse cursor error: %s", err)
+	}
+	if len(cursor.GetBlockid()) == 0 {
+		return nil, fmt.Errorf("parse cursor error: empty blockid")
+	}
+	return cursor, nil
+}
+
+// makeCursor 序列化区块内指定位置事件的游标
+func makeCursor(block *lpb.InternalBlock, txIndex, eventIndex int32, revert bool) []byte {
+	cursor := &protos.EventCursor{
+		BlockHeight: block.GetHeight(),
+		Blockid:     block.GetBlockid(),
+		TxIndex:     txIndex,
+		EventIndex:  eventIndex,
+		Revert:      revert,
+	}
+	buf, _ := proto.Marshal(cursor)
+	return buf
+}
+
+// resumePoint 记录订阅者在游标所在区块内已经处理到的位置
+type resumePoint struct {
+	cursor *protos.EventCursor
+}
+
+func newResumePoint(cursor *protos.EventCursor) *resumePoint {
+	if cursor == nil {
+		return nil
+	}
+	return &resumePoint{
+		cursor: cursor,
+	}
+}
+
+// forBlock 返回block对应的resumePoint，不是游标所在的区块时返回nil
+func (r *resumePoint) forBlock(block *lpb.InternalBlock) *resumePoint {
+	if r == nil || !bytes.Equal(r.cursor.GetBlockid(), block.GetBlockid()) {
+		return nil
+	}
+	return r
+}
+
+// skip 返回区块内指定位置的事件是否需要跳过
+// 正常区块跳过订阅者已经处理过的事件，回滚区块跳过订阅者没有处理过的事件
+func (r *resumePoint) skip(reverted bool, txIndex, eventIndex int32) bool {
+	if r == nil {
+		return false
+	}
+	applied := r.applied(txIndex, eventIndex)
+	if reverted {
+		return !applied
+	}
+	return applied
+}
+
+func (r *resumePoint) applied(txIndex, eventIndex int32) bool {
+	cursor := r.cursor
+	if txIndex != cursor.GetTxIndex() {
+		return txIndex < cursor.GetTxIndex()
+	}
+	if eventIndex != cursor.GetEventIndex() {
+		return eventIndex < cursor.GetEventIndex()
+	}
+	// 游标指向回滚事件时，订阅者已经撤销了该事件
+	return !cursor.GetRevert()
+}
@@ -0,0 +1,281 @@
+package event
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+type blockEvent struct {
+	blockid string
+	revert  bool
+}
+
+func readBlockEvents(t *testing.T, iter Iterator, n int) ([]blockEvent, []byte) {
+	var events []blockEvent
+	var cursor []byte
+	for i := 0; i < n; i++ {
+		if !iter.Next() {
+			t.Fatalf("expect %d events got %d, error:%v", n, i, iter.Error())
+		}
+		fblock := iter.Data().(*protos.FilteredBlock)
+		events = append(events, blockEvent{
+			blockid: fblock.GetBlockid(),
+			revert:  iter.Reverted(),
+		})
+		cursor = iter.Cursor()
+	}
+	return events, cursor
+}
+
+func checkBlockEvents(t *testing.T, events []blockEvent, expect []blockEvent) {
+	if len(events) != len(expect) {
+		t.Fatalf("expect %d events got %d", len(expect), len(events))
+	}
+	for i := range expect {
+		if events[i] != expect[i] {
+			t.Errorf("event %d: expect %v got %v", i, expect[i], events[i])
+		}
+	}
+}
+
+func hexid(block *lpb.InternalBlock) string {
+	return hex.EncodeToString(block.GetBlockid())
+}
+
+func TestBlockTopicResumeFromCursor(t *testing.T) {
+	ledger := newMockBlockStore()
+	var blocks []*lpb.InternalBlock
+	for i := 0; i < 4; i++ {
+		blocks = append(blocks, ledger.AppendBlock(newBlockBuilder().Block()))
+	}
+
+	topic := NewBlockTopic(ledger)
+	filter := &protos.BlockFilter{
+		Range: &protos.BlockRange{
+			Start: "0",
+			End:   "4",
+		},
+	}
+	iter, err := topic.NewIterator(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, cursor := readBlockEvents(t, iter, 2)
+	iter.Close()
+
+	iter, err = topic.NewCursorIterator(filter, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	events, _ := readBlockEvents(t, iter, 2)
+	checkBlockEvents(t, events, []blockEvent{
+		{hexid(blocks[2]), false},
+		{hexid(blocks[3]), false},
+	})
+	if iter.Next() {
+		t.Error("expect iterator end")
+	}
+}
+
+func TestBlockTopicRevert(t *testing.T) {
+	ledger := newMockBlockStore()
+	var blocks []*lpb.InternalBlock
+	for i := 0; i < 3; i++ {
+		blocks = append(blocks, ledger.AppendBlock(newBlockBuilder().Block()))
+	}
+
+	topic := NewBlockTopic(ledger)
+	iter, err := topic.NewIterator(&protos.BlockFilter{
+		Range: &protos.BlockRange{
+			Start: "0",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	readBlockEvents(t, iter, 3)
+
+	// 新主干的高度超过已推送的区块后才能发现主干切换
+	ledger.SwitchTrunk(0)
+	block1 := ledger.AppendBlock(newBlockBuilder().Block())
+	block2 := ledger.AppendBlock(newBlockBuilder().Block())
+	ledger.AppendBlock(newBlockBuilder().Block())
+
+	events, _ := readBlockEvents(t, iter, 4)
+	checkBlockEvents(t, events, []blockEvent{
+		{hexid(blocks[2]), true},
+		{hexid(blocks[1]), true},
+		{hexid(block1), false},
+		{hexid(block2), false},
+	})
+}
+
+func TestBlockTopicResumeFromRevertedCursor(t *testing.T) {
+	ledger := newMockBlockStore()
+	var blocks []*lpb.InternalBlock
+	for i := 0; i < 3; i++ {
+		blocks = append(blocks, ledger.AppendBlock(newBlockBuilder().Block()))
+	}
+
+	topic := NewBlockTopic(ledger)
+	filter := &protos.BlockFilter{
+		Range: &protos.BlockRange{
+			Start: "0",
+			End:   "3",
+		},
+	}
+	iter, err := topic.NewIterator(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, cursor := readBlockEvents(t, iter, 3)
+	iter.Close()
+
+	// 订阅断开期间主干发生切换
+	ledger.SwitchTrunk(0)
+	block1 := ledger.AppendBlock(newBlockBuilder().Block())
+	block2 := ledger.AppendBlock(newBlockBuilder().Block())
+
+	iter, err = topic.NewCursorIterator(filter, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	events, cursor := readBlockEvents(t, iter, 3)
+	checkBlockEvents(t, events, []blockEvent{
+		{hexid(blocks[2]), true},
+		{hexid(blocks[1]), true},
+		{hexid(block1), false},
+	})
+	iter.Close()
+
+	// 从回滚事件之后恢复订阅不会重复推送回滚事件
+	pbcursor := new(protos.EventCursor)
+	proto.Unmarshal(cursor, pbcursor)
+	if pbcursor.GetRevert() || pbcursor.GetBlockHeight() != 1 {
+		t.Fatalf("unexpected cursor %v", pbcursor)
+	}
+	revertCursor := makeCursor(blocks[1], -1, -1, true)
+	iter, err = topic.NewCursorIterator(filter, revertCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	events, _ = readBlockEvents(t, iter, 2)
+	checkBlockEvents(t, events, []blockEvent{
+		{hexid(block1), false},
+		{hexid(block2), false},
+	})
+}
+
+func TestEventTopicResumeFromCursor(t *testing.T) {
+	ledger := newMockBlockStore()
+	tx := newTxBuilder().Invoke("counter", "increase",
+		&protos.ContractEvent{Contract: "counter", Name: "e0"},
+		&protos.ContractEvent{Contract: "counter", Name: "e1"},
+		&protos.ContractEvent{Contract: "counter", Name: "e2"},
+	).Tx()
+	ledger.AppendBlock(newBlockBuilder().Block())
+	ledger.AppendBlock(newBlockBuilder().AddTx(tx).Block())
+
+	topic := NewEventTopic(ledger)
+	filter := &protos.EventFilter{
+		Range: &protos.BlockRange{
+			Start: "0",
+		},
+	}
+	iter, err := topic.NewIterator(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !iter.Next() || !iter.Next() {
+		t.Fatalf("expect events, error:%v", iter.Error())
+	}
+	cursor := iter.Cursor()
+	iter.Close()
+
+	iter, err = topic.NewCursorIterator(filter, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	if !iter.Next() {
+		t.Fatalf("expect event, error:%v", iter.Error())
+	}
+	event := iter.Data().(*protos.ContractEventInfo)
+	if event.GetEvent().GetName() != "e2" {
+		t.Errorf("expect event e2 got %s", event.GetEvent().GetName())
+	}
+
+	// 主干切换后按逆序推送已推送事件的回滚事件
+	ledger.SwitchTrunk(0)
+	ledger.AppendBlock(newBlockBuilder().Block())
+	ledger.AppendBlock(newBlockBuilder().Block())
+	var names []string
+	for i := 0; i < 3; i++ {
+		if !iter.Next() {
+			t.Fatalf("expect revert event, error:%v", iter.Error())
+		}
+		if !iter.Reverted() {
+			t.Fatalf("expect revert event")
+		}
+		names = append(names, iter.Data().(*protos.ContractEventInfo).GetEvent().GetName())
+	}
+	if names[0] != "e2" || names[1] != "e1" || names[2] != "e0" {
+		t.Errorf("unexpected revert events %v", names)
+	}
+}
+
+func TestTxTopicRevert(t *testing.T) {
+	ledger := newMockBlockStore()
+	tx1 := newTxBuilder().Tx()
+	tx2 := newTxBuilder().Tx()
+	ledger.AppendBlock(newBlockBuilder().Block())
+	ledger.AppendBlock(newBlockBuilder().AddTx(tx1, tx2).Block())
+
+	topic := NewTxTopic(ledger)
+	filter := &protos.TxFilter{
+		Range: &protos.BlockRange{
+			Start: "1",
+		},
+	}
+	iter, err := topic.NewIterator(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !iter.Next() {
+		t.Fatalf("expect tx event, error:%v", iter.Error())
+	}
+	cursor := iter.Cursor()
+	iter.Close()
+
+	// 只回滚订阅者已经处理过的交易
+	ledger.SwitchTrunk(0)
+	tx3 := newTxBuilder().Tx()
+	ledger.AppendBlock(newBlockBuilder().AddTx(tx3).Block())
+	iter, err = topic.NewCursorIterator(filter, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	if !iter.Next() {
+		t.Fatalf("expect revert event, error:%v", iter.Error())
+	}
+	txEvent := iter.Data().(*protos.TxEvent)
+	if !iter.Reverted() || txEvent.GetTx().GetTxid() != hex.EncodeToString(tx1.GetTxid()) {
+		t.Errorf("expect revert of %x got %v", tx1.GetTxid(), txEvent)
+	}
+	if !iter.Next() {
+		t.Fatalf("expect tx event, error:%v", iter.Error())
+	}
+	txEvent = iter.Data().(*protos.TxEvent)
+	if iter.Reverted() || txEvent.GetTx().GetTxid() != hex.EncodeToString(tx3.GetTxid()) {
+		t.Errorf("expect %x got %v", tx3.GetTxid(), txEvent)
+	}
+}
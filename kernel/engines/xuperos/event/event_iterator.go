@@ -0,0 +1,137 @@
+package event
+
+import (
+	"encoding/hex"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+var _ Iterator = (*eventIterator)(nil)
+
+// indexedEvent 记录合约事件在交易内的序号，用于生成游标
+type indexedEvent struct {
+	index int32
+	event *protos.ContractEvent
+}
+
+// eventIterator 将区块展开为合约事件
+type eventIterator struct {
+	biter  *BlockIterator
+	filter *blockFilter
+	// 订阅者在游标所在区块已经处理到的位置
+	resume *resumePoint
+
+	block *lpb.InternalBlock
+	// 当前区块内待处理的交易序号，回滚区块按逆序处理
+	txs     []int32
+	point   *resumePoint
+	txIndex int32
+	txid    string
+	pending []indexedEvent
+	current indexedEvent
+	event   *protos.ContractEventInfo
+
+	closed bool
+	err    error
+}
+
+func newEventIterator(biter *BlockIterator, filter *blockFilter, cursor *protos.EventCursor) *eventIterator {
+	return &eventIterator{
+		biter:  biter,
+		filter: filter,
+		resume: newResumePoint(cursor),
+	}
+}
+
+func (e *eventIterator) Next() bool {
+	if e.closed || e.err != nil {
+		return false
+	}
+	for {
+		if len(e.pending) > 0 {
+			e.current = e.pending[0]
+			e.pending = e.pending[1:]
+			e.event = &protos.ContractEventInfo{
+				Bcname:      e.filter.GetBcname(),
+				Blockid:     hex.EncodeToString(e.block.GetBlockid()),
+				BlockHeight: e.block.GetHeight(),
+				Txid:        e.txid,
+				Event:       e.current.event,
+			}
+			return true
+		}
+
+		if len(e.txs) > 0 {
+			e.txIndex = e.txs[0]
+			e.txs = e.txs[1:]
+			tx := e.block.GetTransactions()[e.txIndex]
+			if !matchTx(e.filter, tx) {
+				continue
+			}
+			e.txid = hex.EncodeToString(tx.GetTxid())
+			e.pending = e.parseEvents(tx)
+			continue
+		}
+
+		if !e.biter.Next() {
+			e.err = e.biter.Error()
+			return false
+		}
+		e.block = e.biter.Block()
+		e.point = e.resume.forBlock(e.block)
+		e.resume = nil
+		e.txs = txIndexes(len(e.block.GetTransactions()), e.biter.Reverted())
+	}
+}
+
+// parseEvents 返回交易内符合过滤规则的合约事件，回滚区块按逆序返回
+func (e *eventIterator) parseEvents(tx *lpb.Transaction) []indexedEvent {
+	events, err := sandbox.ParseContractEvents(tx)
+	if err != nil {
+		return nil
+	}
+
+	reverted := e.biter.Reverted()
+	var ret []indexedEvent
+	for i, event := range events {
+		if !matchTxEvent(e.filter, tx, event) {
+			continue
+		}
+		if e.point.skip(reverted, e.txIndex, int32(i)) {
+			continue
+		}
+		ret = append(ret, indexedEvent{
+			index: int32(i),
+			event: event,
+		})
+	}
+	if reverted {
+		for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+			ret[i], ret[j] = ret[j], ret[i]
+		}
+	}
+	return ret
+}
+
+func (e *eventIterator) Data() interface{} {
+	return e.event
+}
+
+func (e *eventIterator) Cursor() []byte {
+	return makeCursor(e.block, e.txIndex, e.current.index, e.biter.Reverted())
+}
+
+func (e *eventIterator) Reverted() bool {
+	return e.biter.Reverted()
+}
+
+func (e *eventIterator) Error() error {
+	return e.err
+}
+
+func (e *eventIterator) Close() {
+	e.closed = true
+	e.biter.Close()
+}
@@ -0,0 +1,86 @@
+package event
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+var _ Topic = (*EventTopic)(nil)
+
+// EventTopic handles contract events
+type EventTopic struct {
+	chainmg ChainManager
+}
+
+// NewEventTopic instances EventTopic from ChainManager
+func NewEventTopic(chainmg ChainManager) *EventTopic {
+	return &EventTopic{
+		chainmg: chainmg,
+	}
+}
+
+// ParseFilter 从指定的bytes buffer反序列化topic过滤器
+// 返回的参数会作为入参传递给NewIterator的filter参数
+func (e *EventTopic) ParseFilter(buf []byte) (interface{}, error) {
+	pbfilter := new(protos.EventFilter)
+	err := proto.Unmarshal(buf, pbfilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return pbfilter, nil
+}
+
+// MarshalEvent encode event payload returns from Iterator.Data()
+func (e *EventTopic) MarshalEvent(x interface{}) ([]byte, error) {
+	msg := x.(proto.Message)
+	return proto.Marshal(msg)
+}
+
+// NewIterator make a new Iterator base on filter
+func (e *EventTopic) NewIterator(ifilter interface{}) (Iterator, error) {
+	return e.NewCursorIterator(ifilter, nil)
+}
+
+// NewCursorIterator make a new Iterator base on filter,
+// non-empty cursor resumes from the event after cursor
+func (e *EventTopic) NewCursorIterator(ifilter interface{}, buf []byte) (Iterator, error) {
+	pbfilter, ok := ifilter.(*protos.EventFilter)
+	if !ok {
+		return nil, errors.New("bad filter type for contract event")
+	}
+	cursor, err := parseCursor(buf)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newBlockFilter(eventFilterToBlockFilter(pbfilter))
+	if err != nil {
+		return nil, err
+	}
+
+	blockStore, err := e.chainmg.GetBlockStore(filter.GetBcname())
+	if err != nil {
+		return nil, err
+	}
+	biter, err := newRangeBlockIterator(blockStore, filter.GetRange(), cursor)
+	if err != nil {
+		return nil, err
+	}
+	return newEventIterator(biter, filter, cursor), nil
+}
+
+func eventFilterToBlockFilter(filter *protos.EventFilter) *protos.BlockFilter {
+	return &protos.BlockFilter{
+		Bcname:      filter.GetBcname(),
+		Range:       filter.GetRange(),
+		Contract:    filter.GetContract(),
+		EventName:   filter.GetEventName(),
+		Initiator:   filter.GetInitiator(),
+		AuthRequire: filter.GetAuthRequire(),
+		FromAddr:    filter.GetFromAddr(),
+		ToAddr:      filter.GetToAddr(),
+		Expr:        filter.GetExpr(),
+	}
+}
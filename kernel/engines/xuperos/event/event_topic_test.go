@@ -0,0 +1,73 @@
+package event
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func TestEventTopicBasic(t *testing.T) {
+	ledger := newMockBlockStore()
+	tx1 := newTxBuilder().Invoke("counter", "increase",
+		&protos.ContractEvent{Contract: "counter", Name: "increase"},
+		&protos.ContractEvent{Contract: "counter", Name: "overflow"},
+	).Tx()
+	tx2 := newTxBuilder().Invoke("erc20", "transfer",
+		&protos.ContractEvent{Contract: "erc20", Name: "transfer"},
+	).Tx()
+	ledger.AppendBlock(newBlockBuilder().AddTx(tx1, tx2).Block())
+
+	topic := NewEventTopic(ledger)
+	t.Run("contractMatch", func(tt *testing.T) {
+		iter, err := topic.NewIterator(&protos.EventFilter{
+			Range: &protos.BlockRange{
+				Start: "0",
+				End:   "1",
+			},
+			Contract: "counter",
+		})
+		if err != nil {
+			tt.Fatal(err)
+		}
+		defer iter.Close()
+
+		var names []string
+		for iter.Next() {
+			event := iter.Data().(*protos.ContractEventInfo)
+			if event.GetTxid() != hex.EncodeToString(tx1.GetTxid()) {
+				tt.Errorf("expect txid %x got %s", tx1.GetTxid(), event.GetTxid())
+			}
+			names = append(names, event.GetEvent().GetName())
+		}
+		if len(names) != 2 || names[0] != "increase" || names[1] != "overflow" {
+			tt.Errorf("unexpected events %v", names)
+		}
+	})
+
+	t.Run("eventNameMatch", func(tt *testing.T) {
+		iter, err := topic.NewIterator(&protos.EventFilter{
+			Range: &protos.BlockRange{
+				Start: "0",
+				End:   "1",
+			},
+			EventName: "transfer",
+		})
+		if err != nil {
+			tt.Fatal(err)
+		}
+		defer iter.Close()
+
+		n := 0
+		for iter.Next() {
+			event := iter.Data().(*protos.ContractEventInfo)
+			if event.GetEvent().GetContract() != "erc20" {
+				tt.Errorf("unexpected event %v", event.GetEvent())
+			}
+			n++
+		}
+		if n != 1 {
+			tt.Errorf("expect 1 event got %d", n)
+		}
+	})
+}
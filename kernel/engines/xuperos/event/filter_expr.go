@@ -0,0 +1,405 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+const (
+	// 过滤表达式的最大深度和最大节点数，避免订阅者提交过于复杂的表达式
+	maxFilterExprDepth = 8
+	maxFilterExprNodes = 64
+)
+
+// exprFunc 在交易和合约事件上对表达式求值，event为nil时只对交易相关的条件求值
+type exprFunc func(tx *lpb.Transaction, event *protos.ContractEvent) bool
+
+// compiledExpr 是编译后的过滤表达式
+type compiledExpr struct {
+	eval exprFunc
+	// 表达式中是否包含合约事件相关的条件
+	hasEventClause bool
+}
+
+// compileFilterExpr 校验并编译过滤表达式，空表达式返回nil
+func compileFilterExpr(expr *protos.FilterExpr) (*compiledExpr, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	var nodes int
+	c, err := compileExprNode(expr, 1, &nodes)
+	if err != nil {
+		return nil, fmt.Errorf("bad filter expr: %s", err)
+	}
+	return c, nil
+}
+
+func compileExprNode(expr *protos.FilterExpr, depth int, nodes *int) (*compiledExpr, error) {
+	if expr == nil {
+		return nil, errors.New("empty expr node")
+	}
+	*nodes++
+	if depth > maxFilterExprDepth {
+		return nil, fmt.Errorf("expr depth exceeds %d", maxFilterExprDepth)
+	}
+	if *nodes > maxFilterExprNodes {
+		return nil, fmt.Errorf("expr nodes exceed %d", maxFilterExprNodes)
+	}
+
+	if expr.GetOp() == protos.FilterExpr_CLAUSE {
+		if len(expr.GetChildren()) != 0 {
+			return nil, errors.New("clause node can not have children")
+		}
+		return compileClause(expr.GetClause())
+	}
+
+	if expr.GetClause() != nil {
+		return nil, fmt.Errorf("%s node can not have clause", expr.GetOp())
+	}
+	var children []*compiledExpr
+	var hasEventClause bool
+	for _, child := range expr.GetChildren() {
+		c, err := compileExprNode(child, depth+1, nodes)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, c)
+		hasEventClause = hasEventClause || c.hasEventClause
+	}
+
+	switch expr.GetOp() {
+	case protos.FilterExpr_AND:
+		if len(children) == 0 {
+			return nil, errors.New("AND node requires children")
+		}
+		return &compiledExpr{
+			eval: func(tx *lpb.Transaction, event *protos.ContractEvent) bool {
+				for _, c := range children {
+					if !c.eval(tx, event) {
+						return false
+					}
+				}
+				return true
+			},
+			hasEventClause: hasEventClause,
+		}, nil
+	case protos.FilterExpr_OR:
+		if len(children) == 0 {
+			return nil, errors.New("OR node requires children")
+		}
+		return &compiledExpr{
+			eval: func(tx *lpb.Transaction, event *protos.ContractEvent) bool {
+				for _, c := range children {
+					if c.eval(tx, event) {
+						return true
+					}
+				}
+				return false
+			},
+			hasEventClause: hasEventClause,
+		}, nil
+	case protos.FilterExpr_NOT:
+		if len(children) != 1 {
+			return nil, errors.New("NOT node requires exactly one child")
+		}
+		child := children[0]
+		return &compiledExpr{
+			eval: func(tx *lpb.Transaction, event *protos.ContractEvent) bool {
+				return !child.eval(tx, event)
+			},
+			hasEventClause: hasEventClause,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown op %d", expr.GetOp())
+	}
+}
+
+func compileClause(clause *protos.FilterClause) (*compiledExpr, error) {
+	if clause == nil {
+		return nil, errors.New("clause node requires clause")
+	}
+	var exprs []*compiledExpr
+	addRegexp := func(name, regstr string, isEvent bool, eval func(*regexp.Regexp, *lpb.Transaction, *protos.ContractEvent) bool) error {
+		if regstr == "" {
+			return nil
+		}
+		r, err := regexp.Compile(regstr)
+		if err != nil {
+			return fmt.Errorf("bad %s regexp: %s", name, err)
+		}
+		exprs = append(exprs, &compiledExpr{
+			eval: func(tx *lpb.Transaction, event *protos.ContractEvent) bool {
+				return eval(r, tx, event)
+			},
+			hasEventClause: isEvent,
+		})
+		return nil
+	}
+
+	err := addRegexp("contract", clause.GetContract(), false, func(r *regexp.Regexp, tx *lpb.Transaction, _ *protos.ContractEvent) bool {
+		for _, req := range tx.GetContractRequests() {
+			if r.MatchString(req.GetContractName()) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = addRegexp("event_name", clause.GetEventName(), true, func(r *regexp.Regexp, _ *lpb.Transaction, event *protos.ContractEvent) bool {
+		return event != nil && r.MatchString(event.GetName())
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = addRegexp("initiator", clause.GetInitiator(), false, func(r *regexp.Regexp, tx *lpb.Transaction, _ *protos.ContractEvent) bool {
+		return r.MatchString(tx.GetInitiator())
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = addRegexp("auth_require", clause.GetAuthRequire(), false, func(r *regexp.Regexp, tx *lpb.Transaction, _ *protos.ContractEvent) bool {
+		for _, addr := range tx.GetAuthRequire() {
+			if r.MatchString(addr) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = addRegexp("from_addr", clause.GetFromAddr(), false, func(r *regexp.Regexp, tx *lpb.Transaction, _ *protos.ContractEvent) bool {
+		for _, input := range tx.GetTxInputs() {
+			if r.Match(input.GetFromAddr()) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = addRegexp("to_addr", clause.GetToAddr(), false, func(r *regexp.Regexp, tx *lpb.Transaction, _ *protos.ContractEvent) bool {
+		for _, output := range tx.GetTxOutputs() {
+			if r.Match(output.GetToAddr()) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if clause.GetAmount() != nil {
+		c, err := compileAmountFilter(clause.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, c)
+	}
+	if clause.GetEventBody() != nil {
+		c, err := compileEventBodyFilter(clause.GetEventBody())
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, c)
+	}
+
+	if len(exprs) != 1 {
+		return nil, fmt.Errorf("clause requires exactly one condition, got %d", len(exprs))
+	}
+	return exprs[0], nil
+}
+
+func compileAmountFilter(filter *protos.AmountFilter) (*compiledExpr, error) {
+	toAddr, err := compileString(filter.GetToAddr())
+	if err != nil {
+		return nil, fmt.Errorf("bad amount to_addr regexp: %s", err)
+	}
+	inRange, err := compileValueRange(filter.GetRange())
+	if err != nil {
+		return nil, fmt.Errorf("bad amount range: %s", err)
+	}
+	if inRange == nil {
+		return nil, errors.New("amount filter requires range")
+	}
+	return &compiledExpr{
+		eval: func(tx *lpb.Transaction, _ *protos.ContractEvent) bool {
+			for _, output := range tx.GetTxOutputs() {
+				if !matchBytes(toAddr, output.GetToAddr()) {
+					continue
+				}
+				if inRange(new(big.Int).SetBytes(output.GetAmount())) {
+					return true
+				}
+			}
+			return false
+		},
+	}, nil
+}
+
+func compileEventBodyFilter(filter *protos.EventBodyFilter) (*compiledExpr, error) {
+	var path []string
+	if filter.GetJsonPath() != "" {
+		path = strings.Split(filter.GetJsonPath(), ".")
+	}
+	prefix := filter.GetPrefix()
+	bodyRegexp, err := compileString(filter.GetRegex())
+	if err != nil {
+		return nil, fmt.Errorf("bad event body regexp: %s", err)
+	}
+	inRange, err := compileValueRange(filter.GetRange())
+	if err != nil {
+		return nil, fmt.Errorf("bad event body range: %s", err)
+	}
+	if path == nil && prefix == nil && bodyRegexp == nil && inRange == nil {
+		return nil, errors.New("empty event body filter")
+	}
+
+	return &compiledExpr{
+		eval: func(_ *lpb.Transaction, event *protos.ContractEvent) bool {
+			if event == nil {
+				return false
+			}
+			value, ok := eventBodyValue(event.GetBody(), path)
+			if !ok {
+				return false
+			}
+			if prefix != nil && !bytes.HasPrefix(value, prefix) {
+				return false
+			}
+			if !matchBytes(bodyRegexp, value) {
+				return false
+			}
+			if inRange != nil {
+				n, ok := new(big.Int).SetString(string(value), 10)
+				if !ok || !inRange(n) {
+					return false
+				}
+			}
+			return true
+		},
+		hasEventClause: true,
+	}, nil
+}
+
+// eventBodyValue 按json路径从body中取值，字符串返回其内容，其他类型返回json编码
+func eventBodyValue(body []byte, path []string) ([]byte, bool) {
+	if path == nil {
+		return body, true
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	for _, key := range path {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			child, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = child
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			v = node[idx]
+		default:
+			return nil, false
+		}
+	}
+
+	switch value := v.(type) {
+	case string:
+		return []byte(value), true
+	case json.Number:
+		return []byte(value.String()), true
+	default:
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return nil, false
+		}
+		return buf, true
+	}
+}
+
+// compileValueRange 返回判断数值是否在区间内的函数，空区间返回nil
+func compileValueRange(r *protos.ValueRange) (func(*big.Int) bool, error) {
+	if r.GetMin() == "" && r.GetMax() == "" {
+		return nil, nil
+	}
+	var min, max *big.Int
+	if r.GetMin() != "" {
+		n, ok := new(big.Int).SetString(r.GetMin(), 10)
+		if !ok {
+			return nil, fmt.Errorf("bad min value %s", r.GetMin())
+		}
+		min = n
+	}
+	if r.GetMax() != "" {
+		n, ok := new(big.Int).SetString(r.GetMax(), 10)
+		if !ok {
+			return nil, fmt.Errorf("bad max value %s", r.GetMax())
+		}
+		max = n
+	}
+	if min != nil && max != nil && min.Cmp(max) > 0 {
+		return nil, errors.New("min value greater than max value")
+	}
+	return func(n *big.Int) bool {
+		if min != nil && n.Cmp(min) < 0 {
+			return false
+		}
+		if max != nil && n.Cmp(max) > 0 {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// matchExpr 交易中存在一个合约事件使表达式成立时交易匹配，
+// 表达式不包含合约事件条件或者交易没有合约事件时只对交易求值
+func matchExpr(filter *blockFilter, tx *lpb.Transaction) bool {
+	expr := filter.compiled.Expr
+	if expr == nil {
+		return true
+	}
+	if !expr.hasEventClause {
+		return expr.eval(tx, nil)
+	}
+	events, err := sandbox.ParseContractEvents(tx)
+	if err != nil || len(events) == 0 {
+		return expr.eval(tx, nil)
+	}
+	for _, event := range events {
+		if expr.eval(tx, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTxEvent 判断交易中的合约事件是否符合过滤规则
+func matchTxEvent(filter *blockFilter, tx *lpb.Transaction, event *protos.ContractEvent) bool {
+	if !matchEvent(filter, event) {
+		return false
+	}
+	expr := filter.compiled.Expr
+	return expr == nil || expr.eval(tx, event)
+}
@@ -13,6 +13,8 @@ type filteredBlockIterator struct {
 	biter  *BlockIterator
 	filter *blockFilter
 	block  *protos.FilteredBlock
+	// 订阅者在游标所在区块已经处理到的位置
+	resume *resumePoint
 
 	endBlockNum int64
 
@@ -20,10 +22,11 @@ type filteredBlockIterator struct {
 	err    error
 }
 
-func newFilteredBlockIterator(iter *BlockIterator, filter *blockFilter) *filteredBlockIterator {
+func newFilteredBlockIterator(iter *BlockIterator, filter *blockFilter, cursor *protos.EventCursor) *filteredBlockIterator {
 	return &filteredBlockIterator{
 		biter:  iter,
 		filter: filter,
+		resume: newResumePoint(cursor),
 	}
 }
 
@@ -39,10 +42,6 @@ func (b *filteredBlockIterator) Next() bool {
 	return cont
 }
 
-func (b *filteredBlockIterator) matchTx(tx *lpb.Transaction) bool {
-	return matchTx(b.filter, tx)
-}
-
 func (b *filteredBlockIterator) toFilteredBlock(block *lpb.InternalBlock) *protos.FilteredBlock {
 	fblock := new(protos.FilteredBlock)
 	fblock.Bcname = b.filter.GetBcname()
@@ -52,31 +51,37 @@ func (b *filteredBlockIterator) toFilteredBlock(block *lpb.InternalBlock) *proto
 		return fblock
 	}
 
-	hasEventFilter := hasEventFilter(b.filter)
 	var txs []*protos.FilteredTransaction
 	for _, tx := range block.GetTransactions() {
-		if !b.matchTx(tx) {
-			continue
-		}
-		events := b.parseFilteredEvents(tx)
-		// 有合约事件过滤器并且当前交易没有匹配的事件，不区分交易没有合约事件或者事件都匹配
-		// 则认为当前交易不符合过滤规则
-		if len(events) == 0 && hasEventFilter {
+		ftx, ok := toFilteredTx(b.filter, tx)
+		if !ok {
 			continue
 		}
-		ftx := &protos.FilteredTransaction{
-			Txid:   hex.EncodeToString(tx.GetTxid()),
-			Events: events,
-		}
-
 		txs = append(txs, ftx)
 	}
 	fblock.Txs = txs
 	return fblock
 }
 
-func (b *filteredBlockIterator) parseFilteredEvents(tx *lpb.Transaction) []*protos.ContractEvent {
-	if b.filter.GetExcludeTxEvent() {
+// toFilteredTx 返回过滤后的交易，交易不符合过滤规则时返回false
+func toFilteredTx(filter *blockFilter, tx *lpb.Transaction) (*protos.FilteredTransaction, bool) {
+	if !matchTx(filter, tx) {
+		return nil, false
+	}
+	events := parseFilteredEvents(filter, tx)
+	// 有合约事件过滤器并且当前交易没有匹配的事件，不区分交易没有合约事件或者事件都匹配
+	// 则认为当前交易不符合过滤规则
+	if len(events) == 0 && hasEventFilter(filter) {
+		return nil, false
+	}
+	return &protos.FilteredTransaction{
+		Txid:   hex.EncodeToString(tx.GetTxid()),
+		Events: events,
+	}, true
+}
+
+func parseFilteredEvents(filter *blockFilter, tx *lpb.Transaction) []*protos.ContractEvent {
+	if filter.GetExcludeTxEvent() {
 		return nil
 	}
 	events, err := sandbox.ParseContractEvents(tx)
@@ -87,7 +92,7 @@ func (b *filteredBlockIterator) parseFilteredEvents(tx *lpb.Transaction) []*prot
 
 	var ret []*protos.ContractEvent
 	for _, event := range events {
-		if !matchEvent(b.filter, event) {
+		if !matchTxEvent(filter, tx, event) {
 			continue
 		}
 		ret = append(ret, event)
@@ -99,6 +104,11 @@ func (b *filteredBlockIterator) parseFilteredEvents(tx *lpb.Transaction) []*prot
 func (b *filteredBlockIterator) fetchBlock() (*protos.FilteredBlock, bool, error) {
 	for b.biter.Next() {
 		block := b.biter.Block()
+		resume := b.resume.forBlock(block)
+		b.resume = nil
+		if resume.skip(b.biter.Reverted(), -1, -1) {
+			continue
+		}
 		filteredBlock := b.toFilteredBlock(block)
 		return filteredBlock, true, nil
 	}
@@ -112,6 +122,14 @@ func (b *filteredBlockIterator) Data() interface{} {
 	return b.block
 }
 
+func (b *filteredBlockIterator) Cursor() []byte {
+	return b.biter.Cursor()
+}
+
+func (b *filteredBlockIterator) Reverted() bool {
+	return b.biter.Reverted()
+}
+
 func (b *filteredBlockIterator) Error() error {
 	return b.err
 }
@@ -16,6 +16,7 @@ var txFilterFuncs = []txFilterFunc{
 	matchAuthRequire,
 	matchFromAddr,
 	matchToAddr,
+	matchExpr,
 }
 
 var contractEventFilterFuncs = []contractEventFilterFunc{
@@ -29,6 +30,7 @@ type compiledBlockFilter struct {
 	AuthRequire *regexp.Regexp
 	FromAddr    *regexp.Regexp
 	ToAddr      *regexp.Regexp
+	Expr        *compiledExpr
 }
 
 type blockFilter struct {
@@ -57,6 +59,9 @@ func newBlockFilter(ori *protos.BlockFilter) (*blockFilter, error) {
 	if c.ToAddr, err = compileString(ori.GetToAddr()); err != nil {
 		return nil, err
 	}
+	if c.Expr, err = compileFilterExpr(ori.GetExpr()); err != nil {
+		return nil, err
+	}
 
 	return &blockFilter{
 		BlockFilter: ori,
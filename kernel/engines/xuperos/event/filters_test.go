@@ -165,3 +165,195 @@ func TestFilterEvent(t *testing.T) {
 		})
 	})
 }
+
+func expectTxEventMatch(t *testing.T, tx *lpb.Transaction, event *protos.ContractEvent, pbfilter *protos.BlockFilter) {
+	filter, err := newBlockFilter(pbfilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matchTxEvent(filter, tx, event) {
+		t.Fatal("event not match")
+	}
+}
+
+func expectTxEventNotMatch(t *testing.T, tx *lpb.Transaction, event *protos.ContractEvent, pbfilter *protos.BlockFilter) {
+	filter, err := newBlockFilter(pbfilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matchTxEvent(filter, tx, event) {
+		t.Fatal("unexpected event match")
+	}
+}
+
+func clauseExpr(clause *protos.FilterClause) *protos.FilterExpr {
+	return &protos.FilterExpr{
+		Op:     protos.FilterExpr_CLAUSE,
+		Clause: clause,
+	}
+}
+
+func TestFilterExprAmount(t *testing.T) {
+	tx := newTxBuilder().TransferAmount("alice", "bob", 100).Tx()
+	amountExpr := func(toAddr, min, max string) *protos.BlockFilter {
+		return &protos.BlockFilter{
+			Expr: clauseExpr(&protos.FilterClause{
+				Amount: &protos.AmountFilter{
+					ToAddr: toAddr,
+					Range: &protos.ValueRange{
+						Min: min,
+						Max: max,
+					},
+				},
+			}),
+		}
+	}
+	t.Run("inRange", func(tt *testing.T) {
+		expectTxMatch(tt, tx, amountExpr("", "100", "200"))
+	})
+	t.Run("noMax", func(tt *testing.T) {
+		expectTxMatch(tt, tx, amountExpr("bob", "50", ""))
+	})
+	t.Run("outOfRange", func(tt *testing.T) {
+		expectTxNotMatch(tt, tx, amountExpr("", "101", ""))
+	})
+	t.Run("toAddrNotMatch", func(tt *testing.T) {
+		expectTxNotMatch(tt, tx, amountExpr("carol", "", "200"))
+	})
+}
+
+func TestFilterExprEventBody(t *testing.T) {
+	tx := newTxBuilder().Tx()
+	event := &protos.ContractEvent{
+		Contract: "erc20",
+		Name:     "transfer",
+		Body:     []byte(`{"to":"bob","amount":150,"tags":["a","b"]}`),
+	}
+	bodyExpr := func(filter *protos.EventBodyFilter) *protos.BlockFilter {
+		return &protos.BlockFilter{
+			Expr: clauseExpr(&protos.FilterClause{
+				EventBody: filter,
+			}),
+		}
+	}
+	t.Run("rawPrefix", func(tt *testing.T) {
+		expectTxEventMatch(tt, tx, event, bodyExpr(&protos.EventBodyFilter{
+			Prefix: []byte(`{"to"`),
+		}))
+	})
+	t.Run("jsonPathRegexp", func(tt *testing.T) {
+		expectTxEventMatch(tt, tx, event, bodyExpr(&protos.EventBodyFilter{
+			JsonPath: "to",
+			Regex:    "^bob$",
+		}))
+	})
+	t.Run("jsonPathIndex", func(tt *testing.T) {
+		expectTxEventMatch(tt, tx, event, bodyExpr(&protos.EventBodyFilter{
+			JsonPath: "tags.1",
+			Prefix:   []byte("b"),
+		}))
+	})
+	t.Run("jsonPathRange", func(tt *testing.T) {
+		expectTxEventMatch(tt, tx, event, bodyExpr(&protos.EventBodyFilter{
+			JsonPath: "amount",
+			Range: &protos.ValueRange{
+				Min: "100",
+				Max: "200",
+			},
+		}))
+		expectTxEventNotMatch(tt, tx, event, bodyExpr(&protos.EventBodyFilter{
+			JsonPath: "amount",
+			Range: &protos.ValueRange{
+				Max: "100",
+			},
+		}))
+	})
+	t.Run("jsonPathNotExist", func(tt *testing.T) {
+		expectTxEventNotMatch(tt, tx, event, bodyExpr(&protos.EventBodyFilter{
+			JsonPath: "from",
+		}))
+	})
+}
+
+func TestFilterExprBoolean(t *testing.T) {
+	tx := newTxBuilder().Initiator("alice").Invoke("erc20", "transfer",
+		&protos.ContractEvent{Contract: "erc20", Name: "transfer"},
+		&protos.ContractEvent{Contract: "erc20", Name: "approve"},
+	).Tx()
+	initiator := func(addr string) *protos.FilterExpr {
+		return clauseExpr(&protos.FilterClause{Initiator: addr})
+	}
+	eventName := func(name string) *protos.FilterExpr {
+		return clauseExpr(&protos.FilterClause{EventName: name})
+	}
+
+	t.Run("or", func(tt *testing.T) {
+		expectTxMatch(tt, tx, &protos.BlockFilter{
+			Expr: &protos.FilterExpr{
+				Op:       protos.FilterExpr_OR,
+				Children: []*protos.FilterExpr{initiator("^bob$"), initiator("^alice$")},
+			},
+		})
+	})
+	t.Run("and", func(tt *testing.T) {
+		expectTxNotMatch(tt, tx, &protos.BlockFilter{
+			Expr: &protos.FilterExpr{
+				Op:       protos.FilterExpr_AND,
+				Children: []*protos.FilterExpr{initiator("^alice$"), eventName("^mint$")},
+			},
+		})
+	})
+	t.Run("not", func(tt *testing.T) {
+		filter := &protos.BlockFilter{
+			Expr: &protos.FilterExpr{
+				Op:       protos.FilterExpr_NOT,
+				Children: []*protos.FilterExpr{eventName("^transfer$")},
+			},
+		}
+		expectTxMatch(tt, tx, filter)
+		expectTxEventNotMatch(tt, tx, &protos.ContractEvent{Name: "transfer"}, filter)
+		expectTxEventMatch(tt, tx, &protos.ContractEvent{Name: "approve"}, filter)
+	})
+}
+
+func TestFilterExprValidate(t *testing.T) {
+	deep := clauseExpr(&protos.FilterClause{Initiator: "alice"})
+	for i := 0; i < maxFilterExprDepth; i++ {
+		deep = &protos.FilterExpr{
+			Op:       protos.FilterExpr_NOT,
+			Children: []*protos.FilterExpr{deep},
+		}
+	}
+	cases := map[string]*protos.FilterExpr{
+		"emptyClause": clauseExpr(&protos.FilterClause{}),
+		"multiCondition": clauseExpr(&protos.FilterClause{
+			Initiator: "alice",
+			Contract:  "erc20",
+		}),
+		"badRegexp": clauseExpr(&protos.FilterClause{Contract: "("}),
+		"emptyOr": {
+			Op: protos.FilterExpr_OR,
+		},
+		"notWithTwoChildren": {
+			Op: protos.FilterExpr_NOT,
+			Children: []*protos.FilterExpr{
+				clauseExpr(&protos.FilterClause{Initiator: "alice"}),
+				clauseExpr(&protos.FilterClause{Initiator: "bob"}),
+			},
+		},
+		"badRange": clauseExpr(&protos.FilterClause{
+			Amount: &protos.AmountFilter{
+				Range: &protos.ValueRange{Min: "10", Max: "1"},
+			},
+		}),
+		"tooDeep": deep,
+	}
+	for name, expr := range cases {
+		t.Run(name, func(tt *testing.T) {
+			_, err := newBlockFilter(&protos.BlockFilter{Expr: expr})
+			if err == nil {
+				tt.Fatal("expect error")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"errors"
 	"sync"
 
@@ -12,12 +13,15 @@ import (
 type mockBlockStore struct {
 	mutex  sync.Mutex
 	blocks []*lpb.InternalBlock
+	// 包括分叉在内的所有区块
+	allBlocks map[string]*lpb.InternalBlock
 
 	heightNotifier *state.BlockHeightNotifier
 }
 
 func newMockBlockStore() *mockBlockStore {
 	return &mockBlockStore{
+		allBlocks:      make(map[string]*lpb.InternalBlock),
 		heightNotifier: state.NewBlockHeightNotifier(),
 	}
 }
@@ -47,13 +51,52 @@ func (m *mockBlockStore) QueryBlockByHeight(height int64) (*lpb.InternalBlock, e
 	return m.blocks[int(height)], nil
 }
 
-func (m *mockBlockStore) AppendBlock(block *lpb.InternalBlock) {
+// QueryBlockByTxid returns the block header which contains the confirmed tx
+func (m *mockBlockStore) QueryBlockByTxid(txid []byte) (*lpb.InternalBlock, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, block := range m.blocks {
+		for _, tx := range block.GetTransactions() {
+			if bytes.Equal(tx.GetTxid(), txid) {
+				return block, nil
+			}
+		}
+	}
+	return nil, ledger.ErrTxNotConfirmed
+}
+
+// QueryBlock returns block by blockid, including blocks on branch
+func (m *mockBlockStore) QueryBlock(blockid []byte) (*lpb.InternalBlock, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	block, ok := m.allBlocks[string(blockid)]
+	if !ok {
+		return nil, ledger.ErrBlockNotExist
+	}
+	return block, nil
+}
+
+func (m *mockBlockStore) AppendBlock(block *lpb.InternalBlock) *lpb.InternalBlock {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 	nblock := *block
 	nblock.Height = int64(len(m.blocks))
+	nblock.InTrunk = true
+	if len(m.blocks) > 0 {
+		nblock.PreHash = m.blocks[len(m.blocks)-1].GetBlockid()
+	}
 	m.blocks = append(m.blocks, &nblock)
+	m.allBlocks[string(nblock.Blockid)] = &nblock
 	m.heightNotifier.UpdateHeight(nblock.Height)
+	return &nblock
+}
+
+// SwitchTrunk 将主干回退到height高度，之后追加的区块组成新的主干
+func (m *mockBlockStore) SwitchTrunk(height int64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.blocks = m.blocks[:height+1]
+	m.heightNotifier.UpdateHeight(height)
 }
 
 // GetBlockStore get BlockStore base bcname(the name of block chain)
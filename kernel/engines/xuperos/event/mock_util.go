@@ -2,6 +2,8 @@ package event
 
 import (
 	"crypto/rand"
+	"math/big"
+
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/xmodel"
 	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
 	"github.com/xuperchain/xupercore/protos"
@@ -66,6 +68,23 @@ func (t *txBuilder) Transfer(from, to, amount string) *txBuilder {
 	return t
 }
 
+// TransferAmount 和链上交易一致，金额按big.Int字节编码
+func (t *txBuilder) TransferAmount(from, to string, amount int64) *txBuilder {
+	value := big.NewInt(amount).Bytes()
+	input := &protos.TxInput{
+		RefTxid:  makeRandID(),
+		FromAddr: []byte(from),
+		Amount:   value,
+	}
+	output := &protos.TxOutput{
+		ToAddr: []byte(to),
+		Amount: value,
+	}
+	t.tx.TxInputs = append(t.tx.TxInputs, input)
+	t.tx.TxOutputs = append(t.tx.TxOutputs, output)
+	return t
+}
+
 func (t *txBuilder) Invoke(contract, method string, events ...*protos.ContractEvent) *txBuilder {
 	req := &protos.InvokeRequest{
 		ModuleName:   "wasm",
@@ -14,11 +14,12 @@ type Router struct {
 
 // NewRounterFromChainMG instance Router from ChainManager
 func NewRounterFromChainMG(chainmg ChainManager) *Router {
-	blockTopic := NewBlockTopic(chainmg)
 	r := &Router{
 		topics: make(map[pb.SubscribeType]Topic),
 	}
-	r.topics[pb.SubscribeType_BLOCK] = blockTopic
+	r.topics[pb.SubscribeType_BLOCK] = NewBlockTopic(chainmg)
+	r.topics[pb.SubscribeType_TX] = NewTxTopic(chainmg)
+	r.topics[pb.SubscribeType_EVENT] = NewEventTopic(chainmg)
 
 	return r
 }
@@ -31,8 +32,27 @@ func NewRouter(engine common.Engine) *Router {
 // EncodeFunc encodes event payload
 type EncodeFunc func(x interface{}) ([]byte, error)
 
+// NewEvent encodes current event of iter as pb.Event
+func NewEvent(encfunc EncodeFunc, iter Iterator) (*pb.Event, error) {
+	payload, err := encfunc(iter.Data())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Event{
+		Payload: payload,
+		Cursor:  iter.Cursor(),
+		Revert:  iter.Reverted(),
+	}, nil
+}
+
 // Subscribe route events from pb.SubscribeType and filter buffer
 func (r *Router) Subscribe(tp pb.SubscribeType, filterbuf []byte) (EncodeFunc, Iterator, error) {
+	return r.SubscribeWithCursor(tp, filterbuf, nil)
+}
+
+// SubscribeWithCursor route events from pb.SubscribeType and filter buffer,
+// non-empty cursor resumes subscription from the event after cursor
+func (r *Router) SubscribeWithCursor(tp pb.SubscribeType, filterbuf []byte, cursor []byte) (EncodeFunc, Iterator, error) {
 	topic, ok := r.topics[tp]
 	if !ok {
 		return nil, nil, fmt.Errorf("subscribe type %s unsupported", tp)
@@ -41,7 +61,7 @@ func (r *Router) Subscribe(tp pb.SubscribeType, filterbuf []byte) (EncodeFunc, I
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse filter error: %s", err)
 	}
-	iter, err := topic.NewIterator(filter)
+	iter, err := topic.NewCursorIterator(filter, cursor)
 	return topic.MarshalEvent, iter, err
 }
 
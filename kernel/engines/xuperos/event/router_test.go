@@ -67,3 +67,45 @@ func TestRouteBlockTopicRaw(t *testing.T) {
 		t.Fatalf("block not equal, expect %x got %s", block.GetBlockid(), fblock.GetBlockid())
 	}
 }
+
+func TestRouteSubscribeWithCursor(t *testing.T) {
+	ledger := newMockBlockStore()
+	ledger.AppendBlock(newBlockBuilder().Block())
+	block := ledger.AppendBlock(newBlockBuilder().Block())
+
+	router := NewRounterFromChainMG(ledger)
+
+	filter := &protos.BlockFilter{
+		Range: &protos.BlockRange{
+			Start: "0",
+		},
+	}
+	buf, err := proto.Marshal(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encfunc, iter, err := router.Subscribe(protos.SubscribeType_BLOCK, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iter.Next()
+	event, err := NewEvent(encfunc, iter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iter.Close()
+	if event.GetRevert() || len(event.GetCursor()) == 0 {
+		t.Fatalf("unexpected event %v", event)
+	}
+
+	_, iter, err = router.SubscribeWithCursor(protos.SubscribeType_BLOCK, buf, event.GetCursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+	iter.Next()
+	fblock := iter.Data().(*protos.FilteredBlock)
+	if fblock.GetBlockid() != hex.EncodeToString(block.GetBlockid()) {
+		t.Fatalf("block not equal, expect %x got %s", block.GetBlockid(), fblock.GetBlockid())
+	}
+}
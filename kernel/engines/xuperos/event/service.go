@@ -0,0 +1,46 @@
+package event
+
+import (
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
+
+	pb "github.com/xuperchain/xupercore/protos"
+)
+
+var _ pb.EventServiceServer = (*Service)(nil)
+
+// Service implements pb.EventServiceServer, request with cursor resumes from the event after cursor
+type Service struct {
+	router *Router
+}
+
+// NewService instance event Service from Router
+func NewService(router *Router) *Service {
+	return &Service{
+		router: router,
+	}
+}
+
+// NewEngineService instance event Service from common.Engine
+func NewEngineService(engine common.Engine) *Service {
+	return NewService(NewRouter(engine))
+}
+
+// Subscribe push events matching the request to stream until the subscription ends or stream breaks
+func (s *Service) Subscribe(req *pb.SubscribeRequest, stream pb.EventService_SubscribeServer) error {
+	encfunc, iter, err := s.router.SubscribeWithCursor(req.GetType(), req.GetFilter(), req.GetCursor())
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		event, err := NewEvent(encfunc, iter)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
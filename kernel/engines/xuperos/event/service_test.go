@@ -0,0 +1,95 @@
+package event
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestEventClient(t *testing.T, ledger *mockBlockStore) (protos.EventServiceClient, func()) {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	protos.RegisterEventServiceServer(server, NewService(NewRounterFromChainMG(ledger)))
+	go server.Serve(lis)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return protos.NewEventServiceClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestServiceResumeFromCursor(t *testing.T) {
+	ledger := newMockBlockStore()
+	var blocks []string
+	for i := 0; i < 3; i++ {
+		block := ledger.AppendBlock(newBlockBuilder().Block())
+		blocks = append(blocks, hex.EncodeToString(block.GetBlockid()))
+	}
+	client, clean := newTestEventClient(t, ledger)
+	defer clean()
+
+	filter, err := proto.Marshal(&protos.BlockFilter{
+		Range: &protos.BlockRange{Start: "0", End: "3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvBlock := func(stream protos.EventService_SubscribeClient) (*protos.Event, *protos.FilteredBlock) {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		block := &protos.FilteredBlock{}
+		if err := proto.Unmarshal(event.GetPayload(), block); err != nil {
+			t.Fatal(err)
+		}
+		return event, block
+	}
+
+	// 收到第一个区块后断开订阅
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Subscribe(ctx, &protos.SubscribeRequest{
+		Type:   protos.SubscribeType_BLOCK,
+		Filter: filter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, block := recvBlock(stream)
+	cancel()
+	if block.GetBlockid() != blocks[0] || len(event.GetCursor()) == 0 {
+		t.Fatalf("unexpected first event, block:%s, cursor:%x", block.GetBlockid(), event.GetCursor())
+	}
+
+	// 从游标恢复订阅，从下一个区块开始推送
+	stream, err = client.Subscribe(context.Background(), &protos.SubscribeRequest{
+		Type:   protos.SubscribeType_BLOCK,
+		Filter: filter,
+		Cursor: event.GetCursor(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, blockid := range blocks[1:] {
+		if _, block := recvBlock(stream); block.GetBlockid() != blockid {
+			t.Fatalf("expect block %s got %s", blockid, block.GetBlockid())
+		}
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("expect subscription end, got %v", err)
+	}
+}
@@ -11,6 +11,10 @@ type Topic interface {
 
 	// NewIterator make a new Iterator base on filter
 	NewIterator(filter interface{}) (Iterator, error)
+
+	// NewCursorIterator make a new Iterator base on filter,
+	// non-empty cursor resumes from the event after cursor
+	NewCursorIterator(filter interface{}, cursor []byte) (Iterator, error)
 }
 
 // Iterator is the event iterator, must be closed after use
@@ -19,4 +23,9 @@ type Iterator interface {
 	Data() interface{}
 	Error() error
 	Close()
+
+	// Cursor returns the opaque cursor of current event
+	Cursor() []byte
+	// Reverted reports whether current event is rolled back by trunk switching
+	Reverted() bool
 }
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+var _ Iterator = (*txIterator)(nil)
+
+// txIterator 将区块展开为交易事件
+type txIterator struct {
+	biter  *BlockIterator
+	filter *blockFilter
+	// 非空时只匹配该交易，匹配成功后迭代结束
+	txid []byte
+	// 订阅者在游标所在区块已经处理到的位置
+	resume *resumePoint
+
+	block *lpb.InternalBlock
+	// 当前区块内待处理的交易序号，回滚区块按逆序处理
+	pending []int32
+	point   *resumePoint
+	txIndex int32
+	txEvent *protos.TxEvent
+	done    bool
+
+	closed bool
+	err    error
+}
+
+func newTxIterator(biter *BlockIterator, filter *blockFilter, txid []byte, cursor *protos.EventCursor) *txIterator {
+	return &txIterator{
+		biter:  biter,
+		filter: filter,
+		txid:   txid,
+		resume: newResumePoint(cursor),
+	}
+}
+
+func (t *txIterator) Next() bool {
+	if t.closed || t.err != nil || t.done {
+		return false
+	}
+	for {
+		for len(t.pending) > 0 {
+			idx := t.pending[0]
+			t.pending = t.pending[1:]
+			if t.point.skip(t.biter.Reverted(), idx, -1) {
+				continue
+			}
+			tx := t.block.GetTransactions()[idx]
+			if t.txid != nil && !bytes.Equal(t.txid, tx.GetTxid()) {
+				continue
+			}
+			// 指定的交易在主干上确认后迭代结束，交易还需要满足其他过滤条件才会返回
+			t.done = t.txid != nil && !t.biter.Reverted()
+			ftx, ok := toFilteredTx(t.filter, tx)
+			if !ok {
+				if t.done {
+					return false
+				}
+				continue
+			}
+			t.txIndex = idx
+			t.txEvent = &protos.TxEvent{
+				Bcname:      t.filter.GetBcname(),
+				Blockid:     hex.EncodeToString(t.block.GetBlockid()),
+				BlockHeight: t.block.GetHeight(),
+				Tx:          ftx,
+			}
+			return true
+		}
+
+		if !t.biter.Next() {
+			t.err = t.biter.Error()
+			return false
+		}
+		t.block = t.biter.Block()
+		t.point = t.resume.forBlock(t.block)
+		t.resume = nil
+		t.pending = txIndexes(len(t.block.GetTransactions()), t.biter.Reverted())
+	}
+}
+
+// txIndexes 返回区块内交易的处理顺序，回滚区块需要逆序撤销
+func txIndexes(n int, reverted bool) []int32 {
+	indexes := make([]int32, n)
+	for i := range indexes {
+		if reverted {
+			indexes[i] = int32(n - 1 - i)
+		} else {
+			indexes[i] = int32(i)
+		}
+	}
+	return indexes
+}
+
+func (t *txIterator) Data() interface{} {
+	return t.txEvent
+}
+
+func (t *txIterator) Cursor() []byte {
+	return makeCursor(t.block, t.txIndex, -1, t.biter.Reverted())
+}
+
+func (t *txIterator) Reverted() bool {
+	return t.biter.Reverted()
+}
+
+func (t *txIterator) Error() error {
+	return t.err
+}
+
+func (t *txIterator) Close() {
+	t.closed = true
+	t.biter.Close()
+}
@@ -0,0 +1,140 @@
+package event
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+var _ Topic = (*TxTopic)(nil)
+
+// TxTopic handles transaction events
+type TxTopic struct {
+	chainmg ChainManager
+}
+
+// NewTxTopic instances TxTopic from ChainManager
+func NewTxTopic(chainmg ChainManager) *TxTopic {
+	return &TxTopic{
+		chainmg: chainmg,
+	}
+}
+
+// ParseFilter 从指定的bytes buffer反序列化topic过滤器
+// 返回的参数会作为入参传递给NewIterator的filter参数
+func (t *TxTopic) ParseFilter(buf []byte) (interface{}, error) {
+	pbfilter := new(protos.TxFilter)
+	err := proto.Unmarshal(buf, pbfilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return pbfilter, nil
+}
+
+// MarshalEvent encode event payload returns from Iterator.Data()
+func (t *TxTopic) MarshalEvent(x interface{}) ([]byte, error) {
+	msg := x.(proto.Message)
+	return proto.Marshal(msg)
+}
+
+// NewIterator make a new Iterator base on filter
+func (t *TxTopic) NewIterator(ifilter interface{}) (Iterator, error) {
+	return t.NewCursorIterator(ifilter, nil)
+}
+
+// NewCursorIterator make a new Iterator base on filter,
+// non-empty cursor resumes from the event after cursor
+func (t *TxTopic) NewCursorIterator(ifilter interface{}, buf []byte) (Iterator, error) {
+	pbfilter, ok := ifilter.(*protos.TxFilter)
+	if !ok {
+		return nil, errors.New("bad filter type for tx event")
+	}
+	cursor, err := parseCursor(buf)
+	if err != nil {
+		return nil, err
+	}
+	var txid []byte
+	if pbfilter.GetTxid() != "" {
+		txid, err = hex.DecodeString(pbfilter.GetTxid())
+		if err != nil {
+			return nil, fmt.Errorf("error %s when parse txid", err)
+		}
+	}
+	filter, err := newBlockFilter(txFilterToBlockFilter(pbfilter))
+	if err != nil {
+		return nil, err
+	}
+
+	blockStore, err := t.chainmg.GetBlockStore(filter.GetBcname())
+	if err != nil {
+		return nil, err
+	}
+
+	blockRange := filter.GetRange()
+	if txid != nil && cursor == nil {
+		// 交易已经确认的情况下直接迭代所在区块，区块不在订阅范围内时不返回任何交易
+		block, err := blockStore.QueryBlockByTxid(txid)
+		if err == nil && block.GetInTrunk() {
+			inRange, err := inBlockRange(filter.GetRange(), block.GetHeight())
+			if err != nil {
+				return nil, err
+			}
+			end := block.GetHeight() + 1
+			if !inRange {
+				end = block.GetHeight()
+			}
+			blockRange = &protos.BlockRange{
+				Start: strconv.FormatInt(block.GetHeight(), 10),
+				End:   strconv.FormatInt(end, 10),
+			}
+		}
+	}
+	biter, err := newRangeBlockIterator(blockStore, blockRange, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return newTxIterator(biter, filter, txid, cursor), nil
+}
+
+func txFilterToBlockFilter(filter *protos.TxFilter) *protos.BlockFilter {
+	return &protos.BlockFilter{
+		Bcname:         filter.GetBcname(),
+		Range:          filter.GetRange(),
+		ExcludeTxEvent: filter.GetExcludeTxEvent(),
+		Contract:       filter.GetContract(),
+		EventName:      filter.GetEventName(),
+		Initiator:      filter.GetInitiator(),
+		AuthRequire:    filter.GetAuthRequire(),
+		FromAddr:       filter.GetFromAddr(),
+		ToAddr:         filter.GetToAddr(),
+		Expr:           filter.GetExpr(),
+	}
+}
+
+// inBlockRange 判断已确认的区块高度是否在订阅范围内，起止为空表示该方向不限制
+func inBlockRange(blockRange *protos.BlockRange, height int64) (bool, error) {
+	if blockRange.GetStart() != "" {
+		start, err := strconv.ParseInt(blockRange.GetStart(), 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("error %s when parse start block number", err)
+		}
+		if height < start {
+			return false, nil
+		}
+	}
+	if blockRange.GetEnd() != "" {
+		end, err := strconv.ParseInt(blockRange.GetEnd(), 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("error %s when parse end block number", err)
+		}
+		if height >= end {
+			return false, nil
+		}
+	}
+	return true, nil
+}
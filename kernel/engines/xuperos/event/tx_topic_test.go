@@ -0,0 +1,143 @@
+package event
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func TestTxTopicBasic(t *testing.T) {
+	ledger := newMockBlockStore()
+	const N = 3
+	var txids []string
+	for i := 0; i < N; i++ {
+		tx1 := newTxBuilder().Initiator("alice").Tx()
+		tx2 := newTxBuilder().Initiator("bob").Tx()
+		txids = append(txids, hex.EncodeToString(tx1.GetTxid()))
+		ledger.AppendBlock(newBlockBuilder().AddTx(tx1, tx2).Block())
+	}
+
+	topic := NewTxTopic(ledger)
+	iter, err := topic.NewIterator(&protos.TxFilter{
+		Range: &protos.BlockRange{
+			Start: "0",
+			End:   strconv.Itoa(N),
+		},
+		Initiator: "alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+
+	i := 0
+	for ; iter.Next(); i++ {
+		txEvent := iter.Data().(*protos.TxEvent)
+		if txEvent.GetTx().GetTxid() != txids[i] {
+			t.Errorf("expect %s got %s", txids[i], txEvent.GetTx().GetTxid())
+		}
+		if txEvent.GetBlockHeight() != int64(i) {
+			t.Errorf("expect height %d got %d", i, txEvent.GetBlockHeight())
+		}
+	}
+	if i != N {
+		t.Errorf("expect %d tx events got %d", N, i)
+	}
+}
+
+func TestTxTopicConfirmedTxid(t *testing.T) {
+	ledger := newMockBlockStore()
+	tx := newTxBuilder().Tx()
+	ledger.AppendBlock(newBlockBuilder().Block())
+	ledger.AppendBlock(newBlockBuilder().AddTx(tx).Block())
+	ledger.AppendBlock(newBlockBuilder().Block())
+
+	topic := NewTxTopic(ledger)
+	iter, err := topic.NewIterator(&protos.TxFilter{
+		Txid: hex.EncodeToString(tx.GetTxid()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+
+	if !iter.Next() {
+		t.Fatalf("expect tx event, error:%v", iter.Error())
+	}
+	txEvent := iter.Data().(*protos.TxEvent)
+	if txEvent.GetBlockHeight() != 1 {
+		t.Errorf("expect height 1 got %d", txEvent.GetBlockHeight())
+	}
+	if iter.Next() {
+		t.Error("expect iterator end after tx confirmed")
+	}
+}
+
+func TestTxTopicWaitTxid(t *testing.T) {
+	ledger := newMockBlockStore()
+	ledger.AppendBlock(newBlockBuilder().Block())
+	tx := newTxBuilder().Tx()
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		ledger.AppendBlock(newBlockBuilder().Block())
+		time.Sleep(time.Millisecond * 100)
+		ledger.AppendBlock(newBlockBuilder().AddTx(tx).Block())
+	}()
+
+	topic := NewTxTopic(ledger)
+	iter, err := topic.NewIterator(&protos.TxFilter{
+		Txid: hex.EncodeToString(tx.GetTxid()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close()
+
+	if !iter.Next() {
+		t.Fatalf("expect tx event, error:%v", iter.Error())
+	}
+	txEvent := iter.Data().(*protos.TxEvent)
+	if txEvent.GetTx().GetTxid() != hex.EncodeToString(tx.GetTxid()) {
+		t.Errorf("expect %x got %s", tx.GetTxid(), txEvent.GetTx().GetTxid())
+	}
+	if txEvent.GetBlockHeight() != 2 {
+		t.Errorf("expect height 2 got %d", txEvent.GetBlockHeight())
+	}
+}
+
+func TestTxTopicTxidWithFilters(t *testing.T) {
+	ledger := newMockBlockStore()
+	tx := newTxBuilder().Initiator("alice").Tx()
+	ledger.AppendBlock(newBlockBuilder().Block())
+	ledger.AppendBlock(newBlockBuilder().AddTx(tx).Block())
+	ledger.AppendBlock(newBlockBuilder().Block())
+
+	topic := NewTxTopic(ledger)
+	txid := hex.EncodeToString(tx.GetTxid())
+	cases := []struct {
+		filter *protos.TxFilter
+		expect bool
+	}{
+		{&protos.TxFilter{Txid: txid, Initiator: "alice"}, true},
+		{&protos.TxFilter{Txid: txid, Initiator: "bob"}, false},
+		{&protos.TxFilter{Txid: txid, Range: &protos.BlockRange{Start: "1", End: "2"}}, true},
+		{&protos.TxFilter{Txid: txid, Range: &protos.BlockRange{Start: "2"}}, false},
+		{&protos.TxFilter{Txid: txid, Range: &protos.BlockRange{End: "1"}}, false},
+	}
+	for i, c := range cases {
+		iter, err := topic.NewIterator(c.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := iter.Next(); got != c.expect {
+			t.Errorf("case %d: expect %v got %v, error:%v", i, c.expect, got, iter.Error())
+		}
+		if iter.Next() {
+			t.Errorf("case %d: expect iterator end", i)
+		}
+		iter.Close()
+	}
+}
@@ -0,0 +1,605 @@
+// 轻节点：只通过GET_BLOCK_HEADERS同步区块头，使用chained-bft的QC签名校验区块头链，
+// 可以内嵌在业务服务中，基于已确认的区块头校验交易证明和状态证明
+package lightclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
+	common "github.com/xuperchain/xupercore/kernel/consensus/base/common"
+	chainedBft "github.com/xuperchain/xupercore/kernel/consensus/base/driver/chained-bft"
+	cCrypto "github.com/xuperchain/xupercore/kernel/consensus/base/driver/chained-bft/crypto"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/reader/verifier"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/xpb"
+	"github.com/xuperchain/xupercore/kernel/network/p2p"
+	cryptoClient "github.com/xuperchain/xupercore/lib/crypto/client"
+	cryptoBase "github.com/xuperchain/xupercore/lib/crypto/client/base"
+	"github.com/xuperchain/xupercore/lib/logs"
+	"github.com/xuperchain/xupercore/lib/utils"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+const (
+	// 与全节点同步时单次请求的区块头个数一致
+	defaultBatchSize  = 10
+	defaultMaxHeaders = 10000
+)
+
+var (
+	ErrBadHeader      = errors.New("bad block header")
+	ErrBadProposer    = errors.New("bad block proposer")
+	ErrBadJustify     = errors.New("bad justify")
+	ErrNotEnoughVotes = errors.New("justify doesn't have enough votes")
+	ErrNotCertified   = errors.New("block header not certified")
+	ErrNoNewHeader    = errors.New("no new header found")
+	ErrNoProofSource  = errors.New("no state proof source")
+	ErrStaleProof     = errors.New("stale state proof")
+)
+
+// Network 轻节点依赖的网络能力，p2p.Server实现了该接口
+type Network interface {
+	SendMessageWithResponse(xctx.XContext, *protos.XuperMessage, ...p2p.OptionFunc) ([]*protos.XuperMessage, error)
+}
+
+// StateProofSource 获取height高度区块下bucket/key的状态证明，与reader的GetStateProof一致。
+// tdpos轮次的验证人集合由共识合约存储中的投票计算得到，xpoa的验证人集合保存在共识合约存储中，
+// 区块头中都没有记录，轻节点通过状态证明读取，并基于已确认的区块头校验
+type StateProofSource func(bucket string, key []byte, height int64) (*xpb.StateProof, error)
+
+// Config 轻节点配置
+type Config struct {
+	BCName string
+	// 共识名称，tdpos或xpoa，需开启chained-bft
+	Consensus string
+	// 共识起始高度，该高度及之前的区块没有justify
+	StartHeight int64
+	// 共识配置版本，用于拼接共识合约存储的key
+	ConsensusVersion int64
+	// tdpos每轮的验证人个数
+	ProposerNum int64
+	// tdpos初始验证人集合，候选人不足ProposerNum时使用
+	InitValidators []string
+	// 加密类型，为空时使用default
+	CryptoType string
+	// 单次请求的区块头个数
+	BatchSize int64
+	// 内存中保留的区块头个数
+	MaxHeaders int
+	// 向指定的节点同步，为空时按就近策略选择节点
+	Peers []string
+}
+
+// LightClient 轻节点，从可信的检查点开始同步并校验区块头
+type LightClient struct {
+	cfg        *Config
+	net        Network
+	crypto     cryptoBase.CryptoClient
+	bftCrypto  *cCrypto.CBFTCrypto
+	saftyRules *chainedBft.DefaultSaftyRules
+	validators *ValidatorTracker
+	proofFn    StateProofSource
+	log        logs.Logger
+
+	mutex      sync.RWMutex
+	checkpoint *lpb.InternalBlock
+	tip        *lpb.InternalBlock
+	headers    map[int64]*lpb.InternalBlock
+	heights    map[string]int64
+	// 已记录的xpoa验证人集合的最高生效高度
+	xpoaHeight int64
+}
+
+// NewLightClient 创建轻节点，checkpoint为可信的区块头（如创世块），validators需包含checkpoint高度的验证人集合
+func NewLightClient(cfg *Config, checkpoint *lpb.InternalBlock, validators *ValidatorTracker,
+	net Network, log logs.Logger) (*LightClient, error) {
+	if cfg == nil || checkpoint == nil || validators == nil || net == nil || log == nil {
+		return nil, errors.New("new light client param error")
+	}
+	if cfg.Consensus != ConsensusTdpos && cfg.Consensus != ConsensusXpoa {
+		return nil, fmt.Errorf("%s, consensus:%s", ErrUnknownConsensus, cfg.Consensus)
+	}
+	blockid, err := ledger.MakeBlockID(checkpoint)
+	if err != nil || !bytes.Equal(blockid, checkpoint.Blockid) {
+		return nil, fmt.Errorf("%s, checkpoint blockid mismatch", ErrBadHeader)
+	}
+	if len(validators.GetValidators(checkpoint.Height)) == 0 {
+		return nil, fmt.Errorf("%s, height:%d", ErrEmptyValidators, checkpoint.Height)
+	}
+	if cfg.Consensus == ConsensusTdpos && (cfg.ProposerNum <= 0 || len(cfg.InitValidators) == 0) {
+		return nil, fmt.Errorf("%s, tdpos proposer num and init validators required", ErrEmptyValidators)
+	}
+
+	cryptoType := cfg.CryptoType
+	if cryptoType == "" {
+		cryptoType = cryptoClient.CryptoTypeDefault
+	}
+	crypto, err := cryptoClient.CreateCryptoClient(cryptoType)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.MaxHeaders <= 0 {
+		cfg.MaxHeaders = defaultMaxHeaders
+	}
+
+	header := stripHeader(checkpoint)
+	return &LightClient{
+		cfg:        cfg,
+		net:        net,
+		crypto:     crypto,
+		bftCrypto:  cCrypto.NewCBFTCrypto(nil, crypto),
+		saftyRules: &chainedBft.DefaultSaftyRules{},
+		validators: validators,
+		log:        log,
+		checkpoint: header,
+		tip:        header,
+		headers:    map[int64]*lpb.InternalBlock{header.Height: header},
+		heights:    map[string]int64{string(header.Blockid): header.Height},
+		xpoaHeight: header.Height,
+	}, nil
+}
+
+// SetStateProofSource 设置获取共识合约存储状态证明的方法，tdpos在轮次变更时读取投票，
+// xpoa在每个区块被确认时读取验证人集合
+func (c *LightClient) SetStateProofSource(fn StateProofSource) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.proofFn = fn
+}
+
+// Tip 返回已校验的最高区块头，该区块头尚未被后续区块的QC确认
+func (c *LightClient) Tip() *lpb.InternalBlock {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.tip
+}
+
+// GetHeader 按高度查询已确认的区块头
+func (c *LightClient) GetHeader(height int64) (*lpb.InternalBlock, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	header, ok := c.headers[height]
+	if !ok || header == c.tip && header != c.checkpoint {
+		return nil, fmt.Errorf("%s, height:%d", ErrNotCertified, height)
+	}
+	return header, nil
+}
+
+// Sync 同步区块头直到没有新的区块，返回新增的区块头个数
+func (c *LightClient) Sync(ctx xctx.XContext) (int, error) {
+	total := 0
+	for {
+		size, err := c.SyncOnce(ctx)
+		total += size
+		if err == ErrNoNewHeader {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// SyncOnce 向节点请求一批区块头并校验，返回新增的区块头个数。请求从当前最高区块开始，
+// 以便发现尚未确认的最高区块被其他区块替换的情况
+func (c *LightClient) SyncOnce(ctx xctx.XContext) (int, error) {
+	input := &xpb.GetBlockHeaderRequest{
+		Bcname: c.cfg.BCName,
+		Height: c.Tip().Height,
+		Size:   c.cfg.BatchSize,
+	}
+	opts := []p2p.OptionFunc{}
+	if len(c.cfg.Peers) > 0 {
+		opts = append(opts, p2p.WithPeerIDs(c.cfg.Peers))
+	} else {
+		opts = append(opts, p2p.WithFilter([]p2p.FilterStrategy{p2p.NearestBucketStrategy}))
+	}
+	msg := p2p.NewMessage(protos.XuperMessage_GET_BLOCK_HEADERS, input, p2p.WithBCName(c.cfg.BCName))
+	responses, err := c.net.SendMessageWithResponse(ctx, msg, opts...)
+	if err != nil {
+		ctx.GetLog().Warn("light client get block headers error", "err", err)
+		return 0, err
+	}
+
+	// 每个节点返回的区块头独立校验，校验通过的部分直接接到本地链上
+	added := 0
+	var lastErr error
+	for _, response := range responses {
+		if response.GetHeader().GetErrorType() != protos.XuperMessage_SUCCESS {
+			continue
+		}
+		var output xpb.GetBlockHeaderResponse
+		if err := p2p.Unmarshal(response, &output); err != nil {
+			ctx.GetLog().Warn("light client unmarshal block headers error", "err", err)
+			continue
+		}
+		size, err := c.AddHeaders(output.Blocks)
+		added += size
+		if err != nil {
+			ctx.GetLog().Warn("light client verify block headers error", "from", response.GetHeader().GetFrom(), "err", err)
+			lastErr = err
+		}
+	}
+	if added > 0 {
+		tip := c.Tip()
+		ctx.GetLog().Info("light client sync block headers", "added", added,
+			"height", tip.Height, "blockid", utils.F(tip.Blockid))
+		return added, nil
+	}
+	if lastErr != nil {
+		return 0, lastErr
+	}
+	return 0, ErrNoNewHeader
+}
+
+// AddHeaders 校验并追加连续的区块头，返回新增的个数，遇到校验失败的区块头时停止。
+// 与最高区块同高度的不同区块头会替换最高区块
+func (c *LightClient) AddHeaders(headers []*lpb.InternalBlock) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	added := 0
+	for _, header := range headers {
+		if header == nil {
+			break
+		}
+		if header.Height <= c.tip.Height {
+			if header.Height < c.tip.Height || bytes.Equal(header.Blockid, c.tip.Blockid) {
+				continue
+			}
+			if c.tip == c.checkpoint {
+				return added, fmt.Errorf("%s, conflict with checkpoint, height:%d", ErrBadHeader, header.Height)
+			}
+			// 尚未确认的最高区块被替换
+			if err := c.addHeader(c.headers[c.tip.Height-1], header); err != nil {
+				return added, err
+			}
+			continue
+		}
+		if err := c.addHeader(c.tip, header); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
+
+func (c *LightClient) addHeader(prev, header *lpb.InternalBlock) error {
+	// 替换最高区块时撤销其带来的tdpos验证人变更，校验失败时恢复。
+	// xpoa的变更来自已确认的区块，替换最高区块后这些区块仍被确认，不需要撤销
+	replace := header.Height == c.tip.Height
+	if replace && c.cfg.Consensus == ConsensusTdpos {
+		removed := c.validators.rollback(header.Height)
+		if err := c.verifyAndAdd(prev, header); err != nil {
+			c.validators.restore(removed)
+			return err
+		}
+		return nil
+	}
+	return c.verifyAndAdd(prev, header)
+}
+
+func (c *LightClient) verifyAndAdd(prev, header *lpb.InternalBlock) error {
+	var newValidators []string
+	if c.cfg.Consensus == ConsensusTdpos && header.CurTerm != prev.CurTerm {
+		validators, err := c.tdposValidators(header.Height)
+		if err != nil {
+			return err
+		}
+		newValidators = validators
+	}
+	validators := newValidators
+	if validators == nil {
+		validators = c.validators.GetValidators(header.Height)
+	}
+	if err := c.verifyHeader(prev, header, validators); err != nil {
+		return err
+	}
+	// header的justify确认了prev，从prev的状态中读取xpoa验证人集合
+	var xpoaValidators []string
+	if c.cfg.Consensus == ConsensusXpoa && c.proofFn != nil && len(prev.StateRoot) > 0 {
+		bucket, key := XpoaValidatorsKey(c.cfg.ConsensusVersion)
+		value, err := c.verifyProvedValue(prev, bucket, key)
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			if xpoaValidators, err = parseXpoaValidators(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if header.Height == c.tip.Height {
+		delete(c.heights, string(c.tip.Blockid))
+	}
+	if newValidators != nil {
+		changed, err := c.validators.Update(header.Height, newValidators)
+		if err != nil {
+			return err
+		}
+		if changed {
+			c.log.Info("light client validators changed", "height", header.Height, "validators", newValidators)
+		}
+	}
+	if xpoaValidators != nil {
+		if _, err := c.updateXpoaValidators(prev.Height+xpoaValidatorsDelay, xpoaValidators); err != nil {
+			return err
+		}
+	}
+	header = stripHeader(header)
+	c.headers[header.Height] = header
+	c.heights[string(header.Blockid)] = header.Height
+	c.tip = header
+	c.prune()
+	return nil
+}
+
+// verifyHeader 校验区块头与前一个区块相连、区块ID和出块人签名正确，且justify中有足够的前一个区块验证人的投票
+func (c *LightClient) verifyHeader(prev, header *lpb.InternalBlock, validators []string) error {
+	if header.Height != prev.Height+1 || !bytes.Equal(header.PreHash, prev.Blockid) {
+		return fmt.Errorf("%s, not linked to previous header, height:%d, blockid:%s",
+			ErrBadHeader, header.Height, utils.F(header.Blockid))
+	}
+	blockid, err := ledger.MakeBlockID(header)
+	if err != nil {
+		return fmt.Errorf("%s, make blockid failed, err:%v", ErrBadHeader, err)
+	}
+	if !bytes.Equal(blockid, header.Blockid) {
+		return fmt.Errorf("%s, blockid mismatch, height:%d, blockid:%s, expect:%s",
+			ErrBadHeader, header.Height, utils.F(header.Blockid), utils.F(blockid))
+	}
+	if err := c.verifyProposer(header, validators); err != nil {
+		return err
+	}
+	// 共识初始化后的第一个区块没有justify
+	if header.Height <= c.cfg.StartHeight {
+		return nil
+	}
+	return c.verifyJustify(prev, header)
+}
+
+func (c *LightClient) verifyProposer(header *lpb.InternalBlock, validators []string) error {
+	if !isInSlice(string(header.Proposer), validators) {
+		return fmt.Errorf("%s, proposer %s not in validators, height:%d", ErrBadProposer, header.Proposer, header.Height)
+	}
+	k, err := c.crypto.GetEcdsaPublicKeyFromJsonStr(string(header.Pubkey))
+	if err != nil {
+		return fmt.Errorf("%s, bad public key, err:%v", ErrBadProposer, err)
+	}
+	if ok, _ := c.crypto.VerifyAddressUsingPublicKey(string(header.Proposer), k); !ok {
+		return fmt.Errorf("%s, address is not match public key, height:%d", ErrBadProposer, header.Height)
+	}
+	if ok, err := c.crypto.VerifyECDSA(k, header.Sign, header.Blockid); err != nil || !ok {
+		return fmt.Errorf("%s, bad block sign, height:%d, err:%v", ErrBadProposer, header.Height, err)
+	}
+	return nil
+}
+
+// verifyJustify 按chained-bft CheckProposal的规则校验justify中的投票，投票人为前一个区块的验证人
+func (c *LightClient) verifyJustify(prev, header *lpb.InternalBlock) error {
+	storage, err := json.Marshal(&common.ConsensusStorage{
+		Justify:    header.Justify,
+		CurTerm:    header.CurTerm,
+		TargetBits: header.TargetBits,
+	})
+	if err != nil {
+		return err
+	}
+	justify, err := common.OldQCToNew(storage)
+	if err != nil {
+		return fmt.Errorf("%s, height:%d, err:%v", ErrBadJustify, header.Height, err)
+	}
+	if !bytes.Equal(justify.GetProposalId(), prev.Blockid) || justify.GetProposalView() != prev.Height {
+		return fmt.Errorf("%s, justify is not for previous block, height:%d", ErrBadJustify, header.Height)
+	}
+
+	validators := c.validators.GetValidators(prev.Height)
+	voted := make(map[string]bool)
+	for _, sign := range justify.GetSignsInfo() {
+		if !isInSlice(sign.GetAddress(), validators) || voted[sign.GetAddress()] {
+			continue
+		}
+		if ok, _ := c.bftCrypto.VerifyVoteMsgSign(sign, justify.GetProposalId()); !ok {
+			return fmt.Errorf("%s, invalid vote sign from %s, height:%d", ErrBadJustify, sign.GetAddress(), header.Height)
+		}
+		voted[sign.GetAddress()] = true
+	}
+	if !c.saftyRules.CalVotesThreshold(len(voted), len(validators)) {
+		return fmt.Errorf("%s, height:%d, votes:%d, validators:%d", ErrNotEnoughVotes, header.Height, len(voted), len(validators))
+	}
+	return nil
+}
+
+// tdposValidators 按tdposSchedule.calTopKNominator的规则，使用height-3高度区块的候选人和投票计算新一轮的验证人
+func (c *LightClient) tdposValidators(height int64) ([]string, error) {
+	if height < c.cfg.StartHeight+3 {
+		return c.cfg.InitValidators, nil
+	}
+	bucket, key := TdposNominateKey(c.cfg.ConsensusVersion)
+	value, err := c.provedValue(height-3, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return c.cfg.InitValidators, nil
+	}
+	candidates, err := parseTdposCandidates(value)
+	if err != nil {
+		return nil, err
+	}
+	ballots := make(map[string]int64)
+	for _, candidate := range candidates {
+		bucket, key := TdposVoteKey(c.cfg.ConsensusVersion, candidate)
+		value, err := c.provedValue(height-3, bucket, key)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		total, err := sumTdposBallots(value)
+		if err != nil {
+			return nil, err
+		}
+		ballots[candidate] = total
+	}
+	return topKCandidates(ballots, c.cfg.ProposerNum, c.cfg.InitValidators), nil
+}
+
+// provedValue 通过状态证明读取已确认区块下bucket/key的值，key不存在时返回nil，调用方需持有锁
+func (c *LightClient) provedValue(height int64, bucket string, key []byte) ([]byte, error) {
+	if c.proofFn == nil {
+		return nil, ErrNoProofSource
+	}
+	header, ok := c.headers[height]
+	if !ok || header == c.tip && header != c.checkpoint {
+		return nil, fmt.Errorf("%s, height:%d", ErrNotCertified, height)
+	}
+	return c.verifyProvedValue(header, bucket, key)
+}
+
+// verifyProvedValue 通过状态证明读取header区块下bucket/key的值，header需已确认
+func (c *LightClient) verifyProvedValue(header *lpb.InternalBlock, bucket string, key []byte) ([]byte, error) {
+	proof, err := c.proofFn(bucket, key, header.Height)
+	if err != nil {
+		return nil, err
+	}
+	if proof == nil || proof.Bucket != bucket || !bytes.Equal(proof.Key, key) {
+		return nil, fmt.Errorf("%s, proof is not for %s/%s", statetree.ErrInvalidProof, bucket, key)
+	}
+	if err := verifier.VerifyStateProof(proof, header.Blockid); err != nil {
+		return nil, err
+	}
+	if !proof.Exist {
+		return nil, nil
+	}
+	return proof.Value, nil
+}
+
+// prune 只保留最近的MaxHeaders个区块头，检查点始终保留
+func (c *LightClient) prune() {
+	for height := c.tip.Height - int64(c.cfg.MaxHeaders); height > c.checkpoint.Height; height-- {
+		header, ok := c.headers[height]
+		if !ok {
+			break
+		}
+		delete(c.headers, height)
+		delete(c.heights, string(header.Blockid))
+	}
+}
+
+// certifiedHeader 返回已被后续区块的QC确认的区块头
+func (c *LightClient) certifiedHeader(blockid []byte) (*lpb.InternalBlock, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	height, ok := c.heights[string(blockid)]
+	if !ok || height == c.tip.Height && c.tip != c.checkpoint {
+		return nil, fmt.Errorf("%s, blockid:%s", ErrNotCertified, utils.F(blockid))
+	}
+	return c.headers[height], nil
+}
+
+// VerifyTxProof 校验交易证明，证明中的区块必须已同步且被后续区块确认
+func (c *LightClient) VerifyTxProof(proof *xpb.TxProof) error {
+	if proof == nil || proof.Header == nil {
+		return fmt.Errorf("%s, empty proof", verifier.ErrInvalidTxProof)
+	}
+	if _, err := c.certifiedHeader(proof.Header.Blockid); err != nil {
+		return err
+	}
+	return verifier.VerifyTxProof(proof, proof.Header.Blockid)
+}
+
+// VerifyStateProof 校验状态证明，证明中的区块必须已同步且被后续区块确认
+func (c *LightClient) VerifyStateProof(proof *xpb.StateProof) error {
+	_, err := c.verifyStateProof(proof)
+	return err
+}
+
+// verifyStateProof 校验状态证明并返回本地已确认的区块头。区块ID不包含高度，
+// 需要使用本地区块头的高度而不是证明中的
+func (c *LightClient) verifyStateProof(proof *xpb.StateProof) (*lpb.InternalBlock, error) {
+	if proof == nil || proof.Header == nil {
+		return nil, fmt.Errorf("%s, empty proof", verifier.ErrInvalidHeader)
+	}
+	header, err := c.certifiedHeader(proof.Header.Blockid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.VerifyStateProof(proof, header.Blockid); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// UpdateXpoaValidators 根据xpoa共识合约存储的状态证明更新验证人集合，
+// 区块中的验证人集合在xpoaValidatorsDelay个区块后生效。未设置StateProofSource时需要按高度顺序定期调用以跟踪验证人变更，
+// 生效高度不高于已记录的变更的证明会被拒绝，以免覆盖之后的变更
+func (c *LightClient) UpdateXpoaValidators(proof *xpb.StateProof) (bool, error) {
+	if c.cfg.Consensus != ConsensusXpoa {
+		return false, fmt.Errorf("%s, consensus:%s", ErrUnknownConsensus, c.cfg.Consensus)
+	}
+	bucket, key := XpoaValidatorsKey(c.cfg.ConsensusVersion)
+	if proof == nil || proof.Bucket != bucket || !bytes.Equal(proof.Key, key) {
+		return false, fmt.Errorf("%s, not xpoa validators", verifier.ErrInvalidHeader)
+	}
+	header, err := c.verifyStateProof(proof)
+	if err != nil {
+		return false, err
+	}
+	// 共识合约中没有记录时沿用当前集合
+	if !proof.Exist {
+		return false, nil
+	}
+	validators, err := parseXpoaValidators(proof.Value)
+	if err != nil {
+		return false, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	height := header.Height + xpoaValidatorsDelay
+	if height <= c.xpoaHeight {
+		return false, fmt.Errorf("%s, height:%d, latest:%d", ErrStaleProof, height, c.xpoaHeight)
+	}
+	return c.updateXpoaValidators(height, validators)
+}
+
+// updateXpoaValidators 记录从height开始生效的xpoa验证人集合，调用方需持有锁
+func (c *LightClient) updateXpoaValidators(height int64, validators []string) (bool, error) {
+	changed, err := c.validators.Update(height, validators)
+	if err != nil {
+		return false, err
+	}
+	c.xpoaHeight = height
+	if changed {
+		c.log.Info("light client xpoa validators changed", "height", height, "validators", validators)
+	}
+	return changed, nil
+}
+
+// stripHeader 只保留区块头，去掉交易和merkle树
+func stripHeader(block *lpb.InternalBlock) *lpb.InternalBlock {
+	header := *block
+	header.Transactions = nil
+	header.MerkleTree = nil
+	return &header
+}
+
+func isInSlice(target string, s []string) bool {
+	for _, v := range s {
+		if target == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,464 @@
+package lightclient
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/xpb"
+	"github.com/xuperchain/xupercore/kernel/mock"
+	"github.com/xuperchain/xupercore/kernel/network/p2p"
+	cryptoClient "github.com/xuperchain/xupercore/lib/crypto/client"
+	cryptoBase "github.com/xuperchain/xupercore/lib/crypto/client/base"
+	"github.com/xuperchain/xupercore/lib/logs"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	"github.com/xuperchain/xupercore/lib/timer"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+type testAccount struct {
+	address string
+	pubkey  string
+	priv    *ecdsa.PrivateKey
+}
+
+type testChain struct {
+	t        *testing.T
+	crypto   cryptoBase.CryptoClient
+	accounts map[string]*testAccount
+	blocks   []*lpb.InternalBlock
+	// 下一个区块的状态树根
+	stateRoot []byte
+}
+
+func newTestChain(t *testing.T, names ...string) *testChain {
+	crypto, err := cryptoClient.CreateCryptoClient(cryptoClient.CryptoTypeDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &testChain{t: t, crypto: crypto, accounts: make(map[string]*testAccount)}
+	for _, name := range names {
+		priv, err := crypto.GenerateKeyBySeed([]byte(strings.Repeat(name, 32)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		address, err := crypto.GetAddressFromPublicKey(&priv.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubkey, err := crypto.GetEcdsaPublicKeyJsonFormatStr(priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.accounts[name] = &testAccount{address: address, pubkey: pubkey, priv: priv}
+	}
+	genesis := &lpb.InternalBlock{Version: 1, Timestamp: 1, MerkleRoot: []byte("genesis")}
+	genesis.Blockid, _ = ledger.MakeBlockID(genesis)
+	c.blocks = append(c.blocks, genesis)
+	return c
+}
+
+func (c *testChain) addresses(names ...string) []string {
+	var out []string
+	for _, name := range names {
+		out = append(out, c.accounts[name].address)
+	}
+	return out
+}
+
+// makeBlock 基于prev生成proposer出的区块，voters对prev投票
+func (c *testChain) makeBlock(prev *lpb.InternalBlock, proposer string, term int64, voters ...string) *lpb.InternalBlock {
+	account := c.accounts[proposer]
+	block := &lpb.InternalBlock{
+		Version:    1,
+		Height:     prev.Height + 1,
+		PreHash:    prev.Blockid,
+		Proposer:   []byte(account.address),
+		Pubkey:     []byte(account.pubkey),
+		Timestamp:  prev.Timestamp + 1,
+		MerkleRoot: []byte(fmt.Sprintf("merkle-%d", prev.Height+1)),
+		CurTerm:    term,
+		StateRoot:  c.stateRoot,
+	}
+	if len(voters) > 0 {
+		parentMsg, _ := proto.Marshal(&lpb.QuorumCert{ProposalId: prev.PreHash, ViewNumber: prev.Height - 1})
+		justify := &lpb.QuorumCert{
+			ProposalId:  prev.Blockid,
+			ViewNumber:  prev.Height,
+			ProposalMsg: parentMsg,
+			SignInfos:   &lpb.QCSignInfos{},
+		}
+		for _, voter := range voters {
+			v := c.accounts[voter]
+			sign, err := c.crypto.SignECDSA(v.priv, prev.Blockid)
+			if err != nil {
+				c.t.Fatal(err)
+			}
+			justify.SignInfos.QCSignInfos = append(justify.SignInfos.QCSignInfos,
+				&lpb.SignInfo{Address: v.address, PublicKey: v.pubkey, Sign: sign})
+		}
+		block.Justify = justify
+	}
+	block.Blockid, _ = ledger.MakeBlockID(block)
+	sign, err := c.crypto.SignECDSA(account.priv, block.Blockid)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	block.Sign = sign
+	return block
+}
+
+func (c *testChain) extend(proposer string, term int64, voters ...string) *lpb.InternalBlock {
+	block := c.makeBlock(c.blocks[len(c.blocks)-1], proposer, term, voters...)
+	c.blocks = append(c.blocks, block)
+	return block
+}
+
+// SendMessageWithResponse 按GET_BLOCK_HEADERS请求返回区块头
+func (c *testChain) SendMessageWithResponse(ctx xctx.XContext, msg *protos.XuperMessage,
+	opts ...p2p.OptionFunc) ([]*protos.XuperMessage, error) {
+	var input xpb.GetBlockHeaderRequest
+	if err := p2p.Unmarshal(msg, &input); err != nil {
+		return nil, err
+	}
+	output := &xpb.GetBlockHeaderResponse{}
+	for h := input.Height; h < input.Height+input.Size && h < int64(len(c.blocks)); h++ {
+		output.Blocks = append(output.Blocks, c.blocks[h])
+	}
+	resp := p2p.NewMessage(protos.XuperMessage_GET_BLOCKS_HEADERS_RES, output,
+		p2p.WithErrorType(protos.XuperMessage_SUCCESS))
+	return []*protos.XuperMessage{resp}, nil
+}
+
+// testStateTree 生成共识合约存储的状态树和状态证明
+type testStateTree struct {
+	t    *testing.T
+	ldb  kvdb.Database
+	tree *statetree.Tree
+	root []byte
+	data map[string][]byte
+}
+
+func newTestStateTree(t *testing.T) (*testStateTree, func()) {
+	workspace, err := ioutil.TempDir("", "lightclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldb, err := kvdb.CreateKVInstance(&kvdb.KVParameter{
+		DBPath:                filepath.Join(workspace, "state"),
+		KVEngineType:          "leveldb",
+		MemCacheSize:          16,
+		FileHandlersCacheSize: 16,
+		StorageType:           "single",
+	})
+	if err != nil {
+		os.RemoveAll(workspace)
+		t.Fatal(err)
+	}
+	s := &testStateTree{t: t, ldb: ldb, tree: statetree.NewTree(ldb), root: statetree.EmptyRoot(),
+		data: make(map[string][]byte)}
+	return s, func() {
+		ldb.Close()
+		os.RemoveAll(workspace)
+	}
+}
+
+// put 写入json编码的值并返回新的状态树根
+func (s *testStateTree) put(bucket string, key []byte, value interface{}) []byte {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	session := s.tree.NewSession(s.root)
+	if err := session.Put(statetree.XModelKey(bucket, key), buf); err != nil {
+		s.t.Fatal(err)
+	}
+	batch := s.ldb.NewBatch()
+	session.Commit(batch)
+	if err := batch.Write(); err != nil {
+		s.t.Fatal(err)
+	}
+	s.root = session.Root()
+	s.data[bucket+string(key)] = buf
+	return s.root
+}
+
+func (s *testStateTree) prove(header *lpb.InternalBlock, bucket string, key []byte) *xpb.StateProof {
+	proof, err := s.tree.Prove(header.StateRoot, statetree.XModelKey(bucket, key))
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	value, exist := s.data[bucket+string(key)]
+	return &xpb.StateProof{
+		Header:        header,
+		Bucket:        bucket,
+		Key:           key,
+		Exist:         exist,
+		Value:         value,
+		Siblings:      proof.Siblings,
+		LeafKeyHash:   proof.LeafKeyHash,
+		LeafValueHash: proof.LeafValueHash,
+	}
+}
+
+func newTestContext(t *testing.T) xctx.XContext {
+	if err := mock.InitLogForTest(); err != nil {
+		t.Fatal(err)
+	}
+	log, err := logs.NewLogger("", "lightclient_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &xctx.BaseCtx{XLog: log, Timer: timer.NewXTimer()}
+}
+
+func TestSyncAndVerify(t *testing.T) {
+	ctx := newTestContext(t)
+	chain := newTestChain(t, "a", "b", "c", "d")
+	chain.extend("a", 1)
+	for i := 0; i < 12; i++ {
+		chain.extend([]string{"a", "b", "c"}[i%3], 1, "a", "b", "c")
+	}
+
+	validators, err := NewValidatorTracker(0, chain.addresses("a", "b", "c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{BCName: "xuper", Consensus: ConsensusTdpos, StartHeight: 1, BatchSize: 5,
+		ProposerNum: 3, InitValidators: chain.addresses("a", "b", "c")}
+	client, err := NewLightClient(cfg, chain.blocks[0], validators, chain, ctx.GetLog())
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, err := client.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 13 || client.Tip().Height != 13 {
+		t.Fatalf("unexpected sync result, added:%d, tip:%d", added, client.Tip().Height)
+	}
+	if _, err := client.GetHeader(12); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetHeader(13); err == nil {
+		t.Fatal("expect tip not certified")
+	}
+
+	// 投票不足
+	tip := chain.blocks[len(chain.blocks)-1]
+	if _, err := client.AddHeaders([]*lpb.InternalBlock{chain.makeBlock(tip, "a", 1, "a")}); err == nil {
+		t.Fatal("expect not enough votes")
+	}
+	// 非验证人的投票不计入
+	if _, err := client.AddHeaders([]*lpb.InternalBlock{chain.makeBlock(tip, "a", 1, "a", "d")}); err == nil {
+		t.Fatal("expect votes from non validator to be ignored")
+	}
+	// 非验证人出块
+	if _, err := client.AddHeaders([]*lpb.InternalBlock{chain.makeBlock(tip, "d", 1, "a", "b", "c")}); err == nil {
+		t.Fatal("expect invalid proposer")
+	}
+	// 篡改区块头
+	forged := chain.makeBlock(tip, "b", 1, "a", "b", "c")
+	forged.Timestamp++
+	if _, err := client.AddHeaders([]*lpb.InternalBlock{forged}); err == nil {
+		t.Fatal("expect forged header to fail")
+	}
+
+	// 替换尚未确认的最高区块
+	replaced := chain.makeBlock(chain.blocks[12], "c", 1, "a", "b")
+	if _, err := client.AddHeaders([]*lpb.InternalBlock{replaced}); err != nil {
+		t.Fatal(err)
+	}
+	if string(client.Tip().Blockid) != string(replaced.Blockid) {
+		t.Fatal("expect tip to be replaced")
+	}
+}
+
+func TestValidatorChange(t *testing.T) {
+	ctx := newTestContext(t)
+	chain := newTestChain(t, "a", "b", "c", "d")
+	stateTree, clean := newTestStateTree(t)
+	defer clean()
+
+	// 高度2的状态中b、c、d得票最多
+	nominateBucket, nominateKey := TdposNominateKey(0)
+	nominate := make(map[string]map[string]int64)
+	for name, ballots := range map[string]int64{"a": 1, "b": 2, "c": 3, "d": 4} {
+		address := chain.accounts[name].address
+		nominate[address] = map[string]int64{"txid": 1}
+		voteBucket, voteKey := TdposVoteKey(0, address)
+		stateTree.put(voteBucket, voteKey, map[string]int64{"voter": ballots})
+	}
+	chain.extend("a", 1)
+	chain.stateRoot = stateTree.put(nominateBucket, nominateKey, nominate)
+	chain.extend("b", 1, "a", "b", "c")
+	chain.stateRoot = nil
+	chain.extend("c", 1, "a", "b", "c")
+	chain.extend("a", 1, "a", "b", "c")
+	// 新的轮次由b、c、d出块，justify仍由上一轮的验证人投票
+	chain.extend("d", 2, "a", "b", "c")
+	chain.extend("c", 2, "b", "c", "d")
+
+	validators, _ := NewValidatorTracker(0, chain.addresses("a", "b", "c"))
+	cfg := &Config{BCName: "xuper", Consensus: ConsensusTdpos, StartHeight: 1,
+		ProposerNum: 3, InitValidators: chain.addresses("a", "b", "c")}
+	client, err := NewLightClient(cfg, chain.blocks[0], validators, chain, ctx.GetLog())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sync(ctx); err == nil {
+		t.Fatal("expect sync to fail without state proof source")
+	}
+	if client.Tip().Height != 4 {
+		t.Fatalf("unexpected tip %d", client.Tip().Height)
+	}
+
+	// 篡改投票的状态证明
+	client.SetStateProofSource(func(bucket string, key []byte, height int64) (*xpb.StateProof, error) {
+		proof := stateTree.prove(chain.blocks[height], bucket, key)
+		if bucket == nominateBucket && string(key) == string(nominateKey) {
+			proof.Value = []byte(`{}`)
+		}
+		return proof, nil
+	})
+	if _, err := client.Sync(ctx); err == nil {
+		t.Fatal("expect forged state proof to fail")
+	}
+
+	client.SetStateProofSource(func(bucket string, key []byte, height int64) (*xpb.StateProof, error) {
+		return stateTree.prove(chain.blocks[height], bucket, key), nil
+	})
+	if _, err := client.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if client.Tip().Height != 6 {
+		t.Fatalf("unexpected tip %d", client.Tip().Height)
+	}
+	if got := validators.GetValidators(4); len(got) != 3 || got[0] != chain.accounts["a"].address {
+		t.Fatalf("unexpected validators before change %v", got)
+	}
+	if got := validators.GetValidators(5); len(got) != 3 || !isInSlice(chain.accounts["d"].address, got) ||
+		isInSlice(chain.accounts["a"].address, got) {
+		t.Fatalf("unexpected validators after change %v", got)
+	}
+}
+
+func TestUpdateXpoaValidators(t *testing.T) {
+	ctx := newTestContext(t)
+	chain := newTestChain(t, "a", "b", "c", "d")
+	stateTree, clean := newTestStateTree(t)
+	defer clean()
+
+	bucket, key := XpoaValidatorsKey(0)
+	chain.extend("a", 1)
+	chain.stateRoot = stateTree.put(bucket, key, &xpoaProposerInfo{Address: chain.addresses("b", "c", "d")})
+	chain.extend("b", 1, "a", "b", "c")
+	chain.stateRoot = nil
+	chain.extend("c", 1, "a", "b", "c")
+
+	validators, _ := NewValidatorTracker(0, chain.addresses("a", "b", "c"))
+	cfg := &Config{BCName: "xuper", Consensus: ConsensusXpoa, StartHeight: 1}
+	client, err := NewLightClient(cfg, chain.blocks[0], validators, chain, ctx.GetLog())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 区块ID不包含高度，证明中篡改的高度不能影响生效高度
+	proof := stateTree.prove(chain.blocks[2], bucket, key)
+	header := *proof.Header
+	header.Height = 100
+	proof.Header = &header
+	changed, err := client.UpdateXpoaValidators(proof)
+	if err != nil || !changed {
+		t.Fatalf("expect validators changed, err:%v", err)
+	}
+	if got := validators.GetValidators(2 + xpoaValidatorsDelay); !isInSlice(chain.accounts["d"].address, got) {
+		t.Fatalf("unexpected validators after change %v", got)
+	}
+	if got := validators.GetValidators(1 + xpoaValidatorsDelay); isInSlice(chain.accounts["d"].address, got) {
+		t.Fatalf("unexpected validators before change %v", got)
+	}
+	// 生效高度不高于已记录的变更的证明不能覆盖之后的变更
+	if _, err := client.UpdateXpoaValidators(stateTree.prove(chain.blocks[2], bucket, key)); err == nil {
+		t.Fatal("expect stale proof to fail")
+	}
+}
+
+func TestTrackXpoaValidators(t *testing.T) {
+	ctx := newTestContext(t)
+	chain := newTestChain(t, "a", "b", "c", "d")
+	stateTree, clean := newTestStateTree(t)
+	defer clean()
+
+	bucket, key := XpoaValidatorsKey(0)
+	chain.extend("a", 1)
+	chain.stateRoot = stateTree.put(bucket, key, &xpoaProposerInfo{Address: chain.addresses("b", "c", "d")})
+	chain.extend("b", 1, "a", "b", "c")
+	chain.extend("c", 1, "a", "b", "c")
+	chain.extend("a", 1, "a", "b", "c")
+
+	validators, _ := NewValidatorTracker(0, chain.addresses("a", "b", "c"))
+	cfg := &Config{BCName: "xuper", Consensus: ConsensusXpoa, StartHeight: 1}
+	client, err := NewLightClient(cfg, chain.blocks[0], validators, chain, ctx.GetLog())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetStateProofSource(func(bucket string, key []byte, height int64) (*xpb.StateProof, error) {
+		return stateTree.prove(chain.blocks[height], bucket, key), nil
+	})
+	if _, err := client.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if client.Tip().Height != 4 {
+		t.Fatalf("unexpected tip %d", client.Tip().Height)
+	}
+	if got := validators.GetValidators(2 + xpoaValidatorsDelay); !isInSlice(chain.accounts["d"].address, got) {
+		t.Fatalf("unexpected validators after change %v", got)
+	}
+	if got := validators.GetValidators(1 + xpoaValidatorsDelay); isInSlice(chain.accounts["d"].address, got) {
+		t.Fatalf("unexpected validators before change %v", got)
+	}
+	// 已自动记录的变更不能被旧的证明覆盖
+	if _, err := client.UpdateXpoaValidators(stateTree.prove(chain.blocks[2], bucket, key)); err == nil {
+		t.Fatal("expect stale proof to fail")
+	}
+}
+
+func TestTopKCandidates(t *testing.T) {
+	defaults := []string{"x"}
+	got := topKCandidates(map[string]int64{"a": 5, "b": 7, "c": 5, "d": 0}, 2, defaults)
+	if len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Fatalf("unexpected top candidates %v", got)
+	}
+	if got := topKCandidates(map[string]int64{"a": 5, "d": 0}, 2, defaults); len(got) != 1 || got[0] != "x" {
+		t.Fatalf("expect default validators, got %v", got)
+	}
+}
+
+func TestParseInitValidators(t *testing.T) {
+	validators, err := ParseInitValidators(ConsensusTdpos, []byte(`{"init_proposer": {"1": ["a", "b"]}}`))
+	if err != nil || len(validators) != 2 {
+		t.Fatalf("parse tdpos init validators failed, %v, err:%v", validators, err)
+	}
+	validators, err = ParseInitValidators(ConsensusXpoa, []byte(`{"init_proposer": {"address": ["a"]}}`))
+	if err != nil || len(validators) != 1 {
+		t.Fatalf("parse xpoa init validators failed, %v, err:%v", validators, err)
+	}
+	if _, err := ParseInitValidators("pow", []byte(`{}`)); err == nil {
+		t.Fatal("expect unknown consensus")
+	}
+}
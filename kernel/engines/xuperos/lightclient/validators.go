@@ -0,0 +1,221 @@
+package lightclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	common "github.com/xuperchain/xupercore/kernel/consensus/base/common"
+)
+
+const (
+	ConsensusTdpos = "tdpos"
+	ConsensusXpoa  = "xpoa"
+
+	// 开启chained-bft的xpoa验证人集合在共识合约中的存储位置
+	xpoaBucket        = "$xpoa"
+	xpoaValidatorsKey = "validates"
+	// xpoa验证人变更在写入区块的后4个区块生效，见xpoaSchedule.GetLocalValidates
+	xpoaValidatorsDelay = 4
+
+	// tdpos候选人和投票在共识合约中的存储位置，见tdposSchedule.calTopKNominator
+	tdposBucket        = "$tdpos"
+	tdposNominateKey   = "nominate"
+	tdposVoteKeyPrefix = "vote_"
+)
+
+var (
+	ErrUnknownConsensus = errors.New("unknown consensus")
+	ErrEmptyValidators  = errors.New("empty validators")
+)
+
+// ValidatorTracker 记录验证人集合的变更，每个集合从生效高度开始对之后的区块有效
+type ValidatorTracker struct {
+	mutex   sync.RWMutex
+	changes []*validatorChange
+}
+
+type validatorChange struct {
+	height     int64
+	validators []string
+}
+
+// NewValidatorTracker 创建验证人集合记录，validators为从height开始生效的集合
+func NewValidatorTracker(height int64, validators []string) (*ValidatorTracker, error) {
+	if len(validators) == 0 {
+		return nil, ErrEmptyValidators
+	}
+	return &ValidatorTracker{
+		changes: []*validatorChange{{height: height, validators: validators}},
+	}, nil
+}
+
+// GetValidators 返回对height高度区块出块和投票的验证人集合
+func (t *ValidatorTracker) GetValidators(height int64) []string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	idx := sort.Search(len(t.changes), func(i int) bool {
+		return t.changes[i].height > height
+	})
+	if idx == 0 {
+		return nil
+	}
+	return t.changes[idx-1].validators
+}
+
+// Update 记录从height开始生效的验证人集合，会覆盖height及之后已记录的变更，返回集合是否发生变化
+func (t *ValidatorTracker) Update(height int64, validators []string) (bool, error) {
+	if len(validators) == 0 {
+		return false, ErrEmptyValidators
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	idx := sort.Search(len(t.changes), func(i int) bool {
+		return t.changes[i].height >= height
+	})
+	if idx == 0 {
+		return false, fmt.Errorf("height %d is lower than the first validators height %d", height, t.changes[0].height)
+	}
+	t.changes = t.changes[:idx]
+	if common.AddressEqual(t.changes[idx-1].validators, validators) {
+		return false, nil
+	}
+	t.changes = append(t.changes, &validatorChange{height: height, validators: validators})
+	return true, nil
+}
+
+// rollback 撤销height及之后生效的变更，第一个集合始终保留，返回被撤销的变更
+func (t *ValidatorTracker) rollback(height int64) []*validatorChange {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	idx := sort.Search(len(t.changes), func(i int) bool {
+		return t.changes[i].height >= height
+	})
+	if idx == 0 {
+		idx = 1
+	}
+	removed := append([]*validatorChange{}, t.changes[idx:]...)
+	t.changes = t.changes[:idx]
+	return removed
+}
+
+// restore 恢复rollback撤销的变更
+func (t *ValidatorTracker) restore(changes []*validatorChange) {
+	if len(changes) == 0 {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	idx := sort.Search(len(t.changes), func(i int) bool {
+		return t.changes[i].height >= changes[0].height
+	})
+	t.changes = append(t.changes[:idx], changes...)
+}
+
+// ParseInitValidators 从创世块的共识配置中解析初始验证人集合
+func ParseInitValidators(consensus string, config []byte) ([]string, error) {
+	var validators []string
+	switch consensus {
+	case ConsensusTdpos:
+		var cfg struct {
+			InitProposer map[string][]string `json:"init_proposer"`
+		}
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+		validators = cfg.InitProposer["1"]
+	case ConsensusXpoa:
+		var cfg struct {
+			InitProposer xpoaProposerInfo `json:"init_proposer"`
+		}
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+		validators = cfg.InitProposer.Address
+	default:
+		return nil, fmt.Errorf("%s, consensus:%s", ErrUnknownConsensus, consensus)
+	}
+	if len(validators) == 0 {
+		return nil, ErrEmptyValidators
+	}
+	return validators, nil
+}
+
+// xpoaProposerInfo xpoa验证人集合的存储格式 { "address": [$ADDR_STRING...] }
+type xpoaProposerInfo struct {
+	Address []string `json:"address"`
+}
+
+// XpoaValidatorsKey 开启chained-bft的xpoa在共识合约存储中保存验证人集合的bucket和key
+func XpoaValidatorsKey(version int64) (string, []byte) {
+	return xpoaBucket, []byte(fmt.Sprintf("%d_%s", version, xpoaValidatorsKey))
+}
+
+func parseXpoaValidators(value []byte) ([]string, error) {
+	var info xpoaProposerInfo
+	if err := json.Unmarshal(value, &info); err != nil {
+		return nil, err
+	}
+	if len(info.Address) == 0 {
+		return nil, ErrEmptyValidators
+	}
+	return info.Address, nil
+}
+
+// TdposNominateKey tdpos在共识合约存储中保存候选人的bucket和key
+func TdposNominateKey(version int64) (string, []byte) {
+	return tdposBucket, []byte(fmt.Sprintf("%s_%d_%s", ConsensusTdpos, version, tdposNominateKey))
+}
+
+// TdposVoteKey tdpos在共识合约存储中保存候选人得票的bucket和key
+func TdposVoteKey(version int64, candidate string) (string, []byte) {
+	return tdposBucket, []byte(fmt.Sprintf("%s_%d_%s%s", ConsensusTdpos, version, tdposVoteKeyPrefix, candidate))
+}
+
+// parseTdposCandidates 候选人的存储格式 { $CANDIDATE: { $TXID: $AMOUNT } }
+func parseTdposCandidates(value []byte) ([]string, error) {
+	nominate := make(map[string]map[string]int64)
+	if err := json.Unmarshal(value, &nominate); err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for candidate := range nominate {
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// sumTdposBallots 得票的存储格式 { $VOTER: $BALLOTS }
+func sumTdposBallots(value []byte) (int64, error) {
+	votes := make(map[string]int64)
+	if err := json.Unmarshal(value, &votes); err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, ballots := range votes {
+		total += ballots
+	}
+	return total, nil
+}
+
+// topKCandidates 按票数从高到低选出k个候选人，票数相同时地址大的优先，有效候选人不足k个时返回defaults
+func topKCandidates(ballots map[string]int64, k int64, defaults []string) []string {
+	var candidates []string
+	for candidate, total := range ballots {
+		if total > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if int64(len(candidates)) < k {
+		return defaults
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if ballots[candidates[i]] == ballots[candidates[j]] {
+			return candidates[i] > candidates[j]
+		}
+		return ballots[candidates[i]] > ballots[candidates[j]]
+	})
+	return candidates[:k]
+}
@@ -1,12 +1,27 @@
 package reader
 
 import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/burrow/crypto"
+	"github.com/xuperchain/crypto/core/hash"
+
+	"github.com/xuperchain/xupercore/bcs/contract/evm"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state"
 	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
+	"github.com/xuperchain/xupercore/kernel/contract/bridge"
 	"github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
+	kledger "github.com/xuperchain/xupercore/kernel/ledger"
 	"github.com/xuperchain/xupercore/lib/logs"
 	"github.com/xuperchain/xupercore/protos"
 )
 
+const (
+	// 单次查询最多返回的合约事件个数
+	MaxQueryContractEventLimit = 1000
+)
+
 type ContractReader interface {
 	// 查询该链合约统计数据
 	QueryContractStatData() (*protos.ContractStatData, error)
@@ -22,6 +37,17 @@ type ContractReader interface {
 	QueryContractMethodACL(contract, method string) (*protos.Acl, error)
 	// 查询账户治理代币余额
 	QueryAccountGovernTokenBalance(account string) (*protos.GovernTokenBalance, error)
+	// 查询合约代码的所有版本，按版本号升序排列
+	GetContractVersions(contractName string) ([]*protos.ContractVersion, error)
+	// 查询指定区块高度时生效的合约版本和代码
+	GetContractCodeAtHeight(contractName string, height int64) (*protos.ContractVersion, []byte, error)
+	// 从事件索引中查询合约在区块高度[startHeight, endHeight]之间的指定事件，
+	// cursor为上一页返回的游标，返回的游标为空表示没有更多事件，limit<=0时返回最多MaxQueryContractEventLimit个
+	QueryContractEvents(contractName, eventName string, startHeight, endHeight int64,
+		cursor []byte, limit int) ([]*protos.ContractEventInfo, []byte, error)
+	// 查询十六进制EVM地址对应的XuperChain地址、合约账户或合约名，
+	// 返回名字和类型(xchain、contract-account、contract-name)
+	QueryEVMAddress(evmAddr string) (string, string, error)
 }
 
 type contractReader struct {
@@ -130,3 +156,162 @@ func (t *contractReader) QueryAccountGovernTokenBalance(account string) (*protos
 
 	return amount, nil
 }
+
+func (t *contractReader) GetContractVersions(contractName string) ([]*protos.ContractVersion, error) {
+	if contractName == "" {
+		return nil, common.ErrParameter
+	}
+
+	xmreader := t.chainCtx.State.CreateXMReader()
+	value, err := getContractState(xmreader, bridge.ContractLatestVersionKey(contractName))
+	if err != nil {
+		return nil, err
+	}
+	latest, err := bridge.ParseContractVersion(value)
+	if err != nil {
+		return nil, common.ErrInternal.More("%v", err)
+	}
+
+	versions := make([]*protos.ContractVersion, 0, latest)
+	for version := int64(1); version <= latest; version++ {
+		record, err := t.getContractVersion(xmreader, contractName, version)
+		if err == common.ErrTxNotExist {
+			// 最新的版本所在交易还未上链
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, record)
+	}
+	return versions, nil
+}
+
+func (t *contractReader) GetContractCodeAtHeight(contractName string,
+	height int64) (*protos.ContractVersion, []byte, error) {
+	if contractName == "" || height < 0 || height > t.chainCtx.Ledger.GetMeta().GetTrunkHeight() {
+		return nil, nil, common.ErrParameter
+	}
+
+	versions, err := t.GetContractVersions(contractName)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 版本按部署高度递增，取部署高度不超过height的最后一个版本
+	var active *protos.ContractVersion
+	for _, version := range versions {
+		if version.GetHeight() > height {
+			break
+		}
+		active = version
+	}
+	if active == nil {
+		return nil, nil, common.ErrContractVersionNotExist.More("contract %s at height %d", contractName, height)
+	}
+
+	code, err := t.getContractVersionCode(active)
+	if err != nil {
+		return nil, nil, err
+	}
+	return active, code, nil
+}
+
+func (t *contractReader) QueryContractEvents(contractName, eventName string, startHeight, endHeight int64,
+	cursor []byte, limit int) ([]*protos.ContractEventInfo, []byte, error) {
+	if limit <= 0 || limit > MaxQueryContractEventLimit {
+		limit = MaxQueryContractEventLimit
+	}
+	events, next, err := t.chainCtx.State.QueryContractEvents(contractName, eventName,
+		startHeight, endHeight, cursor, limit)
+	switch err {
+	case nil:
+		return events, next, nil
+	case state.ErrEventIndexDisabled:
+		return nil, nil, common.ErrEventIndexDisabled
+	case state.ErrInvalidEventQuery:
+		return nil, nil, common.ErrParameter
+	default:
+		t.log.Warn("query contract events error", "contract", contractName, "event", eventName, "err", err)
+		return nil, nil, common.ErrInternal.More("%v", err)
+	}
+}
+
+// getContractVersion 读取版本记录，并根据写入版本记录的交易填充交易id和部署高度
+func (t *contractReader) getContractVersion(xmreader kledger.XMReader,
+	contractName string, version int64) (*protos.ContractVersion, error) {
+	verData, err := xmreader.Get("contract", bridge.ContractVersionKey(contractName, version))
+	if err != nil {
+		return nil, common.ErrInternal.More("%v", err)
+	}
+	if !isValidState(verData) {
+		return nil, common.ErrContractVersionNotExist.More("contract %s version %d", contractName, version)
+	}
+	record := new(protos.ContractVersion)
+	if err := proto.Unmarshal(verData.GetPureData().GetValue(), record); err != nil {
+		return nil, common.ErrInternal.More("%v", err)
+	}
+
+	tx, err := t.chainCtx.Ledger.QueryTransaction(verData.GetRefTxid())
+	if err != nil {
+		t.log.Warn("query contract version tx error", "contract", contractName, "version", version, "err", err)
+		return nil, common.ErrTxNotExist
+	}
+	block, err := t.chainCtx.Ledger.QueryBlockHeader(tx.GetBlockid())
+	if err != nil {
+		t.log.Warn("query contract version block error", "contract", contractName, "version", version, "err", err)
+		return nil, common.ErrBlockNotExist
+	}
+	record.Txid = tx.GetTxid()
+	record.Height = block.GetHeight()
+	return record, nil
+}
+
+// getContractVersionCode 从写入版本记录的部署或升级交易中取出合约代码，并校验代码哈希
+func (t *contractReader) getContractVersionCode(version *protos.ContractVersion) ([]byte, error) {
+	tx, err := t.chainCtx.Ledger.QueryTransaction(version.GetTxid())
+	if err != nil {
+		t.log.Warn("query contract version tx error", "contract", version.GetContractName(),
+			"version", version.GetVersion(), "err", err)
+		return nil, common.ErrTxNotExist
+	}
+	for _, req := range tx.GetContractRequests() {
+		args := req.GetArgs()
+		if string(args["contract_name"]) != version.GetContractName() || args["contract_code"] == nil {
+			continue
+		}
+		if bytes.Equal(hash.DoubleSha256(args["contract_code"]), version.GetCodeHash()) {
+			return args["contract_code"], nil
+		}
+	}
+	return nil, common.ErrContractVersionNotExist.More("code of contract %s version %d not found in tx %x",
+		version.GetContractName(), version.GetVersion(), version.GetTxid())
+}
+
+func getContractState(xmreader kledger.XMReader, key []byte) ([]byte, error) {
+	verData, err := xmreader.Get("contract", key)
+	if err != nil {
+		return nil, common.ErrInternal.More("%v", err)
+	}
+	if !isValidState(verData) {
+		return nil, common.ErrContractVersionNotExist.More("%s", key)
+	}
+	return verData.GetPureData().GetValue(), nil
+}
+
+func (t *contractReader) QueryEVMAddress(evmAddr string) (string, string, error) {
+	addr, err := crypto.AddressFromHexString(evmAddr)
+	if err != nil {
+		return "", "", common.ErrParameter.More("%v", err)
+	}
+	name, addrType, err := evm.DetermineEVMAddress(addr)
+	if err != nil {
+		return "", "", common.ErrParameter.More("%v", err)
+	}
+	if addrType == evm.ContractNameType {
+		// 合约名映射的地址需要合约已经部署
+		if _, err := t.chainCtx.State.GetContractStatus(name); err != nil {
+			return "", "", common.CastError(err)
+		}
+	}
+	return name, addrType, nil
+}
@@ -1,6 +1,10 @@
 package reader
 
 import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+
 	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
 	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
 	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
@@ -19,6 +23,10 @@ type LedgerReader interface {
 	// 通过区块高度查询区块信息（GetBlockByHeight）
 	QueryBlockByHeight(height int64, needContent bool) (*xpb.BlockInfo, error)
 	QueryBlockHeaderByHeight(height int64) (*xpb.BlockInfo, error)
+	// 查询已确认交易的merkle证明
+	GetTxProof(txId []byte) (*xpb.TxProof, error)
+	// 查询指定高度的状态证明，height小于0表示最新高度，目前只支持最新高度
+	GetStateProof(bucket string, key []byte, height int64) (*xpb.StateProof, error)
 }
 
 type ledgerReader struct {
@@ -183,3 +191,88 @@ func (t *ledgerReader) QueryBlockHeaderByHeight(height int64) (*xpb.BlockInfo, e
 
 	return out, nil
 }
+
+func (t *ledgerReader) GetTxProof(txId []byte) (*xpb.TxProof, error) {
+	tx, err := t.chainCtx.Ledger.QueryTransaction(txId)
+	if err != nil {
+		t.log.Warn("ledger query tx error", "txId", utils.F(txId), "error", err)
+		return nil, common.ErrTxNotExist
+	}
+
+	block, err := t.chainCtx.Ledger.QueryBlockHeader(tx.Blockid)
+	if err != nil {
+		t.log.Warn("query block error", "txId", utils.F(txId), "blockId", utils.F(tx.Blockid), "error", err)
+		return nil, common.ErrBlockNotExist
+	}
+
+	index := -1
+	for i := 0; i < int(block.TxCount) && i < len(block.MerkleTree); i++ {
+		if bytes.Equal(block.MerkleTree[i], tx.Txid) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, common.ErrInternal.More("tx not in merkle tree of block %s", utils.F(tx.Blockid))
+	}
+	path, err := ledger.MakeMerklePath(block.MerkleTree, index)
+	if err != nil {
+		return nil, common.ErrInternal.More("%v", err)
+	}
+
+	return &xpb.TxProof{
+		Header:     proofHeader(block),
+		Tx:         tx,
+		Index:      int32(index),
+		MerklePath: path,
+	}, nil
+}
+
+func (t *ledgerReader) GetStateProof(bucket string, key []byte, height int64) (*xpb.StateProof, error) {
+	if bucket == "" || len(key) == 0 {
+		return nil, common.ErrParameter.More("bucket and key required")
+	}
+
+	proof, err := t.chainCtx.State.GetStateProof(bucket, key)
+	if err != nil {
+		t.log.Warn("get state proof error", "bucket", bucket, "key", string(key), "error", err)
+		return nil, common.ErrInternal.More("%v", err)
+	}
+
+	block, err := t.chainCtx.Ledger.QueryBlockHeader(proof.Blockid)
+	if err != nil {
+		t.log.Warn("query block error", "blockId", utils.F(proof.Blockid), "error", err)
+		return nil, common.ErrBlockNotExist
+	}
+	// 状态树只保留最新状态的节点
+	if height >= 0 && height != block.Height {
+		return nil, common.ErrParameter.More("state proof only available at tip height %d", block.Height)
+	}
+	if len(block.StateRoot) == 0 {
+		return nil, common.ErrParameter.More("block %s has no state root", utils.F(block.Blockid))
+	}
+
+	out := &xpb.StateProof{
+		Header:        proofHeader(block),
+		Bucket:        bucket,
+		Key:           key,
+		Siblings:      proof.Proof.Siblings,
+		LeafKeyHash:   proof.Proof.LeafKeyHash,
+		LeafValueHash: proof.Proof.LeafValueHash,
+	}
+	if proof.Data != nil {
+		out.Exist = true
+		out.Value = proof.Data.PureData.Value
+		out.RefTxid = proof.Data.RefTxid
+		out.RefOffset = proof.Data.RefOffset
+	}
+	return out, nil
+}
+
+// proofHeader 去掉交易和merkle树的区块头，保留计算区块ID需要的字段
+func proofHeader(block *lpb.InternalBlock) *lpb.InternalBlock {
+	header := proto.Clone(block).(*lpb.InternalBlock)
+	header.Transactions = nil
+	header.MerkleTree = nil
+	return header
+}
@@ -0,0 +1,143 @@
+package reader
+
+import (
+	xctx "github.com/xuperchain/xupercore/kernel/common/xcontext"
+	"github.com/xuperchain/xupercore/kernel/contract/sandbox"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/common"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/xpb"
+	kledger "github.com/xuperchain/xupercore/kernel/ledger"
+	"github.com/xuperchain/xupercore/lib/logs"
+)
+
+const (
+	// 单次遍历最多返回的kv个数
+	MaxSelectStateLimit = 1000
+)
+
+type StateReader interface {
+	// 查询指定区块高度时合约状态中key的值，key不存在或已删除时返回的states为空
+	GetStateAtHeight(bucket string, key []byte, height int64) (*xpb.StateInfo, error)
+	// 遍历指定区块高度时bucket中的kv，区间为[startKey, endKey)，limit<=0时返回最多MaxSelectStateLimit个
+	SelectStateAtHeight(bucket string, startKey, endKey []byte, height int64, limit int) (*xpb.StateInfo, error)
+}
+
+type stateReader struct {
+	chainCtx *common.ChainCtx
+	baseCtx  xctx.XContext
+	log      logs.Logger
+}
+
+func NewStateReader(chainCtx *common.ChainCtx, baseCtx xctx.XContext) StateReader {
+	if chainCtx == nil || baseCtx == nil {
+		return nil
+	}
+
+	reader := &stateReader{
+		chainCtx: chainCtx,
+		baseCtx:  baseCtx,
+		log:      baseCtx.GetLog(),
+	}
+
+	return reader
+}
+
+func (t *stateReader) GetStateAtHeight(bucket string, key []byte, height int64) (*xpb.StateInfo, error) {
+	if bucket == "" || len(key) < 1 {
+		return nil, common.ErrParameter
+	}
+
+	out, snapshot, err := t.createSnapshot(height)
+	if err != nil {
+		return nil, err
+	}
+
+	verData, err := snapshot.Get(bucket, key)
+	if err != nil {
+		t.log.Warn("get state from snapshot error", "bucket", bucket, "height", height, "err", err)
+		return nil, common.ErrInternal.More("%v", err)
+	}
+	if !isValidState(verData) {
+		return out, nil
+	}
+
+	out.States = append(out.States, toStateData(verData))
+	return out, nil
+}
+
+func (t *stateReader) SelectStateAtHeight(bucket string, startKey, endKey []byte,
+	height int64, limit int) (*xpb.StateInfo, error) {
+	if bucket == "" {
+		return nil, common.ErrParameter
+	}
+	if limit <= 0 || limit > MaxSelectStateLimit {
+		limit = MaxSelectStateLimit
+	}
+
+	out, snapshot, err := t.createSnapshot(height)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := snapshot.Select(bucket, startKey, endKey)
+	if err != nil {
+		t.log.Warn("select state from snapshot error", "bucket", bucket, "height", height, "err", err)
+		return nil, common.ErrInternal.More("%v", err)
+	}
+	defer iter.Close()
+
+	for len(out.States) < limit && iter.Next() {
+		if !isValidState(iter.Value()) {
+			continue
+		}
+		out.States = append(out.States, toStateData(iter.Value()))
+	}
+	if iter.Error() != nil {
+		t.log.Warn("iterate state snapshot error", "bucket", bucket, "height", height, "err", iter.Error())
+		return nil, common.ErrInternal.More("%v", iter.Error())
+	}
+
+	return out, nil
+}
+
+// createSnapshot 根据主干上指定高度的区块创建状态快照
+func (t *stateReader) createSnapshot(height int64) (*xpb.StateInfo, kledger.XMReader, error) {
+	if height < 0 || height > t.chainCtx.Ledger.GetMeta().GetTrunkHeight() {
+		return nil, nil, common.ErrParameter.More("height %d out of range", height)
+	}
+
+	block, err := t.chainCtx.Ledger.QueryBlockHeaderByHeight(height)
+	if err != nil {
+		t.log.Warn("query block by height error", "height", height, "err", err)
+		return nil, nil, common.ErrBlockNotExist
+	}
+
+	snapshot, err := t.chainCtx.State.CreateSnapshot(block.GetBlockid())
+	if err != nil {
+		t.log.Warn("create state snapshot error", "height", height, "err", err)
+		return nil, nil, common.ErrInternal.More("%v", err)
+	}
+
+	out := &xpb.StateInfo{
+		Height:  block.GetHeight(),
+		Blockid: block.GetBlockid(),
+	}
+	return out, snapshot, nil
+}
+
+// isValidState 判断快照中的值是否存在且未被删除
+func isValidState(verData *kledger.VersionedData) bool {
+	if verData == nil || sandbox.IsEmptyVersionedData(verData) {
+		return false
+	}
+	return !sandbox.IsDelFlag(verData.GetPureData().GetValue())
+}
+
+func toStateData(verData *kledger.VersionedData) *xpb.StateData {
+	return &xpb.StateData{
+		Bucket:    verData.GetPureData().GetBucket(),
+		Key:       verData.GetPureData().GetKey(),
+		Value:     verData.GetPureData().GetValue(),
+		RefTxid:   verData.RefTxid,
+		RefOffset: verData.RefOffset,
+	}
+}
@@ -0,0 +1,94 @@
+// 独立校验reader返回的交易证明和状态证明，不依赖节点，可以由链下服务直接使用
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo/txhash"
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/xpb"
+)
+
+var (
+	// ErrInvalidHeader is returned when block header does not match its blockid
+	ErrInvalidHeader = errors.New("invalid block header")
+	// ErrUntrustedBlock is returned when blockid is different from the trusted one
+	ErrUntrustedBlock = errors.New("block is not trusted")
+	// ErrInvalidTxProof is returned when tx is not included in the block
+	ErrInvalidTxProof = errors.New("invalid tx proof")
+)
+
+// VerifyTxProof 校验交易包含在区块中。区块头本身只校验与区块ID一致，
+// trustedBlockid不为空时要求区块ID与之相同，区块是否可信由调用方保证
+func VerifyTxProof(proof *xpb.TxProof, trustedBlockid []byte) error {
+	if proof == nil || proof.Tx == nil {
+		return fmt.Errorf("%s, empty proof", ErrInvalidTxProof)
+	}
+	header := proof.Header
+	if err := verifyHeader(header, trustedBlockid); err != nil {
+		return err
+	}
+
+	txid, err := txhash.MakeTransactionID(proof.Tx)
+	if err != nil {
+		return fmt.Errorf("%s, make txid failed, err:%v", ErrInvalidTxProof, err)
+	}
+	if !bytes.Equal(txid, proof.Tx.Txid) {
+		return fmt.Errorf("%s, txid mismatch, txid:%x, expect:%x", ErrInvalidTxProof, proof.Tx.Txid, txid)
+	}
+	if proof.Index < 0 || proof.Index >= header.TxCount {
+		return fmt.Errorf("%s, index %d out of range, tx count:%d", ErrInvalidTxProof, proof.Index, header.TxCount)
+	}
+	if !ledger.VerifyMerklePath(txid, int(proof.Index), proof.MerklePath, header.MerkleRoot) {
+		return fmt.Errorf("%s, merkle root mismatch, txid:%x", ErrInvalidTxProof, txid)
+	}
+	return nil
+}
+
+// VerifyStateProof 校验区块的状态树根下bucket/key的值，Exist为false时校验key不存在。
+// 证明只覆盖数据的值，RefTxid和RefOffset可以通过对应交易的VerifyTxProof校验
+func VerifyStateProof(proof *xpb.StateProof, trustedBlockid []byte) error {
+	if proof == nil {
+		return fmt.Errorf("%s, empty proof", statetree.ErrInvalidProof)
+	}
+	header := proof.Header
+	if err := verifyHeader(header, trustedBlockid); err != nil {
+		return err
+	}
+	if len(header.StateRoot) == 0 {
+		return fmt.Errorf("%s, block has no state root", statetree.ErrInvalidProof)
+	}
+
+	var value []byte
+	if proof.Exist {
+		// 空值反序列化后为nil，需要与不存在区分
+		value = append([]byte{}, proof.Value...)
+	}
+	return statetree.VerifyProof(header.StateRoot, statetree.XModelKey(proof.Bucket, proof.Key), value,
+		&statetree.Proof{
+			Siblings:      proof.Siblings,
+			LeafKeyHash:   proof.LeafKeyHash,
+			LeafValueHash: proof.LeafValueHash,
+		})
+}
+
+func verifyHeader(header *lpb.InternalBlock, trustedBlockid []byte) error {
+	if header == nil {
+		return fmt.Errorf("%s, empty header", ErrInvalidHeader)
+	}
+	blockid, err := ledger.MakeBlockID(header)
+	if err != nil {
+		return fmt.Errorf("%s, make blockid failed, err:%v", ErrInvalidHeader, err)
+	}
+	if !bytes.Equal(blockid, header.Blockid) {
+		return fmt.Errorf("%s, blockid mismatch, blockid:%x, expect:%x", ErrInvalidHeader, header.Blockid, blockid)
+	}
+	if len(trustedBlockid) > 0 && !bytes.Equal(blockid, trustedBlockid) {
+		return fmt.Errorf("%s, blockid:%x, trusted:%x", ErrUntrustedBlock, blockid, trustedBlockid)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package verifier
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/ledger"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/statetree"
+	"github.com/xuperchain/xupercore/bcs/ledger/xledger/state/utxo/txhash"
+	lpb "github.com/xuperchain/xupercore/bcs/ledger/xledger/xldgpb"
+	"github.com/xuperchain/xupercore/kernel/engines/xuperos/xpb"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+	_ "github.com/xuperchain/xupercore/lib/storage/kvdb/leveldb"
+	"github.com/xuperchain/xupercore/protos"
+)
+
+func makeHeader(t *testing.T, txs []*lpb.Transaction, stateRoot []byte) (*lpb.InternalBlock, [][]byte) {
+	tree := ledger.MakeMerkleTree(txs)
+	header := &lpb.InternalBlock{
+		Version:    1,
+		TxCount:    int32(len(txs)),
+		PreHash:    []byte("prehash"),
+		Timestamp:  1,
+		MerkleRoot: tree[len(tree)-1],
+		StateRoot:  stateRoot,
+	}
+	blockid, err := ledger.MakeBlockID(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Blockid = blockid
+	return header, tree
+}
+
+func TestVerifyTxProof(t *testing.T) {
+	var txs []*lpb.Transaction
+	for i := 0; i < 5; i++ {
+		tx := &lpb.Transaction{
+			Version:   1,
+			Desc:      []byte{byte(i)},
+			Initiator: "alice",
+			TxOutputs: []*protos.TxOutput{{ToAddr: []byte("bob"), Amount: []byte{byte(i + 1)}}},
+		}
+		txid, err := txhash.MakeTransactionID(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.Txid = txid
+		txs = append(txs, tx)
+	}
+	header, tree := makeHeader(t, txs, nil)
+
+	for i, tx := range txs {
+		path, err := ledger.MakeMerklePath(tree, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof := &xpb.TxProof{Header: header, Tx: tx, Index: int32(i), MerklePath: path}
+		if err := VerifyTxProof(proof, header.Blockid); err != nil {
+			t.Fatalf("verify tx %d failed, err:%v", i, err)
+		}
+		if err := VerifyTxProof(proof, []byte("other")); err == nil {
+			t.Fatalf("expect untrusted block for tx %d", i)
+		}
+	}
+
+	path, _ := ledger.MakeMerklePath(tree, 1)
+	// 交易内容被篡改
+	forged := *txs[1]
+	forged.Desc = []byte("forged")
+	if err := VerifyTxProof(&xpb.TxProof{Header: header, Tx: &forged, Index: 1, MerklePath: path}, nil); err == nil {
+		t.Fatal("expect forged tx to fail")
+	}
+	// 区块头被篡改
+	forgedHeader := *header
+	forgedHeader.TxCount = 6
+	if err := VerifyTxProof(&xpb.TxProof{Header: &forgedHeader, Tx: txs[1], Index: 1, MerklePath: path}, nil); err == nil {
+		t.Fatal("expect forged header to fail")
+	}
+	// 序号超出区块交易数
+	if err := VerifyTxProof(&xpb.TxProof{Header: header, Tx: txs[1], Index: 5, MerklePath: path}, nil); err == nil {
+		t.Fatal("expect out of range index to fail")
+	}
+}
+
+func TestVerifyStateProof(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+	ldb, err := kvdb.CreateKVInstance(&kvdb.KVParameter{
+		DBPath:                filepath.Join(workspace, "state"),
+		KVEngineType:          "leveldb",
+		MemCacheSize:          16,
+		FileHandlersCacheSize: 16,
+		StorageType:           "single",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+
+	stateTree := statetree.NewTree(ldb)
+	session := stateTree.NewSession(statetree.EmptyRoot())
+	for _, key := range []string{"k1", "k2", "k3", "empty"} {
+		value := []byte("v" + key)
+		if key == "empty" {
+			value = []byte{}
+		}
+		if err := session.Put(statetree.XModelKey("bucket", []byte(key)), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	batch := ldb.NewBatch()
+	session.Commit(batch)
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	root := session.Root()
+	header, _ := makeHeader(t, []*lpb.Transaction{{Txid: []byte("tx")}}, root)
+
+	makeProof := func(key string, exist bool, value []byte) *xpb.StateProof {
+		proof, err := stateTree.Prove(root, statetree.XModelKey("bucket", []byte(key)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &xpb.StateProof{
+			Header:        header,
+			Bucket:        "bucket",
+			Key:           []byte(key),
+			Exist:         exist,
+			Value:         value,
+			Siblings:      proof.Siblings,
+			LeafKeyHash:   proof.LeafKeyHash,
+			LeafValueHash: proof.LeafValueHash,
+		}
+	}
+
+	if err := VerifyStateProof(makeProof("k1", true, []byte("vk1")), header.Blockid); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyStateProof(makeProof("empty", true, nil), header.Blockid); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyStateProof(makeProof("missing", false, nil), header.Blockid); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyStateProof(makeProof("k1", true, []byte("forged")), header.Blockid); err == nil {
+		t.Fatal("expect forged value to fail")
+	}
+	if err := VerifyStateProof(makeProof("k1", false, nil), header.Blockid); err == nil {
+		t.Fatal("expect existing key to fail non-existence proof")
+	}
+	if err := VerifyStateProof(makeProof("missing", true, nil), header.Blockid); err == nil {
+		t.Fatal("expect missing key to fail existence proof")
+	}
+	if err := VerifyStateProof(makeProof("k1", true, []byte("vk1")), []byte("other")); err == nil {
+		t.Fatal("expect untrusted block to fail")
+	}
+}
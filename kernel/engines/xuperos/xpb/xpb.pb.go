@@ -620,6 +620,316 @@ func (m *GetBlockTxsResponse) GetTxs() []*xldgpb.Transaction {
 	return nil
 }
 
+type StateData struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key    []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value  []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// 写入该值的交易及其在TxOutputsExt中的序号
+	RefTxid              []byte   `protobuf:"bytes,4,opt,name=ref_txid,json=refTxid,proto3" json:"ref_txid,omitempty"`
+	RefOffset            int32    `protobuf:"varint,5,opt,name=ref_offset,json=refOffset,proto3" json:"ref_offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateData) Reset()         { *m = StateData{} }
+func (m *StateData) String() string { return proto.CompactTextString(m) }
+func (*StateData) ProtoMessage()    {}
+func (*StateData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9685bde11a1952e, []int{12}
+}
+
+func (m *StateData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateData.Unmarshal(m, b)
+}
+func (m *StateData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateData.Marshal(b, m, deterministic)
+}
+func (m *StateData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateData.Merge(m, src)
+}
+func (m *StateData) XXX_Size() int {
+	return xxx_messageInfo_StateData.Size(m)
+}
+func (m *StateData) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateData proto.InternalMessageInfo
+
+func (m *StateData) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *StateData) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateData) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateData) GetRefTxid() []byte {
+	if m != nil {
+		return m.RefTxid
+	}
+	return nil
+}
+
+func (m *StateData) GetRefOffset() int32 {
+	if m != nil {
+		return m.RefOffset
+	}
+	return 0
+}
+
+type StateInfo struct {
+	// 查询所在的区块
+	Height               int64        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Blockid              []byte       `protobuf:"bytes,2,opt,name=blockid,proto3" json:"blockid,omitempty"`
+	States               []*StateData `protobuf:"bytes,3,rep,name=states,proto3" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StateInfo) Reset()         { *m = StateInfo{} }
+func (m *StateInfo) String() string { return proto.CompactTextString(m) }
+func (*StateInfo) ProtoMessage()    {}
+func (*StateInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9685bde11a1952e, []int{13}
+}
+
+func (m *StateInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateInfo.Unmarshal(m, b)
+}
+func (m *StateInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateInfo.Marshal(b, m, deterministic)
+}
+func (m *StateInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateInfo.Merge(m, src)
+}
+func (m *StateInfo) XXX_Size() int {
+	return xxx_messageInfo_StateInfo.Size(m)
+}
+func (m *StateInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateInfo proto.InternalMessageInfo
+
+func (m *StateInfo) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateInfo) GetBlockid() []byte {
+	if m != nil {
+		return m.Blockid
+	}
+	return nil
+}
+
+func (m *StateInfo) GetStates() []*StateData {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+type TxProof struct {
+	// 交易所在区块的区块头，不包含交易和merkle树
+	Header *xldgpb.InternalBlock `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Tx     *xldgpb.Transaction   `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	// 交易在区块中的序号
+	Index int32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// 从叶子到merkle根每一层的兄弟节点
+	MerklePath           [][]byte `protobuf:"bytes,4,rep,name=merkle_path,json=merklePath,proto3" json:"merkle_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxProof) Reset()         { *m = TxProof{} }
+func (m *TxProof) String() string { return proto.CompactTextString(m) }
+func (*TxProof) ProtoMessage()    {}
+func (*TxProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9685bde11a1952e, []int{14}
+}
+
+func (m *TxProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxProof.Unmarshal(m, b)
+}
+func (m *TxProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxProof.Marshal(b, m, deterministic)
+}
+func (m *TxProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxProof.Merge(m, src)
+}
+func (m *TxProof) XXX_Size() int {
+	return xxx_messageInfo_TxProof.Size(m)
+}
+func (m *TxProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxProof proto.InternalMessageInfo
+
+func (m *TxProof) GetHeader() *xldgpb.InternalBlock {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *TxProof) GetTx() *xldgpb.Transaction {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *TxProof) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *TxProof) GetMerklePath() [][]byte {
+	if m != nil {
+		return m.MerklePath
+	}
+	return nil
+}
+
+type StateProof struct {
+	// 状态所在区块的区块头，不包含交易和merkle树
+	Header *xldgpb.InternalBlock `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Bucket string                `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key    []byte                `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// key是否存在，不存在时value为空
+	Exist bool   `protobuf:"varint,4,opt,name=exist,proto3" json:"exist,omitempty"`
+	Value []byte `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// 写入该值的交易及其在TxOutputsExt中的序号
+	RefTxid   []byte `protobuf:"bytes,6,opt,name=ref_txid,json=refTxid,proto3" json:"ref_txid,omitempty"`
+	RefOffset int32  `protobuf:"varint,7,opt,name=ref_offset,json=refOffset,proto3" json:"ref_offset,omitempty"`
+	// 从状态树根到路径终点的兄弟节点
+	Siblings [][]byte `protobuf:"bytes,8,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	// 路径终止于其他key的叶子时，该叶子的key哈希和value哈希，用于证明key不存在
+	LeafKeyHash          []byte   `protobuf:"bytes,9,opt,name=leaf_key_hash,json=leafKeyHash,proto3" json:"leaf_key_hash,omitempty"`
+	LeafValueHash        []byte   `protobuf:"bytes,10,opt,name=leaf_value_hash,json=leafValueHash,proto3" json:"leaf_value_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProof) Reset()         { *m = StateProof{} }
+func (m *StateProof) String() string { return proto.CompactTextString(m) }
+func (*StateProof) ProtoMessage()    {}
+func (*StateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9685bde11a1952e, []int{15}
+}
+
+func (m *StateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProof.Unmarshal(m, b)
+}
+func (m *StateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProof.Marshal(b, m, deterministic)
+}
+func (m *StateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProof.Merge(m, src)
+}
+func (m *StateProof) XXX_Size() int {
+	return xxx_messageInfo_StateProof.Size(m)
+}
+func (m *StateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProof proto.InternalMessageInfo
+
+func (m *StateProof) GetHeader() *xldgpb.InternalBlock {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *StateProof) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *StateProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateProof) GetExist() bool {
+	if m != nil {
+		return m.Exist
+	}
+	return false
+}
+
+func (m *StateProof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateProof) GetRefTxid() []byte {
+	if m != nil {
+		return m.RefTxid
+	}
+	return nil
+}
+
+func (m *StateProof) GetRefOffset() int32 {
+	if m != nil {
+		return m.RefOffset
+	}
+	return 0
+}
+
+func (m *StateProof) GetSiblings() [][]byte {
+	if m != nil {
+		return m.Siblings
+	}
+	return nil
+}
+
+func (m *StateProof) GetLeafKeyHash() []byte {
+	if m != nil {
+		return m.LeafKeyHash
+	}
+	return nil
+}
+
+func (m *StateProof) GetLeafValueHash() []byte {
+	if m != nil {
+		return m.LeafValueHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Transactions)(nil), "protos.Transactions")
 	proto.RegisterType((*TxInfo)(nil), "protos.TxInfo")
@@ -633,6 +943,10 @@ func init() {
 	proto.RegisterType((*GetBlockHeaderResponse)(nil), "protos.GetBlockHeaderResponse")
 	proto.RegisterType((*GetBlockTxsRequest)(nil), "protos.GetBlockTxsRequest")
 	proto.RegisterType((*GetBlockTxsResponse)(nil), "protos.GetBlockTxsResponse")
+	proto.RegisterType((*StateData)(nil), "protos.StateData")
+	proto.RegisterType((*StateInfo)(nil), "protos.StateInfo")
+	proto.RegisterType((*TxProof)(nil), "protos.TxProof")
+	proto.RegisterType((*StateProof)(nil), "protos.StateProof")
 }
 
 func init() {
@@ -640,47 +954,64 @@ func init() {
 }
 
 var fileDescriptor_e9685bde11a1952e = []byte{
-	// 672 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x51, 0x6b, 0xdb, 0x3a,
-	0x14, 0x26, 0x71, 0x9a, 0xc6, 0xc7, 0xb9, 0x6d, 0x51, 0x69, 0xf1, 0xed, 0xe5, 0x42, 0xea, 0xad,
-	0x2c, 0x50, 0x9a, 0xd0, 0x94, 0xed, 0x61, 0xf4, 0xa9, 0x1d, 0xb4, 0x85, 0x6d, 0x0f, 0x6e, 0x0a,
-	0x63, 0x83, 0x19, 0xc5, 0x3e, 0x4d, 0x44, 0x1d, 0xc9, 0x93, 0xe4, 0xe2, 0x8d, 0xfd, 0x96, 0xfd,
-	0x93, 0xfd, 0xb7, 0x61, 0xc9, 0x4e, 0xb3, 0xb2, 0xac, 0x0f, 0xc6, 0x3a, 0x47, 0xdf, 0xa7, 0xef,
-	0x3b, 0xc7, 0xf2, 0x81, 0xe7, 0x77, 0x28, 0x39, 0xa6, 0x43, 0xe4, 0x53, 0xc6, 0x51, 0x0d, 0x8b,
-	0x3c, 0x43, 0x29, 0xd4, 0xb0, 0xc8, 0x26, 0xe5, 0x33, 0xc8, 0xa4, 0xd0, 0x82, 0xb4, 0xcd, 0x4b,
-	0xed, 0x1d, 0x9b, 0xed, 0x58, 0x48, 0x1c, 0x4e, 0x62, 0x35, 0x4c, 0x31, 0x99, 0xa2, 0x1c, 0x16,
-	0x8b, 0x77, 0x32, 0x2d, 0x69, 0x36, 0xb4, 0xd4, 0xe0, 0x25, 0x74, 0xc7, 0x92, 0x72, 0x45, 0x63,
-	0xcd, 0x04, 0x57, 0xe4, 0x00, 0x1c, 0x5d, 0x28, 0xbf, 0xd1, 0x73, 0xfa, 0xde, 0x68, 0x7b, 0x60,
-	0x39, 0x83, 0x25, 0x48, 0x58, 0xee, 0x07, 0xdf, 0xa1, 0x3d, 0x2e, 0xae, 0xf8, 0xad, 0x20, 0xc7,
-	0xd0, 0x56, 0x9a, 0xea, 0xbc, 0xe4, 0x34, 0xfa, 0x1b, 0xa3, 0x7f, 0xff, 0xc0, 0xb9, 0x36, 0x80,
-	0xb0, 0x02, 0x92, 0x3d, 0xe8, 0x24, 0x4c, 0x69, 0xca, 0x63, 0xf4, 0x9b, 0xbd, 0x46, 0xdf, 0x09,
-	0x17, 0x31, 0x79, 0x06, 0x4d, 0x5d, 0xf8, 0x4e, 0xaf, 0xb1, 0x4a, 0xbe, 0xa9, 0x8b, 0x00, 0xc1,
-	0x3d, 0x4b, 0x45, 0x7c, 0x67, 0x0c, 0x1c, 0x3e, 0x32, 0xb0, 0x60, 0x19, 0xc8, 0x23, 0xe9, 0x43,
-	0x58, 0x9b, 0x94, 0x69, 0xa3, 0xeb, 0x8d, 0x76, 0x6a, 0xec, 0x15, 0xd7, 0x28, 0x39, 0x4d, 0x0d,
-	0x27, 0xb4, 0x98, 0xe0, 0x67, 0x03, 0xbc, 0xf3, 0x19, 0x65, 0x95, 0x7f, 0x72, 0x02, 0x9e, 0xed,
-	0x5d, 0x34, 0x47, 0x4d, 0x8d, 0x9c, 0x37, 0x22, 0xf5, 0x11, 0x6f, 0xcd, 0xd6, 0x3b, 0xd4, 0x34,
-	0x84, 0x74, 0xb1, 0x26, 0x47, 0xe0, 0xe6, 0xba, 0x10, 0x96, 0x62, 0x55, 0xb7, 0x6a, 0xca, 0x8d,
-	0x2e, 0x84, 0x21, 0x74, 0xf2, 0x6a, 0xf5, 0x60, 0xd0, 0x79, 0xda, 0x20, 0xf9, 0x1f, 0x60, 0x22,
-	0x29, 0x8f, 0x67, 0x11, 0x4b, 0x94, 0xdf, 0xea, 0x39, 0x7d, 0x37, 0x74, 0x6d, 0xe6, 0x2a, 0x51,
-	0x41, 0x0c, 0xdd, 0xeb, 0xaf, 0x4a, 0xe3, 0xbc, 0xf2, 0xff, 0x0a, 0xba, 0x71, 0x59, 0x4e, 0xb4,
-	0xd4, 0xaf, 0xb2, 0xcb, 0xf6, 0xf6, 0x0c, 0x96, 0x4a, 0x0d, 0xbd, 0x78, 0xa9, 0xee, 0xff, 0xc0,
-	0xcd, 0x10, 0x65, 0x94, 0xcb, 0x54, 0xf9, 0x4d, 0xa3, 0xd2, 0x29, 0x13, 0x37, 0x32, 0x55, 0xc1,
-	0x11, 0xb8, 0x63, 0x96, 0x55, 0xc8, 0x1e, 0x74, 0x99, 0x8a, 0xb4, 0xcc, 0xf9, 0x5d, 0xa4, 0x59,
-	0x66, 0x14, 0x3a, 0x21, 0x30, 0x35, 0x2e, 0x53, 0x63, 0x96, 0x05, 0x9f, 0x61, 0xdd, 0x7e, 0xba,
-	0x37, 0x64, 0x17, 0xda, 0x93, 0x98, 0xd3, 0x39, 0x1a, 0x98, 0x1b, 0x56, 0x11, 0xf1, 0x61, 0xdd,
-	0x94, 0xc7, 0x12, 0xd3, 0xaf, 0x6e, 0x58, 0x87, 0x64, 0x1f, 0xba, 0x1c, 0x31, 0x89, 0x62, 0xc1,
-	0x35, 0x72, 0x6d, 0x7a, 0xd4, 0x09, 0xbd, 0x32, 0x77, 0x6e, 0x53, 0xc1, 0x8f, 0x06, 0x6c, 0x9e,
-	0x0b, 0xae, 0x90, 0xab, 0x5c, 0x55, 0xae, 0x7c, 0x58, 0xbf, 0x47, 0xa9, 0x98, 0xe0, 0x95, 0x52,
-	0x1d, 0x92, 0x03, 0xd8, 0x88, 0x6b, 0x70, 0x64, 0xac, 0x34, 0x0d, 0xe0, 0x9f, 0x45, 0xf6, 0x7d,
-	0xe9, 0x68, 0x1f, 0xba, 0x4a, 0x53, 0xa9, 0xa3, 0x19, 0xb2, 0xe9, 0xcc, 0xea, 0xba, 0xa1, 0x67,
-	0x72, 0x97, 0x26, 0x45, 0x5e, 0xc0, 0xe6, 0x3d, 0x4d, 0x59, 0x42, 0xb5, 0x90, 0x2a, 0x62, 0xfc,
-	0x56, 0xf8, 0x2d, 0x83, 0xda, 0x78, 0x48, 0x97, 0xd7, 0x35, 0xf8, 0x04, 0x3b, 0x17, 0xa8, 0x4d,
-	0x0f, 0x2e, 0x91, 0x26, 0x28, 0x43, 0xfc, 0x92, 0xa3, 0xd2, 0x2b, 0xdb, 0xb1, 0x0b, 0xed, 0x4a,
-	0xd6, 0xfe, 0x2b, 0x55, 0x44, 0x08, 0xb4, 0x14, 0xfb, 0x86, 0xc6, 0x8c, 0x13, 0x9a, 0x75, 0x70,
-	0x01, 0xbb, 0x8f, 0x0f, 0x57, 0x59, 0x59, 0x0a, 0x39, 0x82, 0xb6, 0xe9, 0x62, 0xfd, 0x6b, 0xaf,
-	0xb8, 0x58, 0x15, 0x28, 0xf8, 0x00, 0xa4, 0x3e, 0x68, 0x5c, 0xa8, 0xa7, 0x2c, 0xae, 0xfe, 0x62,
-	0x5b, 0x76, 0x9c, 0x38, 0x3d, 0xa7, 0xbf, 0x66, 0x27, 0xc7, 0x29, 0x6c, 0xff, 0x76, 0x72, 0xe5,
-	0xaf, 0x9a, 0x3b, 0xad, 0xbf, 0xcf, 0x9d, 0xb3, 0xd3, 0x8f, 0xaf, 0xa7, 0x4c, 0xcf, 0xf2, 0xc9,
-	0x20, 0x16, 0x73, 0x3b, 0x0d, 0xcd, 0x4d, 0x1d, 0x3e, 0x4c, 0xbe, 0xd5, 0x13, 0x73, 0x62, 0xe7,
-	0xe4, 0xc9, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5b, 0x60, 0xeb, 0x04, 0x56, 0x05, 0x00, 0x00,
+	// 932 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x6e, 0xdb, 0x46,
+	0x13, 0x85, 0x24, 0xeb, 0x87, 0x43, 0xe5, 0xe7, 0xdb, 0x7c, 0x31, 0x98, 0x14, 0x45, 0x15, 0xb6,
+	0x69, 0x55, 0x04, 0xb6, 0x10, 0x07, 0xed, 0x45, 0x91, 0xab, 0x38, 0x40, 0x6c, 0xf4, 0x2f, 0xd8,
+	0x28, 0x45, 0xd1, 0x02, 0x25, 0x56, 0xe4, 0x48, 0x5c, 0x88, 0x5e, 0xaa, 0xbb, 0x4b, 0x83, 0x2e,
+	0x7a, 0xdb, 0x37, 0xe8, 0x75, 0x5f, 0xa2, 0xd7, 0x7d, 0xb7, 0x62, 0x67, 0x29, 0xc9, 0x36, 0xa2,
+	0x18, 0xe8, 0x85, 0x20, 0xce, 0xe1, 0x99, 0x9d, 0xb3, 0x73, 0xb8, 0xb3, 0xf0, 0xc9, 0x12, 0xb5,
+	0xc2, 0x62, 0x82, 0x6a, 0x21, 0x15, 0x9a, 0x49, 0x5d, 0xad, 0x50, 0x97, 0x66, 0x52, 0xaf, 0x66,
+	0xee, 0x77, 0xb8, 0xd2, 0xa5, 0x2d, 0x59, 0x8f, 0xfe, 0xcc, 0xc3, 0xa7, 0xf4, 0x3a, 0x2d, 0x35,
+	0x4e, 0x66, 0xa9, 0x99, 0x14, 0x98, 0x2d, 0x50, 0x4f, 0xea, 0xcd, 0x7f, 0xb6, 0x58, 0xcd, 0xd6,
+	0xa1, 0x4f, 0x8d, 0xbf, 0x80, 0xe1, 0x54, 0x0b, 0x65, 0x44, 0x6a, 0x65, 0xa9, 0x0c, 0x7b, 0x0c,
+	0x1d, 0x5b, 0x9b, 0xa8, 0x35, 0xea, 0x8c, 0xc3, 0xa3, 0x7b, 0x87, 0x3e, 0xe7, 0xf0, 0x12, 0x85,
+	0xbb, 0xf7, 0xf1, 0xef, 0xd0, 0x9b, 0xd6, 0xa7, 0x6a, 0x5e, 0xb2, 0xa7, 0xd0, 0x33, 0x56, 0xd8,
+	0xca, 0xe5, 0xb4, 0xc6, 0xb7, 0x8f, 0x1e, 0xbc, 0x23, 0xe7, 0x0d, 0x11, 0x78, 0x43, 0x64, 0x0f,
+	0x61, 0x90, 0x49, 0x63, 0x85, 0x4a, 0x31, 0x6a, 0x8f, 0x5a, 0xe3, 0x0e, 0xdf, 0xc4, 0xec, 0x63,
+	0x68, 0xdb, 0x3a, 0xea, 0x8c, 0x5a, 0xbb, 0xca, 0xb7, 0x6d, 0x1d, 0x23, 0x04, 0x2f, 0x8a, 0x32,
+	0x5d, 0x92, 0x80, 0x27, 0xd7, 0x04, 0x6c, 0xb2, 0x88, 0x72, 0xad, 0xf4, 0x13, 0xe8, 0xce, 0x1c,
+	0x4c, 0x75, 0xc3, 0xa3, 0xfb, 0x6b, 0xee, 0xa9, 0xb2, 0xa8, 0x95, 0x28, 0x28, 0x87, 0x7b, 0x4e,
+	0xfc, 0x4f, 0x0b, 0xc2, 0xe3, 0x5c, 0xc8, 0x46, 0x3f, 0x7b, 0x06, 0xa1, 0xef, 0x5d, 0x72, 0x86,
+	0x56, 0x50, 0xb9, 0xf0, 0x88, 0xad, 0x97, 0xf8, 0x86, 0x5e, 0x7d, 0x8b, 0x56, 0x70, 0x28, 0x36,
+	0xcf, 0xec, 0x00, 0x82, 0xca, 0xd6, 0xa5, 0x4f, 0xf1, 0x55, 0xef, 0xae, 0x53, 0xde, 0xda, 0xba,
+	0xa4, 0x84, 0x41, 0xd5, 0x3c, 0x6d, 0x05, 0x76, 0x6e, 0x16, 0xc8, 0x3e, 0x04, 0x98, 0x69, 0xa1,
+	0xd2, 0x3c, 0x91, 0x99, 0x89, 0xf6, 0x46, 0x9d, 0x71, 0xc0, 0x03, 0x8f, 0x9c, 0x66, 0x26, 0x4e,
+	0x61, 0xf8, 0xe6, 0xc2, 0x58, 0x3c, 0x6b, 0xf4, 0x7f, 0x09, 0xc3, 0xd4, 0x6d, 0x27, 0xb9, 0xd4,
+	0x2f, 0xd7, 0x65, 0xff, 0xf5, 0x1c, 0x5e, 0xda, 0x2a, 0x0f, 0xd3, 0x6d, 0xc0, 0x3e, 0x80, 0x60,
+	0x85, 0xa8, 0x93, 0x4a, 0x17, 0x26, 0x6a, 0x53, 0x95, 0x81, 0x03, 0xde, 0xea, 0xc2, 0xc4, 0x07,
+	0x10, 0x4c, 0xe5, 0xaa, 0x61, 0x8e, 0x60, 0x28, 0x4d, 0x62, 0x75, 0xa5, 0x96, 0x89, 0x95, 0x2b,
+	0xaa, 0x30, 0xe0, 0x20, 0xcd, 0xd4, 0x41, 0x53, 0xb9, 0x8a, 0x7f, 0x81, 0xbe, 0xb7, 0xee, 0x25,
+	0xdb, 0x87, 0xde, 0x2c, 0x55, 0xe2, 0x0c, 0x89, 0x16, 0xf0, 0x26, 0x62, 0x11, 0xf4, 0x69, 0x7b,
+	0x32, 0xa3, 0x7e, 0x0d, 0xf9, 0x3a, 0x64, 0x8f, 0x60, 0xa8, 0x10, 0xb3, 0x24, 0x2d, 0x95, 0x45,
+	0x65, 0xa9, 0x47, 0x03, 0x1e, 0x3a, 0xec, 0xd8, 0x43, 0xf1, 0x5f, 0x2d, 0xb8, 0x73, 0x5c, 0x2a,
+	0x83, 0xca, 0x54, 0xa6, 0x51, 0x15, 0x41, 0xff, 0x1c, 0xb5, 0x91, 0xa5, 0x6a, 0x2a, 0xad, 0x43,
+	0xf6, 0x18, 0x6e, 0xa7, 0x6b, 0x72, 0x42, 0x52, 0xda, 0x44, 0xb8, 0xb5, 0x41, 0xbf, 0x73, 0x8a,
+	0x1e, 0xc1, 0xd0, 0x58, 0xa1, 0x6d, 0x92, 0xa3, 0x5c, 0xe4, 0xbe, 0x6e, 0xc0, 0x43, 0xc2, 0x4e,
+	0x08, 0x62, 0x9f, 0xc1, 0x9d, 0x73, 0x51, 0xc8, 0x4c, 0xd8, 0x52, 0x9b, 0x44, 0xaa, 0x79, 0x19,
+	0xed, 0x11, 0xeb, 0xf6, 0x16, 0x76, 0x9f, 0x6b, 0xfc, 0x33, 0xdc, 0x7f, 0x85, 0x96, 0x7a, 0x70,
+	0x82, 0x22, 0x43, 0xcd, 0xf1, 0xd7, 0x0a, 0x8d, 0xdd, 0xd9, 0x8e, 0x7d, 0xe8, 0x35, 0x65, 0xfd,
+	0x59, 0x69, 0x22, 0xc6, 0x60, 0xcf, 0xc8, 0xdf, 0x90, 0xc4, 0x74, 0x38, 0x3d, 0xc7, 0xaf, 0x60,
+	0xff, 0xfa, 0xe2, 0x66, 0xe5, 0xb6, 0xc2, 0x0e, 0xa0, 0x47, 0x5d, 0x5c, 0x1f, 0xed, 0x1d, 0x1f,
+	0x56, 0x43, 0x8a, 0x7f, 0x04, 0xb6, 0x5e, 0x68, 0x5a, 0x9b, 0x9b, 0x24, 0xee, 0x76, 0xec, 0xae,
+	0x1f, 0x27, 0x9d, 0x51, 0x67, 0xdc, 0xf5, 0x93, 0xe3, 0x39, 0xdc, 0xbb, 0xb2, 0x72, 0xa3, 0xaf,
+	0x99, 0x3b, 0x7b, 0x37, 0xcc, 0x9d, 0x3f, 0x5a, 0x10, 0x38, 0x57, 0xf1, 0xa5, 0xb0, 0x82, 0xf4,
+	0x54, 0xe9, 0x12, 0xed, 0x46, 0x0f, 0x45, 0xae, 0xea, 0x12, 0x2f, 0x1a, 0x2d, 0xee, 0x91, 0xfd,
+	0x1f, 0xba, 0xe7, 0xa2, 0xa8, 0x7c, 0xb7, 0x86, 0xdc, 0x07, 0xec, 0x01, 0x0c, 0x34, 0xce, 0x13,
+	0x5b, 0xcb, 0x8c, 0xdc, 0x1a, 0xf2, 0xbe, 0xc6, 0xf9, 0xb4, 0x96, 0x99, 0x3b, 0x5a, 0xee, 0x55,
+	0x39, 0x9f, 0x1b, 0xb4, 0x51, 0x77, 0xd4, 0x1a, 0x77, 0x79, 0xa0, 0x71, 0xfe, 0x3d, 0x01, 0x71,
+	0xde, 0xc8, 0xa0, 0x09, 0xb4, 0x75, 0xa8, 0x75, 0xc5, 0xa1, 0xdd, 0x6d, 0xf9, 0xdc, 0xcf, 0x2c,
+	0xf4, 0x9d, 0x09, 0x8f, 0xfe, 0xb7, 0x3e, 0x83, 0x9b, 0xbd, 0xf1, 0x86, 0x10, 0xff, 0xd9, 0x82,
+	0xfe, 0xb4, 0x7e, 0xad, 0xcb, 0x72, 0xee, 0x4c, 0xcc, 0xc9, 0xd6, 0xe6, 0xe8, 0xee, 0x32, 0xd1,
+	0x93, 0x9a, 0x59, 0xda, 0x7e, 0xef, 0x2c, 0x75, 0x9d, 0x91, 0x2a, 0x43, 0x3f, 0x73, 0xbb, 0xdc,
+	0x07, 0xec, 0x23, 0x08, 0xcf, 0x50, 0x2f, 0x0b, 0x4c, 0x56, 0xc2, 0xe6, 0x64, 0xcb, 0x90, 0x83,
+	0x87, 0x5e, 0x0b, 0x9b, 0xc7, 0x7f, 0xb7, 0x01, 0x48, 0xec, 0x7f, 0x52, 0xb6, 0x35, 0xae, 0xfd,
+	0x2e, 0xe3, 0x3a, 0x57, 0x8c, 0xc3, 0x5a, 0x1a, 0x4b, 0xfe, 0x0c, 0xb8, 0x0f, 0xb6, 0x76, 0x76,
+	0x77, 0xd9, 0xd9, 0x7b, 0x9f, 0x9d, 0xfd, 0x6b, 0x76, 0xba, 0x1b, 0xc9, 0xc8, 0x59, 0x21, 0xd5,
+	0xc2, 0x44, 0x03, 0xda, 0xeb, 0x26, 0x66, 0x31, 0xdc, 0x2a, 0x50, 0xcc, 0x93, 0x25, 0x5e, 0x24,
+	0xb9, 0x30, 0x79, 0x14, 0xd0, 0xd2, 0xa1, 0x03, 0xbf, 0xc6, 0x8b, 0x13, 0x61, 0x72, 0xf6, 0x29,
+	0xdc, 0x21, 0x0e, 0xe9, 0xf0, 0x2c, 0x20, 0x16, 0xa5, 0xfe, 0xe0, 0x50, 0xc7, 0x7b, 0xf1, 0xfc,
+	0xa7, 0xaf, 0x16, 0xd2, 0xe6, 0xd5, 0xec, 0x30, 0x2d, 0xcf, 0xfc, 0x65, 0x4e, 0x83, 0x76, 0xb2,
+	0xbd, 0xb8, 0x77, 0x5f, 0xf8, 0x33, 0x7f, 0xcd, 0x3f, 0xfb, 0x77, 0x00, 0x25, 0x9b, 0x06, 0x23,
+	0x15, 0x08, 0x00, 0x00,
 }
@@ -22,6 +22,9 @@ const (
 	accountContractValue   = "true"
 )
 
+// 已销毁合约的墓碑记录，合约的数据后续可以被压缩清理
+const contractTombstoneBucket = "XCContractTombstone"
+
 // GetContract2AccountBucket get the bucket name of contract to account map
 func GetContract2AccountBucket() string {
 	return contract2AccountBucket
@@ -32,6 +35,11 @@ func GetAccount2ContractBucket() string {
 	return account2ContractBucket
 }
 
+// GetContractTombstoneBucket get the bucket name of destroyed contracts
+func GetContractTombstoneBucket() string {
+	return contractTombstoneBucket
+}
+
 // MakeAccountContractKey generate account and contract mapping key
 func MakeAccountContractKey(accountName string, contractName string) string {
 	return accountName + aclSeparator + contractName
@@ -38,3 +38,8 @@ type Batch interface {
 	PutIfAbsent(key []byte, value []byte) error
 	Exist(key []byte) bool
 }
+
+// Compactor 支持在线触发compaction的存储引擎实现该接口，limit为nil时压缩到最后一个key
+type Compactor interface {
+	Compact(start []byte, limit []byte) error
+}
@@ -20,6 +20,7 @@ type KVParameter struct {
 const (
 	KVEngineTypeLDB    = "leveldb"
 	KVEngineTypeBadger = "badger"
+	KVEngineTypePebble = "pebble"
 )
 
 const (
@@ -88,6 +89,11 @@ func (param *KVParameter) GetFileHandlersCacheSize() int {
 	return param.FileHandlersCacheSize
 }
 
+// GetOptions return the value of Options
+func (param *KVParameter) GetOptions() map[string]interface{} {
+	return param.Options
+}
+
 // GetOtherPaths return the value of OtherPaths
 func (param *KVParameter) GetOtherPaths() []string {
 	return param.OtherPaths
@@ -127,6 +127,12 @@ func (db *LDBDatabase) NewIteratorWithPrefix(prefix []byte) kvdb.Iterator {
 	return db.db.NewIterator(util.BytesPrefix(prefix), nil)
 }
 
+// Compact compacts the underlying storage for the key range [start, limit)
+func (db *LDBDatabase) Compact(start []byte, limit []byte) error {
+	metrics.CallMethodCounter.WithLabelValues("levelDB", "Compact", "OK").Inc()
+	return db.db.CompactRange(util.Range{Start: start, Limit: limit})
+}
+
 // Close close database instance
 func (db *LDBDatabase) Close() {
 	db.db.Close()
@@ -0,0 +1,93 @@
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+)
+
+// 迭代器相对于范围的位置，用于和leveldb保持一致：
+// 新建的迭代器调用Next定位到第一个key，调用Prev定位到最后一个key，
+// 越过两端后可以从另一个方向重新进入范围
+const (
+	posUnpositioned = iota
+	posValid
+	posBeforeFirst
+	posAfterLast
+)
+
+// PebbleIterator wrap pebble.Iterator as kvdb.Iterator
+type PebbleIterator struct {
+	iter *pebble.Iterator
+	pos  int
+}
+
+func newIterator(db *pebble.DB, start []byte, limit []byte) *PebbleIterator {
+	// pebble要求迭代期间上下界不被修改，这里复制一份
+	opts := &pebble.IterOptions{}
+	if start != nil {
+		opts.LowerBound = append([]byte{}, start...)
+	}
+	if limit != nil {
+		opts.UpperBound = append([]byte{}, limit...)
+	}
+	return &PebbleIterator{iter: db.NewIter(opts)}
+}
+
+func (it *PebbleIterator) Key() []byte {
+	if it.pos != posValid {
+		return nil
+	}
+	return it.iter.Key()
+}
+
+func (it *PebbleIterator) Value() []byte {
+	if it.pos != posValid {
+		return nil
+	}
+	return it.iter.Value()
+}
+
+func (it *PebbleIterator) Next() bool {
+	switch it.pos {
+	case posUnpositioned, posBeforeFirst:
+		return it.First()
+	case posAfterLast:
+		return false
+	}
+	return it.move(it.iter.Next(), posAfterLast)
+}
+
+func (it *PebbleIterator) Prev() bool {
+	switch it.pos {
+	case posUnpositioned, posAfterLast:
+		return it.Last()
+	case posBeforeFirst:
+		return false
+	}
+	return it.move(it.iter.Prev(), posBeforeFirst)
+}
+
+func (it *PebbleIterator) First() bool {
+	return it.move(it.iter.First(), posAfterLast)
+}
+
+func (it *PebbleIterator) Last() bool {
+	return it.move(it.iter.Last(), posBeforeFirst)
+}
+
+// move 记录移动后的位置，移动失败时迭代器停在exhausted一端
+func (it *PebbleIterator) move(valid bool, exhausted int) bool {
+	if valid {
+		it.pos = posValid
+	} else {
+		it.pos = exhausted
+	}
+	return valid
+}
+
+func (it *PebbleIterator) Error() error {
+	return it.iter.Error()
+}
+
+func (it *PebbleIterator) Release() {
+	it.iter.Close()
+}
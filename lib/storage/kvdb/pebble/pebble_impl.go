@@ -0,0 +1,306 @@
+// pebble wrapper plugin
+// pebble是纯go实现的LSM存储引擎，兼容rocksdb的文件格式，写放大低于leveldb
+package pebbledb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/xuperchain/xupercore/lib/metrics"
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+)
+
+// 可以通过KVParameter.Options调整的参数，大小的单位为MB
+const (
+	OptionMemTableSize             = "memTableSize"
+	OptionL0CompactionThreshold    = "l0CompactionThreshold"
+	OptionL0StopWritesThreshold    = "l0StopWritesThreshold"
+	OptionLBaseMaxBytes            = "lBaseMaxBytes"
+	OptionTargetFileSize           = "targetFileSize"
+	OptionMaxConcurrentCompactions = "maxConcurrentCompactions"
+	OptionBloomBitsPerKey          = "bloomBitsPerKey"
+)
+
+const (
+	defaultCacheSize       = 16
+	defaultFileHandlers    = 16
+	defaultBloomBitsPerKey = 10
+	numLevels              = 7
+)
+
+// PebbleDatabase define db backend based on pebble
+type PebbleDatabase struct {
+	fn string     // filename of db
+	db *pebble.DB // pebble instance
+}
+
+// NewKVDBInstance create instance of PebbleDatabase
+func NewKVDBInstance(param *kvdb.KVParameter) (kvdb.Database, error) {
+	options := map[string]interface{}{
+		"cache":       param.GetMemCacheSize(),
+		"fds":         param.GetFileHandlersCacheSize(),
+		"storageType": param.GetStorageType(),
+	}
+	for k, v := range param.GetOptions() {
+		options[k] = v
+	}
+	baseDB := new(PebbleDatabase)
+	err := baseDB.Open(param.GetDBPath(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	return baseDB, nil
+}
+
+func init() {
+	kvdb.Register(kvdb.KVEngineTypePebble, NewKVDBInstance)
+}
+
+// Open opens an instance of pebble with parameters (path and other options)
+func (db *PebbleDatabase) Open(path string, options map[string]interface{}) error {
+	// pebble只支持单盘存储
+	storageType, _ := options["storageType"].(string)
+	if storageType != "" && storageType != kvdb.StorageTypeSingle {
+		return fmt.Errorf("open database fail. err:storageType %s not supported by pebble", storageType)
+	}
+
+	opts, err := makeOptions(options)
+	if err != nil {
+		return fmt.Errorf("open database fail. err:%v", err)
+	}
+	// Cache在Open时被引用，这里释放创建时的引用，数据库关闭后回收
+	defer opts.Cache.Unref()
+
+	pdb, err := pebble.Open(path, opts)
+	if err != nil {
+		return err
+	}
+	db.fn = path
+	db.db = pdb
+	return nil
+}
+
+func makeOptions(options map[string]interface{}) (*pebble.Options, error) {
+	var err error
+	values := map[string]int{
+		"cache":                        defaultCacheSize,
+		"fds":                          defaultFileHandlers,
+		OptionMemTableSize:             0,
+		OptionL0CompactionThreshold:    0,
+		OptionL0StopWritesThreshold:    0,
+		OptionLBaseMaxBytes:            0,
+		OptionTargetFileSize:           0,
+		OptionMaxConcurrentCompactions: 0,
+		OptionBloomBitsPerKey:          defaultBloomBitsPerKey,
+	}
+	for name, def := range values {
+		if values[name], err = getIntOption(options, name, def); err != nil {
+			return nil, err
+		}
+	}
+
+	// 和leveldb一样，一半内存用于block cache，memtable默认为四分之一；未设置的参数使用pebble的默认值
+	cache := values["cache"]
+	memTableSize := cache / 4
+	if values[OptionMemTableSize] > 0 {
+		memTableSize = values[OptionMemTableSize]
+	}
+	opts := &pebble.Options{
+		Cache:                    pebble.NewCache(int64(cache/2) << 20),
+		MaxOpenFiles:             values["fds"],
+		MemTableSize:             memTableSize << 20,
+		L0CompactionThreshold:    values[OptionL0CompactionThreshold],
+		L0StopWritesThreshold:    values[OptionL0StopWritesThreshold],
+		LBaseMaxBytes:            int64(values[OptionLBaseMaxBytes]) << 20,
+		MaxConcurrentCompactions: values[OptionMaxConcurrentCompactions],
+	}
+	// 每层使用bloom过滤器加速点查，文件大小逐层翻倍
+	opts.Levels = make([]pebble.LevelOptions, numLevels)
+	for i := range opts.Levels {
+		level := &opts.Levels[i]
+		if bitsPerKey := values[OptionBloomBitsPerKey]; bitsPerKey > 0 {
+			level.FilterPolicy = bloom.FilterPolicy(bitsPerKey)
+		}
+		if targetFileSize := values[OptionTargetFileSize]; targetFileSize > 0 {
+			level.TargetFileSize = int64(targetFileSize) << 20 << i
+		}
+	}
+	return opts.EnsureDefaults(), nil
+}
+
+// getIntOption 读取整数参数，未设置时返回def
+func getIntOption(options map[string]interface{}, name string, def int) (int, error) {
+	switch v := options[name].(type) {
+	case nil:
+		return def, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("invalid option %s:%v", name, options[name])
+	}
+}
+
+// Path returns the path to the database directory.
+func (db *PebbleDatabase) Path() string {
+	return db.fn
+}
+
+// Put puts the given key / value to the queue
+func (db *PebbleDatabase) Put(key []byte, value []byte) error {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "Put", "OK").Inc()
+	metrics.BytesCounter.WithLabelValues("pebble", "Put", "key").Add(float64(len(key)))
+	metrics.BytesCounter.WithLabelValues("pebble", "Put", "value").Add(float64(len(value)))
+	return db.db.Set(key, value, pebble.NoSync)
+}
+
+// Has if the given key exists
+func (db *PebbleDatabase) Has(key []byte) (bool, error) {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "Has", "OK").Inc()
+	_, closer, err := db.db.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+// Get returns the given key if it's present.
+func (db *PebbleDatabase) Get(key []byte) ([]byte, error) {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "Get", "OK").Inc()
+	value, closer, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	// value只在closer关闭前有效
+	dat := make([]byte, len(value))
+	copy(dat, value)
+	return dat, nil
+}
+
+// Delete deletes the key from the queue and database
+func (db *PebbleDatabase) Delete(key []byte) error {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "Delete", "OK").Inc()
+	return db.db.Delete(key, pebble.NoSync)
+}
+
+// NewIteratorWithRange returns an instance of Iterator with range
+func (db *PebbleDatabase) NewIteratorWithRange(start []byte, limit []byte) kvdb.Iterator {
+	return newIterator(db.db, start, limit)
+}
+
+// NewIteratorWithPrefix returns an instance of Iterator with prefix
+func (db *PebbleDatabase) NewIteratorWithPrefix(prefix []byte) kvdb.Iterator {
+	return newIterator(db.db, prefix, kvdb.PrefixLimit(prefix))
+}
+
+// Compact compacts the underlying storage for the key range [start, limit),
+// start为nil时从第一个key开始，limit为nil时压缩到最后一个key
+func (db *PebbleDatabase) Compact(start []byte, limit []byte) error {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "Compact", "OK").Inc()
+	it := db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: limit})
+	defer it.Close()
+	if start == nil {
+		if !it.First() {
+			return it.Error()
+		}
+		start = append([]byte{}, it.Key()...)
+	}
+	if limit == nil {
+		if !it.Last() {
+			return it.Error()
+		}
+		// 最后一个key的后继，保证最后一个key在压缩范围内
+		limit = append(append([]byte{}, it.Key()...), 0)
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	return db.db.Compact(start, limit, true)
+}
+
+// Close close database instance
+func (db *PebbleDatabase) Close() {
+	db.db.Close()
+}
+
+// PebbleDB returns pebble instance
+func (db *PebbleDatabase) PebbleDB() *pebble.DB {
+	return db.db
+}
+
+// NewBatch returns batch instance of pebble
+func (db *PebbleDatabase) NewBatch() kvdb.Batch {
+	return &pebbleBatch{b: db.db.NewBatch(), keys: map[string]bool{}}
+}
+
+type pebbleBatch struct {
+	b    *pebble.Batch
+	size int
+	keys map[string]bool
+}
+
+func (b *pebbleBatch) Put(key, value []byte) error {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "BatchPut", "OK").Inc()
+	metrics.BytesCounter.WithLabelValues("pebble", "BatchPut", "key").Add(float64(len(key)))
+	metrics.BytesCounter.WithLabelValues("pebble", "BatchPut", "value").Add(float64(len(value)))
+	err := b.b.Set(key, value, nil)
+	if err != nil {
+		return err
+	}
+	b.size += len(value)
+	return nil
+}
+
+func (b *pebbleBatch) Delete(key []byte) error {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "BatchDelete", "OK").Inc()
+	err := b.b.Delete(key, nil)
+	if err != nil {
+		return err
+	}
+	b.size += len(key)
+	return nil
+}
+
+func (b *pebbleBatch) PutIfAbsent(key, value []byte) error {
+	if !b.keys[string(key)] {
+		err := b.b.Set(key, value, nil)
+		if err != nil {
+			return err
+		}
+		b.size += len(value)
+		b.keys[string(key)] = true
+		return nil
+	}
+	return fmt.Errorf("duplicated key in batch, (HEX) %x", key)
+}
+
+func (b *pebbleBatch) Exist(key []byte) bool {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "BatchExist", "OK").Inc()
+	return b.keys[string(key)]
+}
+
+func (b *pebbleBatch) Write() error {
+	metrics.CallMethodCounter.WithLabelValues("pebble", "BatchWrite", "OK").Inc()
+	return b.b.Commit(pebble.NoSync)
+}
+
+func (b *pebbleBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *pebbleBatch) Reset() {
+	b.b.Reset()
+	b.size = 0
+	b.keys = map[string]bool{}
+}
@@ -0,0 +1,199 @@
+package pebbledb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/xuperchain/xupercore/lib/storage/kvdb"
+)
+
+func makeDB(t *testing.T) (kvdb.Database, func()) {
+	dir, err := ioutil.TempDir("", "pebble")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kvParam := &kvdb.KVParameter{
+		DBPath:                dir,
+		KVEngineType:          kvdb.KVEngineTypePebble,
+		StorageType:           kvdb.StorageTypeSingle,
+		MemCacheSize:          128,
+		FileHandlersCacheSize: 1024,
+		Options: map[string]interface{}{
+			OptionMemTableSize:          16,
+			OptionL0CompactionThreshold: 4,
+		},
+	}
+	db, err := kvdb.CreateKVInstance(kvParam)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGetPutDelete(t *testing.T) {
+	db, clean := makeDB(t)
+	defer clean()
+
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	value, err := db.Get([]byte("key"))
+	if err != nil || string(value) != "value" {
+		t.Fatalf("unexpected value %s, err:%v", value, err)
+	}
+	if has, err := db.Has([]byte("key")); err != nil || !has {
+		t.Fatalf("expect key exist, err:%v", err)
+	}
+	if err := db.Delete([]byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Get([]byte("key")); err == nil || !kvdb.ErrNotFound(err) {
+		t.Fatalf("expect not found, err:%v", err)
+	}
+	if has, err := db.Has([]byte("key")); err != nil || has {
+		t.Fatalf("expect key not exist, err:%v", err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	db, clean := makeDB(t)
+	defer clean()
+
+	batch := db.NewBatch()
+	if err := batch.PutIfAbsent([]byte("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.PutIfAbsent([]byte("a"), []byte("2")); err == nil {
+		t.Fatal("expect duplicated key")
+	}
+	if !batch.Exist([]byte("a")) || batch.Exist([]byte("b")) {
+		t.Fatal("unexpected exist result")
+	}
+	batch.Put([]byte("b"), []byte("22"))
+	if batch.ValueSize() != 3 {
+		t.Fatalf("unexpected value size %d", batch.ValueSize())
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := db.Get([]byte("a")); string(value) != "1" {
+		t.Fatalf("unexpected value %s", value)
+	}
+
+	batch.Reset()
+	if batch.Exist([]byte("a")) || batch.ValueSize() != 0 {
+		t.Fatal("expect batch reset")
+	}
+	batch.Delete([]byte("a"))
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := db.Has([]byte("a")); has {
+		t.Fatal("expect key deleted")
+	}
+}
+
+func TestIterator(t *testing.T) {
+	db, clean := makeDB(t)
+	defer clean()
+
+	for _, key := range []string{"a1", "a2", "a3", "b1", "b2"} {
+		db.Put([]byte(key), []byte("v"+key))
+	}
+	db.Put([]byte{'c', 0xff}, []byte("c"))
+
+	collect := func(it kvdb.Iterator) []string {
+		defer it.Release()
+		var keys []string
+		for it.Next() {
+			keys = append(keys, string(it.Key()))
+		}
+		return keys
+	}
+	if keys := collect(db.NewIteratorWithPrefix([]byte("a"))); fmt.Sprint(keys) != "[a1 a2 a3]" {
+		t.Fatalf("unexpected prefix keys %v", keys)
+	}
+	if keys := collect(db.NewIteratorWithRange([]byte("a2"), []byte("b2"))); fmt.Sprint(keys) != "[a2 a3 b1]" {
+		t.Fatalf("unexpected range keys %v", keys)
+	}
+	if keys := collect(db.NewIteratorWithPrefix([]byte{'c', 0xff})); len(keys) != 1 {
+		t.Fatalf("unexpected prefix keys %v", keys)
+	}
+
+	it := db.NewIteratorWithPrefix([]byte("a"))
+	defer it.Release()
+	if !it.Prev() || string(it.Key()) != "a3" || string(it.Value()) != "va3" {
+		t.Fatalf("expect prev of new iterator at last, got %s", it.Key())
+	}
+	if !it.Prev() || string(it.Key()) != "a2" {
+		t.Fatalf("unexpected prev key %s", it.Key())
+	}
+	if !it.First() || string(it.Key()) != "a1" {
+		t.Fatalf("unexpected first key %s", it.Key())
+	}
+	if it.Prev() || it.Key() != nil {
+		t.Fatal("expect iterator exhausted")
+	}
+	if !it.Next() || string(it.Key()) != "a1" {
+		t.Fatalf("expect next to reenter at first, got %s", it.Key())
+	}
+	if !it.Last() || string(it.Key()) != "a3" {
+		t.Fatalf("unexpected last key %s", it.Key())
+	}
+	if it.Next() || !it.Prev() || string(it.Key()) != "a3" {
+		t.Fatal("expect prev to reenter at last")
+	}
+	if it.Error() != nil {
+		t.Fatal(it.Error())
+	}
+}
+
+func TestCompact(t *testing.T) {
+	db, clean := makeDB(t)
+	defer clean()
+
+	compactor, ok := db.(kvdb.Compactor)
+	if !ok {
+		t.Fatal("expect pebble to support compaction")
+	}
+	if err := compactor.Compact(nil, nil); err != nil {
+		t.Fatalf("compact empty db failed, err:%v", err)
+	}
+	for i := 0; i < 1000; i++ {
+		db.Put([]byte(fmt.Sprintf("key%04d", i)), []byte("value"))
+	}
+	for i := 0; i < 500; i++ {
+		db.Delete([]byte(fmt.Sprintf("key%04d", i)))
+	}
+	if err := compactor.Compact([]byte("key"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := compactor.Compact(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := db.Get([]byte("key0999")); err != nil || string(value) != "value" {
+		t.Fatalf("unexpected value after compact %s, err:%v", value, err)
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pebble")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db := new(PebbleDatabase)
+	if err := db.Open(dir, map[string]interface{}{"storageType": kvdb.StorageTypeMulti}); err == nil {
+		t.Fatal("expect multi disk not supported")
+	}
+	if err := db.Open(dir, map[string]interface{}{OptionMemTableSize: "64"}); err == nil {
+		t.Fatal("expect invalid option")
+	}
+}
@@ -56,3 +56,16 @@ func (dt *table) NewIteratorWithRange(start []byte, limit []byte) Iterator {
 func ErrNotFound(err error) bool {
 	return strings.HasSuffix(err.Error(), "not found")
 }
+
+// PrefixLimit 返回以prefix为前缀的key的上界(不包含)，prefix全为0xff时返回nil表示没有上界
+func PrefixLimit(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
@@ -246,17 +246,19 @@ func (m *InvokeRequest) GetAmount() string {
 
 // 预执行的返回结构
 type InvokeResponse struct {
-	Inputs               []*TxInputExt       `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
-	Outputs              []*TxOutputExt      `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	Response             [][]byte            `protobuf:"bytes,3,rep,name=response,proto3" json:"response,omitempty"`
-	GasUsed              int64               `protobuf:"varint,4,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	Requests             []*InvokeRequest    `protobuf:"bytes,5,rep,name=requests,proto3" json:"requests,omitempty"`
-	Responses            []*ContractResponse `protobuf:"bytes,6,rep,name=responses,proto3" json:"responses,omitempty"`
-	UtxoInputs           []*TxInput          `protobuf:"bytes,7,rep,name=utxoInputs,proto3" json:"utxoInputs,omitempty"`
-	UtxoOutputs          []*TxOutput         `protobuf:"bytes,8,rep,name=utxoOutputs,proto3" json:"utxoOutputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Inputs      []*TxInputExt       `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs     []*TxOutputExt      `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Response    [][]byte            `protobuf:"bytes,3,rep,name=response,proto3" json:"response,omitempty"`
+	GasUsed     int64               `protobuf:"varint,4,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Requests    []*InvokeRequest    `protobuf:"bytes,5,rep,name=requests,proto3" json:"requests,omitempty"`
+	Responses   []*ContractResponse `protobuf:"bytes,6,rep,name=responses,proto3" json:"responses,omitempty"`
+	UtxoInputs  []*TxInput          `protobuf:"bytes,7,rep,name=utxoInputs,proto3" json:"utxoInputs,omitempty"`
+	UtxoOutputs []*TxOutput         `protobuf:"bytes,8,rep,name=utxoOutputs,proto3" json:"utxoOutputs,omitempty"`
+	// 跟踪模式下每个请求的调用树
+	Traces               []*CallTrace `protobuf:"bytes,9,rep,name=traces,proto3" json:"traces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
@@ -340,6 +342,210 @@ func (m *InvokeResponse) GetUtxoOutputs() []*TxOutput {
 	return nil
 }
 
+func (m *InvokeResponse) GetTraces() []*CallTrace {
+	if m != nil {
+		return m.Traces
+	}
+	return nil
+}
+
+// 合约调用中的一次状态读写
+type TraceKV struct {
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key    []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value  []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// 读操作表示key不存在，写操作表示删除key
+	Deleted              bool     `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceKV) Reset()         { *m = TraceKV{} }
+func (m *TraceKV) String() string { return proto.CompactTextString(m) }
+func (*TraceKV) ProtoMessage()    {}
+func (*TraceKV) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{4}
+}
+
+func (m *TraceKV) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceKV.Unmarshal(m, b)
+}
+func (m *TraceKV) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceKV.Marshal(b, m, deterministic)
+}
+func (m *TraceKV) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceKV.Merge(m, src)
+}
+func (m *TraceKV) XXX_Size() int {
+	return xxx_messageInfo_TraceKV.Size(m)
+}
+func (m *TraceKV) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceKV.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceKV proto.InternalMessageInfo
+
+func (m *TraceKV) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *TraceKV) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *TraceKV) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TraceKV) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// CallTrace 预执行跟踪模式下的一次合约调用，嵌套调用记录在calls中
+type CallTrace struct {
+	ModuleName   string            `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	ContractName string            `protobuf:"bytes,2,opt,name=contract_name,json=contractName,proto3" json:"contract_name,omitempty"`
+	MethodName   string            `protobuf:"bytes,3,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
+	Args         map[string][]byte `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Response     *ContractResponse `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"`
+	// 本次调用自身的状态读写，不包含嵌套调用
+	Reads  []*TraceKV       `protobuf:"bytes,6,rep,name=reads,proto3" json:"reads,omitempty"`
+	Writes []*TraceKV       `protobuf:"bytes,7,rep,name=writes,proto3" json:"writes,omitempty"`
+	Events []*ContractEvent `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty"`
+	// 合约通过PostLog输出的日志
+	Logs []string `protobuf:"bytes,9,rep,name=logs,proto3" json:"logs,omitempty"`
+	// 本次调用的资源消耗，包含嵌套调用
+	ResourceUsed []*ResourceLimit `protobuf:"bytes,10,rep,name=resource_used,json=resourceUsed,proto3" json:"resource_used,omitempty"`
+	Calls        []*CallTrace     `protobuf:"bytes,11,rep,name=calls,proto3" json:"calls,omitempty"`
+	// 调用失败时的错误信息
+	Error                string   `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallTrace) Reset()         { *m = CallTrace{} }
+func (m *CallTrace) String() string { return proto.CompactTextString(m) }
+func (*CallTrace) ProtoMessage()    {}
+func (*CallTrace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{5}
+}
+
+func (m *CallTrace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallTrace.Unmarshal(m, b)
+}
+func (m *CallTrace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallTrace.Marshal(b, m, deterministic)
+}
+func (m *CallTrace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallTrace.Merge(m, src)
+}
+func (m *CallTrace) XXX_Size() int {
+	return xxx_messageInfo_CallTrace.Size(m)
+}
+func (m *CallTrace) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallTrace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallTrace proto.InternalMessageInfo
+
+func (m *CallTrace) GetModuleName() string {
+	if m != nil {
+		return m.ModuleName
+	}
+	return ""
+}
+
+func (m *CallTrace) GetContractName() string {
+	if m != nil {
+		return m.ContractName
+	}
+	return ""
+}
+
+func (m *CallTrace) GetMethodName() string {
+	if m != nil {
+		return m.MethodName
+	}
+	return ""
+}
+
+func (m *CallTrace) GetArgs() map[string][]byte {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *CallTrace) GetResponse() *ContractResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *CallTrace) GetReads() []*TraceKV {
+	if m != nil {
+		return m.Reads
+	}
+	return nil
+}
+
+func (m *CallTrace) GetWrites() []*TraceKV {
+	if m != nil {
+		return m.Writes
+	}
+	return nil
+}
+
+func (m *CallTrace) GetEvents() []*ContractEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *CallTrace) GetLogs() []string {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *CallTrace) GetResourceUsed() []*ResourceLimit {
+	if m != nil {
+		return m.ResourceUsed
+	}
+	return nil
+}
+
+func (m *CallTrace) GetCalls() []*CallTrace {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+func (m *CallTrace) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 // ContractResponse is the response returnd by contract
 type ContractResponse struct {
 	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -354,7 +560,7 @@ func (m *ContractResponse) Reset()         { *m = ContractResponse{} }
 func (m *ContractResponse) String() string { return proto.CompactTextString(m) }
 func (*ContractResponse) ProtoMessage()    {}
 func (*ContractResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_919de52f3bf773d2, []int{4}
+	return fileDescriptor_919de52f3bf773d2, []int{6}
 }
 
 func (m *ContractResponse) XXX_Unmarshal(b []byte) error {
@@ -411,7 +617,7 @@ func (m *WasmCodeDesc) Reset()         { *m = WasmCodeDesc{} }
 func (m *WasmCodeDesc) String() string { return proto.CompactTextString(m) }
 func (*WasmCodeDesc) ProtoMessage()    {}
 func (*WasmCodeDesc) Descriptor() ([]byte, []int) {
-	return fileDescriptor_919de52f3bf773d2, []int{5}
+	return fileDescriptor_919de52f3bf773d2, []int{7}
 }
 
 func (m *WasmCodeDesc) XXX_Unmarshal(b []byte) error {
@@ -467,6 +673,96 @@ func (m *WasmCodeDesc) GetContractType() string {
 	return ""
 }
 
+// ContractVersion 合约代码的一个版本，部署和升级合约时记录
+type ContractVersion struct {
+	ContractName string `protobuf:"bytes,1,opt,name=contract_name,json=contractName,proto3" json:"contract_name,omitempty"`
+	Version      int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// 合约代码的DoubleSha256
+	CodeHash []byte        `protobuf:"bytes,3,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+	Deployer string        `protobuf:"bytes,4,opt,name=deployer,proto3" json:"deployer,omitempty"`
+	Desc     *WasmCodeDesc `protobuf:"bytes,5,opt,name=desc,proto3" json:"desc,omitempty"`
+	// 部署该版本的交易id和区块高度，不写入状态，由读接口根据版本记录的写入交易填充
+	Txid                 []byte   `protobuf:"bytes,6,opt,name=txid,proto3" json:"txid,omitempty"`
+	Height               int64    `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContractVersion) Reset()         { *m = ContractVersion{} }
+func (m *ContractVersion) String() string { return proto.CompactTextString(m) }
+func (*ContractVersion) ProtoMessage()    {}
+func (*ContractVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{8}
+}
+
+func (m *ContractVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractVersion.Unmarshal(m, b)
+}
+func (m *ContractVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractVersion.Marshal(b, m, deterministic)
+}
+func (m *ContractVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractVersion.Merge(m, src)
+}
+func (m *ContractVersion) XXX_Size() int {
+	return xxx_messageInfo_ContractVersion.Size(m)
+}
+func (m *ContractVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractVersion proto.InternalMessageInfo
+
+func (m *ContractVersion) GetContractName() string {
+	if m != nil {
+		return m.ContractName
+	}
+	return ""
+}
+
+func (m *ContractVersion) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ContractVersion) GetCodeHash() []byte {
+	if m != nil {
+		return m.CodeHash
+	}
+	return nil
+}
+
+func (m *ContractVersion) GetDeployer() string {
+	if m != nil {
+		return m.Deployer
+	}
+	return ""
+}
+
+func (m *ContractVersion) GetDesc() *WasmCodeDesc {
+	if m != nil {
+		return m.Desc
+	}
+	return nil
+}
+
+func (m *ContractVersion) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *ContractVersion) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 type ContractEvent struct {
 	Contract             string   `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -480,7 +776,7 @@ func (m *ContractEvent) Reset()         { *m = ContractEvent{} }
 func (m *ContractEvent) String() string { return proto.CompactTextString(m) }
 func (*ContractEvent) ProtoMessage()    {}
 func (*ContractEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_919de52f3bf773d2, []int{6}
+	return fileDescriptor_919de52f3bf773d2, []int{9}
 }
 
 func (m *ContractEvent) XXX_Unmarshal(b []byte) error {
@@ -523,18 +819,22 @@ func (m *ContractEvent) GetBody() []byte {
 }
 
 type ContractStatData struct {
-	AccountCount         int64    `protobuf:"varint,1,opt,name=accountCount,proto3" json:"accountCount,omitempty"`
-	ContractCount        int64    `protobuf:"varint,2,opt,name=contractCount,proto3" json:"contractCount,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AccountCount  int64 `protobuf:"varint,1,opt,name=accountCount,proto3" json:"accountCount,omitempty"`
+	ContractCount int64 `protobuf:"varint,2,opt,name=contractCount,proto3" json:"contractCount,omitempty"`
+	// 已销毁的合约数
+	DestroyedContractCount int64 `protobuf:"varint,3,opt,name=destroyedContractCount,proto3" json:"destroyedContractCount,omitempty"`
+	// 本地统计的调用次数最多的合约，按调用次数降序排列
+	HotContracts         []*ContractCallStat `protobuf:"bytes,4,rep,name=hotContracts,proto3" json:"hotContracts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ContractStatData) Reset()         { *m = ContractStatData{} }
 func (m *ContractStatData) String() string { return proto.CompactTextString(m) }
 func (*ContractStatData) ProtoMessage()    {}
 func (*ContractStatData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_919de52f3bf773d2, []int{7}
+	return fileDescriptor_919de52f3bf773d2, []int{10}
 }
 
 func (m *ContractStatData) XXX_Unmarshal(b []byte) error {
@@ -569,6 +869,126 @@ func (m *ContractStatData) GetContractCount() int64 {
 	return 0
 }
 
+func (m *ContractStatData) GetDestroyedContractCount() int64 {
+	if m != nil {
+		return m.DestroyedContractCount
+	}
+	return 0
+}
+
+func (m *ContractStatData) GetHotContracts() []*ContractCallStat {
+	if m != nil {
+		return m.HotContracts
+	}
+	return nil
+}
+
+// 合约的存储使用情况和预付的存储租金
+type ContractStorage struct {
+	ContractName string `protobuf:"bytes,1,opt,name=contractName,proto3" json:"contractName,omitempty"`
+	// 合约状态占用的字节数，每个key按key和value的长度之和计算
+	UsedBytes int64 `protobuf:"varint,2,opt,name=usedBytes,proto3" json:"usedBytes,omitempty"`
+	// 剩余的预付租金，存储增长时扣除，释放时退还
+	Prepaid              string   `protobuf:"bytes,3,opt,name=prepaid,proto3" json:"prepaid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContractStorage) Reset()         { *m = ContractStorage{} }
+func (m *ContractStorage) String() string { return proto.CompactTextString(m) }
+func (*ContractStorage) ProtoMessage()    {}
+func (*ContractStorage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{11}
+}
+
+func (m *ContractStorage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractStorage.Unmarshal(m, b)
+}
+func (m *ContractStorage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractStorage.Marshal(b, m, deterministic)
+}
+func (m *ContractStorage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractStorage.Merge(m, src)
+}
+func (m *ContractStorage) XXX_Size() int {
+	return xxx_messageInfo_ContractStorage.Size(m)
+}
+func (m *ContractStorage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractStorage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractStorage proto.InternalMessageInfo
+
+func (m *ContractStorage) GetContractName() string {
+	if m != nil {
+		return m.ContractName
+	}
+	return ""
+}
+
+func (m *ContractStorage) GetUsedBytes() int64 {
+	if m != nil {
+		return m.UsedBytes
+	}
+	return 0
+}
+
+func (m *ContractStorage) GetPrepaid() string {
+	if m != nil {
+		return m.Prepaid
+	}
+	return ""
+}
+
+// 合约被交易调用的次数，只在本地统计，不参与共识
+type ContractCallStat struct {
+	ContractName         string   `protobuf:"bytes,1,opt,name=contractName,proto3" json:"contractName,omitempty"`
+	CallCount            int64    `protobuf:"varint,2,opt,name=callCount,proto3" json:"callCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContractCallStat) Reset()         { *m = ContractCallStat{} }
+func (m *ContractCallStat) String() string { return proto.CompactTextString(m) }
+func (*ContractCallStat) ProtoMessage()    {}
+func (*ContractCallStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{12}
+}
+
+func (m *ContractCallStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractCallStat.Unmarshal(m, b)
+}
+func (m *ContractCallStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractCallStat.Marshal(b, m, deterministic)
+}
+func (m *ContractCallStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractCallStat.Merge(m, src)
+}
+func (m *ContractCallStat) XXX_Size() int {
+	return xxx_messageInfo_ContractCallStat.Size(m)
+}
+func (m *ContractCallStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractCallStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractCallStat proto.InternalMessageInfo
+
+func (m *ContractCallStat) GetContractName() string {
+	if m != nil {
+		return m.ContractName
+	}
+	return ""
+}
+
+func (m *ContractCallStat) GetCallCount() int64 {
+	if m != nil {
+		return m.CallCount
+	}
+	return 0
+}
+
 // Status of a contract
 type ContractStatus struct {
 	ContractName         string   `protobuf:"bytes,1,opt,name=contract_name,json=contractName,proto3" json:"contract_name,omitempty"`
@@ -577,6 +997,7 @@ type ContractStatus struct {
 	IsBanned             bool     `protobuf:"varint,4,opt,name=is_banned,json=isBanned,proto3" json:"is_banned,omitempty"`
 	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Runtime              string   `protobuf:"bytes,6,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	IsFrozen             bool     `protobuf:"varint,7,opt,name=is_frozen,json=isFrozen,proto3" json:"is_frozen,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -586,7 +1007,7 @@ func (m *ContractStatus) Reset()         { *m = ContractStatus{} }
 func (m *ContractStatus) String() string { return proto.CompactTextString(m) }
 func (*ContractStatus) ProtoMessage()    {}
 func (*ContractStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_919de52f3bf773d2, []int{8}
+	return fileDescriptor_919de52f3bf773d2, []int{13}
 }
 
 func (m *ContractStatus) XXX_Unmarshal(b []byte) error {
@@ -649,6 +1070,200 @@ func (m *ContractStatus) GetRuntime() string {
 	return ""
 }
 
+func (m *ContractStatus) GetIsFrozen() bool {
+	if m != nil {
+		return m.IsFrozen
+	}
+	return false
+}
+
+// 跨链只读查询请求，查询对象为同一引擎中的其他平行链
+// bucket非空时直接读取目标链状态，否则以只读方式调用request指定的合约方法
+type CrossQueryRequest struct {
+	ChainName string `protobuf:"bytes,1,opt,name=chain_name,json=chainName,proto3" json:"chain_name,omitempty"`
+	// 查询基于的目标链区块高度，验证节点会在同一高度复核查询结果
+	Height               int64          `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Bucket               string         `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key                  []byte         `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Request              *InvokeRequest `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"`
+	Initiator            string         `protobuf:"bytes,6,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire          []string       `protobuf:"bytes,7,rep,name=auth_require,json=authRequire,proto3" json:"auth_require,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CrossQueryRequest) Reset()         { *m = CrossQueryRequest{} }
+func (m *CrossQueryRequest) String() string { return proto.CompactTextString(m) }
+func (*CrossQueryRequest) ProtoMessage()    {}
+func (*CrossQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{14}
+}
+
+func (m *CrossQueryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossQueryRequest.Unmarshal(m, b)
+}
+func (m *CrossQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossQueryRequest.Marshal(b, m, deterministic)
+}
+func (m *CrossQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossQueryRequest.Merge(m, src)
+}
+func (m *CrossQueryRequest) XXX_Size() int {
+	return xxx_messageInfo_CrossQueryRequest.Size(m)
+}
+func (m *CrossQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossQueryRequest proto.InternalMessageInfo
+
+func (m *CrossQueryRequest) GetChainName() string {
+	if m != nil {
+		return m.ChainName
+	}
+	return ""
+}
+
+func (m *CrossQueryRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CrossQueryRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *CrossQueryRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *CrossQueryRequest) GetRequest() *InvokeRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *CrossQueryRequest) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+func (m *CrossQueryRequest) GetAuthRequire() []string {
+	if m != nil {
+		return m.AuthRequire
+	}
+	return nil
+}
+
+// 跨链只读查询结果
+type CrossQueryResponse struct {
+	Response *ContractResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// 查询所基于的目标链区块
+	Blockid              []byte   `protobuf:"bytes,2,opt,name=blockid,proto3" json:"blockid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossQueryResponse) Reset()         { *m = CrossQueryResponse{} }
+func (m *CrossQueryResponse) String() string { return proto.CompactTextString(m) }
+func (*CrossQueryResponse) ProtoMessage()    {}
+func (*CrossQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{15}
+}
+
+func (m *CrossQueryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossQueryResponse.Unmarshal(m, b)
+}
+func (m *CrossQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossQueryResponse.Marshal(b, m, deterministic)
+}
+func (m *CrossQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossQueryResponse.Merge(m, src)
+}
+func (m *CrossQueryResponse) XXX_Size() int {
+	return xxx_messageInfo_CrossQueryResponse.Size(m)
+}
+func (m *CrossQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossQueryResponse proto.InternalMessageInfo
+
+func (m *CrossQueryResponse) GetResponse() *ContractResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *CrossQueryResponse) GetBlockid() []byte {
+	if m != nil {
+		return m.Blockid
+	}
+	return nil
+}
+
+// 跨链查询记录，写入交易读写集的TransientBucket供验证节点复核
+type CrossQueryInfo struct {
+	Request              *CrossQueryRequest  `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Response             *CrossQueryResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *CrossQueryInfo) Reset()         { *m = CrossQueryInfo{} }
+func (m *CrossQueryInfo) String() string { return proto.CompactTextString(m) }
+func (*CrossQueryInfo) ProtoMessage()    {}
+func (*CrossQueryInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_919de52f3bf773d2, []int{16}
+}
+
+func (m *CrossQueryInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossQueryInfo.Unmarshal(m, b)
+}
+func (m *CrossQueryInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossQueryInfo.Marshal(b, m, deterministic)
+}
+func (m *CrossQueryInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossQueryInfo.Merge(m, src)
+}
+func (m *CrossQueryInfo) XXX_Size() int {
+	return xxx_messageInfo_CrossQueryInfo.Size(m)
+}
+func (m *CrossQueryInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossQueryInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossQueryInfo proto.InternalMessageInfo
+
+func (m *CrossQueryInfo) GetRequest() *CrossQueryRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *CrossQueryInfo) GetResponse() *CrossQueryResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("protos.ResourceType", ResourceType_name, ResourceType_value)
 	proto.RegisterType((*GasPrice)(nil), "protos.GasPrice")
@@ -656,67 +1271,108 @@ func init() {
 	proto.RegisterType((*InvokeRequest)(nil), "protos.InvokeRequest")
 	proto.RegisterMapType((map[string][]byte)(nil), "protos.InvokeRequest.ArgsEntry")
 	proto.RegisterType((*InvokeResponse)(nil), "protos.InvokeResponse")
+	proto.RegisterType((*TraceKV)(nil), "protos.TraceKV")
+	proto.RegisterType((*CallTrace)(nil), "protos.CallTrace")
+	proto.RegisterMapType((map[string][]byte)(nil), "protos.CallTrace.ArgsEntry")
 	proto.RegisterType((*ContractResponse)(nil), "protos.ContractResponse")
 	proto.RegisterType((*WasmCodeDesc)(nil), "protos.WasmCodeDesc")
+	proto.RegisterType((*ContractVersion)(nil), "protos.ContractVersion")
 	proto.RegisterType((*ContractEvent)(nil), "protos.ContractEvent")
 	proto.RegisterType((*ContractStatData)(nil), "protos.ContractStatData")
+	proto.RegisterType((*ContractStorage)(nil), "protos.ContractStorage")
+	proto.RegisterType((*ContractCallStat)(nil), "protos.ContractCallStat")
 	proto.RegisterType((*ContractStatus)(nil), "protos.ContractStatus")
+	proto.RegisterType((*CrossQueryRequest)(nil), "protos.CrossQueryRequest")
+	proto.RegisterType((*CrossQueryResponse)(nil), "protos.CrossQueryResponse")
+	proto.RegisterType((*CrossQueryInfo)(nil), "protos.CrossQueryInfo")
 }
 
 func init() { proto.RegisterFile("protos/contract.proto", fileDescriptor_919de52f3bf773d2) }
 
 var fileDescriptor_919de52f3bf773d2 = []byte{
-	// 817 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xc6, 0xbe, 0x8b, 0x7d, 0x9e, 0x38, 0xa9, 0xb5, 0xb4, 0xe8, 0x08, 0xa0, 0x46, 0x07, 0x42,
-	0x56, 0x25, 0x62, 0x91, 0x4a, 0x14, 0xf1, 0x01, 0x89, 0x3a, 0x06, 0x45, 0x50, 0x52, 0x6d, 0x5a,
-	0x51, 0x10, 0x92, 0xb5, 0xbe, 0x5b, 0x2e, 0xa7, 0x78, 0x6f, 0x8f, 0x7d, 0xb1, 0x6c, 0x7e, 0x0e,
-	0x3f, 0x81, 0xcf, 0xfc, 0x2a, 0x7e, 0x01, 0xda, 0x37, 0xfb, 0xec, 0xf6, 0xdb, 0x3e, 0x33, 0xcf,
-	0xcc, 0x3d, 0x33, 0xb3, 0xb3, 0x07, 0x8f, 0x1a, 0xc1, 0x15, 0x97, 0x93, 0x9c, 0xd7, 0x4a, 0x90,
-	0x5c, 0x5d, 0x58, 0x8c, 0x7a, 0xce, 0x7c, 0xf6, 0xc9, 0x5a, 0x37, 0x54, 0xe4, 0x5c, 0xd0, 0x89,
-	0x27, 0x2e, 0x69, 0x51, 0x52, 0xe1, 0x68, 0xd9, 0x5f, 0x90, 0xfc, 0x40, 0xe4, 0x4b, 0x51, 0xe5,
-	0x14, 0x7d, 0x08, 0x49, 0xde, 0xe8, 0xb9, 0x20, 0x8a, 0xa6, 0x9d, 0xf3, 0xce, 0x38, 0xc2, 0xfd,
-	0xbc, 0xd1, 0x98, 0x28, 0xeb, 0x62, 0x94, 0x39, 0x57, 0xd7, 0xb9, 0x18, 0x65, 0xd6, 0xf5, 0x11,
-	0x0c, 0x8a, 0x4a, 0xde, 0x3b, 0x5f, 0x64, 0x7d, 0x89, 0x31, 0x04, 0xe7, 0xfa, 0x0f, 0x4a, 0x9d,
-	0x33, 0x76, 0x4e, 0x63, 0x30, 0xce, 0xec, 0x06, 0x4e, 0x30, 0x95, 0x5c, 0x8b, 0x9c, 0xfe, 0x54,
-	0xb1, 0x4a, 0xa1, 0x31, 0xc4, 0x6a, 0xd3, 0xb8, 0x8f, 0x9f, 0x5e, 0x3e, 0x74, 0x12, 0xe5, 0x45,
-	0x20, 0xbd, 0xda, 0x34, 0x14, 0x5b, 0x06, 0x7a, 0x08, 0x47, 0x4b, 0x13, 0xe2, 0xc5, 0x38, 0x90,
-	0xfd, 0xdb, 0x85, 0x93, 0xeb, 0x7a, 0xc5, 0xef, 0x29, 0xa6, 0x7f, 0x6a, 0x2a, 0x15, 0x7a, 0x0c,
-	0xc7, 0x8c, 0x17, 0x7a, 0x49, 0xe7, 0x35, 0x61, 0x2e, 0xf1, 0x00, 0x83, 0x33, 0xfd, 0x4c, 0x18,
-	0x45, 0x9f, 0xc2, 0x49, 0x68, 0x9c, 0xa3, 0x74, 0x2d, 0x65, 0x18, 0x8c, 0x96, 0x64, 0xb2, 0x50,
-	0x75, 0xc7, 0x0b, 0x47, 0x89, 0x7c, 0x16, 0x6b, 0xb2, 0x84, 0xa7, 0x10, 0x13, 0x51, 0xca, 0x34,
-	0x3e, 0x8f, 0xc6, 0xc7, 0x97, 0x8f, 0x83, 0xf0, 0x3d, 0x2d, 0x17, 0xdf, 0x89, 0x52, 0xce, 0x6a,
-	0x25, 0x36, 0xd8, 0x92, 0xd1, 0xb7, 0xf0, 0x40, 0xf8, 0xca, 0xe6, 0x56, 0xbf, 0x4c, 0x8f, 0x6c,
-	0xfc, 0xa3, 0xc3, 0xc2, 0x6d, 0x77, 0xf0, 0xa9, 0x68, 0x43, 0x89, 0x3e, 0x80, 0x1e, 0x61, 0x5c,
-	0xd7, 0x2a, 0xed, 0x59, 0x41, 0x1e, 0x9d, 0x3d, 0x83, 0xc1, 0xf6, 0x53, 0x68, 0x04, 0xd1, 0x3d,
-	0xdd, 0xf8, 0xc2, 0xcd, 0xd1, 0xb4, 0x6e, 0x45, 0x96, 0xda, 0x55, 0x3a, 0xc4, 0x0e, 0x7c, 0xd3,
-	0xfd, 0xba, 0x93, 0xfd, 0xd7, 0x85, 0xd3, 0x20, 0x59, 0x36, 0xbc, 0x96, 0x14, 0x3d, 0x81, 0x5e,
-	0x55, 0x37, 0x5a, 0xc9, 0xb4, 0x63, 0xa5, 0xa1, 0x20, 0xed, 0xd5, 0xfa, 0xda, 0xd8, 0x67, 0x6b,
-	0x85, 0x3d, 0x03, 0x7d, 0x01, 0x7d, 0xae, 0x95, 0x25, 0x77, 0x2d, 0xf9, 0xfd, 0x1d, 0xf9, 0xc6,
-	0x3a, 0x0c, 0x3b, 0x70, 0xd0, 0x19, 0x24, 0xc2, 0x7f, 0x26, 0x8d, 0xce, 0xa3, 0xf1, 0x10, 0x6f,
-	0xb1, 0xb9, 0x6e, 0x25, 0x91, 0x73, 0x2d, 0x69, 0xe1, 0x6f, 0x4d, 0xbf, 0x24, 0xf2, 0xb5, 0xa4,
-	0x05, 0xfa, 0xd2, 0x84, 0xd9, 0x86, 0xbe, 0xd5, 0xae, 0xbd, 0x76, 0xe3, 0x2d, 0x0d, 0x7d, 0x05,
-	0x83, 0x90, 0x59, 0xa6, 0x3d, 0x1b, 0x93, 0x86, 0x98, 0xa9, 0x9f, 0x73, 0xa8, 0x18, 0xef, 0xa8,
-	0x68, 0x02, 0xa0, 0xd5, 0x9a, 0x5f, 0xbb, 0x06, 0xf4, 0x6d, 0xe0, 0x83, 0x83, 0x06, 0xe0, 0x16,
-	0x05, 0x5d, 0xc2, 0xb1, 0x41, 0x37, 0xbe, 0x0b, 0x89, 0x8d, 0x18, 0x1d, 0x76, 0x01, 0xb7, 0x49,
-	0xd9, 0x1b, 0x18, 0x1d, 0x6a, 0x30, 0x93, 0x95, 0x8a, 0x28, 0x2d, 0xed, 0xdc, 0x8e, 0xb0, 0x47,
-	0x28, 0x85, 0x3e, 0xa3, 0x52, 0x92, 0x32, 0x5c, 0xd3, 0x00, 0x11, 0x82, 0x78, 0xc1, 0x8b, 0x8d,
-	0xbd, 0x9a, 0x43, 0x6c, 0xcf, 0xd9, 0xdf, 0x1d, 0x18, 0xfe, 0x42, 0x24, 0x9b, 0xf2, 0x82, 0x5e,
-	0x51, 0x99, 0x9b, 0x70, 0xa1, 0x6b, 0x55, 0x6d, 0x17, 0x21, 0x40, 0x33, 0x8b, 0x9c, 0xb3, 0xa6,
-	0x5a, 0x52, 0xe1, 0x33, 0x6f, 0xb1, 0x11, 0x53, 0x54, 0x25, 0x95, 0xca, 0x27, 0xf7, 0xc8, 0x2c,
-	0xc5, 0x8a, 0xcd, 0xb7, 0x61, 0xb1, 0x5b, 0x8a, 0x15, 0x9b, 0x86, 0xc0, 0xf6, 0x6a, 0xd9, 0xb5,
-	0x3e, 0xda, 0x5f, 0x2d, 0xb3, 0xce, 0xd9, 0x2d, 0x9c, 0x84, 0xf2, 0x67, 0x2b, 0x5a, 0x2b, 0x27,
-	0xc5, 0x19, 0xbc, 0xca, 0x2d, 0x36, 0x55, 0xb6, 0x76, 0xd4, 0x9e, 0xdf, 0x59, 0xf9, 0xef, 0xbb,
-	0x9e, 0xde, 0x2a, 0xa2, 0xae, 0x88, 0x22, 0x28, 0x83, 0x21, 0xc9, 0x73, 0xb3, 0x20, 0x53, 0xbb,
-	0x33, 0xee, 0x81, 0xdb, 0xb3, 0xa1, 0xcf, 0x76, 0x8a, 0x1d, 0xc9, 0xbd, 0x2e, 0xfb, 0xc6, 0xec,
-	0x9f, 0x0e, 0x9c, 0xb6, 0xd3, 0x6b, 0xf9, 0xf6, 0x2b, 0xd2, 0x79, 0xc7, 0x2b, 0x82, 0x20, 0x56,
-	0xeb, 0xaa, 0x08, 0xea, 0xcd, 0xd9, 0xd8, 0x0a, 0x2a, 0xf3, 0xa0, 0xde, 0x9c, 0xcd, 0x9b, 0x59,
-	0xc9, 0xf9, 0x82, 0xd4, 0xb5, 0xbf, 0xfd, 0x09, 0x4e, 0x2a, 0xf9, 0xdc, 0x62, 0xf4, 0x31, 0x0c,
-	0xcc, 0xc4, 0xa4, 0x22, 0xac, 0xb1, 0x0d, 0x8d, 0xf0, 0xce, 0xd0, 0x9e, 0x70, 0x6f, 0x6f, 0xc2,
-	0x4f, 0x9e, 0xc1, 0xb0, 0xfd, 0x8c, 0xa2, 0x3e, 0x44, 0xd3, 0x97, 0xaf, 0x47, 0xef, 0x21, 0x80,
-	0xde, 0x8b, 0xd9, 0x8b, 0x1b, 0xfc, 0xeb, 0xa8, 0x83, 0x12, 0x88, 0xaf, 0xae, 0x6f, 0x7f, 0x1c,
-	0x75, 0xcd, 0xe9, 0xcd, 0xf7, 0xb3, 0xd9, 0x28, 0x7a, 0x3e, 0xfe, 0xed, 0xf3, 0xb2, 0x52, 0x77,
-	0x7a, 0x71, 0x91, 0x73, 0x36, 0x71, 0x3f, 0x93, 0x3b, 0x52, 0xd5, 0x93, 0xc3, 0xff, 0xca, 0xc2,
-	0xfd, 0x71, 0x9e, 0xfe, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x4e, 0xe4, 0xd3, 0xa2, 0x91, 0x06, 0x00,
-	0x00,
+	// 1331 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xef, 0x6e, 0xdc, 0xc4,
+	0x16, 0xbf, 0x5e, 0xef, 0xdf, 0x93, 0x4d, 0xba, 0x9d, 0xdb, 0x56, 0x6e, 0x7a, 0xab, 0xe6, 0xfa,
+	0x5e, 0x68, 0xa8, 0xd4, 0xac, 0x68, 0x51, 0x8b, 0x2a, 0x84, 0x44, 0xb7, 0x29, 0x44, 0xa5, 0xa4,
+	0x4c, 0xd2, 0x52, 0xf8, 0xb2, 0x9a, 0xd8, 0xd3, 0x5d, 0x2b, 0xb6, 0xc7, 0xcc, 0x8c, 0x43, 0xb6,
+	0x12, 0x8f, 0x82, 0x84, 0x78, 0x0e, 0xde, 0x01, 0x89, 0x17, 0xe0, 0x1b, 0xcf, 0x81, 0xe6, 0x9f,
+	0xed, 0xdd, 0xa4, 0x55, 0xc5, 0x07, 0xbe, 0xac, 0xe6, 0x9c, 0xf3, 0x9b, 0x99, 0xf3, 0xe7, 0x77,
+	0xe6, 0x78, 0xe1, 0x72, 0xc1, 0x99, 0x64, 0x62, 0x1c, 0xb1, 0x5c, 0x72, 0x12, 0xc9, 0x1d, 0x2d,
+	0xa3, 0xae, 0x51, 0x6f, 0x5e, 0x3f, 0x2d, 0x0b, 0xca, 0x23, 0xc6, 0xe9, 0xd8, 0x02, 0x53, 0x1a,
+	0xcf, 0x28, 0x37, 0xb0, 0xf0, 0x35, 0xf4, 0x3f, 0x27, 0xe2, 0x19, 0x4f, 0x22, 0x8a, 0xae, 0x42,
+	0x3f, 0x2a, 0xca, 0x29, 0x27, 0x92, 0x06, 0xde, 0x96, 0xb7, 0xed, 0xe3, 0x5e, 0x54, 0x94, 0x98,
+	0x48, 0x6d, 0xca, 0x68, 0x66, 0x4c, 0x2d, 0x63, 0xca, 0x68, 0xa6, 0x4d, 0xd7, 0x60, 0x10, 0x27,
+	0xe2, 0xd8, 0xd8, 0x7c, 0x6d, 0xeb, 0x2b, 0x85, 0x33, 0x9e, 0xbe, 0xa2, 0xd4, 0x18, 0xdb, 0xc6,
+	0xa8, 0x14, 0xca, 0x18, 0xee, 0xc3, 0x3a, 0xa6, 0x82, 0x95, 0x3c, 0xa2, 0x5f, 0x26, 0x59, 0x22,
+	0xd1, 0x36, 0xb4, 0xe5, 0xa2, 0x30, 0x97, 0x6f, 0xdc, 0xb9, 0x64, 0x5c, 0x14, 0x3b, 0x0e, 0x74,
+	0xb8, 0x28, 0x28, 0xd6, 0x08, 0x74, 0x09, 0x3a, 0xa9, 0xda, 0x62, 0x9d, 0x31, 0x42, 0xf8, 0x6b,
+	0x0b, 0xd6, 0xf7, 0xf2, 0x13, 0x76, 0x4c, 0x31, 0xfd, 0xbe, 0xa4, 0x42, 0xa2, 0x1b, 0xb0, 0x96,
+	0xb1, 0xb8, 0x4c, 0xe9, 0x34, 0x27, 0x99, 0x39, 0x78, 0x80, 0xc1, 0xa8, 0xbe, 0x22, 0x19, 0x45,
+	0xff, 0x83, 0x75, 0x97, 0x38, 0x03, 0x69, 0x69, 0xc8, 0xd0, 0x29, 0x35, 0x48, 0x9d, 0x42, 0xe5,
+	0x9c, 0xc5, 0x06, 0xe2, 0xdb, 0x53, 0xb4, 0x4a, 0x03, 0xee, 0x42, 0x9b, 0xf0, 0x99, 0x08, 0xda,
+	0x5b, 0xfe, 0xf6, 0xda, 0x9d, 0x1b, 0xce, 0xf1, 0x25, 0x5f, 0x76, 0x3e, 0xe3, 0x33, 0xb1, 0x9b,
+	0x4b, 0xbe, 0xc0, 0x1a, 0x8c, 0x3e, 0x85, 0x0b, 0xdc, 0x46, 0x36, 0xd5, 0xfe, 0x8b, 0xa0, 0xa3,
+	0xf7, 0x5f, 0x5e, 0x0d, 0x5c, 0x67, 0x07, 0x6f, 0xf0, 0xa6, 0x28, 0xd0, 0x15, 0xe8, 0x92, 0x8c,
+	0x95, 0xb9, 0x0c, 0xba, 0xda, 0x21, 0x2b, 0x6d, 0xde, 0x87, 0x41, 0x75, 0x15, 0x1a, 0x81, 0x7f,
+	0x4c, 0x17, 0x36, 0x70, 0xb5, 0x54, 0xa9, 0x3b, 0x21, 0x69, 0x69, 0x22, 0x1d, 0x62, 0x23, 0x3c,
+	0x68, 0x7d, 0xec, 0x85, 0x3f, 0xf9, 0xb0, 0xe1, 0x5c, 0x16, 0x05, 0xcb, 0x05, 0x45, 0xb7, 0xa0,
+	0x9b, 0xe4, 0x45, 0x29, 0x45, 0xe0, 0x69, 0xd7, 0x90, 0x73, 0xed, 0xf0, 0x74, 0x4f, 0xe9, 0x77,
+	0x4f, 0x25, 0xb6, 0x08, 0x74, 0x1b, 0x7a, 0xac, 0x94, 0x1a, 0xdc, 0xd2, 0xe0, 0x7f, 0xd7, 0xe0,
+	0xfd, 0x52, 0x5a, 0xb4, 0xc3, 0xa0, 0x4d, 0xe8, 0x73, 0x7b, 0x4d, 0xe0, 0x6f, 0xf9, 0xdb, 0x43,
+	0x5c, 0xc9, 0x8a, 0x6e, 0x33, 0x22, 0xa6, 0xa5, 0xa0, 0xb1, 0x65, 0x4d, 0x6f, 0x46, 0xc4, 0x73,
+	0x41, 0x63, 0xf4, 0xa1, 0xda, 0xa6, 0x13, 0x7a, 0x26, 0x5d, 0x4b, 0xe9, 0xc6, 0x15, 0x0c, 0xdd,
+	0x83, 0x81, 0x3b, 0x59, 0x04, 0x5d, 0xbd, 0x27, 0x70, 0x7b, 0x26, 0xb6, 0xce, 0x2e, 0x62, 0x5c,
+	0x43, 0xd1, 0x18, 0xa0, 0x94, 0xa7, 0x6c, 0xcf, 0x24, 0xa0, 0xa7, 0x37, 0x5e, 0x58, 0x49, 0x00,
+	0x6e, 0x40, 0xd0, 0x1d, 0x58, 0x53, 0xd2, 0xbe, 0xcd, 0x42, 0x5f, 0xef, 0x18, 0xad, 0x66, 0x01,
+	0x37, 0x41, 0xe8, 0x03, 0xe8, 0x2a, 0x07, 0xa8, 0x08, 0x06, 0x1a, 0x7e, 0xb1, 0xf2, 0x8c, 0xa4,
+	0xe9, 0xa1, 0xb2, 0x60, 0x0b, 0x08, 0x23, 0xe8, 0x69, 0xc5, 0x93, 0x17, 0xaa, 0xf6, 0x47, 0x65,
+	0x74, 0x4c, 0xa5, 0xad, 0xac, 0x95, 0x5c, 0xb9, 0x4d, 0x69, 0x97, 0xcb, 0xed, 0x37, 0xca, 0x8d,
+	0x02, 0xe8, 0xc5, 0x34, 0xa5, 0xd2, 0xe6, 0xb7, 0x8f, 0x9d, 0x18, 0xfe, 0xdc, 0x86, 0x41, 0x75,
+	0xf5, 0x3f, 0xd5, 0x3f, 0xe3, 0xa5, 0xfe, 0xb9, 0x76, 0x26, 0x05, 0x67, 0x7a, 0xe7, 0xa3, 0x06,
+	0x79, 0x3a, 0x5b, 0xde, 0x5b, 0x2b, 0x5a, 0xd3, 0xea, 0x3d, 0xe8, 0x70, 0x4a, 0x62, 0x47, 0x82,
+	0xba, 0x96, 0x26, 0xab, 0xd8, 0x58, 0xd1, 0x4d, 0xe8, 0xfe, 0xc0, 0x13, 0x49, 0xcf, 0xd6, 0xdc,
+	0xe2, 0xac, 0x19, 0xdd, 0x86, 0x2e, 0x3d, 0xa1, 0x79, 0x55, 0xea, 0xcb, 0xab, 0x3e, 0xec, 0x2a,
+	0x2b, 0xb6, 0x20, 0x84, 0xa0, 0x9d, 0xb2, 0x99, 0x29, 0xf4, 0x00, 0xeb, 0x35, 0x7a, 0x00, 0xeb,
+	0xd5, 0x23, 0xa0, 0xe9, 0x0e, 0x6f, 0x7b, 0x02, 0x86, 0x0e, 0xab, 0x5b, 0xe1, 0x26, 0x74, 0x22,
+	0x92, 0xa6, 0x22, 0x58, 0x7b, 0x13, 0x73, 0x8c, 0x5d, 0x71, 0x80, 0x72, 0xce, 0x78, 0x30, 0xd4,
+	0x99, 0x37, 0xc2, 0xdf, 0x7f, 0x27, 0x5e, 0xc2, 0x68, 0x35, 0xc9, 0x8a, 0x90, 0x42, 0x12, 0x59,
+	0x0a, 0x7d, 0x44, 0x07, 0x5b, 0x49, 0x11, 0x2d, 0xa3, 0x42, 0x90, 0x99, 0x63, 0x86, 0x13, 0x55,
+	0x36, 0x8e, 0x58, 0xbc, 0xb0, 0xbc, 0xd4, 0xeb, 0xf0, 0x17, 0x0f, 0x86, 0xdf, 0x10, 0x91, 0x4d,
+	0x58, 0x4c, 0x1f, 0x51, 0x11, 0xa9, 0xed, 0xbc, 0xcc, 0x65, 0x52, 0x71, 0xcf, 0x89, 0xea, 0xf9,
+	0x88, 0x58, 0x56, 0x24, 0x29, 0xe5, 0xf6, 0xe4, 0x4a, 0x56, 0xce, 0xc4, 0xc9, 0x8c, 0x0a, 0x69,
+	0x0f, 0xb7, 0x92, 0xe2, 0xe1, 0x49, 0x36, 0xad, 0xb6, 0xb5, 0x0d, 0x0f, 0x4f, 0xb2, 0x89, 0xdb,
+	0xd8, 0x64, 0xb3, 0x9e, 0x44, 0x9d, 0x65, 0x36, 0xab, 0x09, 0x14, 0xfe, 0xe1, 0xc1, 0x05, 0x17,
+	0xff, 0x0b, 0xca, 0x45, 0xc2, 0xf2, 0xb3, 0x6d, 0xe0, 0x9d, 0xd3, 0x06, 0x01, 0xf4, 0x4e, 0x0c,
+	0xde, 0xcd, 0x50, 0x2b, 0xaa, 0x31, 0x19, 0xb1, 0x98, 0x4e, 0xe7, 0x44, 0xcc, 0xad, 0xcf, 0x7d,
+	0xa5, 0xf8, 0x82, 0x88, 0xb9, 0x8a, 0x34, 0xa6, 0x45, 0xca, 0x16, 0x95, 0xcb, 0x95, 0xac, 0x26,
+	0x66, 0x4c, 0x45, 0x64, 0x7b, 0xa0, 0x9a, 0x98, 0xcd, 0x1c, 0x62, 0x8d, 0x50, 0xe9, 0x96, 0xa7,
+	0x49, 0xac, 0x67, 0xc5, 0x10, 0xeb, 0xb5, 0xca, 0xd3, 0x9c, 0x26, 0xb3, 0xb9, 0x0c, 0x7a, 0xda,
+	0x1f, 0x2b, 0x85, 0x07, 0xb0, 0xbe, 0xc4, 0x60, 0x93, 0x6c, 0xa3, 0xb0, 0x91, 0x55, 0xb2, 0x3a,
+	0xb8, 0xd1, 0xf8, 0x7a, 0x7d, 0x6e, 0x6d, 0x7f, 0xf7, 0x6a, 0xda, 0x1c, 0x48, 0x22, 0x1f, 0x11,
+	0x49, 0x50, 0x08, 0x43, 0x12, 0x45, 0x6a, 0x6c, 0x4d, 0xd4, 0x8f, 0xfd, 0xec, 0x58, 0xd2, 0xa1,
+	0xff, 0xd7, 0xb9, 0x35, 0x20, 0x93, 0xbc, 0x65, 0x25, 0xba, 0x07, 0x57, 0x62, 0x2a, 0x24, 0x67,
+	0x0b, 0x1a, 0x4f, 0x96, 0xe0, 0xe6, 0x9b, 0xe4, 0x0d, 0x56, 0xf4, 0x09, 0x0c, 0xe7, 0x4c, 0x3a,
+	0x9d, 0x7b, 0x82, 0xce, 0xbc, 0x26, 0xaa, 0xa7, 0x94, 0xd7, 0x78, 0x09, 0x1d, 0x66, 0x35, 0x15,
+	0x0e, 0x24, 0xe3, 0x8a, 0xd7, 0x21, 0x2c, 0x55, 0xfd, 0x5c, 0x26, 0xfc, 0x07, 0x06, 0xaa, 0xd9,
+	0x1f, 0x2e, 0xd4, 0x23, 0x63, 0xc2, 0xa9, 0x15, 0x8a, 0x27, 0x05, 0xa7, 0x05, 0x49, 0x62, 0xfb,
+	0x54, 0x3a, 0x31, 0x3c, 0x84, 0xd1, 0xaa, 0x43, 0xef, 0x7a, 0x9f, 0x7a, 0x09, 0x9a, 0xe9, 0xab,
+	0x15, 0xe1, 0x6f, 0x1e, 0x6c, 0x34, 0x2b, 0x53, 0x8a, 0x77, 0xe3, 0xb3, 0xa3, 0x94, 0xad, 0xbc,
+	0x5a, 0x2b, 0x9d, 0x26, 0xa4, 0xad, 0xbc, 0x5a, 0x2b, 0x76, 0x27, 0x62, 0x7a, 0x44, 0xf2, 0xbc,
+	0x1a, 0x37, 0xfd, 0x44, 0x3c, 0xd4, 0xb2, 0x72, 0x4d, 0xf5, 0xb3, 0x90, 0x24, 0x2b, 0x34, 0x8d,
+	0x7d, 0x5c, 0x2b, 0x9a, 0xfd, 0xdf, 0x5d, 0xee, 0x7f, 0x73, 0xe8, 0x2b, 0xce, 0x5e, 0xd3, 0x3c,
+	0xe8, 0xb9, 0x43, 0x1f, 0x6b, 0x39, 0xfc, 0xd3, 0x83, 0x8b, 0x13, 0xce, 0x84, 0xf8, 0xba, 0xa4,
+	0x7c, 0xe1, 0x3e, 0x06, 0xaf, 0x03, 0x44, 0x73, 0x92, 0xe4, 0xcd, 0x88, 0x06, 0x5a, 0xa3, 0xc3,
+	0xa9, 0xbb, 0xa1, 0xd5, 0xec, 0x86, 0xc6, 0xac, 0xf5, 0xcf, 0x9b, 0xb5, 0xed, 0x7a, 0xd6, 0x8e,
+	0xa1, 0x67, 0x3f, 0x3a, 0x6c, 0x43, 0xbe, 0xe1, 0xd3, 0xc4, 0xa1, 0x54, 0xf0, 0x49, 0x9e, 0xc8,
+	0x84, 0x48, 0xc6, 0x6d, 0x80, 0xb5, 0x02, 0xfd, 0x17, 0x86, 0xa4, 0x94, 0xf3, 0xa9, 0x42, 0x27,
+	0x9c, 0xea, 0x69, 0x34, 0xc0, 0x6b, 0x4a, 0x87, 0x8d, 0x2a, 0x8c, 0x01, 0x35, 0xe3, 0xb4, 0x8f,
+	0x71, 0x73, 0x3a, 0x7a, 0xef, 0x3c, 0x1d, 0x03, 0xe8, 0x1d, 0xa5, 0x2c, 0x3a, 0xb6, 0x15, 0x1d,
+	0x62, 0x27, 0x86, 0x3f, 0xc2, 0x46, 0x7d, 0xcb, 0x5e, 0xfe, 0x8a, 0xa1, 0xbb, 0x75, 0xa4, 0xe6,
+	0x82, 0xab, 0xd5, 0x05, 0xab, 0x69, 0xaf, 0xa3, 0xbd, 0xd7, 0x70, 0xab, 0xa5, 0x77, 0x6d, 0x9e,
+	0xb7, 0x6b, 0xd5, 0xb1, 0x5b, 0xf7, 0x61, 0xd8, 0xfc, 0x0b, 0x80, 0x7a, 0xe0, 0x4f, 0x9e, 0x3d,
+	0x1f, 0xfd, 0x0b, 0x01, 0x74, 0x9f, 0xee, 0x3e, 0xdd, 0xc7, 0xdf, 0x8e, 0x3c, 0xd4, 0x87, 0xf6,
+	0xa3, 0xbd, 0x83, 0x27, 0xa3, 0x96, 0x5a, 0xbd, 0x7c, 0xbc, 0xbb, 0x3b, 0xf2, 0x1f, 0x6e, 0x7f,
+	0xf7, 0xfe, 0x2c, 0x91, 0xf3, 0xf2, 0x68, 0x27, 0x62, 0xd9, 0xd8, 0xfc, 0x11, 0x52, 0xe5, 0x1e,
+	0xaf, 0xfe, 0x27, 0x3a, 0x32, 0xff, 0x96, 0xee, 0xfe, 0x35, 0x00, 0x13, 0x1f, 0xfb, 0xea, 0x4d,
+	0x0d, 0x00, 0x00,
 }
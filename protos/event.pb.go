@@ -29,14 +29,22 @@ type SubscribeType int32
 const (
 	// 区块事件，payload为BlockFilter
 	SubscribeType_BLOCK SubscribeType = 0
+	// 交易事件，payload为TxFilter
+	SubscribeType_TX SubscribeType = 1
+	// 合约事件，payload为EventFilter
+	SubscribeType_EVENT SubscribeType = 2
 )
 
 var SubscribeType_name = map[int32]string{
 	0: "BLOCK",
+	1: "TX",
+	2: "EVENT",
 }
 
 var SubscribeType_value = map[string]int32{
 	"BLOCK": 0,
+	"TX":    1,
+	"EVENT": 2,
 }
 
 func (x SubscribeType) String() string {
@@ -47,12 +55,47 @@ func (SubscribeType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_bec55cd27928da5d, []int{0}
 }
 
+type FilterExpr_Op int32
+
+const (
+	// 叶子节点，使用clause作为过滤条件
+	FilterExpr_CLAUSE FilterExpr_Op = 0
+	FilterExpr_AND    FilterExpr_Op = 1
+	FilterExpr_OR     FilterExpr_Op = 2
+	// 对唯一的子表达式取反
+	FilterExpr_NOT FilterExpr_Op = 3
+)
+
+var FilterExpr_Op_name = map[int32]string{
+	0: "CLAUSE",
+	1: "AND",
+	2: "OR",
+	3: "NOT",
+}
+
+var FilterExpr_Op_value = map[string]int32{
+	"CLAUSE": 0,
+	"AND":    1,
+	"OR":     2,
+	"NOT":    3,
+}
+
+func (x FilterExpr_Op) String() string {
+	return proto.EnumName(FilterExpr_Op_name, int32(x))
+}
+
+func (FilterExpr_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{7, 0}
+}
+
 type SubscribeRequest struct {
-	Type                 SubscribeType `protobuf:"varint,1,opt,name=type,proto3,enum=protos.SubscribeType" json:"type,omitempty"`
-	Filter               []byte        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Type   SubscribeType `protobuf:"varint,1,opt,name=type,proto3,enum=protos.SubscribeType" json:"type,omitempty"`
+	Filter []byte        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 上次收到的Event.cursor，非空时从该事件之后恢复订阅，此时忽略filter中range的start
+	Cursor               []byte   `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
@@ -94,8 +137,19 @@ func (m *SubscribeRequest) GetFilter() []byte {
 	return nil
 }
 
+func (m *SubscribeRequest) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
 type Event struct {
-	Payload              []byte   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// 事件的不透明游标，可以作为SubscribeRequest.cursor断点续订
+	Cursor []byte `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// 主干切换时推送的回滚事件，payload为被回滚的区块/交易/合约事件
+	Revert               bool     `protobuf:"varint,3,opt,name=revert,proto3" json:"revert,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -133,6 +187,94 @@ func (m *Event) GetPayload() []byte {
 	return nil
 }
 
+func (m *Event) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *Event) GetRevert() bool {
+	if m != nil {
+		return m.Revert
+	}
+	return false
+}
+
+// EventCursor 是Event.cursor的编码格式，对订阅者不透明
+type EventCursor struct {
+	BlockHeight int64  `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Blockid     []byte `protobuf:"bytes,2,opt,name=blockid,proto3" json:"blockid,omitempty"`
+	// 事件在区块内的交易序号，区块事件为-1
+	TxIndex int32 `protobuf:"varint,3,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	// 事件在交易内的合约事件序号，区块事件和交易事件为-1
+	EventIndex           int32    `protobuf:"varint,4,opt,name=event_index,json=eventIndex,proto3" json:"event_index,omitempty"`
+	Revert               bool     `protobuf:"varint,5,opt,name=revert,proto3" json:"revert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventCursor) Reset()         { *m = EventCursor{} }
+func (m *EventCursor) String() string { return proto.CompactTextString(m) }
+func (*EventCursor) ProtoMessage()    {}
+func (*EventCursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{2}
+}
+
+func (m *EventCursor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventCursor.Unmarshal(m, b)
+}
+func (m *EventCursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventCursor.Marshal(b, m, deterministic)
+}
+func (m *EventCursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventCursor.Merge(m, src)
+}
+func (m *EventCursor) XXX_Size() int {
+	return xxx_messageInfo_EventCursor.Size(m)
+}
+func (m *EventCursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventCursor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventCursor proto.InternalMessageInfo
+
+func (m *EventCursor) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *EventCursor) GetBlockid() []byte {
+	if m != nil {
+		return m.Blockid
+	}
+	return nil
+}
+
+func (m *EventCursor) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *EventCursor) GetEventIndex() int32 {
+	if m != nil {
+		return m.EventIndex
+	}
+	return 0
+}
+
+func (m *EventCursor) GetRevert() bool {
+	if m != nil {
+		return m.Revert
+	}
+	return false
+}
+
 type BlockRange struct {
 	Start                string   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
 	End                  string   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
@@ -145,7 +287,7 @@ func (m *BlockRange) Reset()         { *m = BlockRange{} }
 func (m *BlockRange) String() string { return proto.CompactTextString(m) }
 func (*BlockRange) ProtoMessage()    {}
 func (*BlockRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bec55cd27928da5d, []int{2}
+	return fileDescriptor_bec55cd27928da5d, []int{3}
 }
 
 func (m *BlockRange) XXX_Unmarshal(b []byte) error {
@@ -181,16 +323,18 @@ func (m *BlockRange) GetEnd() string {
 }
 
 type BlockFilter struct {
-	Bcname               string      `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
-	Range                *BlockRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
-	ExcludeTx            bool        `protobuf:"varint,3,opt,name=exclude_tx,json=excludeTx,proto3" json:"exclude_tx,omitempty"`
-	ExcludeTxEvent       bool        `protobuf:"varint,4,opt,name=exclude_tx_event,json=excludeTxEvent,proto3" json:"exclude_tx_event,omitempty"`
-	Contract             string      `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
-	EventName            string      `protobuf:"bytes,11,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
-	Initiator            string      `protobuf:"bytes,12,opt,name=initiator,proto3" json:"initiator,omitempty"`
-	AuthRequire          string      `protobuf:"bytes,13,opt,name=auth_require,json=authRequire,proto3" json:"auth_require,omitempty"`
-	FromAddr             string      `protobuf:"bytes,14,opt,name=from_addr,json=fromAddr,proto3" json:"from_addr,omitempty"`
-	ToAddr               string      `protobuf:"bytes,15,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	Bcname         string      `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Range          *BlockRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	ExcludeTx      bool        `protobuf:"varint,3,opt,name=exclude_tx,json=excludeTx,proto3" json:"exclude_tx,omitempty"`
+	ExcludeTxEvent bool        `protobuf:"varint,4,opt,name=exclude_tx_event,json=excludeTxEvent,proto3" json:"exclude_tx_event,omitempty"`
+	Contract       string      `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
+	EventName      string      `protobuf:"bytes,11,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	Initiator      string      `protobuf:"bytes,12,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire    string      `protobuf:"bytes,13,opt,name=auth_require,json=authRequire,proto3" json:"auth_require,omitempty"`
+	FromAddr       string      `protobuf:"bytes,14,opt,name=from_addr,json=fromAddr,proto3" json:"from_addr,omitempty"`
+	ToAddr         string      `protobuf:"bytes,15,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	// 附加的过滤表达式，与上面的过滤条件是AND关系
+	Expr                 *FilterExpr `protobuf:"bytes,20,opt,name=expr,proto3" json:"expr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -200,7 +344,7 @@ func (m *BlockFilter) Reset()         { *m = BlockFilter{} }
 func (m *BlockFilter) String() string { return proto.CompactTextString(m) }
 func (*BlockFilter) ProtoMessage()    {}
 func (*BlockFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bec55cd27928da5d, []int{3}
+	return fileDescriptor_bec55cd27928da5d, []int{4}
 }
 
 func (m *BlockFilter) XXX_Unmarshal(b []byte) error {
@@ -291,6 +435,554 @@ func (m *BlockFilter) GetToAddr() string {
 	return ""
 }
 
+func (m *BlockFilter) GetExpr() *FilterExpr {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+type TxFilter struct {
+	Bcname string      `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Range  *BlockRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	// 非空时只订阅该交易的确认事件，交易确认后迭代结束
+	Txid           string `protobuf:"bytes,3,opt,name=txid,proto3" json:"txid,omitempty"`
+	ExcludeTxEvent bool   `protobuf:"varint,4,opt,name=exclude_tx_event,json=excludeTxEvent,proto3" json:"exclude_tx_event,omitempty"`
+	Contract       string `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
+	EventName      string `protobuf:"bytes,11,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	Initiator      string `protobuf:"bytes,12,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire    string `protobuf:"bytes,13,opt,name=auth_require,json=authRequire,proto3" json:"auth_require,omitempty"`
+	FromAddr       string `protobuf:"bytes,14,opt,name=from_addr,json=fromAddr,proto3" json:"from_addr,omitempty"`
+	ToAddr         string `protobuf:"bytes,15,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	// 附加的过滤表达式，与上面的过滤条件是AND关系
+	Expr                 *FilterExpr `protobuf:"bytes,20,opt,name=expr,proto3" json:"expr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TxFilter) Reset()         { *m = TxFilter{} }
+func (m *TxFilter) String() string { return proto.CompactTextString(m) }
+func (*TxFilter) ProtoMessage()    {}
+func (*TxFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{5}
+}
+
+func (m *TxFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxFilter.Unmarshal(m, b)
+}
+func (m *TxFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxFilter.Marshal(b, m, deterministic)
+}
+func (m *TxFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxFilter.Merge(m, src)
+}
+func (m *TxFilter) XXX_Size() int {
+	return xxx_messageInfo_TxFilter.Size(m)
+}
+func (m *TxFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxFilter proto.InternalMessageInfo
+
+func (m *TxFilter) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *TxFilter) GetRange() *BlockRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+func (m *TxFilter) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *TxFilter) GetExcludeTxEvent() bool {
+	if m != nil {
+		return m.ExcludeTxEvent
+	}
+	return false
+}
+
+func (m *TxFilter) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *TxFilter) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *TxFilter) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+func (m *TxFilter) GetAuthRequire() string {
+	if m != nil {
+		return m.AuthRequire
+	}
+	return ""
+}
+
+func (m *TxFilter) GetFromAddr() string {
+	if m != nil {
+		return m.FromAddr
+	}
+	return ""
+}
+
+func (m *TxFilter) GetToAddr() string {
+	if m != nil {
+		return m.ToAddr
+	}
+	return ""
+}
+
+func (m *TxFilter) GetExpr() *FilterExpr {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+type EventFilter struct {
+	Bcname      string      `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Range       *BlockRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	Contract    string      `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
+	EventName   string      `protobuf:"bytes,11,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	Initiator   string      `protobuf:"bytes,12,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire string      `protobuf:"bytes,13,opt,name=auth_require,json=authRequire,proto3" json:"auth_require,omitempty"`
+	FromAddr    string      `protobuf:"bytes,14,opt,name=from_addr,json=fromAddr,proto3" json:"from_addr,omitempty"`
+	ToAddr      string      `protobuf:"bytes,15,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	// 附加的过滤表达式，与上面的过滤条件是AND关系
+	Expr                 *FilterExpr `protobuf:"bytes,20,opt,name=expr,proto3" json:"expr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EventFilter) Reset()         { *m = EventFilter{} }
+func (m *EventFilter) String() string { return proto.CompactTextString(m) }
+func (*EventFilter) ProtoMessage()    {}
+func (*EventFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{6}
+}
+
+func (m *EventFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventFilter.Unmarshal(m, b)
+}
+func (m *EventFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventFilter.Marshal(b, m, deterministic)
+}
+func (m *EventFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventFilter.Merge(m, src)
+}
+func (m *EventFilter) XXX_Size() int {
+	return xxx_messageInfo_EventFilter.Size(m)
+}
+func (m *EventFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventFilter proto.InternalMessageInfo
+
+func (m *EventFilter) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *EventFilter) GetRange() *BlockRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+func (m *EventFilter) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *EventFilter) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *EventFilter) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+func (m *EventFilter) GetAuthRequire() string {
+	if m != nil {
+		return m.AuthRequire
+	}
+	return ""
+}
+
+func (m *EventFilter) GetFromAddr() string {
+	if m != nil {
+		return m.FromAddr
+	}
+	return ""
+}
+
+func (m *EventFilter) GetToAddr() string {
+	if m != nil {
+		return m.ToAddr
+	}
+	return ""
+}
+
+func (m *EventFilter) GetExpr() *FilterExpr {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+// FilterExpr 是由过滤条件组成的表达式树
+// 交易中存在一个合约事件使表达式成立时交易匹配，没有合约事件的交易只对交易相关的条件求值
+type FilterExpr struct {
+	Op                   FilterExpr_Op `protobuf:"varint,1,opt,name=op,proto3,enum=protos.FilterExpr_Op" json:"op,omitempty"`
+	Children             []*FilterExpr `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	Clause               *FilterClause `protobuf:"bytes,3,opt,name=clause,proto3" json:"clause,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *FilterExpr) Reset()         { *m = FilterExpr{} }
+func (m *FilterExpr) String() string { return proto.CompactTextString(m) }
+func (*FilterExpr) ProtoMessage()    {}
+func (*FilterExpr) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{7}
+}
+
+func (m *FilterExpr) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilterExpr.Unmarshal(m, b)
+}
+func (m *FilterExpr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilterExpr.Marshal(b, m, deterministic)
+}
+func (m *FilterExpr) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilterExpr.Merge(m, src)
+}
+func (m *FilterExpr) XXX_Size() int {
+	return xxx_messageInfo_FilterExpr.Size(m)
+}
+func (m *FilterExpr) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilterExpr.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilterExpr proto.InternalMessageInfo
+
+func (m *FilterExpr) GetOp() FilterExpr_Op {
+	if m != nil {
+		return m.Op
+	}
+	return FilterExpr_CLAUSE
+}
+
+func (m *FilterExpr) GetChildren() []*FilterExpr {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *FilterExpr) GetClause() *FilterClause {
+	if m != nil {
+		return m.Clause
+	}
+	return nil
+}
+
+// FilterClause 是表达式树的叶子条件，有且只有一个字段非空
+// 字符串类型的字段为正则表达式
+type FilterClause struct {
+	Contract             string           `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	EventName            string           `protobuf:"bytes,2,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	Initiator            string           `protobuf:"bytes,3,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	AuthRequire          string           `protobuf:"bytes,4,opt,name=auth_require,json=authRequire,proto3" json:"auth_require,omitempty"`
+	FromAddr             string           `protobuf:"bytes,5,opt,name=from_addr,json=fromAddr,proto3" json:"from_addr,omitempty"`
+	ToAddr               string           `protobuf:"bytes,6,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	Amount               *AmountFilter    `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	EventBody            *EventBodyFilter `protobuf:"bytes,8,opt,name=event_body,json=eventBody,proto3" json:"event_body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FilterClause) Reset()         { *m = FilterClause{} }
+func (m *FilterClause) String() string { return proto.CompactTextString(m) }
+func (*FilterClause) ProtoMessage()    {}
+func (*FilterClause) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{8}
+}
+
+func (m *FilterClause) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilterClause.Unmarshal(m, b)
+}
+func (m *FilterClause) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilterClause.Marshal(b, m, deterministic)
+}
+func (m *FilterClause) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilterClause.Merge(m, src)
+}
+func (m *FilterClause) XXX_Size() int {
+	return xxx_messageInfo_FilterClause.Size(m)
+}
+func (m *FilterClause) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilterClause.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilterClause proto.InternalMessageInfo
+
+func (m *FilterClause) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *FilterClause) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *FilterClause) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+func (m *FilterClause) GetAuthRequire() string {
+	if m != nil {
+		return m.AuthRequire
+	}
+	return ""
+}
+
+func (m *FilterClause) GetFromAddr() string {
+	if m != nil {
+		return m.FromAddr
+	}
+	return ""
+}
+
+func (m *FilterClause) GetToAddr() string {
+	if m != nil {
+		return m.ToAddr
+	}
+	return ""
+}
+
+func (m *FilterClause) GetAmount() *AmountFilter {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *FilterClause) GetEventBody() *EventBodyFilter {
+	if m != nil {
+		return m.EventBody
+	}
+	return nil
+}
+
+// ValueRange 是十进制整数的闭区间，空字符串表示不限
+type ValueRange struct {
+	Min                  string   `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max                  string   `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValueRange) Reset()         { *m = ValueRange{} }
+func (m *ValueRange) String() string { return proto.CompactTextString(m) }
+func (*ValueRange) ProtoMessage()    {}
+func (*ValueRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{9}
+}
+
+func (m *ValueRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValueRange.Unmarshal(m, b)
+}
+func (m *ValueRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValueRange.Marshal(b, m, deterministic)
+}
+func (m *ValueRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValueRange.Merge(m, src)
+}
+func (m *ValueRange) XXX_Size() int {
+	return xxx_messageInfo_ValueRange.Size(m)
+}
+func (m *ValueRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValueRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValueRange proto.InternalMessageInfo
+
+func (m *ValueRange) GetMin() string {
+	if m != nil {
+		return m.Min
+	}
+	return ""
+}
+
+func (m *ValueRange) GetMax() string {
+	if m != nil {
+		return m.Max
+	}
+	return ""
+}
+
+// AmountFilter 匹配转账金额在指定范围内的交易输出
+type AmountFilter struct {
+	// 只匹配转给该地址的输出，为空时匹配所有输出
+	ToAddr               string      `protobuf:"bytes,1,opt,name=to_addr,json=toAddr,proto3" json:"to_addr,omitempty"`
+	Range                *ValueRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *AmountFilter) Reset()         { *m = AmountFilter{} }
+func (m *AmountFilter) String() string { return proto.CompactTextString(m) }
+func (*AmountFilter) ProtoMessage()    {}
+func (*AmountFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{10}
+}
+
+func (m *AmountFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AmountFilter.Unmarshal(m, b)
+}
+func (m *AmountFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AmountFilter.Marshal(b, m, deterministic)
+}
+func (m *AmountFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AmountFilter.Merge(m, src)
+}
+func (m *AmountFilter) XXX_Size() int {
+	return xxx_messageInfo_AmountFilter.Size(m)
+}
+func (m *AmountFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_AmountFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AmountFilter proto.InternalMessageInfo
+
+func (m *AmountFilter) GetToAddr() string {
+	if m != nil {
+		return m.ToAddr
+	}
+	return ""
+}
+
+func (m *AmountFilter) GetRange() *ValueRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+// EventBodyFilter 匹配合约事件的body，所有非空条件是AND关系
+type EventBodyFilter struct {
+	// body为json时按路径取值，如a.b.0，为空时使用原始body
+	JsonPath             string      `protobuf:"bytes,1,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
+	Prefix               []byte      `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Regex                string      `protobuf:"bytes,3,opt,name=regex,proto3" json:"regex,omitempty"`
+	Range                *ValueRange `protobuf:"bytes,4,opt,name=range,proto3" json:"range,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EventBodyFilter) Reset()         { *m = EventBodyFilter{} }
+func (m *EventBodyFilter) String() string { return proto.CompactTextString(m) }
+func (*EventBodyFilter) ProtoMessage()    {}
+func (*EventBodyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{11}
+}
+
+func (m *EventBodyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventBodyFilter.Unmarshal(m, b)
+}
+func (m *EventBodyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventBodyFilter.Marshal(b, m, deterministic)
+}
+func (m *EventBodyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventBodyFilter.Merge(m, src)
+}
+func (m *EventBodyFilter) XXX_Size() int {
+	return xxx_messageInfo_EventBodyFilter.Size(m)
+}
+func (m *EventBodyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventBodyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventBodyFilter proto.InternalMessageInfo
+
+func (m *EventBodyFilter) GetJsonPath() string {
+	if m != nil {
+		return m.JsonPath
+	}
+	return ""
+}
+
+func (m *EventBodyFilter) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *EventBodyFilter) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *EventBodyFilter) GetRange() *ValueRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
 type FilteredBlock struct {
 	Bcname               string                 `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
 	Blockid              string                 `protobuf:"bytes,2,opt,name=blockid,proto3" json:"blockid,omitempty"`
@@ -305,7 +997,7 @@ func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bec55cd27928da5d, []int{4}
+	return fileDescriptor_bec55cd27928da5d, []int{12}
 }
 
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
@@ -366,7 +1058,7 @@ func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bec55cd27928da5d, []int{5}
+	return fileDescriptor_bec55cd27928da5d, []int{13}
 }
 
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
@@ -401,54 +1093,229 @@ func (m *FilteredTransaction) GetEvents() []*ContractEvent {
 	return nil
 }
 
+// 交易事件，携带交易所在区块的位置信息
+type TxEvent struct {
+	Bcname               string               `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Blockid              string               `protobuf:"bytes,2,opt,name=blockid,proto3" json:"blockid,omitempty"`
+	BlockHeight          int64                `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Tx                   *FilteredTransaction `protobuf:"bytes,4,opt,name=tx,proto3" json:"tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TxEvent) Reset()         { *m = TxEvent{} }
+func (m *TxEvent) String() string { return proto.CompactTextString(m) }
+func (*TxEvent) ProtoMessage()    {}
+func (*TxEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{14}
+}
+
+func (m *TxEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxEvent.Unmarshal(m, b)
+}
+func (m *TxEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxEvent.Marshal(b, m, deterministic)
+}
+func (m *TxEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxEvent.Merge(m, src)
+}
+func (m *TxEvent) XXX_Size() int {
+	return xxx_messageInfo_TxEvent.Size(m)
+}
+func (m *TxEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxEvent proto.InternalMessageInfo
+
+func (m *TxEvent) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *TxEvent) GetBlockid() string {
+	if m != nil {
+		return m.Blockid
+	}
+	return ""
+}
+
+func (m *TxEvent) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *TxEvent) GetTx() *FilteredTransaction {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+// 合约事件，携带事件所在交易和区块的位置信息
+type ContractEventInfo struct {
+	Bcname               string         `protobuf:"bytes,1,opt,name=bcname,proto3" json:"bcname,omitempty"`
+	Blockid              string         `protobuf:"bytes,2,opt,name=blockid,proto3" json:"blockid,omitempty"`
+	BlockHeight          int64          `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Txid                 string         `protobuf:"bytes,4,opt,name=txid,proto3" json:"txid,omitempty"`
+	Event                *ContractEvent `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ContractEventInfo) Reset()         { *m = ContractEventInfo{} }
+func (m *ContractEventInfo) String() string { return proto.CompactTextString(m) }
+func (*ContractEventInfo) ProtoMessage()    {}
+func (*ContractEventInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bec55cd27928da5d, []int{15}
+}
+
+func (m *ContractEventInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractEventInfo.Unmarshal(m, b)
+}
+func (m *ContractEventInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractEventInfo.Marshal(b, m, deterministic)
+}
+func (m *ContractEventInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractEventInfo.Merge(m, src)
+}
+func (m *ContractEventInfo) XXX_Size() int {
+	return xxx_messageInfo_ContractEventInfo.Size(m)
+}
+func (m *ContractEventInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractEventInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractEventInfo proto.InternalMessageInfo
+
+func (m *ContractEventInfo) GetBcname() string {
+	if m != nil {
+		return m.Bcname
+	}
+	return ""
+}
+
+func (m *ContractEventInfo) GetBlockid() string {
+	if m != nil {
+		return m.Blockid
+	}
+	return ""
+}
+
+func (m *ContractEventInfo) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ContractEventInfo) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *ContractEventInfo) GetEvent() *ContractEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("protos.SubscribeType", SubscribeType_name, SubscribeType_value)
+	proto.RegisterEnum("protos.FilterExpr_Op", FilterExpr_Op_name, FilterExpr_Op_value)
 	proto.RegisterType((*SubscribeRequest)(nil), "protos.SubscribeRequest")
 	proto.RegisterType((*Event)(nil), "protos.Event")
+	proto.RegisterType((*EventCursor)(nil), "protos.EventCursor")
 	proto.RegisterType((*BlockRange)(nil), "protos.BlockRange")
 	proto.RegisterType((*BlockFilter)(nil), "protos.BlockFilter")
+	proto.RegisterType((*TxFilter)(nil), "protos.TxFilter")
+	proto.RegisterType((*EventFilter)(nil), "protos.EventFilter")
+	proto.RegisterType((*FilterExpr)(nil), "protos.FilterExpr")
+	proto.RegisterType((*FilterClause)(nil), "protos.FilterClause")
+	proto.RegisterType((*ValueRange)(nil), "protos.ValueRange")
+	proto.RegisterType((*AmountFilter)(nil), "protos.AmountFilter")
+	proto.RegisterType((*EventBodyFilter)(nil), "protos.EventBodyFilter")
 	proto.RegisterType((*FilteredBlock)(nil), "protos.FilteredBlock")
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
+	proto.RegisterType((*TxEvent)(nil), "protos.TxEvent")
+	proto.RegisterType((*ContractEventInfo)(nil), "protos.ContractEventInfo")
 }
 
 func init() { proto.RegisterFile("protos/event.proto", fileDescriptor_bec55cd27928da5d) }
 
 var fileDescriptor_bec55cd27928da5d = []byte{
-	// 536 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0x6d, 0x6f, 0xd3, 0x30,
-	0x10, 0xc7, 0xc9, 0xda, 0x6e, 0xcb, 0xa5, 0x2d, 0x95, 0x79, 0xb2, 0x3a, 0x10, 0x5d, 0x5e, 0xa0,
-	0x80, 0xb4, 0x16, 0x15, 0xc4, 0x7b, 0x3a, 0x31, 0x21, 0x81, 0x40, 0xf2, 0x8a, 0x84, 0x78, 0x13,
-	0x39, 0x89, 0xd7, 0x5a, 0xb4, 0x71, 0xe7, 0x38, 0x53, 0xfa, 0x39, 0xf8, 0x56, 0x7c, 0x2a, 0xe4,
-	0x73, 0x92, 0x89, 0xa7, 0x77, 0xbe, 0xff, 0xfd, 0x7c, 0x77, 0xf9, 0x3b, 0x07, 0x64, 0xa7, 0x95,
-	0x51, 0xc5, 0x4c, 0xdc, 0x88, 0xdc, 0x4c, 0x31, 0x20, 0x87, 0x4e, 0x1b, 0x3f, 0xad, 0xca, 0x9d,
-	0xd0, 0xa9, 0xd2, 0x62, 0x56, 0x53, 0xa9, 0xca, 0x8d, 0xe6, 0x69, 0x0d, 0x86, 0x5f, 0x60, 0x74,
-	0x59, 0x26, 0x45, 0xaa, 0x65, 0x22, 0x98, 0xb8, 0x2e, 0x45, 0x61, 0xc8, 0x73, 0xe8, 0x9a, 0xfd,
-	0x4e, 0x50, 0x6f, 0xe2, 0x45, 0xc3, 0xf9, 0x03, 0x47, 0x16, 0xd3, 0x96, 0x5b, 0xee, 0x77, 0x82,
-	0x21, 0x42, 0x1e, 0xc2, 0xe1, 0x95, 0xdc, 0x18, 0xa1, 0xe9, 0xc1, 0xc4, 0x8b, 0xfa, 0xac, 0x8e,
-	0xc2, 0x53, 0xe8, 0xbd, 0xb3, 0xe3, 0x10, 0x0a, 0x47, 0x3b, 0xbe, 0xdf, 0x28, 0x9e, 0x61, 0xb9,
-	0x3e, 0x6b, 0xc2, 0xf0, 0x35, 0xc0, 0x62, 0xa3, 0xd2, 0xef, 0x8c, 0xe7, 0x2b, 0x41, 0xee, 0x43,
-	0xaf, 0x30, 0x5c, 0x1b, 0xa4, 0x7c, 0xe6, 0x02, 0x32, 0x82, 0x8e, 0xc8, 0x33, 0xac, 0xed, 0x33,
-	0x7b, 0x0c, 0x7f, 0x1e, 0x40, 0x80, 0xd7, 0x2e, 0xb0, 0x91, 0x1d, 0x20, 0x49, 0x73, 0xbe, 0x15,
-	0xf5, 0xc5, 0x3a, 0x22, 0x11, 0xf4, 0xb4, 0x2d, 0x8c, 0x77, 0x83, 0x39, 0x69, 0x3e, 0xe2, 0xb6,
-	0x25, 0x73, 0x00, 0x79, 0x02, 0x20, 0xaa, 0x74, 0x53, 0x66, 0x22, 0x36, 0x15, 0xed, 0x4c, 0xbc,
-	0xe8, 0x98, 0xf9, 0xb5, 0xb2, 0xac, 0x48, 0x04, 0xa3, 0xdb, 0x74, 0x8c, 0x1e, 0xd3, 0x2e, 0x42,
-	0xc3, 0x16, 0x72, 0x9f, 0x3a, 0x86, 0xe3, 0xc6, 0x5c, 0x0a, 0x38, 0x4c, 0x1b, 0x63, 0x13, 0x0b,
-	0xc5, 0x38, 0x6a, 0x80, 0x59, 0x1f, 0x95, 0x4f, 0x76, 0xda, 0xc7, 0xe0, 0xcb, 0x5c, 0x1a, 0xc9,
-	0x8d, 0xd2, 0xb4, 0xef, 0xb2, 0xad, 0x40, 0x4e, 0xa1, 0xcf, 0x4b, 0xb3, 0x8e, 0xb5, 0xb8, 0x2e,
-	0xa5, 0x16, 0x74, 0x80, 0x40, 0x60, 0x35, 0xe6, 0x24, 0x72, 0x02, 0xfe, 0x95, 0x56, 0xdb, 0x98,
-	0x67, 0x99, 0xa6, 0x43, 0xd7, 0xdc, 0x0a, 0x6f, 0xb3, 0x4c, 0x93, 0x47, 0x70, 0x64, 0x94, 0x4b,
-	0xdd, 0x75, 0x26, 0x19, 0x65, 0x13, 0xe1, 0x0f, 0x0f, 0x06, 0xce, 0x47, 0x91, 0xa1, 0x31, 0xff,
-	0xb5, 0x93, 0xc2, 0x51, 0x62, 0x01, 0xd9, 0x3c, 0x46, 0x13, 0xda, 0xe1, 0xf0, 0x18, 0xaf, 0x85,
-	0x5c, 0xad, 0x0d, 0x1a, 0xd8, 0x61, 0x01, 0x6a, 0xef, 0x51, 0x22, 0x67, 0xd0, 0x31, 0x55, 0x41,
-	0xbb, 0x93, 0x4e, 0x14, 0xcc, 0x4f, 0x9a, 0x97, 0x68, 0x1a, 0x2f, 0x35, 0xcf, 0x0b, 0x9e, 0x1a,
-	0xa9, 0x72, 0x66, 0xb9, 0xf0, 0x2b, 0xdc, 0xfb, 0x47, 0x8e, 0x10, 0xe8, 0x9a, 0x4a, 0x66, 0xf5,
-	0x60, 0x78, 0x26, 0x67, 0x70, 0x88, 0x26, 0x16, 0xf4, 0x00, 0x8b, 0xb7, 0xff, 0xea, 0x79, 0x6d,
-	0x3c, 0xbe, 0x0c, 0xab, 0xa1, 0x17, 0x63, 0x18, 0xfc, 0xf6, 0x13, 0x13, 0x1f, 0x7a, 0x8b, 0x8f,
-	0x9f, 0xcf, 0x3f, 0x8c, 0xee, 0xcc, 0x2f, 0xa0, 0x8f, 0xf0, 0xa5, 0xd0, 0x37, 0x32, 0x15, 0xe4,
-	0x0d, 0xf8, 0x2d, 0x4b, 0xe8, 0x5f, 0x3b, 0x50, 0xef, 0xca, 0x78, 0xd0, 0x64, 0xf0, 0xf2, 0x4b,
-	0x6f, 0x11, 0x7d, 0x7b, 0xb6, 0x92, 0x66, 0x5d, 0x26, 0xd3, 0x54, 0x6d, 0x67, 0x6e, 0xfd, 0xd6,
-	0x5c, 0xe6, 0xb3, 0x3f, 0x37, 0x31, 0x71, 0x3b, 0xfa, 0xea, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xe7, 0xdd, 0xc8, 0x91, 0xc0, 0x03, 0x00, 0x00,
+	// 990 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x0e, 0x49, 0xfd, 0x71, 0x24, 0x3b, 0xec, 0xd6, 0x6d, 0x58, 0xa7, 0x45, 0x5c, 0x02, 0x0d,
+	0xdc, 0x24, 0xb6, 0x03, 0xb7, 0xc8, 0xdd, 0x76, 0x1d, 0x34, 0x68, 0x60, 0x35, 0x6b, 0x35, 0x08,
+	0x7a, 0x11, 0x28, 0x72, 0x6d, 0xb1, 0x95, 0xb8, 0xcc, 0x72, 0x69, 0x50, 0x2f, 0xd0, 0x4b, 0x8f,
+	0xbd, 0xf7, 0x58, 0x20, 0x0f, 0xd1, 0xb7, 0xe9, 0x13, 0xf4, 0x09, 0x8a, 0x9d, 0x5d, 0x4a, 0xa2,
+	0x52, 0xd9, 0x97, 0xe4, 0x52, 0xf4, 0xc6, 0xf9, 0xe6, 0x9b, 0x9d, 0xd9, 0x6f, 0x38, 0xbb, 0x0b,
+	0x24, 0x13, 0x5c, 0xf2, 0xfc, 0x80, 0x5d, 0xb1, 0x54, 0xee, 0xa3, 0x41, 0x5a, 0x1a, 0xdb, 0xbe,
+	0x57, 0x16, 0x19, 0x13, 0x11, 0x17, 0xec, 0xc0, 0xb0, 0x22, 0x9e, 0x4a, 0x11, 0x46, 0x86, 0x18,
+	0x4c, 0xc1, 0x3b, 0x2f, 0x46, 0x79, 0x24, 0x92, 0x11, 0xa3, 0xec, 0x75, 0xc1, 0x72, 0x49, 0xbe,
+	0x84, 0x86, 0x9c, 0x65, 0xcc, 0xb7, 0x76, 0xac, 0xdd, 0xcd, 0xc3, 0x8f, 0x34, 0x33, 0xdf, 0x9f,
+	0xf3, 0x06, 0xb3, 0x8c, 0x51, 0xa4, 0x90, 0x8f, 0xa1, 0x75, 0x91, 0x4c, 0x24, 0x13, 0xbe, 0xbd,
+	0x63, 0xed, 0xf6, 0xa8, 0xb1, 0x14, 0x1e, 0x15, 0x22, 0xe7, 0xc2, 0x77, 0x34, 0xae, 0xad, 0xe0,
+	0x05, 0x34, 0x4f, 0x55, 0x99, 0xc4, 0x87, 0x76, 0x16, 0xce, 0x26, 0x3c, 0x8c, 0x31, 0x4d, 0x8f,
+	0x56, 0xe6, 0x52, 0xa8, 0xbd, 0x1c, 0xaa, 0x70, 0xc1, 0xae, 0x98, 0x90, 0xb8, 0x64, 0x87, 0x1a,
+	0x2b, 0xf8, 0xdd, 0x82, 0x2e, 0xae, 0x79, 0xa2, 0x79, 0x9f, 0x43, 0x6f, 0x34, 0xe1, 0xd1, 0xcf,
+	0xc3, 0x31, 0x4b, 0x2e, 0xc7, 0x12, 0x97, 0x77, 0x68, 0x17, 0xb1, 0x6f, 0x11, 0x52, 0xc9, 0xd1,
+	0x4c, 0x62, 0x93, 0xa3, 0x32, 0xc9, 0x27, 0xd0, 0x91, 0xe5, 0x30, 0x49, 0x63, 0x56, 0x62, 0x9a,
+	0x26, 0x6d, 0xcb, 0xf2, 0x99, 0x32, 0xc9, 0x3d, 0xe8, 0xa2, 0xc2, 0xc6, 0xdb, 0x40, 0x2f, 0x20,
+	0xa4, 0x09, 0x8b, 0x02, 0x9b, 0xb5, 0x02, 0xbf, 0x06, 0x38, 0x56, 0xcb, 0xd3, 0x30, 0xbd, 0x64,
+	0x64, 0x0b, 0x9a, 0xb9, 0x0c, 0x85, 0xae, 0xcb, 0xa5, 0xda, 0x20, 0x1e, 0x38, 0x2c, 0xd5, 0xd5,
+	0xb8, 0x54, 0x7d, 0x06, 0x7f, 0xdb, 0xd0, 0xc5, 0xb0, 0xa7, 0x73, 0x45, 0x47, 0x51, 0x1a, 0x4e,
+	0x99, 0x09, 0x34, 0x16, 0xd9, 0x85, 0xa6, 0x50, 0x0b, 0x63, 0x6c, 0xf7, 0x90, 0x54, 0xdd, 0x5a,
+	0xa4, 0xa4, 0x9a, 0x40, 0x3e, 0x03, 0x60, 0x65, 0x34, 0x29, 0x62, 0x36, 0x94, 0xa5, 0x11, 0xd1,
+	0x35, 0xc8, 0xa0, 0x24, 0xbb, 0xe0, 0x2d, 0xdc, 0x43, 0xdc, 0x17, 0x6e, 0xb2, 0x43, 0x37, 0xe7,
+	0x24, 0xdd, 0xbb, 0x6d, 0xe8, 0x54, 0x7f, 0x91, 0x0f, 0x58, 0xcc, 0xdc, 0xc6, 0x24, 0xa8, 0x12,
+	0x96, 0xda, 0x45, 0xaf, 0x8b, 0xc8, 0x99, 0xaa, 0xf6, 0x53, 0x70, 0x93, 0x34, 0x91, 0x49, 0x28,
+	0xb9, 0xf0, 0x7b, 0xda, 0x3b, 0x07, 0x54, 0xeb, 0xc2, 0x42, 0x8e, 0x87, 0x82, 0xbd, 0x2e, 0x12,
+	0xc1, 0xfc, 0x0d, 0x24, 0x74, 0x15, 0x46, 0x35, 0x44, 0xee, 0x82, 0x7b, 0x21, 0xf8, 0x74, 0x18,
+	0xc6, 0xb1, 0xf0, 0x37, 0x75, 0x72, 0x05, 0x1c, 0xc5, 0xb1, 0x20, 0x77, 0xa0, 0x2d, 0xb9, 0x76,
+	0xdd, 0xd6, 0x22, 0x49, 0x8e, 0x8e, 0xfb, 0xd0, 0x60, 0x65, 0x26, 0xfc, 0xad, 0xba, 0x46, 0x5a,
+	0xda, 0xd3, 0x32, 0x13, 0x14, 0xfd, 0xc1, 0x5f, 0x36, 0x74, 0x06, 0xe5, 0x3b, 0x53, 0x9c, 0x40,
+	0x43, 0x96, 0x49, 0x8c, 0x5a, 0xbb, 0x14, 0xbf, 0xff, 0x97, 0x39, 0x13, 0xc1, 0x1b, 0xdb, 0x8c,
+	0xec, 0x3b, 0x53, 0xfa, 0xbf, 0xab, 0xd5, 0x9f, 0x16, 0xc0, 0x02, 0x24, 0x5f, 0x80, 0xcd, 0xb3,
+	0xd5, 0x93, 0x79, 0xe1, 0xdf, 0xef, 0x67, 0xd4, 0xe6, 0x19, 0xd9, 0x87, 0x4e, 0x34, 0x4e, 0x26,
+	0xb1, 0x60, 0xa9, 0x6f, 0xef, 0x38, 0x6b, 0x32, 0xcc, 0x39, 0xe4, 0x11, 0xb4, 0xa2, 0x49, 0x58,
+	0xe4, 0x0c, 0xff, 0xd5, 0xee, 0xe1, 0x56, 0x9d, 0x7d, 0x82, 0x3e, 0x6a, 0x38, 0xc1, 0x03, 0xb0,
+	0xfb, 0x19, 0x01, 0x68, 0x9d, 0x3c, 0x3f, 0xfa, 0xe1, 0xfc, 0xd4, 0xbb, 0x45, 0xda, 0xe0, 0x1c,
+	0x9d, 0x7d, 0xe3, 0x59, 0xa4, 0x05, 0x76, 0x9f, 0x7a, 0xb6, 0x02, 0xce, 0xfa, 0x03, 0xcf, 0x09,
+	0xfe, 0xb0, 0xa1, 0xb7, 0xbc, 0x48, 0xad, 0x55, 0xd6, 0xb5, 0xad, 0xb2, 0xaf, 0x6d, 0x95, 0x73,
+	0x53, 0xab, 0x1a, 0x37, 0xb4, 0xaa, 0xb9, 0xbe, 0x55, 0xad, 0x5a, 0xab, 0x1e, 0x41, 0x2b, 0x9c,
+	0xf2, 0x22, 0x95, 0x7e, 0xbb, 0x2e, 0xce, 0x11, 0xa2, 0x7a, 0x77, 0xd4, 0x70, 0xc8, 0x93, 0x6a,
+	0x0f, 0x23, 0x1e, 0xcf, 0xfc, 0x0e, 0x46, 0xdc, 0xa9, 0x22, 0xf0, 0xaf, 0x3f, 0xe6, 0xf1, 0xcc,
+	0x04, 0xb9, 0xac, 0x02, 0x82, 0xc7, 0x00, 0x2f, 0xc3, 0x49, 0xc1, 0xf4, 0x35, 0xe1, 0x81, 0x33,
+	0x4d, 0x52, 0x23, 0x90, 0xfa, 0x44, 0x24, 0x2c, 0xab, 0x2b, 0x62, 0x1a, 0x96, 0xc1, 0x0b, 0xe8,
+	0x2d, 0x57, 0xb0, 0xbc, 0x01, 0xab, 0xb6, 0x81, 0x75, 0x73, 0xb4, 0xc8, 0x67, 0xe6, 0x28, 0xf8,
+	0xc5, 0x82, 0xdb, 0x2b, 0x35, 0x2a, 0xd1, 0x7e, 0xca, 0x79, 0x3a, 0xcc, 0x42, 0x39, 0xae, 0x3a,
+	0xa6, 0x80, 0xef, 0x43, 0x39, 0x56, 0xa3, 0x9b, 0x09, 0x76, 0x91, 0x94, 0xd5, 0x6d, 0xad, 0x2d,
+	0x75, 0xcd, 0x09, 0x76, 0x69, 0x6e, 0x51, 0x97, 0x6a, 0x63, 0x51, 0x48, 0xe3, 0xa6, 0x42, 0x7e,
+	0xb3, 0x60, 0x43, 0xe7, 0x67, 0x31, 0x8e, 0xfb, 0xda, 0x43, 0x62, 0xe5, 0x32, 0x77, 0x17, 0x97,
+	0xf9, 0xea, 0x4b, 0xc0, 0x79, 0xfb, 0x25, 0xb0, 0x07, 0x8e, 0x2c, 0x73, 0xbf, 0x81, 0x23, 0x72,
+	0xb7, 0xfe, 0xd3, 0xb3, 0x78, 0x20, 0xc2, 0x34, 0x0f, 0x23, 0x99, 0xf0, 0x94, 0x2a, 0x5e, 0xf0,
+	0x0a, 0x3e, 0xfc, 0x17, 0xdf, 0xfc, 0x9c, 0xb7, 0x96, 0xce, 0xf9, 0x3d, 0x68, 0x61, 0x6f, 0x73,
+	0x33, 0x7f, 0xf3, 0x61, 0x3d, 0x31, 0x3f, 0x3b, 0xca, 0x4c, 0x0d, 0x29, 0xf8, 0xd5, 0x82, 0x76,
+	0x75, 0xf0, 0xbf, 0x97, 0x9d, 0x3e, 0x04, 0x5b, 0x96, 0x46, 0xf7, 0x6b, 0x37, 0x6a, 0xcb, 0x32,
+	0x78, 0x63, 0xc1, 0x07, 0xb5, 0x3a, 0x9f, 0xa5, 0x17, 0xfc, 0xfd, 0xd4, 0x55, 0x69, 0xd7, 0x58,
+	0xd2, 0xee, 0x21, 0x34, 0xf5, 0xc5, 0xd8, 0xdc, 0xb1, 0xd6, 0x4b, 0xa7, 0x39, 0x0f, 0xf6, 0x60,
+	0xa3, 0xf6, 0x32, 0x25, 0x2e, 0x34, 0x8f, 0x9f, 0xf7, 0x4f, 0xbe, 0xf3, 0x6e, 0xa9, 0xd3, 0x68,
+	0xf0, 0xca, 0xb3, 0x14, 0x74, 0xfa, 0xf2, 0xf4, 0x6c, 0xe0, 0xd9, 0x87, 0x4f, 0xa1, 0x87, 0xe1,
+	0xe7, 0x4c, 0x5c, 0x25, 0x11, 0x23, 0x4f, 0xc0, 0x9d, 0x87, 0x13, 0xff, 0xad, 0xb7, 0xae, 0x79,
+	0x13, 0x6f, 0x6f, 0xd4, 0x26, 0xf8, 0xb1, 0x75, 0xbc, 0xfb, 0xe3, 0xfd, 0xcb, 0x44, 0x8e, 0x8b,
+	0xd1, 0x7e, 0xc4, 0xa7, 0x07, 0xfa, 0x99, 0x3d, 0x0e, 0x93, 0xf4, 0x60, 0xf5, 0xc5, 0x3d, 0xd2,
+	0x6f, 0xf1, 0xaf, 0xfe, 0x19, 0x00, 0x0d, 0x78, 0x4a, 0x28, 0xa8, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.